@@ -1,11 +1,22 @@
 package config
 
 import (
+	"errors"
 	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"time"
 
 	"github.com/BurntSushi/toml"
 )
 
+const (
+	// EnvConfigPath names the config file when no -config flag is given.
+	EnvConfigPath = "CONFIG_PATH"
+	DefaultPath   = "cfg/config.toml"
+)
+
 type DBConfig struct {
 	Host     string `toml:"host"`
 	Port     int    `toml:"port"`
@@ -13,40 +24,206 @@ type DBConfig struct {
 	Password string `toml:"password"`
 	Database string `toml:"database"`
 	SSLMode  string `toml:"sslmode"`
+	// AutoMigrate applies pending embedded migrations before the server starts.
+	AutoMigrate bool `toml:"auto_migrate"`
 }
 
 type ServerConfig struct {
 	Host    string `toml:"host"`
 	Port    int    `toml:"port"`
 	IsDevel bool   `toml:"is_devel"`
+	// ShutdownTimeout bounds draining in-flight requests and stopping workers, 15s when unset.
+	ShutdownTimeout time.Duration `toml:"shutdown_timeout"`
+	// TrustedProxies are the CIDRs of reverse proxies whose X-Forwarded-For is used to find the client IP.
+	TrustedProxies []string `toml:"trusted_proxies"`
 }
 
 type APIKeysConfig struct {
 	MaxBot string `toml:"max_bot"`
 }
 
+type JWTConfig struct {
+	Enabled           bool          `toml:"enabled"`
+	JWKSURL           string        `toml:"jwks_url"`
+	JWKSCacheTTL      time.Duration `toml:"jwks_cache_ttl"`
+	Issuer            string        `toml:"issuer"`
+	Audience          string        `toml:"audience"`
+	UserIDClaim       string        `toml:"user_id_claim"`
+	RolesClaim        string        `toml:"roles_claim"`
+	OrganizationClaim string        `toml:"organization_claim"`
+}
+
+type AuthConfig struct {
+	Enabled bool      `toml:"enabled"`
+	JWT     JWTConfig `toml:"jwt"`
+}
+
+type RouteLimitConfig struct {
+	Path              string  `toml:"path"`
+	RequestsPerSecond float64 `toml:"requests_per_second"`
+	Burst             int     `toml:"burst"`
+	MaxBodyBytes      int64   `toml:"max_body_bytes"`
+}
+
+type RateLimitConfig struct {
+	Enabled           bool               `toml:"enabled"`
+	RequestsPerSecond float64            `toml:"requests_per_second"`
+	Burst             int                `toml:"burst"`
+	MaxBodyBytes      int64              `toml:"max_body_bytes"`
+	Routes            []RouteLimitConfig `toml:"routes"`
+	// PerIPRequestsPerSecond and PerIPBurst limit each client IP before authentication; 0 disables the limit.
+	PerIPRequestsPerSecond float64 `toml:"per_ip_requests_per_second"`
+	PerIPBurst             int     `toml:"per_ip_burst"`
+}
+
+type MetricsConfig struct {
+	RefreshInterval time.Duration `toml:"refresh_interval"`
+}
+
+type AssignmentConfig struct {
+	// RetryInterval is how often PRs waiting for reviewers are retried; 0 disables the retries.
+	RetryInterval time.Duration `toml:"retry_interval"`
+}
+
+type NotificationsConfig struct {
+	// WebhookURL receives notifications as JSON; without it they are only logged.
+	WebhookURL string        `toml:"webhook_url"`
+	Timeout    time.Duration `toml:"timeout"`
+}
+
+type TracingConfig struct {
+	Enabled     bool    `toml:"enabled"`
+	Endpoint    string  `toml:"endpoint"`
+	Insecure    bool    `toml:"insecure"`
+	SampleRatio float64 `toml:"sample_ratio"`
+	ServiceName string  `toml:"service_name"`
+}
+
 type Config struct {
-	Database DBConfig      `toml:"database"`
-	Server   ServerConfig  `toml:"server"`
-	APIKeys  APIKeysConfig `toml:"api_keys"`
+	Database  DBConfig        `toml:"database"`
+	Server    ServerConfig    `toml:"server"`
+	APIKeys   APIKeysConfig   `toml:"api_keys"`
+	Auth      AuthConfig      `toml:"auth"`
+	RateLimit RateLimitConfig `toml:"rate_limit"`
+	Metrics   MetricsConfig   `toml:"metrics"`
+	Tracing   TracingConfig   `toml:"tracing"`
+
+	Assignment    AssignmentConfig    `toml:"assignment"`
+	Notifications NotificationsConfig `toml:"notifications"`
 }
 
+// ResolvePath returns the config path from the flag, then CONFIG_PATH, then the default.
+func ResolvePath(flagPath string) string {
+	if flagPath != "" {
+		return flagPath
+	}
+	if path := os.Getenv(EnvConfigPath); path != "" {
+		return path
+	}
+	return DefaultPath
+}
+
+// Load reads the file at path, applies the PRR_* environment overrides and validates the result.
 func Load(path string) (*Config, error) {
 	var cfg Config
 	if _, err := toml.DecodeFile(path, &cfg); err != nil {
 		return nil, fmt.Errorf("failed to load config: %w", err)
 	}
 
+	if err := applyEnv(&cfg, os.LookupEnv); err != nil {
+		return nil, fmt.Errorf("failed to apply env overrides: %w", err)
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid config %s: %w", path, err)
+	}
+
 	return &cfg, nil
 }
 
+// Validate reports all invalid fields at once.
+func (c *Config) Validate() error {
+	var errs []error
+	check := func(ok bool, format string, args ...any) {
+		if !ok {
+			errs = append(errs, fmt.Errorf(format, args...))
+		}
+	}
+
+	check(c.Database.Host != "", "database.host is required")
+	check(validPort(c.Database.Port), "database.port must be between 1 and 65535, got %d", c.Database.Port)
+	check(c.Database.User != "", "database.user is required")
+	check(c.Database.Database != "", "database.database is required")
+	check(validPort(c.Server.Port), "server.port must be between 1 and 65535, got %d", c.Server.Port)
+	check(c.Server.ShutdownTimeout >= 0, "server.shutdown_timeout must not be negative")
+	for i, cidr := range c.Server.TrustedProxies {
+		_, _, err := net.ParseCIDR(cidr)
+		check(err == nil, "server.trusted_proxies[%d] must be a CIDR, got %q", i, cidr)
+	}
+
+	if jwt := c.Auth.JWT; jwt.Enabled {
+		check(jwt.JWKSURL != "", "auth.jwt.jwks_url is required when auth.jwt is enabled")
+		check(jwt.JWKSCacheTTL >= 0, "auth.jwt.jwks_cache_ttl must not be negative")
+	}
+
+	if rl := c.RateLimit; rl.Enabled {
+		check(rl.RequestsPerSecond > 0, "rate_limit.requests_per_second must be positive")
+		check(rl.Burst > 0, "rate_limit.burst must be positive")
+		check(rl.MaxBodyBytes >= 0, "rate_limit.max_body_bytes must not be negative")
+		for i, r := range rl.Routes {
+			check(r.Path != "", "rate_limit.routes[%d].path is required", i)
+			check(r.RequestsPerSecond >= 0 && r.Burst >= 0 && r.MaxBodyBytes >= 0,
+				"rate_limit.routes[%d] limits must not be negative", i)
+		}
+	}
+
+	check(c.Metrics.RefreshInterval >= 0, "metrics.refresh_interval must not be negative")
+	check(c.Assignment.RetryInterval >= 0, "assignment.retry_interval must not be negative")
+	check(c.Notifications.Timeout >= 0, "notifications.timeout must not be negative")
+	if raw := c.Notifications.WebhookURL; raw != "" {
+		u, err := url.Parse(raw)
+		check(err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != "",
+			"notifications.webhook_url must be an http or https URL")
+	}
+
+	if tr := c.Tracing; tr.Enabled {
+		check(tr.Endpoint != "", "tracing.endpoint is required when tracing is enabled")
+		check(tr.SampleRatio >= 0 && tr.SampleRatio <= 1, "tracing.sample_ratio must be between 0 and 1, got %g", tr.SampleRatio)
+	}
+
+	return errors.Join(errs...)
+}
+
+// TrustedProxyNets returns the parsed TrustedProxies, skipping invalid entries rejected by Validate.
+func (c *ServerConfig) TrustedProxyNets() []*net.IPNet {
+	var nets []*net.IPNet
+	for _, cidr := range c.TrustedProxies {
+		if _, ipNet, err := net.ParseCIDR(cidr); err == nil {
+			nets = append(nets, ipNet)
+		}
+	}
+	return nets
+}
+
+func validPort(port int) bool {
+	return port > 0 && port <= 65535
+}
+
 func (c *DBConfig) DSN() string {
-	return fmt.Sprintf("postgres://%s:%s@%s:%d/%s?sslmode=%s",
-		c.User,
-		c.Password,
-		c.Host,
-		c.Port,
-		c.Database,
-		c.SSLMode,
-	)
+	return c.url().String()
+}
+
+// RedactedDSN is the DSN with the password masked, safe to log.
+func (c *DBConfig) RedactedDSN() string {
+	return c.url().Redacted()
+}
+
+func (c *DBConfig) url() *url.URL {
+	return &url.URL{
+		Scheme:   "postgres",
+		User:     url.UserPassword(c.User, c.Password),
+		Host:     fmt.Sprintf("%s:%d", c.Host, c.Port),
+		Path:     "/" + c.Database,
+		RawQuery: url.Values{"sslmode": {c.SSLMode}}.Encode(),
+	}
 }
@@ -0,0 +1,111 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func validConfig() Config {
+	return Config{
+		Database: DBConfig{Host: "db", Port: 5432, User: "postgres", Password: "p@ss:word", Database: "pr_system", SSLMode: "disable"},
+		Server:   ServerConfig{Host: "0.0.0.0", Port: 8080},
+	}
+}
+
+func TestResolvePath(t *testing.T) {
+	t.Setenv(EnvConfigPath, "")
+	assert.Equal(t, DefaultPath, ResolvePath(""))
+
+	t.Setenv(EnvConfigPath, "/app/cfg/config.toml")
+	assert.Equal(t, "/app/cfg/config.toml", ResolvePath(""))
+	assert.Equal(t, "custom.toml", ResolvePath("custom.toml"))
+}
+
+func TestApplyEnv(t *testing.T) {
+	secret := filepath.Join(t.TempDir(), "db_password")
+	require.NoError(t, os.WriteFile(secret, []byte("from-secret\n"), 0o600))
+
+	env := map[string]string{
+		"PRR_DATABASE_PASSWORD_FILE":    secret,
+		"PRR_SERVER_PORT":               "9090",
+		"PRR_AUTH_JWT_ENABLED":          "true",
+		"PRR_AUTH_JWT_JWKS_CACHE_TTL":   "5m",
+		"PRR_TRACING_SAMPLE_RATIO":      "0.5",
+		"PRR_RATE_LIMIT_MAX_BODY_BYTES": "2048",
+	}
+	lookup := func(key string) (string, bool) {
+		v, ok := env[key]
+		return v, ok
+	}
+
+	cfg := validConfig()
+	require.NoError(t, applyEnv(&cfg, lookup))
+
+	assert.Equal(t, "from-secret", cfg.Database.Password)
+	assert.Equal(t, 9090, cfg.Server.Port)
+	assert.True(t, cfg.Auth.JWT.Enabled)
+	assert.Equal(t, 5*time.Minute, cfg.Auth.JWT.JWKSCacheTTL)
+	assert.InDelta(t, 0.5, cfg.Tracing.SampleRatio, 1e-9)
+	assert.Equal(t, int64(2048), cfg.RateLimit.MaxBodyBytes)
+	assert.Equal(t, "db", cfg.Database.Host, "fields without overrides keep file values")
+
+	t.Run("value wins over file", func(t *testing.T) {
+		env["PRR_DATABASE_PASSWORD"] = "from-env"
+		defer delete(env, "PRR_DATABASE_PASSWORD")
+
+		cfg := validConfig()
+		require.NoError(t, applyEnv(&cfg, lookup))
+		assert.Equal(t, "from-env", cfg.Database.Password)
+	})
+
+	t.Run("invalid value", func(t *testing.T) {
+		cfg := validConfig()
+		err := applyEnv(&cfg, func(key string) (string, bool) {
+			if key == "PRR_SERVER_PORT" {
+				return "http", true
+			}
+			return "", false
+		})
+		assert.ErrorContains(t, err, "PRR_SERVER_PORT")
+	})
+}
+
+func TestValidate(t *testing.T) {
+	cfg := validConfig()
+	assert.NoError(t, cfg.Validate())
+
+	cfg.Database.Host = ""
+	cfg.Server.Port = 0
+	cfg.Tracing = TracingConfig{Enabled: true, SampleRatio: 2}
+	cfg.Notifications.WebhookURL = "bot.example.com/hook"
+	cfg.Server.TrustedProxies = []string{"10.0.0.0/8", "10.0.0.1"}
+	err := cfg.Validate()
+	require.Error(t, err)
+	assert.ErrorContains(t, err, "database.host is required")
+	assert.ErrorContains(t, err, "server.port must be between 1 and 65535, got 0")
+	assert.ErrorContains(t, err, "tracing.endpoint is required")
+	assert.ErrorContains(t, err, "tracing.sample_ratio must be between 0 and 1, got 2")
+	assert.ErrorContains(t, err, "notifications.webhook_url must be an http or https URL")
+	assert.ErrorContains(t, err, `server.trusted_proxies[1] must be a CIDR, got "10.0.0.1"`)
+	assert.NotContains(t, err.Error(), "trusted_proxies[0]")
+	assert.Len(t, cfg.Server.TrustedProxyNets(), 1)
+}
+
+func TestDBConfig_DSN(t *testing.T) {
+	cfg := validConfig()
+	assert.Equal(t, "postgres://postgres:p%40ss%3Aword@db:5432/pr_system?sslmode=disable", cfg.Database.DSN())
+	assert.Equal(t, "postgres://postgres:xxxxx@db:5432/pr_system?sslmode=disable", cfg.Database.RedactedDSN())
+}
+
+func TestLoad(t *testing.T) {
+	t.Setenv("PRR_DATABASE_HOST", "localhost")
+
+	cfg, err := Load("config.dist.toml")
+	require.NoError(t, err)
+	assert.Equal(t, "localhost", cfg.Database.Host)
+}
@@ -0,0 +1,109 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// EnvPrefix prefixes the environment variables that override config fields: the variable name is the path of
+// toml keys in upper case, e.g. PRR_DATABASE_PASSWORD or PRR_AUTH_JWT_ISSUER. With the _FILE suffix the value
+// is read from the named file, which is how Docker secrets are mounted. Array sections such as
+// [[rate_limit.routes]] can only be set in the file.
+const EnvPrefix = "PRR"
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+type lookupEnvFunc func(key string) (string, bool)
+
+func applyEnv(cfg *Config, lookup lookupEnvFunc) error {
+	return applyEnvStruct(reflect.ValueOf(cfg).Elem(), EnvPrefix, lookup)
+}
+
+func applyEnvStruct(v reflect.Value, prefix string, lookup lookupEnvFunc) error {
+	t := v.Type()
+	for i := range t.NumField() {
+		key := t.Field(i).Tag.Get("toml")
+		if key == "" || key == "-" {
+			continue
+		}
+		name := prefix + "_" + strings.ToUpper(key)
+		field := v.Field(i)
+
+		if field.Kind() == reflect.Struct {
+			if err := applyEnvStruct(field, name, lookup); err != nil {
+				return err
+			}
+			continue
+		}
+
+		value, ok, err := lookupValue(name, lookup)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			continue
+		}
+		if err := setField(field, value); err != nil {
+			return fmt.Errorf("invalid %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// lookupValue prefers the variable itself over its _FILE variant.
+func lookupValue(name string, lookup lookupEnvFunc) (string, bool, error) {
+	if value, ok := lookup(name); ok {
+		return value, true, nil
+	}
+
+	path, ok := lookup(name + "_FILE")
+	if !ok {
+		return "", false, nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", false, fmt.Errorf("failed to read %s_FILE: %w", name, err)
+	}
+	return strings.TrimRight(string(data), "\r\n"), true, nil
+}
+
+func setField(field reflect.Value, value string) error {
+	if field.Type() == durationType {
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			return err
+		}
+		field.SetInt(int64(d))
+		return nil
+	}
+
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(value)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return err
+		}
+		field.SetBool(b)
+	case reflect.Int, reflect.Int64:
+		n, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return err
+		}
+		field.SetInt(n)
+	case reflect.Float64:
+		f, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return err
+		}
+		field.SetFloat(f)
+	default:
+		return fmt.Errorf("unsupported field type %s", field.Type())
+	}
+	return nil
+}
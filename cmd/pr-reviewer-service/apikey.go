@@ -0,0 +1,128 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/ssokov/pr-reviewer-service/internal/model/domain"
+	postgres "github.com/ssokov/pr-reviewer-service/internal/repository/postgres"
+	"github.com/ssokov/pr-reviewer-service/internal/service"
+	"github.com/vmkteam/embedlog"
+)
+
+const apiKeyUsage = "usage: pr-reviewer-service apikey create -name NAME -scopes pr:write,stats:read [-org SLUG] | list [-org SLUG] | revoke -prefix PREFIX [-org SLUG]"
+
+// runAPIKeyCommand manages API keys directly in the database. Keys belong to the organization given by -org.
+func runAPIKeyCommand(ctx context.Context, sl embedlog.Logger, pool *pgxpool.Pool, args []string) error {
+	if len(args) == 0 {
+		return errors.New(apiKeyUsage)
+	}
+
+	apiKeyService := service.NewAPIKeyService(postgres.NewAPIKeyRepository(pool), sl)
+
+	switch args[0] {
+	case "create":
+		fs := flag.NewFlagSet("apikey create", flag.ContinueOnError)
+		name := fs.String("name", "", "key name, e.g. the client that will use it")
+		scopes := fs.String("scopes", "", "comma-separated scopes: "+scopesList())
+		org := orgFlag(fs)
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+
+		ctx, err := withOrganization(ctx, sl, pool, *org)
+		if err != nil {
+			return err
+		}
+
+		key, rawKey, err := apiKeyService.CreateKey(ctx, *name, parseScopes(*scopes))
+		if err != nil {
+			return err
+		}
+		fmt.Printf("created key %q (prefix %s) with scopes %v\n", key.Name, key.Prefix, key.Scopes)
+		fmt.Printf("%s\n", rawKey)
+		fmt.Println("store it now, it will not be shown again")
+		return nil
+
+	case "list":
+		fs := flag.NewFlagSet("apikey list", flag.ContinueOnError)
+		org := orgFlag(fs)
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+
+		ctx, err := withOrganization(ctx, sl, pool, *org)
+		if err != nil {
+			return err
+		}
+
+		keys, err := apiKeyService.ListKeys(ctx)
+		if err != nil {
+			return err
+		}
+		w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+		fmt.Fprintln(w, "PREFIX\tNAME\tSCOPES\tLAST USED\tREVOKED")
+		for _, k := range keys {
+			fmt.Fprintf(w, "%s\t%s\t%v\t%s\t%s\n", k.Prefix, k.Name, k.Scopes, formatTime(k.LastUsedAt), formatTime(k.RevokedAt))
+		}
+		return w.Flush()
+
+	case "revoke":
+		fs := flag.NewFlagSet("apikey revoke", flag.ContinueOnError)
+		prefix := fs.String("prefix", "", "prefix of the key to revoke")
+		org := orgFlag(fs)
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+
+		ctx, err := withOrganization(ctx, sl, pool, *org)
+		if err != nil {
+			return err
+		}
+
+		key, err := apiKeyService.RevokeKey(ctx, *prefix)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("revoked key %q (prefix %s)\n", key.Name, key.Prefix)
+		return nil
+	}
+
+	return errors.New(apiKeyUsage)
+}
+
+func orgFlag(fs *flag.FlagSet) *string {
+	return fs.String("org", domain.DefaultOrganizationSlug, "organization slug")
+}
+
+func parseScopes(raw string) []domain.Scope {
+	var scopes []domain.Scope
+	for _, s := range strings.Split(raw, ",") {
+		if s = strings.TrimSpace(s); s != "" {
+			scopes = append(scopes, domain.Scope(s))
+		}
+	}
+	return scopes
+}
+
+func scopesList() string {
+	names := make([]string, len(domain.KnownScopes))
+	for i, s := range domain.KnownScopes {
+		names[i] = string(s)
+	}
+	return strings.Join(names, ", ")
+}
+
+func formatTime(t *time.Time) string {
+	if t == nil {
+		return "-"
+	}
+	return t.Format(time.DateTime)
+}
@@ -1,13 +1,22 @@
 // @title PR Reviewer Service API
 // @version 1.0
-// @description API for managing pull request reviews and team assignments
+// @description API for managing pull request reviews and team assignments.
+// @description Requests are scoped to the organization of the API key or token. X-Organization, when sent, must name it.
 // @BasePath /
 // @host localhost:8080
 // @schemes http
+// @securityDefinitions.apikey ApiKeyAuth
+// @in header
+// @name X-API-Key
+// @securityDefinitions.apikey BearerAuth
+// @in header
+// @name Authorization
+// @description JWT issued by the corporate identity provider, as "Bearer <token>"
 package main
 
 import (
 	"context"
+	"errors"
 	"flag"
 	"log/slog"
 	"os"
@@ -17,6 +26,9 @@ import (
 	"github.com/jackc/pgx/v5/pgxpool"
 	config "github.com/ssokov/pr-reviewer-service/cfg"
 	"github.com/ssokov/pr-reviewer-service/internal/app"
+	"github.com/ssokov/pr-reviewer-service/internal/buildinfo"
+	"github.com/ssokov/pr-reviewer-service/internal/tracing"
+	"github.com/ssokov/pr-reviewer-service/migrations"
 	"github.com/vmkteam/embedlog"
 )
 
@@ -24,6 +36,7 @@ var (
 	flVerbose = flag.Bool("verbose", false, "print verbose output")
 	flJSON    = flag.Bool("json", false, "print output as JSON")
 	flDev     = flag.Bool("dev", true, "uses development mode")
+	flConfig  = flag.String("config", "", "path to the config file (default $CONFIG_PATH or cfg/config.toml)")
 )
 
 const (
@@ -38,23 +51,54 @@ func main() {
 	if *flDev {
 		sl = embedlog.NewDevLogger()
 	}
-	slog.SetDefault(sl.Log())
+	slog.SetDefault(slog.New(tracing.NewLogHandler(sl.Log().Handler())))
 
-	cfg, err := config.Load("cfg/config.toml")
+	cfg, err := config.Load(config.ResolvePath(*flConfig))
 	if err != nil {
 		sl.Errorf("Failed to load config. error: %v", err)
 		exitOnError(err)
 	}
 
-	dsn := cfg.Database.DSN()
-	sl.Print(ctx, "connecting to database", "host", cfg.Database.Host, "database", cfg.Database.Database, "dsn", dsn)
+	shutdownTracing, err := tracing.Setup(ctx, tracing.Config{
+		Enabled:     cfg.Tracing.Enabled,
+		Endpoint:    cfg.Tracing.Endpoint,
+		Insecure:    cfg.Tracing.Insecure,
+		SampleRatio: cfg.Tracing.SampleRatio,
+		ServiceName: cfg.Tracing.ServiceName,
+		Version:     buildinfo.Get().Version,
+	})
+	if err != nil {
+		sl.Errorf("failed to set up tracing: %v", err)
+		exitOnError(err)
+	}
+	defer func() {
+		if err := shutdownTracing(context.Background()); err != nil {
+			sl.Errorf("failed to flush traces: %v", err)
+		}
+	}()
+
+	if flag.Arg(0) == "migrate" {
+		exitOnError(runMigrateCommand(ctx, sl, cfg, flag.Args()[1:]))
+		return
+	}
 
-	poolCfg, err := pgxpool.ParseConfig(dsn)
+	if cfg.Database.AutoMigrate {
+		if err := autoMigrate(ctx, sl, cfg); err != nil {
+			sl.Errorf("failed to apply migrations: %v", err)
+			exitOnError(err)
+		}
+	}
+
+	sl.Print(ctx, "connecting to database", "host", cfg.Database.Host, "database", cfg.Database.Database, "dsn", cfg.Database.RedactedDSN())
+
+	poolCfg, err := pgxpool.ParseConfig(cfg.Database.DSN())
 	if err != nil {
 		sl.Errorf("failed to parse pgx config: %v", err)
 		exitOnError(err)
 	}
 
+	poolCfg.ConnConfig.Tracer = tracing.NewPgxTracer()
+
 	pool, err := pgxpool.NewWithConfig(ctx, poolCfg)
 	if err != nil {
 		sl.Errorf("failed to create pgx pool: %v", err)
@@ -74,22 +118,47 @@ func main() {
 	}
 	sl.Print(ctx, "connected to db", "version", version)
 
+	switch flag.Arg(0) {
+	case "apikey":
+		exitOnError(runAPIKeyCommand(ctx, sl, pool, flag.Args()[1:]))
+		return
+	case "org":
+		exitOnError(runOrgCommand(ctx, sl, pool, flag.Args()[1:]))
+		return
+	}
+
 	application := app.New(appName, sl, cfg, pool)
 
-	quit := make(chan os.Signal, 1)
-	signal.Notify(quit, os.Interrupt, syscall.SIGTERM)
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
 
-	// Run
-	go func() {
-		if err := application.Run(ctx); err != nil {
-			exitOnError(err)
-		}
-	}()
-	<-quit
+	if err := application.Run(ctx); err != nil {
+		sl.Errorf("application stopped with error: %v", err)
+		exitOnError(err)
+	}
 
 	sl.Print(ctx, "Application finished")
 }
 
+func autoMigrate(ctx context.Context, sl embedlog.Logger, cfg *config.Config) (err error) {
+	migrator, err := migrations.NewMigrator(cfg.Database.DSN(), sl)
+	if err != nil {
+		return err
+	}
+	defer func() { err = errors.Join(err, migrator.Close()) }()
+
+	if err := migrator.Up(); err != nil {
+		return err
+	}
+
+	status, err := migrator.Status()
+	if err != nil {
+		return err
+	}
+	sl.Print(ctx, "database migrated", "version", status.Version)
+	return nil
+}
+
 func exitOnError(err error) {
 	if err != nil {
 		slog.Error(err.Error())
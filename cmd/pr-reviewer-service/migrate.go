@@ -0,0 +1,82 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"strconv"
+
+	config "github.com/ssokov/pr-reviewer-service/cfg"
+	"github.com/ssokov/pr-reviewer-service/migrations"
+	"github.com/vmkteam/embedlog"
+)
+
+const migrateUsage = "usage: pr-reviewer-service migrate up | down [-steps N] | status | force VERSION"
+
+// runMigrateCommand applies or inspects the migrations embedded in the binary.
+func runMigrateCommand(ctx context.Context, sl embedlog.Logger, cfg *config.Config, args []string) (err error) {
+	if len(args) == 0 {
+		return errors.New(migrateUsage)
+	}
+
+	migrator, err := migrations.NewMigrator(cfg.Database.DSN(), sl)
+	if err != nil {
+		return err
+	}
+	defer func() { err = errors.Join(err, migrator.Close()) }()
+
+	switch args[0] {
+	case "up":
+		if err := migrator.Up(); err != nil {
+			return err
+		}
+		return printMigrationStatus(migrator)
+
+	case "down":
+		fs := flag.NewFlagSet("migrate down", flag.ContinueOnError)
+		steps := fs.Int("steps", 1, "number of migrations to roll back")
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+
+		if err := migrator.Down(*steps); err != nil {
+			return err
+		}
+		return printMigrationStatus(migrator)
+
+	case "status":
+		return printMigrationStatus(migrator)
+
+	case "force":
+		if len(args) != 2 {
+			return errors.New(migrateUsage)
+		}
+		version, err := strconv.Atoi(args[1])
+		if err != nil {
+			return fmt.Errorf("invalid version %q: %w", args[1], err)
+		}
+
+		if err := migrator.Force(version); err != nil {
+			return err
+		}
+		sl.Print(ctx, "forced migration version", "version", version)
+		return printMigrationStatus(migrator)
+	}
+
+	return errors.New(migrateUsage)
+}
+
+func printMigrationStatus(migrator *migrations.Migrator) error {
+	status, err := migrator.Status()
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("version: %d, latest: %d", status.Version, status.Latest)
+	if status.Dirty {
+		fmt.Print(" (dirty)")
+	}
+	fmt.Println()
+	return nil
+}
@@ -0,0 +1,101 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/ssokov/pr-reviewer-service/internal/model/domain"
+	postgres "github.com/ssokov/pr-reviewer-service/internal/repository/postgres"
+	"github.com/ssokov/pr-reviewer-service/internal/service"
+	"github.com/ssokov/pr-reviewer-service/internal/tenant"
+	"github.com/vmkteam/embedlog"
+)
+
+const orgUsage = "usage: pr-reviewer-service org create -slug SLUG -name NAME [settings flags] | list | settings -slug SLUG [settings flags]; " +
+	"settings flags: [-reviewer-count N] [-require-senior] [-top-reviewers N] [-pairing-window DAYS] [-availability-window HOURS] [-review-sla HOURS] [-max-open-reviews N] [-at-capacity reject|queue]"
+
+// runOrgCommand manages organizations directly in the database.
+func runOrgCommand(ctx context.Context, sl embedlog.Logger, pool *pgxpool.Pool, args []string) error {
+	if len(args) == 0 {
+		return errors.New(orgUsage)
+	}
+
+	orgService := service.NewOrganizationService(postgres.NewOrganizationRepository(pool), sl)
+
+	switch args[0] {
+	case "create":
+		fs := flag.NewFlagSet("org create", flag.ContinueOnError)
+		slug := fs.String("slug", "", "short name used in the X-Organization header")
+		name := fs.String("name", "", "display name")
+		settings := settingsFlags(fs)
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+
+		org, err := orgService.CreateOrganization(ctx, *slug, *name, *settings)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("created organization %q (id %d)\n", org.Slug, org.ID)
+		return nil
+
+	case "list":
+		orgs, err := orgService.ListOrganizations(ctx)
+		if err != nil {
+			return err
+		}
+		w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+		fmt.Fprintln(w, "ID\tSLUG\tNAME\tREVIEWERS\tTOP REVIEWERS")
+		for _, o := range orgs {
+			fmt.Fprintf(w, "%d\t%s\t%s\t%d\t%d\n", o.ID, o.Slug, o.Name, o.Settings.ReviewerCount, o.Settings.TopReviewers())
+		}
+		return w.Flush()
+
+	case "settings":
+		fs := flag.NewFlagSet("org settings", flag.ContinueOnError)
+		slug := fs.String("slug", "", "organization to update")
+		settings := settingsFlags(fs)
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+
+		org, err := orgService.UpdateSettings(ctx, *slug, *settings)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("updated organization %q: reviewer_count=%d require_senior=%t top_reviewers_limit=%d pairing_window_days=%d availability_window_hours=%d review_sla_hours=%d max_open_reviews=%d at_capacity=%s\n",
+			org.Slug, org.Settings.ReviewerCount, org.Settings.RequireSenior, org.Settings.TopReviewersLimit, org.Settings.PairingWindowDays,
+			org.Settings.AvailabilityWindowHours, org.Settings.ReviewSLAHours, org.Settings.MaxOpenReviews, org.Settings.AtCapacity)
+		return nil
+	}
+
+	return errors.New(orgUsage)
+}
+
+func settingsFlags(fs *flag.FlagSet) *domain.OrganizationSettings {
+	var settings domain.OrganizationSettings
+	fs.IntVar(&settings.ReviewerCount, "reviewer-count", 0, "max reviewers assigned to a new PR, 0 for every active teammate")
+	fs.BoolVar(&settings.RequireSenior, "require-senior", false, "require a lead or senior reviewer on every new PR")
+	fs.IntVar(&settings.TopReviewersLimit, "top-reviewers", 0, "length of the top reviewers list in /stats, 0 for the default")
+	fs.IntVar(&settings.PairingWindowDays, "pairing-window", 0, "days of review history that count against repeated author-reviewer pairs, 0 for the default")
+	fs.IntVar(&settings.AvailabilityWindowHours, "availability-window", 0, "hours before their working day starts that reviewers count as nearly available, 0 for the default")
+	fs.IntVar(&settings.ReviewSLAHours, "review-sla", 0, "working hours reviewers have to review a PR, 0 for the default")
+	fs.IntVar(&settings.MaxOpenReviews, "max-open-reviews", 0, "open reviews a user can have at once, 0 for no limit")
+	fs.StringVar((*string)(&settings.AtCapacity), "at-capacity", "", "reject or queue new PRs nobody can review, empty for reject")
+	return &settings
+}
+
+// withOrganization scopes ctx to the organization with the given slug, as the HTTP API does per request.
+func withOrganization(ctx context.Context, sl embedlog.Logger, pool *pgxpool.Pool, slug string) (context.Context, error) {
+	orgService := service.NewOrganizationService(postgres.NewOrganizationRepository(pool), sl)
+	org, err := orgService.GetBySlug(ctx, slug)
+	if err != nil {
+		return nil, err
+	}
+	return tenant.WithOrganization(ctx, org), nil
+}
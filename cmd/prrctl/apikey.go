@@ -0,0 +1,81 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"github.com/ssokov/pr-reviewer-service/internal/model/domain"
+)
+
+func newAPIKeyCommand(c *cli) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "apikey",
+		Short: "Manage API keys (direct mode only)",
+	}
+	cmd.AddCommand(newAPIKeyCreateCommand(c), newAPIKeyRevokeCommand(c))
+	return cmd
+}
+
+func newAPIKeyCreateCommand(c *cli) *cobra.Command {
+	var (
+		name   string
+		scopes []string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "create --name NAME --scopes pr:write,stats:read",
+		Short: "Create an API key in the organization given by --org",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			keyScopes := make([]domain.Scope, len(scopes))
+			for i, s := range scopes {
+				keyScopes[i] = domain.Scope(strings.TrimSpace(s))
+			}
+
+			key, rawKey, err := c.client.CreateAPIKey(cmd.Context(), name, keyScopes)
+			if err != nil {
+				return err
+			}
+
+			out := struct {
+				*domain.APIKey
+				Key string
+			}{key, rawKey}
+			return c.print(out, func(w *tabwriter.Writer) {
+				fmt.Fprintf(w, "created key %q (prefix %s) with scopes %v\n", key.Name, key.Prefix, key.Scopes)
+				fmt.Fprintln(w, rawKey)
+				fmt.Fprintln(w, "store it now, it will not be shown again")
+			})
+		},
+	}
+
+	known := make([]string, len(domain.KnownScopes))
+	for i, s := range domain.KnownScopes {
+		known[i] = string(s)
+	}
+	cmd.Flags().StringVar(&name, "name", "", "key name, e.g. the client that will use it")
+	cmd.Flags().StringSliceVar(&scopes, "scopes", nil, "comma-separated scopes: "+strings.Join(known, ", "))
+	_ = cmd.MarkFlagRequired("name")
+	_ = cmd.MarkFlagRequired("scopes")
+	return cmd
+}
+
+func newAPIKeyRevokeCommand(c *cli) *cobra.Command {
+	return &cobra.Command{
+		Use:   "revoke PREFIX",
+		Short: "Revoke an API key by its prefix",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			key, err := c.client.RevokeAPIKey(cmd.Context(), args[0])
+			if err != nil {
+				return err
+			}
+
+			return c.print(key, func(w *tabwriter.Writer) {
+				fmt.Fprintf(w, "revoked key %q (prefix %s)\n", key.Name, key.Prefix)
+			})
+		},
+	}
+}
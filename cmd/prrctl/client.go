@@ -0,0 +1,33 @@
+package main
+
+import (
+	"context"
+	"errors"
+
+	"github.com/ssokov/pr-reviewer-service/internal/model/domain"
+	"github.com/ssokov/pr-reviewer-service/internal/model/dto"
+	"github.com/ssokov/pr-reviewer-service/internal/roster"
+)
+
+// errDirectOnly is returned by the remote client for commands the HTTP API does not expose.
+var errDirectOnly = errors.New("this command needs direct database access, run it without --server")
+
+// client runs operations either against the database through the services or against the HTTP API.
+// Both return the API response types, so the output does not depend on the mode.
+type client interface {
+	AddTeam(ctx context.Context, req dto.AddTeamRequest) (*dto.TeamResponse, error)
+	GetTeam(ctx context.Context, teamName string) (*dto.TeamResponse, error)
+	DeactivateTeam(ctx context.Context, teamName string) (*dto.DeactivateTeamResponse, error)
+	ImportRoster(ctx context.Context, format roster.Format, data []byte) (*dto.ImportRosterResponse, error)
+	ExportRoster(ctx context.Context, format roster.Format) ([]byte, error)
+	SetIsActive(ctx context.Context, userID string, isActive bool) (*dto.UserResponse, error)
+	GetReview(ctx context.Context, userID string) (*dto.GetReviewResponse, error)
+	ReassignReviewer(ctx context.Context, prID, oldUserID string) (*dto.ReassignResponse, error)
+	AddReviewer(ctx context.Context, prID, userID string, pin bool) (*dto.ReviewerResponse, error)
+	RemoveReviewer(ctx context.Context, prID, userID string) (*dto.ReviewerResponse, error)
+	ListPRs(ctx context.Context, filter domain.PRFilter) (*dto.ListPRsResponse, error)
+	Stats(ctx context.Context) (*dto.StatsResponse, error)
+	CreateAPIKey(ctx context.Context, name string, scopes []domain.Scope) (*domain.APIKey, string, error)
+	RevokeAPIKey(ctx context.Context, prefix string) (*domain.APIKey, error)
+	Close()
+}
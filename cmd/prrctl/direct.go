@@ -0,0 +1,218 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os/user"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	config "github.com/ssokov/pr-reviewer-service/cfg"
+	"github.com/ssokov/pr-reviewer-service/internal/apperror"
+	"github.com/ssokov/pr-reviewer-service/internal/auth"
+	"github.com/ssokov/pr-reviewer-service/internal/dryrun"
+	"github.com/ssokov/pr-reviewer-service/internal/http/mapper"
+	"github.com/ssokov/pr-reviewer-service/internal/model/domain"
+	"github.com/ssokov/pr-reviewer-service/internal/model/dto"
+	postgres "github.com/ssokov/pr-reviewer-service/internal/repository/postgres"
+	"github.com/ssokov/pr-reviewer-service/internal/roster"
+	"github.com/ssokov/pr-reviewer-service/internal/service"
+	"github.com/ssokov/pr-reviewer-service/internal/tenant"
+	"github.com/vmkteam/embedlog"
+)
+
+// directClient calls the services on the database from the config. Mutations go through the audited
+// services and are recorded with a cli:<os user> actor.
+type directClient struct {
+	pool *pgxpool.Pool
+	org  *domain.Organization
+
+	teamService   service.TeamService
+	rosterService service.RosterService
+	userService   service.UserService
+	prService     service.PRService
+	statsService  service.StatsService
+	apiKeyService service.APIKeyService
+}
+
+func newDirectClient(ctx context.Context, sl embedlog.Logger, cfg *config.Config, orgSlug string) (*directClient, error) {
+	pool, err := pgxpool.New(ctx, cfg.Database.DSN())
+	if err != nil {
+		return nil, fmt.Errorf("failed to create pgx pool: %w", err)
+	}
+	if err := pool.Ping(ctx); err != nil {
+		pool.Close()
+		return nil, fmt.Errorf("db ping failed: %w", err)
+	}
+
+	userRepo := postgres.NewUserRepository(pool)
+	teamRepo := postgres.NewTeamRepository(pool)
+	prRepo := postgres.NewPRRepository(pool)
+	auditRepo := postgres.NewAuditRepository(pool)
+	transactor := postgres.NewTransactor(pool)
+
+	if orgSlug == "" {
+		orgSlug = domain.DefaultOrganizationSlug
+	}
+	org, err := service.NewOrganizationService(postgres.NewOrganizationRepository(pool), sl).GetBySlug(ctx, orgSlug)
+	if err != nil {
+		pool.Close()
+		return nil, err
+	}
+
+	return &directClient{
+		pool: pool,
+		org:  org,
+		teamService: service.NewDryRunTeamService(
+			service.NewAuditedTeamService(service.NewTeamService(teamRepo, userRepo, prRepo, sl), teamRepo, auditRepo, sl), transactor,
+		),
+		rosterService: service.NewAuditedRosterService(service.NewRosterService(teamRepo, userRepo, transactor, sl), auditRepo, sl),
+		userService: service.NewDryRunUserService(
+			service.NewAuditedUserService(service.NewUserService(userRepo, teamRepo, sl), userRepo, auditRepo, sl), transactor,
+		),
+		prService: service.NewDryRunPRService(
+			service.NewAuditedPRService(service.NewPRService(prRepo, userRepo, teamRepo, postgres.NewCodeOwnersRepository(pool), postgres.NewSkillRepository(pool), postgres.NewPoolRepository(pool), sl), prRepo, auditRepo, sl), transactor,
+		),
+		statsService:  service.NewStatsService(postgres.NewStatsRepository(pool), sl),
+		apiKeyService: service.NewAPIKeyService(postgres.NewAPIKeyRepository(pool), sl),
+	}, nil
+}
+
+func (c *directClient) ctx(ctx context.Context) context.Context {
+	subject := "cli"
+	if u, err := user.Current(); err == nil {
+		subject = "cli:" + u.Username
+	}
+
+	ctx = tenant.WithOrganization(ctx, c.org)
+	return auth.WithPrincipal(ctx, &auth.Principal{
+		Subject:        subject,
+		Scopes:         []domain.Scope{domain.ScopeAll},
+		OrganizationID: c.org.ID,
+	})
+}
+
+func (c *directClient) AddTeam(ctx context.Context, req dto.AddTeamRequest) (*dto.TeamResponse, error) {
+	team, err := c.teamService.AddTeam(c.ctx(ctx), mapper.AddTeamRequestToDomain(req))
+	if err != nil {
+		return nil, err
+	}
+	resp := mapper.TeamToResponse(team)
+	return &resp, nil
+}
+
+func (c *directClient) GetTeam(ctx context.Context, teamName string) (*dto.TeamResponse, error) {
+	team, err := c.teamService.GetTeam(c.ctx(ctx), teamName)
+	if err != nil {
+		return nil, err
+	}
+	resp := mapper.TeamToResponse(team)
+	return &resp, nil
+}
+
+func (c *directClient) DeactivateTeam(ctx context.Context, teamName string) (*dto.DeactivateTeamResponse, error) {
+	users, prs, err := c.teamService.DeactivateTeam(c.ctx(ctx), teamName)
+	if err != nil {
+		return nil, err
+	}
+	resp := mapper.DeactivationToResponse(users, prs)
+	resp.DryRun = dryrun.Enabled(ctx)
+	return &resp, nil
+}
+
+func (c *directClient) ImportRoster(ctx context.Context, format roster.Format, data []byte) (*dto.ImportRosterResponse, error) {
+	parsed, err := roster.Parse(bytes.NewReader(data), format)
+	if err != nil {
+		return nil, apperror.NewInvalidInputError(err.Error())
+	}
+
+	result, err := c.rosterService.ImportRoster(c.ctx(ctx), parsed)
+	if err != nil {
+		return nil, err
+	}
+	resp := mapper.RosterImportToResponse(result)
+	resp.DryRun = dryrun.Enabled(ctx)
+	return &resp, nil
+}
+
+func (c *directClient) ExportRoster(ctx context.Context, format roster.Format) ([]byte, error) {
+	entries, err := c.rosterService.ExportRoster(c.ctx(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	if err := roster.Write(&buf, format, entries); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (c *directClient) SetIsActive(ctx context.Context, userID string, isActive bool) (*dto.UserResponse, error) {
+	u, err := c.userService.SetIsActive(c.ctx(ctx), userID, isActive)
+	if err != nil {
+		return nil, err
+	}
+	resp := mapper.UserToResponse(u)
+	return &resp, nil
+}
+
+func (c *directClient) GetReview(ctx context.Context, userID string) (*dto.GetReviewResponse, error) {
+	prs, err := c.userService.GetReview(c.ctx(ctx), userID)
+	if err != nil {
+		return nil, err
+	}
+	return &dto.GetReviewResponse{UserID: userID, PullRequests: mapper.PullRequestsToShort(prs)}, nil
+}
+
+func (c *directClient) ReassignReviewer(ctx context.Context, prID, oldUserID string) (*dto.ReassignResponse, error) {
+	pr, newReviewerID, err := c.prService.ReassignReviewer(c.ctx(ctx), prID, oldUserID)
+	if err != nil {
+		return nil, err
+	}
+	return &dto.ReassignResponse{PR: mapper.PullRequestToResponse(pr), ReplacedBy: newReviewerID, DryRun: dryrun.Enabled(ctx)}, nil
+}
+
+func (c *directClient) AddReviewer(ctx context.Context, prID, userID string, pin bool) (*dto.ReviewerResponse, error) {
+	pr, err := c.prService.AddReviewer(c.ctx(ctx), prID, userID, pin)
+	if err != nil {
+		return nil, err
+	}
+	return &dto.ReviewerResponse{PR: mapper.PullRequestToResponse(pr), DryRun: dryrun.Enabled(ctx)}, nil
+}
+
+func (c *directClient) RemoveReviewer(ctx context.Context, prID, userID string) (*dto.ReviewerResponse, error) {
+	pr, err := c.prService.RemoveReviewer(c.ctx(ctx), prID, userID)
+	if err != nil {
+		return nil, err
+	}
+	return &dto.ReviewerResponse{PR: mapper.PullRequestToResponse(pr), DryRun: dryrun.Enabled(ctx)}, nil
+}
+
+func (c *directClient) ListPRs(ctx context.Context, filter domain.PRFilter) (*dto.ListPRsResponse, error) {
+	prs, err := c.prService.ListPRs(c.ctx(ctx), filter)
+	if err != nil {
+		return nil, err
+	}
+	resp := &dto.ListPRsResponse{PullRequests: make([]dto.PullRequestResponse, 0, len(prs))}
+	for i := range prs {
+		resp.PullRequests = append(resp.PullRequests, mapper.PullRequestToResponse(&prs[i]))
+	}
+	return resp, nil
+}
+
+func (c *directClient) Stats(ctx context.Context) (*dto.StatsResponse, error) {
+	return c.statsService.GetStats(c.ctx(ctx))
+}
+
+func (c *directClient) CreateAPIKey(ctx context.Context, name string, scopes []domain.Scope) (*domain.APIKey, string, error) {
+	return c.apiKeyService.CreateKey(c.ctx(ctx), name, scopes)
+}
+
+func (c *directClient) RevokeAPIKey(ctx context.Context, prefix string) (*domain.APIKey, error) {
+	return c.apiKeyService.RevokeKey(c.ctx(ctx), prefix)
+}
+
+func (c *directClient) Close() {
+	c.pool.Close()
+}
@@ -0,0 +1,133 @@
+// Command prrctl is the operator CLI for the PR reviewer service. It works directly against the database from
+// the service config, or remotely against the HTTP API when --server is set.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	config "github.com/ssokov/pr-reviewer-service/cfg"
+	"github.com/ssokov/pr-reviewer-service/internal/dryrun"
+	"github.com/vmkteam/embedlog"
+)
+
+type cli struct {
+	configPath string
+	server     string
+	apiKey     string
+	token      string
+	org        string
+	output     string
+	verbose    bool
+
+	client client
+}
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if err := newRootCommand().ExecuteContext(ctx); err != nil {
+		os.Exit(1)
+	}
+}
+
+func newRootCommand() *cobra.Command {
+	c := &cli{}
+
+	root := &cobra.Command{
+		Use:           "prrctl",
+		Short:         "Operate the PR reviewer service",
+		SilenceUsage:  true,
+		SilenceErrors: false,
+		PersistentPreRunE: func(cmd *cobra.Command, _ []string) error {
+			if c.output != "table" && c.output != "json" {
+				return fmt.Errorf("--output must be table or json, got %q", c.output)
+			}
+			if !needsClient(cmd) {
+				return nil
+			}
+			return c.connect(cmd.Context())
+		},
+		PersistentPostRun: func(*cobra.Command, []string) {
+			if c.client != nil {
+				c.client.Close()
+			}
+		},
+	}
+
+	flags := root.PersistentFlags()
+	flags.StringVar(&c.configPath, "config", "", "service config for direct mode (default $CONFIG_PATH or cfg/config.toml)")
+	flags.StringVar(&c.server, "server", os.Getenv("PRRCTL_SERVER"), "API base URL, e.g. http://localhost:8080; enables remote mode ($PRRCTL_SERVER)")
+	flags.StringVar(&c.apiKey, "api-key", os.Getenv("PRRCTL_API_KEY"), "API key for remote mode ($PRRCTL_API_KEY)")
+	flags.StringVar(&c.token, "token", os.Getenv("PRRCTL_TOKEN"), "bearer token for remote mode ($PRRCTL_TOKEN)")
+	flags.StringVar(&c.org, "org", os.Getenv("PRRCTL_ORG"), "organization slug the credentials must belong to ($PRRCTL_ORG)")
+	flags.StringVarP(&c.output, "output", "o", "table", "output format: table or json")
+	flags.BoolVarP(&c.verbose, "verbose", "v", false, "log service calls in direct mode")
+
+	root.AddCommand(
+		newTeamCommand(c),
+		newRosterCommand(c),
+		newUserCommand(c),
+		newPRCommand(c),
+		newStatsCommand(c),
+		newAPIKeyCommand(c),
+	)
+	return root
+}
+
+func (c *cli) connect(ctx context.Context) error {
+	if c.server != "" {
+		c.client = newRemoteClient(c.server, c.apiKey, c.token, c.org)
+		return nil
+	}
+
+	cfg, err := config.Load(config.ResolvePath(c.configPath))
+	if err != nil {
+		return err
+	}
+
+	direct, err := newDirectClient(ctx, embedlog.NewLogger(c.verbose, false), cfg, c.org)
+	if err != nil {
+		return err
+	}
+	c.client = direct
+	return nil
+}
+
+// needsClient is false for the built-in help and completion commands, which must work without a database.
+func needsClient(cmd *cobra.Command) bool {
+	for ; cmd != nil; cmd = cmd.Parent() {
+		if cmd.Name() == "help" || cmd.Name() == "completion" {
+			return false
+		}
+	}
+	return true
+}
+
+// print writes v as indented JSON with -o json, and otherwise calls table with a tab-aligned writer.
+func (c *cli) print(v any, table func(w *tabwriter.Writer)) error {
+	if c.output == "json" {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(v)
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	table(w)
+	return w.Flush()
+}
+
+// withDryRun marks ctx so that the client previews the operation instead of applying it.
+func withDryRun(ctx context.Context, enabled bool) context.Context {
+	if !enabled {
+		return ctx
+	}
+	return dryrun.With(ctx)
+}
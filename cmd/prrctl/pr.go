@@ -0,0 +1,141 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/ssokov/pr-reviewer-service/internal/model/domain"
+	"github.com/ssokov/pr-reviewer-service/internal/model/dto"
+)
+
+func newPRCommand(c *cli) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "pr",
+		Short: "Manage pull requests",
+	}
+	cmd.AddCommand(newPRListCommand(c), newPRReassignCommand(c), newPRAddReviewerCommand(c), newPRRemoveReviewerCommand(c))
+	return cmd
+}
+
+func newPRListCommand(c *cli) *cobra.Command {
+	var (
+		status string
+		stale  time.Duration
+		limit  int
+	)
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List pull requests, oldest first",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			filter := domain.PRFilter{Status: domain.PRStatus(strings.ToUpper(status)), Limit: limit}
+			if stale > 0 {
+				// Merged PRs are never stale.
+				filter.Status = domain.PRStatusOpen
+				before := time.Now().Add(-stale)
+				filter.CreatedBefore = &before
+			}
+
+			resp, err := c.client.ListPRs(cmd.Context(), filter)
+			if err != nil {
+				return err
+			}
+
+			return c.print(resp, func(w *tabwriter.Writer) {
+				fmt.Fprintln(w, "PR\tNAME\tAUTHOR\tSTATUS\tAGE\tREVIEWERS")
+				for _, pr := range resp.PullRequests {
+					age := "-"
+					if pr.CreatedAt != nil {
+						age = time.Since(*pr.CreatedAt).Round(time.Hour).String()
+					}
+					fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n",
+						pr.PullRequestID, pr.PullRequestName, pr.AuthorID, pr.Status, age, strings.Join(pr.AssignedReviewers, ","))
+				}
+			})
+		},
+	}
+	cmd.Flags().StringVar(&status, "status", "", "OPEN or MERGED")
+	cmd.Flags().DurationVar(&stale, "stale", 0, "only open PRs older than this, e.g. 72h")
+	cmd.Flags().IntVar(&limit, "limit", 0, "max PRs to list (server default 100)")
+	return cmd
+}
+
+func newPRReassignCommand(c *cli) *cobra.Command {
+	var dryRun bool
+
+	cmd := &cobra.Command{
+		Use:   "reassign PR_ID OLD_REVIEWER_ID",
+		Short: "Replace a reviewer of a pull request",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			resp, err := c.client.ReassignReviewer(withDryRun(cmd.Context(), dryRun), args[0], args[1])
+			if err != nil {
+				return err
+			}
+
+			return c.print(resp, func(w *tabwriter.Writer) {
+				verb := "replaced by"
+				if resp.DryRun {
+					verb = "would be replaced by"
+				}
+				fmt.Fprintf(w, "%s: %s %s %s\n", resp.PR.PullRequestID, args[1], verb, resp.ReplacedBy)
+				fmt.Fprintf(w, "reviewers: %s\n", strings.Join(resp.PR.AssignedReviewers, ", "))
+			})
+		},
+	}
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "show the reviewer that would be picked without changing anything")
+	return cmd
+}
+
+func newPRAddReviewerCommand(c *cli) *cobra.Command {
+	var (
+		pin    bool
+		dryRun bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "add-reviewer PR_ID USER_ID",
+		Short: "Assign a reviewer to a pull request by hand",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			resp, err := c.client.AddReviewer(withDryRun(cmd.Context(), dryRun), args[0], args[1], pin)
+			if err != nil {
+				return err
+			}
+			return c.print(resp, func(w *tabwriter.Writer) { printReviewers(w, resp.PR) })
+		},
+	}
+	cmd.Flags().BoolVar(&pin, "pin", false, "keep the reviewer through reassignment and deprovisioning")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "show the reviewers without changing anything")
+	return cmd
+}
+
+func newPRRemoveReviewerCommand(c *cli) *cobra.Command {
+	var dryRun bool
+
+	cmd := &cobra.Command{
+		Use:   "remove-reviewer PR_ID USER_ID",
+		Short: "Unassign a reviewer of a pull request without a replacement",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			resp, err := c.client.RemoveReviewer(withDryRun(cmd.Context(), dryRun), args[0], args[1])
+			if err != nil {
+				return err
+			}
+			return c.print(resp, func(w *tabwriter.Writer) { printReviewers(w, resp.PR) })
+		},
+	}
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "show the reviewers without changing anything")
+	return cmd
+}
+
+func printReviewers(w *tabwriter.Writer, pr dto.PullRequestResponse) {
+	fmt.Fprintf(w, "%s reviewers: %s\n", pr.PullRequestID, strings.Join(pr.AssignedReviewers, ", "))
+	if len(pr.PinnedReviewers) > 0 {
+		fmt.Fprintf(w, "pinned: %s\n", strings.Join(pr.PinnedReviewers, ", "))
+	}
+}
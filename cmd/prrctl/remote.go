@@ -0,0 +1,244 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ssokov/pr-reviewer-service/internal/apperror"
+	"github.com/ssokov/pr-reviewer-service/internal/dryrun"
+	"github.com/ssokov/pr-reviewer-service/internal/http/middleware"
+	"github.com/ssokov/pr-reviewer-service/internal/model/domain"
+	"github.com/ssokov/pr-reviewer-service/internal/model/dto"
+	"github.com/ssokov/pr-reviewer-service/internal/roster"
+)
+
+// remoteClient calls the HTTP API of a running server with an API key or a bearer token.
+type remoteClient struct {
+	baseURL string
+	apiKey  string
+	token   string
+	org     string
+	http    *http.Client
+}
+
+func newRemoteClient(baseURL, apiKey, token, org string) *remoteClient {
+	return &remoteClient{
+		baseURL: strings.TrimRight(baseURL, "/"),
+		apiKey:  apiKey,
+		token:   token,
+		org:     org,
+		http:    &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+func (c *remoteClient) AddTeam(ctx context.Context, req dto.AddTeamRequest) (*dto.TeamResponse, error) {
+	var resp dto.AddTeamResponse
+	if err := c.do(ctx, http.MethodPost, "/team/add", nil, req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp.Team, nil
+}
+
+func (c *remoteClient) GetTeam(ctx context.Context, teamName string) (*dto.TeamResponse, error) {
+	var resp dto.TeamResponse
+	if err := c.do(ctx, http.MethodGet, "/team/get", url.Values{"team_name": {teamName}}, nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func (c *remoteClient) DeactivateTeam(ctx context.Context, teamName string) (*dto.DeactivateTeamResponse, error) {
+	var resp dto.DeactivateTeamResponse
+	if err := c.do(ctx, http.MethodPost, "/team/deactivate", nil, dto.DeactivateTeamRequest{TeamName: teamName}, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func (c *remoteClient) SetIsActive(ctx context.Context, userID string, isActive bool) (*dto.UserResponse, error) {
+	var resp dto.SetIsActiveResponse
+	if err := c.do(ctx, http.MethodPost, "/users/setIsActive", nil, dto.SetIsActiveRequest{UserID: userID, IsActive: isActive}, &resp); err != nil {
+		return nil, err
+	}
+	return &resp.User, nil
+}
+
+func (c *remoteClient) GetReview(ctx context.Context, userID string) (*dto.GetReviewResponse, error) {
+	var resp dto.GetReviewResponse
+	if err := c.do(ctx, http.MethodGet, "/users/getReview", url.Values{"user_id": {userID}}, nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func (c *remoteClient) ReassignReviewer(ctx context.Context, prID, oldUserID string) (*dto.ReassignResponse, error) {
+	var resp dto.ReassignResponse
+	if err := c.do(ctx, http.MethodPost, "/pullRequest/reassign", nil, dto.ReassignRequest{PullRequestID: prID, OldUserID: oldUserID}, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func (c *remoteClient) AddReviewer(ctx context.Context, prID, userID string, pin bool) (*dto.ReviewerResponse, error) {
+	var resp dto.ReviewerResponse
+	if err := c.do(ctx, http.MethodPost, "/pullRequest/addReviewer", nil, dto.AddReviewerRequest{PullRequestID: prID, UserID: userID, Pin: pin}, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func (c *remoteClient) RemoveReviewer(ctx context.Context, prID, userID string) (*dto.ReviewerResponse, error) {
+	var resp dto.ReviewerResponse
+	if err := c.do(ctx, http.MethodPost, "/pullRequest/removeReviewer", nil, dto.RemoveReviewerRequest{PullRequestID: prID, UserID: userID}, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func (c *remoteClient) ListPRs(ctx context.Context, filter domain.PRFilter) (*dto.ListPRsResponse, error) {
+	query := url.Values{}
+	if filter.Status != "" {
+		query.Set("status", string(filter.Status))
+	}
+	if filter.CreatedBefore != nil {
+		query.Set("older_than", time.Since(*filter.CreatedBefore).Round(time.Second).String())
+	}
+	if filter.Limit != 0 {
+		query.Set("limit", strconv.Itoa(filter.Limit))
+	}
+
+	var resp dto.ListPRsResponse
+	if err := c.do(ctx, http.MethodGet, "/pullRequest/list", query, nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func (c *remoteClient) Stats(ctx context.Context) (*dto.StatsResponse, error) {
+	var resp dto.StatsResponse
+	if err := c.do(ctx, http.MethodGet, "/stats", nil, nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// ImportRoster also decodes 422 responses: they carry the per-line errors.
+func (c *remoteClient) ImportRoster(ctx context.Context, format roster.Format, data []byte) (*dto.ImportRosterResponse, error) {
+	query := url.Values{"format": {string(format)}}
+	resp, err := c.send(ctx, http.MethodPost, "/team/import", query, format.ContentType(), bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 && resp.StatusCode != http.StatusUnprocessableEntity {
+		return nil, responseError(http.MethodPost, "/team/import", resp)
+	}
+
+	var out dto.ImportRosterResponse
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func (c *remoteClient) ExportRoster(ctx context.Context, format roster.Format) ([]byte, error) {
+	resp, err := c.send(ctx, http.MethodGet, "/team/export", url.Values{"format": {string(format)}}, "", nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return nil, responseError(http.MethodGet, "/team/export", resp)
+	}
+	return io.ReadAll(resp.Body)
+}
+
+// API keys are managed only with database access, so that a leaked key cannot mint new ones.
+func (c *remoteClient) CreateAPIKey(context.Context, string, []domain.Scope) (*domain.APIKey, string, error) {
+	return nil, "", errDirectOnly
+}
+
+func (c *remoteClient) RevokeAPIKey(context.Context, string) (*domain.APIKey, error) {
+	return nil, errDirectOnly
+}
+
+func (c *remoteClient) Close() {}
+
+// do sends body as JSON and decodes a 2xx response into out. Error responses become AppErrors with the
+// code returned by the server.
+func (c *remoteClient) do(ctx context.Context, method, path string, query url.Values, body, out any) error {
+	var (
+		reqBody     io.Reader
+		contentType string
+	)
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reqBody, contentType = bytes.NewReader(data), "application/json"
+	}
+
+	resp, err := c.send(ctx, method, path, query, contentType, reqBody)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return responseError(method, path, resp)
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+// send performs an authenticated request; the caller closes the response body.
+func (c *remoteClient) send(ctx context.Context, method, path string, query url.Values, contentType string, body io.Reader) (*http.Response, error) {
+	if dryrun.Enabled(ctx) {
+		if query == nil {
+			query = url.Values{}
+		}
+		query.Set(middleware.QueryDryRun, "true")
+	}
+
+	u := c.baseURL + path
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, u, body)
+	if err != nil {
+		return nil, err
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	if c.apiKey != "" {
+		req.Header.Set(middleware.HeaderAPIKey, c.apiKey)
+	}
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+	if c.org != "" {
+		req.Header.Set(middleware.HeaderOrganization, c.org)
+	}
+
+	return c.http.Do(req)
+}
+
+func responseError(method, path string, resp *http.Response) error {
+	var errResp dto.ErrorResponse
+	if err := json.NewDecoder(resp.Body).Decode(&errResp); err != nil || errResp.Error.Code == "" {
+		return fmt.Errorf("%s %s: %s", method, path, resp.Status)
+	}
+	return apperror.New(apperror.ErrorCode(errResp.Error.Code), errResp.Error.Message)
+}
@@ -0,0 +1,118 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ssokov/pr-reviewer-service/internal/apperror"
+	"github.com/ssokov/pr-reviewer-service/internal/model/domain"
+	"github.com/ssokov/pr-reviewer-service/internal/model/dto"
+	"github.com/ssokov/pr-reviewer-service/internal/roster"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRemoteClient_SendsCredentialsAndDecodes(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/pullRequest/list", r.URL.Path)
+		assert.Equal(t, "OPEN", r.URL.Query().Get("status"))
+		assert.Equal(t, "prr_key", r.Header.Get("X-API-Key"))
+		assert.Equal(t, "acme", r.Header.Get("X-Organization"))
+
+		_ = json.NewEncoder(w).Encode(dto.ListPRsResponse{PullRequests: []dto.PullRequestResponse{{PullRequestID: "pr-1"}}})
+	}))
+	defer srv.Close()
+
+	cl := newRemoteClient(srv.URL+"/", "prr_key", "", "acme")
+	resp, err := cl.ListPRs(context.Background(), domain.PRFilter{Status: domain.PRStatusOpen})
+
+	require.NoError(t, err)
+	require.Len(t, resp.PullRequests, 1)
+	assert.Equal(t, "pr-1", resp.PullRequests[0].PullRequestID)
+}
+
+func TestRemoteClient_ErrorResponse(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		var req dto.ReassignRequest
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		assert.Equal(t, dto.ReassignRequest{PullRequestID: "pr-1", OldUserID: "u2"}, req)
+
+		w.WriteHeader(http.StatusConflict)
+		_ = json.NewEncoder(w).Encode(dto.ErrorResponse{Error: dto.ErrorDetail{Code: "NOT_ASSIGNED", Message: "user 'u2' is not assigned to PR 'pr-1'"}})
+	}))
+	defer srv.Close()
+
+	_, err := newRemoteClient(srv.URL, "", "token", "").ReassignReviewer(context.Background(), "pr-1", "u2")
+
+	require.Error(t, err)
+	assert.True(t, apperror.Is(err, apperror.ErrCodeNotAssigned))
+	assert.Contains(t, err.Error(), "is not assigned")
+}
+
+func TestRemoteClient_AddReviewer(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/pullRequest/addReviewer", r.URL.Path)
+		assert.Equal(t, "true", r.URL.Query().Get("dry_run"))
+		var req dto.AddReviewerRequest
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		assert.Equal(t, dto.AddReviewerRequest{PullRequestID: "pr-1", UserID: "u3", Pin: true}, req)
+
+		_ = json.NewEncoder(w).Encode(dto.ReviewerResponse{
+			PR:     dto.PullRequestResponse{PullRequestID: "pr-1", AssignedReviewers: []string{"u2", "u3"}, PinnedReviewers: []string{"u3"}},
+			DryRun: true,
+		})
+	}))
+	defer srv.Close()
+
+	resp, err := newRemoteClient(srv.URL, "prr_key", "", "").AddReviewer(withDryRun(context.Background(), true), "pr-1", "u3", true)
+
+	require.NoError(t, err)
+	assert.True(t, resp.DryRun)
+	assert.Equal(t, []string{"u3"}, resp.PR.PinnedReviewers)
+}
+
+func TestRemoteClient_APIKeysAreDirectOnly(t *testing.T) {
+	_, _, err := newRemoteClient("http://localhost", "", "", "").CreateAPIKey(context.Background(), "ci", nil)
+	assert.ErrorIs(t, err, errDirectOnly)
+}
+
+func TestRemoteClient_DryRun(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/team/deactivate", r.URL.Path)
+		assert.Equal(t, "true", r.URL.Query().Get("dry_run"))
+
+		_ = json.NewEncoder(w).Encode(dto.DeactivateTeamResponse{DeactivatedUsers: 2, PullRequests: []string{"pr-1"}, DryRun: true})
+	}))
+	defer srv.Close()
+
+	resp, err := newRemoteClient(srv.URL, "prr_key", "", "").DeactivateTeam(withDryRun(context.Background(), true), "backend")
+
+	require.NoError(t, err)
+	assert.True(t, resp.DryRun)
+	assert.Equal(t, []string{"pr-1"}, resp.PullRequests)
+}
+
+func TestRemoteClient_ImportRosterLineErrors(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/team/import", r.URL.Path)
+		assert.Equal(t, "csv", r.URL.Query().Get("format"))
+		assert.Equal(t, "text/csv; charset=utf-8", r.Header.Get("Content-Type"))
+
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		_ = json.NewEncoder(w).Encode(dto.ImportRosterResponse{
+			Errors: []dto.RosterLineErrorResponse{{Line: 3, Message: "username is required"}},
+		})
+	}))
+	defer srv.Close()
+
+	resp, err := newRemoteClient(srv.URL, "prr_key", "", "").
+		ImportRoster(context.Background(), roster.FormatCSV, []byte("team_name,user_id,username\n"))
+
+	require.NoError(t, err)
+	assert.False(t, resp.Applied)
+	assert.Equal(t, []dto.RosterLineErrorResponse{{Line: 3, Message: "username is required"}}, resp.Errors)
+}
@@ -0,0 +1,138 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"github.com/ssokov/pr-reviewer-service/internal/roster"
+)
+
+func newRosterCommand(c *cli) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "roster",
+		Short: "Import and export team rosters as CSV, JSON or YAML",
+	}
+	cmd.AddCommand(newRosterImportCommand(c), newRosterExportCommand(c))
+	return cmd
+}
+
+func newRosterImportCommand(c *cli) *cobra.Command {
+	var (
+		file   string
+		format string
+		dryRun bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "import -f FILE",
+		Short: "Apply a roster: create teams and users, move and (de)activate users",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			f, err := rosterFormat(format, file)
+			if err != nil {
+				return err
+			}
+			data, err := readInput(file)
+			if err != nil {
+				return err
+			}
+
+			resp, err := c.client.ImportRoster(withDryRun(cmd.Context(), dryRun), f, data)
+			if err != nil {
+				return err
+			}
+
+			err = c.print(resp, func(w *tabwriter.Writer) {
+				switch {
+				case len(resp.Errors) > 0:
+					fmt.Fprintf(w, "%d invalid lines, nothing applied\n", len(resp.Errors))
+				case resp.DryRun:
+					fmt.Fprintf(w, "would apply %d changes\n", len(resp.Changes))
+				default:
+					fmt.Fprintf(w, "applied %d changes\n", len(resp.Changes))
+				}
+
+				if len(resp.Changes) > 0 {
+					fmt.Fprintln(w, "\nLINE\tACTION\tTEAM\tUSER\tFROM\tTO")
+					for _, ch := range resp.Changes {
+						fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%s\t%s\n", ch.Line, ch.Action, ch.TeamName, ch.UserID, ch.From, ch.To)
+					}
+				}
+				if len(resp.Errors) > 0 {
+					fmt.Fprintln(w, "\nLINE\tERROR")
+					for _, e := range resp.Errors {
+						fmt.Fprintf(w, "%d\t%s\n", e.Line, e.Message)
+					}
+				}
+			})
+			if err != nil {
+				return err
+			}
+			if len(resp.Errors) > 0 {
+				return fmt.Errorf("roster has %d invalid lines", len(resp.Errors))
+			}
+			return nil
+		},
+	}
+	cmd.Flags().StringVarP(&file, "file", "f", "", "roster file, - for stdin")
+	cmd.Flags().StringVar(&format, "format", "", "csv, json or yaml (default from the file extension)")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "show the diff without applying it")
+	_ = cmd.MarkFlagRequired("file")
+	return cmd
+}
+
+func newRosterExportCommand(c *cli) *cobra.Command {
+	var (
+		file   string
+		format string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Write all teams and their members as a roster",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			f, err := rosterFormat(format, file)
+			if err != nil {
+				return err
+			}
+
+			data, err := c.client.ExportRoster(cmd.Context(), f)
+			if err != nil {
+				return err
+			}
+
+			if file == "" || file == "-" {
+				_, err = os.Stdout.Write(data)
+				return err
+			}
+			return os.WriteFile(file, data, 0o644)
+		},
+	}
+	cmd.Flags().StringVarP(&file, "file", "f", "", "output file (default stdout)")
+	cmd.Flags().StringVar(&format, "format", "", "csv, json or yaml (default from the file extension, json for stdout)")
+	return cmd
+}
+
+// rosterFormat takes the explicit --format, then the file extension, and falls back to JSON for stdout.
+func rosterFormat(format, file string) (roster.Format, error) {
+	if format != "" {
+		return roster.ParseFormat(format)
+	}
+	if ext := strings.TrimPrefix(filepath.Ext(file), "."); ext != "" {
+		return roster.ParseFormat(ext)
+	}
+	return roster.FormatJSON, nil
+}
+
+func readInput(path string) ([]byte, error) {
+	if path == "-" {
+		return io.ReadAll(os.Stdin)
+	}
+	return os.ReadFile(path)
+}
@@ -0,0 +1,31 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/ssokov/pr-reviewer-service/internal/roster"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRosterFormat(t *testing.T) {
+	tests := []struct {
+		format string
+		file   string
+		want   roster.Format
+	}{
+		{file: "teams.csv", want: roster.FormatCSV},
+		{file: "teams.yml", want: roster.FormatYAML},
+		{format: "csv", file: "teams.txt", want: roster.FormatCSV},
+		{file: "-", want: roster.FormatJSON},
+		{file: "", want: roster.FormatJSON},
+	}
+
+	for _, tt := range tests {
+		got, err := rosterFormat(tt.format, tt.file)
+		assert.NoError(t, err)
+		assert.Equal(t, tt.want, got, "format %q file %q", tt.format, tt.file)
+	}
+
+	_, err := rosterFormat("", "teams.xlsx")
+	assert.Error(t, err)
+}
@@ -0,0 +1,35 @@
+package main
+
+import (
+	"fmt"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+)
+
+func newStatsCommand(c *cli) *cobra.Command {
+	return &cobra.Command{
+		Use:   "stats",
+		Short: "Show PR and reviewer statistics",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			stats, err := c.client.Stats(cmd.Context())
+			if err != nil {
+				return err
+			}
+
+			return c.print(stats, func(w *tabwriter.Writer) {
+				fmt.Fprintf(w, "PRs\t%d\n", stats.TotalPRs)
+				for _, s := range stats.PRsByStatus {
+					fmt.Fprintf(w, "  %s\t%d\n", s.Status, s.Count)
+				}
+				fmt.Fprintf(w, "users\t%d (%d active)\n\n", stats.TotalUsers, stats.ActiveUsers)
+
+				fmt.Fprintln(w, "REVIEWER\tNAME\tASSIGNED\tACTIVE\tCOMPLETED")
+				for _, r := range stats.TopReviewers {
+					fmt.Fprintf(w, "%s\t%s\t%d\t%d\t%d\n", r.UserID, r.Username, r.AssignedCount, r.ActiveCount, r.CompletedCount)
+				}
+			})
+		},
+	}
+}
@@ -0,0 +1,125 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"github.com/ssokov/pr-reviewer-service/internal/model/dto"
+)
+
+func newTeamCommand(c *cli) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "team",
+		Short: "Manage teams",
+	}
+	cmd.AddCommand(newTeamImportCommand(c), newTeamGetCommand(c), newTeamDeactivateCommand(c))
+	return cmd
+}
+
+func newTeamImportCommand(c *cli) *cobra.Command {
+	var file string
+
+	cmd := &cobra.Command{
+		Use:   "import -f FILE",
+		Short: "Create a team from a JSON file in the /team/add format",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			req, err := readTeamFile(file)
+			if err != nil {
+				return err
+			}
+
+			team, err := c.client.AddTeam(cmd.Context(), req)
+			if err != nil {
+				return err
+			}
+			return c.printTeam(team)
+		},
+	}
+	cmd.Flags().StringVarP(&file, "file", "f", "", "team JSON file, - for stdin")
+	_ = cmd.MarkFlagRequired("file")
+	return cmd
+}
+
+func newTeamGetCommand(c *cli) *cobra.Command {
+	return &cobra.Command{
+		Use:   "get TEAM",
+		Short: "Show a team and its members",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			team, err := c.client.GetTeam(cmd.Context(), args[0])
+			if err != nil {
+				return err
+			}
+			return c.printTeam(team)
+		},
+	}
+}
+
+func newTeamDeactivateCommand(c *cli) *cobra.Command {
+	var dryRun bool
+
+	cmd := &cobra.Command{
+		Use:   "deactivate TEAM",
+		Short: "Deactivate all members of a team",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			resp, err := c.client.DeactivateTeam(withDryRun(cmd.Context(), dryRun), args[0])
+			if err != nil {
+				return err
+			}
+
+			return c.print(resp, func(w *tabwriter.Writer) {
+				verb := "deactivated"
+				if resp.DryRun {
+					verb = "would deactivate"
+				}
+				fmt.Fprintf(w, "%s %d users, %d open PRs affected\n", verb, resp.DeactivatedUsers, resp.ReassignedPRs)
+				if len(resp.PullRequests) > 0 {
+					fmt.Fprintf(w, "pull requests: %s\n", strings.Join(resp.PullRequests, ", "))
+				}
+				fmt.Fprintln(w)
+				fmt.Fprintln(w, "USER\tNAME\tOPEN REVIEWS")
+				for _, u := range resp.Users {
+					fmt.Fprintf(w, "%s\t%s\t%d\n", u.UserID, u.Username, u.OpenPRsCount)
+				}
+			})
+		},
+	}
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "show who would be deactivated and which PRs are affected without changing anything")
+	return cmd
+}
+
+func (c *cli) printTeam(team *dto.TeamResponse) error {
+	return c.print(team, func(w *tabwriter.Writer) {
+		fmt.Fprintf(w, "team %s\n\n", team.TeamName)
+		fmt.Fprintln(w, "USER\tNAME\tACTIVE")
+		for _, m := range team.Members {
+			fmt.Fprintf(w, "%s\t%s\t%t\n", m.UserID, m.Username, m.IsActive)
+		}
+	})
+}
+
+func readTeamFile(path string) (dto.AddTeamRequest, error) {
+	var req dto.AddTeamRequest
+
+	var r io.Reader = os.Stdin
+	if path != "-" {
+		f, err := os.Open(path)
+		if err != nil {
+			return req, err
+		}
+		defer f.Close()
+		r = f
+	}
+
+	if err := json.NewDecoder(r).Decode(&req); err != nil {
+		return req, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	return req, nil
+}
@@ -0,0 +1,41 @@
+package main
+
+import (
+	"fmt"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+)
+
+func newUserCommand(c *cli) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "user",
+		Short: "Manage users",
+	}
+	cmd.AddCommand(newUserSetActiveCommand(c))
+	return cmd
+}
+
+func newUserSetActiveCommand(c *cli) *cobra.Command {
+	var active, dryRun bool
+
+	cmd := &cobra.Command{
+		Use:   "set-active USER_ID",
+		Short: "Activate or deactivate a user",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			user, err := c.client.SetIsActive(withDryRun(cmd.Context(), dryRun), args[0], active)
+			if err != nil {
+				return err
+			}
+
+			return c.print(user, func(w *tabwriter.Writer) {
+				fmt.Fprintln(w, "USER\tNAME\tTEAM\tACTIVE")
+				fmt.Fprintf(w, "%s\t%s\t%s\t%t\n", user.UserID, user.Username, user.TeamName, user.IsActive)
+			})
+		},
+	}
+	cmd.Flags().BoolVar(&active, "active", true, "new state, --active=false to deactivate")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "validate the change without applying it")
+	return cmd
+}
@@ -2,15 +2,23 @@ package app
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net"
+	nethttp "net/http"
 	"time"
 
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/labstack/echo/v4"
 	config "github.com/ssokov/pr-reviewer-service/cfg"
+	"github.com/ssokov/pr-reviewer-service/internal/auth"
 	"github.com/ssokov/pr-reviewer-service/internal/http"
+	"github.com/ssokov/pr-reviewer-service/internal/http/middleware"
+	"github.com/ssokov/pr-reviewer-service/internal/metrics"
+	"github.com/ssokov/pr-reviewer-service/internal/notify"
 	postgres "github.com/ssokov/pr-reviewer-service/internal/repository/postgres"
 	"github.com/ssokov/pr-reviewer-service/internal/service"
+	"github.com/ssokov/pr-reviewer-service/migrations"
 	"github.com/vmkteam/embedlog"
 )
 
@@ -21,10 +29,23 @@ type App struct {
 	db      *pgxpool.Pool
 	echo    *echo.Echo
 
-	userService  service.UserService
-	prService    service.PRService
-	teamService  service.TeamService
-	statsService service.StatsService
+	userService       service.UserService
+	prService         service.PRService
+	teamService       service.TeamService
+	rosterService     service.RosterService
+	dirService        service.DirectoryService
+	codeOwnersService service.CodeOwnersService
+	skillService      service.SkillService
+	poolService       service.PoolService
+	hierarchyService  service.HierarchyService
+	statsService      service.StatsService
+	auditService      service.AuditService
+	apiKeyService     service.APIKeyService
+	orgService        service.OrganizationService
+	healthService     service.HealthService
+
+	metricsRefresher *service.MetricsRefresher
+	pendingAssigner  *service.PendingAssigner
 }
 
 func New(appName string, slogger embedlog.Logger, c *config.Config, db *pgxpool.Pool) *App {
@@ -41,7 +62,21 @@ func New(appName string, slogger embedlog.Logger, c *config.Config, db *pgxpool.
 		a.userService,
 		a.prService,
 		a.teamService,
+		a.rosterService,
+		a.hierarchyService,
+		a.dirService,
+		a.codeOwnersService,
+		a.skillService,
+		a.poolService,
 		a.statsService,
+		a.auditService,
+		a.apiKeyService,
+		a.orgService,
+		a.healthService,
+		a.tokenValidator(),
+		a.config.Auth.Enabled,
+		a.rateLimiter(),
+		middleware.IPExtractor(a.config.Server.TrustedProxyNets()),
 	)
 	return a
 }
@@ -52,31 +87,200 @@ func (a *App) initDependencies() {
 	teamRepo := postgres.NewTeamRepository(a.db)
 	prRepo := postgres.NewPRRepository(a.db)
 	statsRepo := postgres.NewStatsRepository(a.db)
+	apiKeyRepo := postgres.NewAPIKeyRepository(a.db)
+	auditRepo := postgres.NewAuditRepository(a.db)
+	orgRepo := postgres.NewOrganizationRepository(a.db)
+	codeOwnersRepo := postgres.NewCodeOwnersRepository(a.db)
+	skillRepo := postgres.NewSkillRepository(a.db)
+	poolRepo := postgres.NewPoolRepository(a.db)
+	healthRepo := postgres.NewHealthRepository(a.db)
+	transactor := postgres.NewTransactor(a.db)
 
 	// init services
-	a.prService = service.NewPRService(prRepo, userRepo, teamRepo, a.sl)
-	a.teamService = service.NewTeamService(teamRepo, userRepo, prRepo, a.sl)
-	a.userService = service.NewUserService(userRepo, teamRepo, a.sl)
+	a.prService = service.NewDryRunPRService(service.NewAuditedPRService(
+		service.NewInstrumentedPRService(service.NewTracedPRService(service.NewPRService(prRepo, userRepo, teamRepo, codeOwnersRepo, skillRepo, poolRepo, a.sl)), prRepo),
+		prRepo, auditRepo, a.sl,
+	), transactor)
+	a.teamService = service.NewDryRunTeamService(service.NewAuditedTeamService(
+		service.NewTracedTeamService(service.NewTeamService(teamRepo, userRepo, prRepo, a.sl)),
+		teamRepo, auditRepo, a.sl,
+	), transactor)
+	a.userService = service.NewDryRunUserService(
+		service.NewAuditedUserService(service.NewUserService(userRepo, teamRepo, a.sl), userRepo, auditRepo, a.sl),
+		transactor,
+	)
+	a.rosterService = service.NewAuditedRosterService(service.NewRosterService(teamRepo, userRepo, transactor, a.sl), auditRepo, a.sl)
+	a.dirService = service.NewDirectoryService(userRepo, teamRepo, a.userService, a.prService, transactor, a.sl)
+	a.codeOwnersService = service.NewAuditedCodeOwnersService(
+		service.NewCodeOwnersService(codeOwnersRepo, userRepo, teamRepo, a.sl), codeOwnersRepo, auditRepo, a.sl,
+	)
+	a.skillService = service.NewAuditedSkillService(
+		service.NewSkillService(skillRepo, userRepo, prRepo, a.sl), skillRepo, prRepo, auditRepo, a.sl,
+	)
+	a.poolService = service.NewAuditedPoolService(
+		service.NewPoolService(poolRepo, userRepo, teamRepo, a.sl), poolRepo, teamRepo, auditRepo, a.sl,
+	)
+	a.hierarchyService = service.NewAuditedHierarchyService(
+		service.NewHierarchyService(teamRepo, poolRepo, transactor, a.sl), teamRepo, auditRepo, a.sl,
+	)
 	a.statsService = service.NewStatsService(statsRepo, a.sl)
+	a.apiKeyService = service.NewAPIKeyService(apiKeyRepo, a.sl)
+	a.auditService = service.NewAuditService(auditRepo, a.sl)
+	a.orgService = service.NewOrganizationService(orgRepo, a.sl)
+	a.metricsRefresher = service.NewMetricsRefresher(statsRepo, orgRepo, a.sl)
+	a.pendingAssigner = service.NewPendingAssigner(a.prService, orgRepo, a.notifier(), a.sl)
+
+	migrationVersion, err := migrations.LatestVersion()
+	if err != nil {
+		a.sl.Errorf("failed to read embedded migrations: %v", err)
+	}
+	a.healthService = service.NewHealthService(healthRepo, migrationVersion, []service.Worker{a.metricsRefresher, a.pendingAssigner}, a.sl)
+
+	if err := metrics.RegisterPool(a.db); err != nil {
+		a.sl.Errorf("failed to register db pool metrics: %v", err)
+	}
 }
 
-func (a *App) Run(ctx context.Context) error {
-	addr := fmt.Sprintf("%s:%d", a.config.Server.Host, a.config.Server.Port)
-	a.sl.Print(ctx, "starting server", "addr", addr)
+// notifier posts to the configured webhook, or only logs notifications without one.
+func (a *App) notifier() service.Notifier {
+	nCfg := a.config.Notifications
+	if nCfg.WebhookURL == "" {
+		return notify.NewLog(a.sl)
+	}
+
+	var client *nethttp.Client
+	if nCfg.Timeout > 0 {
+		client = &nethttp.Client{Timeout: nCfg.Timeout}
+	}
+	return notify.NewWebhook(nCfg.WebhookURL, client)
+}
+
+// tokenValidator returns nil when bearer tokens are disabled, so only API keys are accepted.
+func (a *App) tokenValidator() middleware.TokenValidator {
+	jwtCfg := a.config.Auth.JWT
+	if !jwtCfg.Enabled {
+		return nil
+	}
+
+	jwks := auth.NewJWKSCache(jwtCfg.JWKSURL, jwtCfg.JWKSCacheTTL, nil)
+	return auth.NewJWTValidator(auth.JWTConfig{
+		Issuer:            jwtCfg.Issuer,
+		Audience:          jwtCfg.Audience,
+		UserIDClaim:       jwtCfg.UserIDClaim,
+		RolesClaim:        jwtCfg.RolesClaim,
+		OrganizationClaim: jwtCfg.OrganizationClaim,
+	}, jwks)
+}
 
-	serverErr := make(chan error, 1)
-	go func() {
-		if err := a.echo.Start(addr); err != nil {
-			serverErr <- err
+// rateLimiter returns nil when rate limiting is disabled in the config.
+func (a *App) rateLimiter() *middleware.RateLimiter {
+	rlCfg := a.config.RateLimit
+	if !rlCfg.Enabled {
+		return nil
+	}
+
+	routes := make(map[string]middleware.Limit, len(rlCfg.Routes))
+	for _, r := range rlCfg.Routes {
+		routes[r.Path] = middleware.Limit{
+			RequestsPerSecond: r.RequestsPerSecond,
+			Burst:             r.Burst,
+			MaxBodyBytes:      r.MaxBodyBytes,
 		}
-	}()
-
-	select {
-	case err := <-serverErr:
-		return err
-	case <-ctx.Done():
-		shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-		defer cancel()
-		return a.echo.Shutdown(shutdownCtx)
+	}
+
+	return middleware.NewRateLimiter(middleware.RateLimitConfig{
+		Default: middleware.Limit{
+			RequestsPerSecond: rlCfg.RequestsPerSecond,
+			Burst:             rlCfg.Burst,
+			MaxBodyBytes:      rlCfg.MaxBodyBytes,
+		},
+		Routes: routes,
+		PerIP: middleware.Limit{
+			RequestsPerSecond: rlCfg.PerIPRequestsPerSecond,
+			Burst:             rlCfg.PerIPBurst,
+		},
+	})
+}
+
+// Run starts the database-backed workers and the HTTP server and blocks until ctx is done. Shutdown drains
+// in-flight requests, stops the workers and closes the pool, in that order.
+func (a *App) Run(ctx context.Context) error {
+	lc := NewLifecycle(a.sl, a.config.Server.ShutdownTimeout)
+	lc.Append(Component{
+		Name: "database",
+		Stop: func(context.Context) error {
+			a.db.Close()
+			return nil
+		},
+	})
+	lc.Append(a.workerComponent("metrics_refresher", a.config.Metrics.RefreshInterval, a.metricsRefresher.Run))
+	lc.Append(a.workerComponent("pending_assigner", a.config.Assignment.RetryInterval, a.pendingAssigner.Run))
+	lc.Append(a.httpComponent())
+
+	return lc.Run(ctx)
+}
+
+func (a *App) httpComponent() Component {
+	return Component{
+		Name: "http",
+		Start: func(ctx context.Context, fail func(error)) error {
+			addr := fmt.Sprintf("%s:%d", a.config.Server.Host, a.config.Server.Port)
+			ln, err := net.Listen("tcp", addr)
+			if err != nil {
+				return err
+			}
+			a.echo.Listener = ln
+
+			a.sl.Print(ctx, "starting server", "addr", addr)
+			go func() {
+				if err := a.echo.Start(addr); err != nil && !errors.Is(err, nethttp.ErrServerClosed) {
+					fail(err)
+				}
+			}()
+			return nil
+		},
+		Stop: func(ctx context.Context) error {
+			return a.echo.Shutdown(ctx)
+		},
+	}
+}
+
+// workerComponent runs fn every interval in the background; a zero interval disables the worker.
+func (a *App) workerComponent(name string, interval time.Duration, fn func(ctx context.Context, interval time.Duration)) Component {
+	var (
+		cancel context.CancelFunc
+		done   chan struct{}
+	)
+
+	return Component{
+		Name: name,
+		Start: func(ctx context.Context, _ func(error)) error {
+			if interval <= 0 {
+				return nil
+			}
+
+			// The worker outlives ctx until it is stopped, so that it is stopped after the HTTP server.
+			var workerCtx context.Context
+			workerCtx, cancel = context.WithCancel(context.WithoutCancel(ctx))
+			done = make(chan struct{})
+			go func() {
+				defer close(done)
+				fn(workerCtx, interval)
+			}()
+			return nil
+		},
+		Stop: func(ctx context.Context) error {
+			if cancel == nil {
+				return nil
+			}
+
+			cancel()
+			select {
+			case <-done:
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		},
 	}
 }
@@ -0,0 +1,107 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/vmkteam/embedlog"
+)
+
+const defaultShutdownTimeout = 15 * time.Second
+
+// Component is a part of the application that is started and stopped by the Lifecycle.
+type Component struct {
+	Name string
+	// Start must not block: long-running work goes to a goroutine that reports fatal errors through fail.
+	Start func(ctx context.Context, fail func(error)) error
+	// Stop releases the component; it must return once ctx is done.
+	Stop func(ctx context.Context) error
+}
+
+// Lifecycle starts components in the order they were added and stops them in reverse order.
+type Lifecycle struct {
+	logger          embedlog.Logger
+	shutdownTimeout time.Duration
+	components      []Component
+
+	failOnce sync.Once
+	failed   chan error
+}
+
+func NewLifecycle(logger embedlog.Logger, shutdownTimeout time.Duration) *Lifecycle {
+	if shutdownTimeout <= 0 {
+		shutdownTimeout = defaultShutdownTimeout
+	}
+	return &Lifecycle{
+		logger:          logger,
+		shutdownTimeout: shutdownTimeout,
+		failed:          make(chan error, 1),
+	}
+}
+
+func (l *Lifecycle) Append(c Component) {
+	l.components = append(l.components, c)
+}
+
+// Run starts all components and blocks until ctx is done or a component fails, then stops everything that was
+// started within the shutdown timeout. The returned error joins the failure, if any, with every stop error.
+func (l *Lifecycle) Run(ctx context.Context) error {
+	var runErr error
+	started := 0
+	for _, c := range l.components {
+		if c.Start != nil {
+			if err := c.Start(ctx, l.fail(c.Name)); err != nil {
+				runErr = fmt.Errorf("start %s: %w", c.Name, err)
+				break
+			}
+		}
+		started++
+		l.logger.Print(ctx, "component started", "component", c.Name)
+	}
+
+	if runErr == nil {
+		select {
+		case <-ctx.Done():
+			l.logger.Print(ctx, "shutting down")
+		case runErr = <-l.failed:
+			l.logger.Errorf("shutting down after failure: %v", runErr)
+		}
+	}
+
+	return errors.Join(runErr, l.stop(started))
+}
+
+// stop stops the first n components in reverse order. A component that fails to stop does not keep the
+// others from stopping.
+func (l *Lifecycle) stop(n int) error {
+	ctx, cancel := context.WithTimeout(context.Background(), l.shutdownTimeout)
+	defer cancel()
+
+	var errs []error
+	for i := n - 1; i >= 0; i-- {
+		c := l.components[i]
+		if c.Stop == nil {
+			continue
+		}
+
+		start := time.Now()
+		if err := c.Stop(ctx); err != nil {
+			l.logger.Errorf("failed to stop %s: %v", c.Name, err)
+			errs = append(errs, fmt.Errorf("stop %s: %w", c.Name, err))
+			continue
+		}
+		l.logger.Print(ctx, "component stopped", "component", c.Name, "duration", time.Since(start))
+	}
+	return errors.Join(errs...)
+}
+
+func (l *Lifecycle) fail(name string) func(error) {
+	return func(err error) {
+		l.failOnce.Do(func() {
+			l.failed <- fmt.Errorf("%s: %w", name, err)
+		})
+	}
+}
@@ -0,0 +1,94 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vmkteam/embedlog"
+)
+
+type recorder struct {
+	events []string
+}
+
+func (r *recorder) component(name string, startErr, stopErr error) Component {
+	return Component{
+		Name: name,
+		Start: func(context.Context, func(error)) error {
+			r.events = append(r.events, "start "+name)
+			return startErr
+		},
+		Stop: func(context.Context) error {
+			r.events = append(r.events, "stop "+name)
+			return stopErr
+		},
+	}
+}
+
+func TestLifecycle_StopsInReverseOrder(t *testing.T) {
+	rec := &recorder{}
+	lc := NewLifecycle(embedlog.NewLogger(false, false), time.Second)
+	lc.Append(rec.component("database", nil, nil))
+	lc.Append(rec.component("worker", nil, errors.New("still running")))
+	lc.Append(rec.component("http", nil, nil))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := lc.Run(ctx)
+	require.Error(t, err)
+	assert.EqualError(t, err, "stop worker: still running")
+	assert.Equal(t, []string{
+		"start database", "start worker", "start http",
+		"stop http", "stop worker", "stop database",
+	}, rec.events)
+}
+
+func TestLifecycle_StartFailure(t *testing.T) {
+	rec := &recorder{}
+	lc := NewLifecycle(embedlog.NewLogger(false, false), time.Second)
+	lc.Append(rec.component("database", nil, nil))
+	lc.Append(rec.component("http", errors.New("address already in use"), nil))
+
+	err := lc.Run(context.Background())
+	assert.EqualError(t, err, "start http: address already in use")
+	assert.Equal(t, []string{"start database", "start http", "stop database"}, rec.events)
+}
+
+func TestLifecycle_ComponentFailure(t *testing.T) {
+	rec := &recorder{}
+	lc := NewLifecycle(embedlog.NewLogger(false, false), time.Second)
+	lc.Append(rec.component("database", nil, nil))
+	lc.Append(Component{
+		Name: "http",
+		Start: func(_ context.Context, fail func(error)) error {
+			go fail(errors.New("listener closed"))
+			return nil
+		},
+	})
+
+	err := lc.Run(context.Background())
+	assert.EqualError(t, err, "http: listener closed")
+	assert.Equal(t, []string{"start database", "stop database"}, rec.events)
+}
+
+func TestLifecycle_ShutdownTimeout(t *testing.T) {
+	lc := NewLifecycle(embedlog.NewLogger(false, false), 10*time.Millisecond)
+	lc.Append(Component{
+		Name: "http",
+		Stop: func(ctx context.Context) error {
+			<-ctx.Done()
+			return ctx.Err()
+		},
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := lc.Run(ctx)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
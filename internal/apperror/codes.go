@@ -7,12 +7,20 @@ const (
 	ErrCodeTeamNotFound ErrorCode = "TEAM_NOT_FOUND"
 
 	ErrCodeUserNotFound ErrorCode = "USER_NOT_FOUND"
+	ErrCodeUserExists   ErrorCode = "USER_EXISTS"
 
 	ErrCodePRExists    ErrorCode = "PR_EXISTS"
 	ErrCodePRNotFound  ErrorCode = "PR_NOT_FOUND"
 	ErrCodePRMerged    ErrorCode = "PR_MERGED"
 	ErrCodeNotAssigned ErrorCode = "NOT_ASSIGNED"
 	ErrCodeNoCandidate ErrorCode = "NO_CANDIDATE"
+	ErrCodePinned      ErrorCode = "REVIEWER_PINNED"
+
+	ErrCodeUnauthorized ErrorCode = "UNAUTHORIZED"
+	ErrCodeForbidden    ErrorCode = "FORBIDDEN"
+
+	ErrCodeRateLimited     ErrorCode = "RATE_LIMITED"
+	ErrCodeRequestTooLarge ErrorCode = "REQUEST_TOO_LARGE"
 
 	ErrCodeNotFound      ErrorCode = "NOT_FOUND"
 	ErrCodeInvalidInput  ErrorCode = "INVALID_INPUT"
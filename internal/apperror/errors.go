@@ -37,6 +37,15 @@ func Wrap(code ErrorCode, message string, err error) *AppError {
 	}
 }
 
+// CodeOf returns the code of an AppError in the chain of err, or ErrCodeInternalError for any other error.
+func CodeOf(err error) ErrorCode {
+	var appErr *AppError
+	if errors.As(err, &appErr) {
+		return appErr.Code
+	}
+	return ErrCodeInternalError
+}
+
 func Is(err error, code ErrorCode) bool {
 	var appErr *AppError
 	if errors.As(err, &appErr) {
@@ -57,6 +66,10 @@ func NewUserNotFoundError(userID string) *AppError {
 	return New(ErrCodeUserNotFound, fmt.Sprintf("user '%s' not found", userID))
 }
 
+func NewUserExistsError(userID string) *AppError {
+	return New(ErrCodeUserExists, fmt.Sprintf("user '%s' already exists", userID))
+}
+
 func NewPRExistsError(prID string) *AppError {
 	return New(ErrCodePRExists, fmt.Sprintf("pull request '%s' already exists", prID))
 }
@@ -73,10 +86,41 @@ func NewNotAssignedError(userID, prID string) *AppError {
 	return New(ErrCodeNotAssigned, fmt.Sprintf("user '%s' is not assigned to PR '%s'", userID, prID))
 }
 
+func NewPinnedError(userID, prID string) *AppError {
+	return New(ErrCodePinned, fmt.Sprintf("reviewer '%s' is pinned to PR '%s'", userID, prID))
+}
+
 func NewNoCandidateError(teamName string) *AppError {
 	return New(ErrCodeNoCandidate, fmt.Sprintf("no active candidate available in team '%s'", teamName))
 }
 
+// NewNoSkilledReviewerError reports that neither the author's team nor its fallback chain has a reviewer with a
+// skill the PR labels require.
+func NewNoSkilledReviewerError(skill string) *AppError {
+	return New(ErrCodeNoCandidate, fmt.Sprintf("no active reviewer with skill '%s' available in team or its fallback chain", skill))
+}
+
+// NewAtCapacityError reports that every candidate reviewer in a team is at their max_open_reviews limit.
+func NewAtCapacityError(teamName string, candidates int) *AppError {
+	return New(ErrCodeNoCandidate, fmt.Sprintf("all %d active reviewers in team '%s' are at their max_open_reviews limit", candidates, teamName))
+}
+
+func NewUnauthorizedError(message string) *AppError {
+	return New(ErrCodeUnauthorized, message)
+}
+
+func NewForbiddenError(message string) *AppError {
+	return New(ErrCodeForbidden, message)
+}
+
+func NewRateLimitedError() *AppError {
+	return New(ErrCodeRateLimited, "rate limit exceeded, retry later")
+}
+
+func NewRequestTooLargeError(limit int64) *AppError {
+	return New(ErrCodeRequestTooLarge, fmt.Sprintf("request body exceeds %d bytes", limit))
+}
+
 func NewNotFoundError(resource string) *AppError {
 	return New(ErrCodeNotFound, fmt.Sprintf("%s not found", resource))
 }
@@ -0,0 +1,111 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/ssokov/pr-reviewer-service/internal/model/domain"
+)
+
+type JWTConfig struct {
+	Issuer      string
+	Audience    string
+	UserIDClaim string
+	RolesClaim  string
+	// OrganizationClaim names the claim holding the organization slug; empty binds every user to the default
+	// organization.
+	OrganizationClaim string
+}
+
+// JWTValidator validates bearer tokens signed by keys from a JWKS and maps their claims to a Principal.
+type JWTValidator struct {
+	cfg  JWTConfig
+	jwks *JWKSCache
+}
+
+func NewJWTValidator(cfg JWTConfig, jwks *JWKSCache) *JWTValidator {
+	if cfg.UserIDClaim == "" {
+		cfg.UserIDClaim = "sub"
+	}
+	if cfg.RolesClaim == "" {
+		cfg.RolesClaim = "roles"
+	}
+	return &JWTValidator{
+		cfg:  cfg,
+		jwks: jwks,
+	}
+}
+
+func (v *JWTValidator) Validate(ctx context.Context, rawToken string) (*Principal, error) {
+	opts := []jwt.ParserOption{
+		jwt.WithValidMethods([]string{"RS256", "RS384", "RS512", "ES256", "ES384", "ES512"}),
+		jwt.WithExpirationRequired(),
+	}
+	if v.cfg.Issuer != "" {
+		opts = append(opts, jwt.WithIssuer(v.cfg.Issuer))
+	}
+	if v.cfg.Audience != "" {
+		opts = append(opts, jwt.WithAudience(v.cfg.Audience))
+	}
+
+	claims := jwt.MapClaims{}
+	_, err := jwt.ParseWithClaims(rawToken, claims, func(token *jwt.Token) (any, error) {
+		kid, _ := token.Header["kid"].(string)
+		return v.jwks.Key(ctx, kid)
+	}, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("invalid token: %w", err)
+	}
+
+	userID, _ := claims[v.cfg.UserIDClaim].(string)
+	if userID == "" {
+		return nil, fmt.Errorf("invalid token: missing %q claim", v.cfg.UserIDClaim)
+	}
+
+	roles := parseRoles(claims[v.cfg.RolesClaim])
+	if len(roles) == 0 {
+		return nil, errors.New("invalid token: no known roles")
+	}
+
+	var orgSlug string
+	if v.cfg.OrganizationClaim != "" {
+		orgSlug, _ = claims[v.cfg.OrganizationClaim].(string)
+		if orgSlug == "" {
+			return nil, fmt.Errorf("invalid token: missing %q claim", v.cfg.OrganizationClaim)
+		}
+	}
+
+	return &Principal{
+		Subject:          "user:" + userID,
+		UserID:           userID,
+		Roles:            roles,
+		Scopes:           ScopesForRoles(roles),
+		OrganizationSlug: orgSlug,
+	}, nil
+}
+
+// parseRoles accepts roles as a JSON array or a space-separated string and drops unknown values.
+func parseRoles(claim any) []domain.Role {
+	var raw []string
+	switch v := claim.(type) {
+	case string:
+		raw = strings.Fields(v)
+	case []any:
+		for _, item := range v {
+			if s, ok := item.(string); ok {
+				raw = append(raw, s)
+			}
+		}
+	}
+
+	var roles []domain.Role
+	for _, r := range raw {
+		if role := domain.Role(r); domain.IsKnownRole(role) {
+			roles = append(roles, role)
+		}
+	}
+	return roles
+}
@@ -0,0 +1,166 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/ssokov/pr-reviewer-service/internal/model/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testIdP is a local JWKS stand-in that signs tokens with an in-memory RSA key.
+type testIdP struct {
+	server   *httptest.Server
+	key      *rsa.PrivateKey
+	kid      string
+	requests atomic.Int32
+}
+
+func newTestIdP(t *testing.T) *testIdP {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	idp := &testIdP{key: key, kid: "test-key"}
+	idp.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		idp.requests.Add(1)
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"keys": []map[string]string{{
+				"kid": idp.kid,
+				"kty": "RSA",
+				"alg": "RS256",
+				"use": "sig",
+				"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+				"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+			}},
+		})
+	}))
+	t.Cleanup(idp.server.Close)
+
+	return idp
+}
+
+func (idp *testIdP) sign(t *testing.T, claims jwt.MapClaims) string {
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = idp.kid
+	signed, err := token.SignedString(idp.key)
+	require.NoError(t, err)
+	return signed
+}
+
+func newTestValidator(idp *testIdP) *JWTValidator {
+	return NewJWTValidator(JWTConfig{
+		Issuer:   "https://idp.test",
+		Audience: "pr-reviewer",
+	}, NewJWKSCache(idp.server.URL, time.Minute, nil))
+}
+
+func validClaims() jwt.MapClaims {
+	return jwt.MapClaims{
+		"iss":   "https://idp.test",
+		"aud":   "pr-reviewer",
+		"sub":   "u1",
+		"roles": []string{"team-lead"},
+		"exp":   time.Now().Add(time.Hour).Unix(),
+	}
+}
+
+func TestJWTValidator_Validate(t *testing.T) {
+	ctx := context.Background()
+	idp := newTestIdP(t)
+	validator := newTestValidator(idp)
+
+	t.Run("success", func(t *testing.T) {
+		p, err := validator.Validate(ctx, idp.sign(t, validClaims()))
+		require.NoError(t, err)
+		assert.Equal(t, "u1", p.UserID)
+		assert.Equal(t, []domain.Role{domain.RoleTeamLead}, p.Roles)
+		assert.True(t, p.HasScope(domain.ScopeUserWrite))
+		assert.False(t, p.HasScope(domain.ScopeTeamAdmin))
+	})
+
+	t.Run("keys are cached", func(t *testing.T) {
+		before := idp.requests.Load()
+		_, err := validator.Validate(ctx, idp.sign(t, validClaims()))
+		require.NoError(t, err)
+		assert.Equal(t, before, idp.requests.Load())
+	})
+
+	t.Run("error - expired", func(t *testing.T) {
+		claims := validClaims()
+		claims["exp"] = time.Now().Add(-time.Minute).Unix()
+
+		_, err := validator.Validate(ctx, idp.sign(t, claims))
+		assert.Error(t, err)
+	})
+
+	t.Run("error - wrong audience", func(t *testing.T) {
+		claims := validClaims()
+		claims["aud"] = "someone-else"
+
+		_, err := validator.Validate(ctx, idp.sign(t, claims))
+		assert.Error(t, err)
+	})
+
+	t.Run("error - unknown signing key", func(t *testing.T) {
+		other := newTestIdP(t)
+		other.kid = "other-key"
+
+		_, err := validator.Validate(ctx, other.sign(t, validClaims()))
+		assert.Error(t, err)
+	})
+
+	t.Run("error - no known roles", func(t *testing.T) {
+		claims := validClaims()
+		claims["roles"] = []string{"superuser"}
+
+		_, err := validator.Validate(ctx, idp.sign(t, claims))
+		assert.Error(t, err)
+	})
+}
+
+func TestJWTValidator_OrganizationClaim(t *testing.T) {
+	ctx := context.Background()
+	idp := newTestIdP(t)
+	validator := NewJWTValidator(JWTConfig{
+		Issuer:            "https://idp.test",
+		Audience:          "pr-reviewer",
+		OrganizationClaim: "org",
+	}, NewJWKSCache(idp.server.URL, time.Minute, nil))
+
+	t.Run("success", func(t *testing.T) {
+		claims := validClaims()
+		claims["org"] = "acme"
+
+		p, err := validator.Validate(ctx, idp.sign(t, claims))
+		require.NoError(t, err)
+		assert.Equal(t, "acme", p.OrganizationSlug)
+	})
+
+	t.Run("error - missing claim", func(t *testing.T) {
+		_, err := validator.Validate(ctx, idp.sign(t, validClaims()))
+		assert.Error(t, err)
+	})
+}
+
+func TestParseRoles(t *testing.T) {
+	assert.Equal(t, []domain.Role{domain.RoleAdmin, domain.RoleMember}, parseRoles("admin member guest"))
+	assert.Equal(t, []domain.Role{domain.RoleTeamLead}, parseRoles([]any{"team-lead", 42}))
+	assert.Nil(t, parseRoles(nil))
+}
+
+func TestScopesForRoles(t *testing.T) {
+	assert.Equal(t, []domain.Scope{domain.ScopeAll}, ScopesForRoles([]domain.Role{domain.RoleMember, domain.RoleAdmin}))
+	assert.NotContains(t, ScopesForRoles([]domain.Role{domain.RoleMember}), domain.ScopeUserWrite)
+	assert.Contains(t, ScopesForRoles([]domain.Role{domain.RoleTeamLead}), domain.ScopeUserWrite)
+}
@@ -0,0 +1,97 @@
+package auth
+
+import (
+	"context"
+
+	"github.com/ssokov/pr-reviewer-service/internal/model/domain"
+)
+
+type principalKey struct{}
+
+// Principal is the authenticated caller of a request: either an API key or a user from a bearer token.
+// OrganizationID or OrganizationSlug bind the principal to a tenant; both are empty when it may pick one per request.
+type Principal struct {
+	Subject          string
+	APIKeyID         int64
+	UserID           string
+	Roles            []domain.Role
+	Scopes           []domain.Scope
+	OrganizationID   int64
+	OrganizationSlug string
+}
+
+// Anonymous is used when authentication is disabled in the config.
+var Anonymous = &Principal{
+	Subject: "anonymous",
+	Scopes:  []domain.Scope{domain.ScopeAll},
+}
+
+func (p *Principal) HasScope(scope domain.Scope) bool {
+	for _, s := range p.Scopes {
+		if s == domain.ScopeAll || s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// IsUser reports whether the principal is a person authenticated by a bearer token.
+func (p *Principal) IsUser() bool {
+	return p.UserID != ""
+}
+
+func (p *Principal) HasRole(role domain.Role) bool {
+	for _, r := range p.Roles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
+
+// ScopesForRoles maps user roles to the route scopes they grant.
+// Finer rules, like a team lead only managing their own team, are checked by the services.
+func ScopesForRoles(roles []domain.Role) []domain.Scope {
+	scopes := make(map[domain.Scope]struct{})
+	for _, role := range roles {
+		switch role {
+		case domain.RoleAdmin:
+			return []domain.Scope{domain.ScopeAll}
+		case domain.RoleTeamLead:
+			scopes[domain.ScopeUserWrite] = struct{}{}
+			fallthrough
+		case domain.RoleMember:
+			scopes[domain.ScopePRRead] = struct{}{}
+			scopes[domain.ScopePRWrite] = struct{}{}
+			scopes[domain.ScopeTeamRead] = struct{}{}
+			scopes[domain.ScopeUserRead] = struct{}{}
+			scopes[domain.ScopeStatsRead] = struct{}{}
+		}
+	}
+
+	result := make([]domain.Scope, 0, len(scopes))
+	for _, s := range domain.KnownScopes {
+		if _, ok := scopes[s]; ok {
+			result = append(result, s)
+		}
+	}
+	return result
+}
+
+func WithPrincipal(ctx context.Context, p *Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, p)
+}
+
+// FromContext returns the principal stored in ctx or nil when the request is not authenticated.
+func FromContext(ctx context.Context) *Principal {
+	p, _ := ctx.Value(principalKey{}).(*Principal)
+	return p
+}
+
+// Actor returns the subject of the principal in ctx for logging.
+func Actor(ctx context.Context) string {
+	if p := FromContext(ctx); p != nil {
+		return p.Subject
+	}
+	return "system"
+}
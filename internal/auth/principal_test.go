@@ -0,0 +1,28 @@
+package auth
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ssokov/pr-reviewer-service/internal/model/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPrincipal_HasScope(t *testing.T) {
+	p := &Principal{Subject: "apikey:ab12cd34", Scopes: []domain.Scope{domain.ScopePRWrite}}
+
+	assert.True(t, p.HasScope(domain.ScopePRWrite))
+	assert.False(t, p.HasScope(domain.ScopeTeamAdmin))
+	assert.True(t, Anonymous.HasScope(domain.ScopeTeamAdmin))
+}
+
+func TestFromContext(t *testing.T) {
+	ctx := context.Background()
+	assert.Nil(t, FromContext(ctx))
+	assert.Equal(t, "system", Actor(ctx))
+
+	p := &Principal{Subject: "apikey:ab12cd34"}
+	ctx = WithPrincipal(ctx, p)
+	assert.Equal(t, p, FromContext(ctx))
+	assert.Equal(t, "apikey:ab12cd34", Actor(ctx))
+}
@@ -0,0 +1,255 @@
+// Package codeowners parses CODEOWNERS files in GitHub syntax and matches changed paths against them.
+package codeowners
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net/mail"
+	"regexp"
+	"strings"
+)
+
+type OwnerKind string
+
+const (
+	OwnerUser  OwnerKind = "user"
+	OwnerTeam  OwnerKind = "team"
+	OwnerEmail OwnerKind = "email"
+)
+
+// Owner is one owner of a rule. For @org/team owners Name is the team part, which is matched against team names.
+type Owner struct {
+	Raw  string
+	Kind OwnerKind
+	Name string
+}
+
+// Rule is a pattern line. A rule without owners is valid and makes the matching paths unowned.
+type Rule struct {
+	Line    int
+	Pattern string
+	Owners  []Owner
+	re      *regexp.Regexp
+}
+
+func (r *Rule) Matches(path string) bool {
+	return r.re.MatchString(normalizePath(path))
+}
+
+type Ruleset struct {
+	Rules []Rule
+}
+
+// Match returns the last rule matching path, as GitHub does, or nil when no rule matches.
+func (rs *Ruleset) Match(path string) *Rule {
+	path = normalizePath(path)
+	for i := len(rs.Rules) - 1; i >= 0; i-- {
+		if rs.Rules[i].re.MatchString(path) {
+			return &rs.Rules[i]
+		}
+	}
+	return nil
+}
+
+// Owners returns every distinct owner in the order of first appearance.
+func (rs *Ruleset) Owners() []Owner {
+	seen := make(map[string]bool)
+	var owners []Owner
+	for _, r := range rs.Rules {
+		for _, o := range r.Owners {
+			if !seen[o.Raw] {
+				seen[o.Raw] = true
+				owners = append(owners, o)
+			}
+		}
+	}
+	return owners
+}
+
+type LineError struct {
+	Line    int
+	Message string
+}
+
+func (e LineError) Error() string {
+	return fmt.Sprintf("line %d: %s", e.Line, e.Message)
+}
+
+// ParseError lists every invalid line of a file.
+type ParseError struct {
+	Errors []LineError
+}
+
+func (e *ParseError) Error() string {
+	msgs := make([]string, 0, len(e.Errors))
+	for _, le := range e.Errors {
+		msgs = append(msgs, le.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// Parse reads a CODEOWNERS file. Invalid lines are collected into a *ParseError instead of stopping at the first.
+func Parse(r io.Reader) (*Ruleset, error) {
+	rs := &Ruleset{}
+	var lineErrors []LineError
+
+	scanner := bufio.NewScanner(r)
+	line := 0
+	for scanner.Scan() {
+		line++
+		fields := splitFields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+
+		rule, err := parseRule(line, fields)
+		if err != nil {
+			lineErrors = append(lineErrors, LineError{Line: line, Message: err.Error()})
+			continue
+		}
+		rs.Rules = append(rs.Rules, *rule)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(lineErrors) > 0 {
+		return nil, &ParseError{Errors: lineErrors}
+	}
+	return rs, nil
+}
+
+// splitFields splits a line on whitespace, dropping comments. A backslash escapes the next character, so
+// "\#file" is a pattern and "my\ file" is one field.
+func splitFields(line string) []string {
+	var (
+		fields  []string
+		current strings.Builder
+		escaped bool
+	)
+	flush := func() {
+		if current.Len() > 0 {
+			fields = append(fields, current.String())
+			current.Reset()
+		}
+	}
+
+	for _, ch := range line {
+		switch {
+		case escaped:
+			current.WriteRune(ch)
+			escaped = false
+		case ch == '\\':
+			escaped = true
+		case ch == '#' && current.Len() == 0:
+			flush()
+			return fields
+		case ch == ' ' || ch == '\t':
+			flush()
+		default:
+			current.WriteRune(ch)
+		}
+	}
+	flush()
+	return fields
+}
+
+func parseRule(line int, fields []string) (*Rule, error) {
+	pattern := fields[0]
+	re, err := compilePattern(pattern)
+	if err != nil {
+		return nil, err
+	}
+
+	rule := &Rule{Line: line, Pattern: pattern, re: re}
+	for _, raw := range fields[1:] {
+		owner, err := ParseOwner(raw)
+		if err != nil {
+			return nil, err
+		}
+		rule.Owners = append(rule.Owners, owner)
+	}
+	return rule, nil
+}
+
+// ParseOwner accepts @user, @org/team and email owners.
+func ParseOwner(raw string) (Owner, error) {
+	if name, ok := strings.CutPrefix(raw, "@"); ok {
+		if name == "" {
+			return Owner{}, fmt.Errorf("empty owner %q", raw)
+		}
+		if org, team, ok := strings.Cut(name, "/"); ok {
+			if org == "" || team == "" || strings.Contains(team, "/") {
+				return Owner{}, fmt.Errorf("invalid team owner %q, expected @org/team", raw)
+			}
+			return Owner{Raw: raw, Kind: OwnerTeam, Name: team}, nil
+		}
+		return Owner{Raw: raw, Kind: OwnerUser, Name: name}, nil
+	}
+
+	if addr, err := mail.ParseAddress(raw); err == nil && addr.Address == raw {
+		return Owner{Raw: raw, Kind: OwnerEmail, Name: raw}, nil
+	}
+	return Owner{}, fmt.Errorf("invalid owner %q, expected @user, @org/team or an email", raw)
+}
+
+// compilePattern translates a gitignore-style pattern with the restrictions GitHub applies to CODEOWNERS:
+// no negation and no character ranges. A pattern without a slash, or with only a trailing one, matches at any
+// depth; otherwise it is relative to the repository root. A pattern matching a directory owns everything below
+// it, except that a trailing /* only covers the files directly in the directory.
+func compilePattern(pattern string) (*regexp.Regexp, error) {
+	switch {
+	case strings.HasPrefix(pattern, "!"):
+		return nil, fmt.Errorf("negation pattern %q is not supported", pattern)
+	case strings.ContainsAny(pattern, "[]"):
+		return nil, fmt.Errorf("character range in %q is not supported", pattern)
+	}
+
+	p := pattern
+	dirOnly := strings.HasSuffix(p, "/")
+	p = strings.TrimSuffix(p, "/")
+	anchored := strings.Contains(p, "/")
+	p = strings.TrimPrefix(p, "/")
+	if p == "" {
+		return nil, fmt.Errorf("empty pattern %q", pattern)
+	}
+
+	var b strings.Builder
+	b.WriteString("^")
+	if !anchored {
+		b.WriteString("(?:.*/)?")
+	}
+	for i := 0; i < len(p); i++ {
+		switch {
+		case strings.HasPrefix(p[i:], "**/"):
+			b.WriteString("(?:.*/)?")
+			i += 2
+		case strings.HasPrefix(p[i:], "**"):
+			b.WriteString(".*")
+			i++
+		case p[i] == '*':
+			b.WriteString("[^/]*")
+		case p[i] == '?':
+			b.WriteString("[^/]")
+		default:
+			b.WriteString(regexp.QuoteMeta(p[i : i+1]))
+		}
+	}
+
+	lastSegment := p[strings.LastIndex(p, "/")+1:]
+	switch {
+	case dirOnly:
+		b.WriteString("/.*")
+	case !strings.Contains(lastSegment, "*"):
+		b.WriteString("(?:/.*)?")
+	}
+	b.WriteString("$")
+
+	return regexp.Compile(b.String())
+}
+
+func normalizePath(path string) string {
+	path = strings.TrimPrefix(path, "./")
+	return strings.TrimPrefix(path, "/")
+}
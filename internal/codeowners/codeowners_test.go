@@ -0,0 +1,115 @@
+package codeowners
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Patterns and expectations follow the examples in GitHub's CODEOWNERS documentation.
+const example = `# Default owners for everything in the repo.
+*       @global-owner1 @global-owner2
+
+*.js    @js-owner #This is an inline comment.
+*.go docs@example.com
+
+/build/logs/ @doctocat
+
+docs/*  docs@example.com
+apps/ @octocat
+/docs/ @doctocat
+/scripts/ @doctocat @octocat
+**/logs @octocat
+
+# No owners: the subdirectory is unowned.
+/apps/github
+\#hash.txt @acme/hash-team
+`
+
+func TestParse(t *testing.T) {
+	rs, err := Parse(strings.NewReader(example))
+	require.NoError(t, err)
+	require.Len(t, rs.Rules, 11)
+
+	assert.Equal(t, 2, rs.Rules[0].Line)
+	assert.Equal(t, []Owner{{Raw: "@js-owner", Kind: OwnerUser, Name: "js-owner"}}, rs.Rules[1].Owners)
+	assert.Equal(t, OwnerEmail, rs.Rules[2].Owners[0].Kind)
+	assert.Equal(t, "#hash.txt", rs.Rules[10].Pattern)
+	assert.Equal(t, Owner{Raw: "@acme/hash-team", Kind: OwnerTeam, Name: "hash-team"}, rs.Rules[10].Owners[0])
+	assert.Empty(t, rs.Rules[9].Owners)
+	assert.Len(t, rs.Owners(), 7)
+}
+
+func TestRuleset_Match(t *testing.T) {
+	rs, err := Parse(strings.NewReader(example))
+	require.NoError(t, err)
+
+	tests := []struct {
+		path    string
+		pattern string
+	}{
+		{path: "README.md", pattern: "*"},
+		{path: "web/app.js", pattern: "*.js"},
+		{path: "/cmd/main.go", pattern: "*.go"},
+		{path: "build/logs/today.txt", pattern: "**/logs"},
+		{path: "docs/getting-started.md", pattern: "/docs/"},
+		{path: "docs/build-app/troubleshooting.md", pattern: "/docs/"},
+		{path: "src/docs/readme.md", pattern: "*"},
+		{path: "nested/apps/main.c", pattern: "apps/"},
+		{path: "apps/github/main.c", pattern: "/apps/github"},
+		{path: "scripts/deploy.sh", pattern: "/scripts/"},
+		{path: "deeply/nested/logs/x.txt", pattern: "**/logs"},
+		{path: "./#hash.txt", pattern: "#hash.txt"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			rule := rs.Match(tt.path)
+			require.NotNil(t, rule)
+			assert.Equal(t, tt.pattern, rule.Pattern)
+		})
+	}
+}
+
+func TestCompilePattern(t *testing.T) {
+	tests := []struct {
+		pattern string
+		match   []string
+		noMatch []string
+	}{
+		{pattern: "docs/*", match: []string{"docs/a.md"}, noMatch: []string{"docs/sub/a.md", "x/docs/a.md"}},
+		{pattern: "docs/**", match: []string{"docs/a.md", "docs/sub/a.md"}, noMatch: []string{"docs"}},
+		{pattern: "*.md", match: []string{"a.md", "x/y/a.md"}, noMatch: []string{"a.mdx"}},
+		{pattern: "internal/*/handler.go", match: []string{"internal/pr/handler.go"}, noMatch: []string{"internal/a/b/handler.go"}},
+		{pattern: "a?c.txt", match: []string{"abc.txt"}, noMatch: []string{"a/c.txt", "abbc.txt"}},
+		{pattern: "Makefile", match: []string{"Makefile", "sub/Makefile"}, noMatch: []string{"Makefile.bak"}},
+		{pattern: "/internal/service", match: []string{"internal/service/pr.go"}, noMatch: []string{"x/internal/service/pr.go"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.pattern, func(t *testing.T) {
+			re, err := compilePattern(tt.pattern)
+			require.NoError(t, err)
+			for _, p := range tt.match {
+				assert.True(t, re.MatchString(p), "%s should match %s", tt.pattern, p)
+			}
+			for _, p := range tt.noMatch {
+				assert.False(t, re.MatchString(p), "%s should not match %s", tt.pattern, p)
+			}
+		})
+	}
+}
+
+func TestParse_Errors(t *testing.T) {
+	_, err := Parse(strings.NewReader("!*.go @u1\n*.go @u1\nsrc/[ab].go @u2\n*.md owner\n*.txt @acme/\n"))
+
+	var parseErr *ParseError
+	require.True(t, errors.As(err, &parseErr))
+	lines := make([]int, 0, len(parseErr.Errors))
+	for _, le := range parseErr.Errors {
+		lines = append(lines, le.Line)
+	}
+	assert.Equal(t, []int{1, 3, 4, 5}, lines)
+	assert.Contains(t, err.Error(), "line 4: invalid owner")
+}
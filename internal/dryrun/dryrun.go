@@ -0,0 +1,16 @@
+package dryrun
+
+import "context"
+
+type dryRunKey struct{}
+
+// With marks ctx as a dry run: mutating services run their full logic in a rolled-back transaction,
+// and nothing is persisted, audited or counted in metrics.
+func With(ctx context.Context) context.Context {
+	return context.WithValue(ctx, dryRunKey{}, true)
+}
+
+func Enabled(ctx context.Context) bool {
+	enabled, _ := ctx.Value(dryRunKey{}).(bool)
+	return enabled
+}
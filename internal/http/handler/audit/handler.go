@@ -0,0 +1,144 @@
+package audit
+
+import (
+	"encoding/csv"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/ssokov/pr-reviewer-service/internal/http/mapper"
+	"github.com/ssokov/pr-reviewer-service/internal/http/response"
+	"github.com/ssokov/pr-reviewer-service/internal/model/domain"
+	"github.com/ssokov/pr-reviewer-service/internal/model/dto"
+	"github.com/ssokov/pr-reviewer-service/internal/service"
+	"github.com/ssokov/pr-reviewer-service/internal/tracing"
+	"github.com/vmkteam/embedlog"
+)
+
+type Handler struct {
+	auditService service.AuditService
+	logger       embedlog.Logger
+}
+
+func NewHandler(auditService service.AuditService, logger embedlog.Logger) *Handler {
+	return &Handler{
+		auditService: auditService,
+		logger:       logger,
+	}
+}
+
+// ListAudit godoc
+// @Summary List audit log entries
+// @Description List mutating operations with optional filters. Use format=csv to export for compliance reviews
+// @Tags audit
+// @Produce json
+// @Produce text/csv
+// @Param actor query string false "Actor, e.g. apikey:ab12cd34 or user:u1"
+// @Param action query string false "Action, e.g. team.deactivate"
+// @Param target query string false "Target id, e.g. team name or PR id"
+// @Param from query string false "Start of the range, RFC3339"
+// @Param to query string false "End of the range, RFC3339"
+// @Param limit query int false "Max entries, 100 by default"
+// @Param offset query int false "Entries to skip"
+// @Param format query string false "json (default) or csv"
+// @Success 200 {object} dto.AuditListResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /audit [get]
+func (h *Handler) ListAudit(c echo.Context) error {
+	filter, err := parseFilter(c)
+	if err != nil {
+		return response.Error(c, http.StatusBadRequest, "INVALID_INPUT", err.Error())
+	}
+
+	format := c.QueryParam("format")
+	if format != "" && format != "json" && format != "csv" {
+		return response.Error(c, http.StatusBadRequest, "INVALID_INPUT", "format must be json or csv")
+	}
+
+	ctx := c.Request().Context()
+	entries, err := h.auditService.List(ctx, filter)
+	if err != nil {
+		tracing.Logger(c.Request().Context(), h.logger).Errorf("failed to list audit entries: %v", err)
+		return response.HandleError(c, err)
+	}
+
+	if format == "csv" {
+		return writeCSV(c, entries)
+	}
+
+	return c.JSON(http.StatusOK, dto.AuditListResponse{
+		Entries: mapper.AuditEntriesToResponse(entries),
+	})
+}
+
+func parseFilter(c echo.Context) (domain.AuditFilter, error) {
+	filter := domain.AuditFilter{
+		Actor:  c.QueryParam("actor"),
+		Action: domain.AuditAction(c.QueryParam("action")),
+		Target: c.QueryParam("target"),
+	}
+
+	for name, dst := range map[string]**time.Time{"from": &filter.From, "to": &filter.To} {
+		if raw := c.QueryParam(name); raw != "" {
+			t, err := time.Parse(time.RFC3339, raw)
+			if err != nil {
+				return filter, &paramError{name: name, reason: "must be RFC3339"}
+			}
+			*dst = &t
+		}
+	}
+
+	for name, dst := range map[string]*int{"limit": &filter.Limit, "offset": &filter.Offset} {
+		if raw := c.QueryParam(name); raw != "" {
+			n, err := strconv.Atoi(raw)
+			if err != nil {
+				return filter, &paramError{name: name, reason: "must be an integer"}
+			}
+			*dst = n
+		}
+	}
+
+	return filter, nil
+}
+
+type paramError struct {
+	name   string
+	reason string
+}
+
+func (e *paramError) Error() string {
+	return e.name + " " + e.reason
+}
+
+func writeCSV(c echo.Context, entries []domain.AuditEntry) error {
+	c.Response().Header().Set(echo.HeaderContentType, "text/csv; charset=utf-8")
+	c.Response().Header().Set(echo.HeaderContentDisposition, `attachment; filename="audit.csv"`)
+	c.Response().WriteHeader(http.StatusOK)
+
+	w := csv.NewWriter(c.Response())
+	if err := w.Write([]string{"id", "created_at", "actor", "action", "target", "request_id", "before", "after"}); err != nil {
+		return err
+	}
+	for _, e := range entries {
+		if err := w.Write([]string{
+			strconv.FormatInt(e.ID, 10),
+			e.CreatedAt.UTC().Format(time.RFC3339),
+			e.Actor,
+			string(e.Action),
+			e.Target,
+			e.RequestID,
+			string(e.Before),
+			string(e.After),
+		}); err != nil {
+			return err
+		}
+	}
+	w.Flush()
+	return w.Error()
+}
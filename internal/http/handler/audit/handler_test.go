@@ -0,0 +1,121 @@
+package audit
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/ssokov/pr-reviewer-service/internal/model/domain"
+	"github.com/ssokov/pr-reviewer-service/internal/model/dto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/vmkteam/embedlog"
+)
+
+type MockAuditService struct {
+	mock.Mock
+}
+
+func (m *MockAuditService) List(ctx context.Context, filter domain.AuditFilter) ([]domain.AuditEntry, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.AuditEntry), args.Error(1)
+}
+
+var testEntries = []domain.AuditEntry{
+	{
+		ID:        1,
+		Actor:     "apikey:ab12cd34",
+		Action:    domain.AuditActionTeamDeactivate,
+		Target:    "backend",
+		After:     json.RawMessage(`{"deactivated_users":["u1"]}`),
+		RequestID: "req-1",
+		CreatedAt: time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC),
+	},
+}
+
+func TestListAudit_JSON(t *testing.T) {
+	e := echo.New()
+	mockService := new(MockAuditService)
+	handler := NewHandler(mockService, embedlog.NewLogger(false, false))
+
+	req := httptest.NewRequest(http.MethodGet, "/audit?action=team.deactivate&from=2025-01-01T00:00:00Z&limit=10", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	mockService.On("List", mock.Anything, mock.MatchedBy(func(f domain.AuditFilter) bool {
+		return f.Action == domain.AuditActionTeamDeactivate && f.From != nil && f.To == nil && f.Limit == 10
+	})).Return(testEntries, nil)
+
+	err := handler.ListAudit(c)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var resp dto.AuditListResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	assert.Len(t, resp.Entries, 1)
+	assert.Equal(t, "backend", resp.Entries[0].Target)
+	mockService.AssertExpectations(t)
+}
+
+func TestListAudit_CSV(t *testing.T) {
+	e := echo.New()
+	mockService := new(MockAuditService)
+	handler := NewHandler(mockService, embedlog.NewLogger(false, false))
+
+	req := httptest.NewRequest(http.MethodGet, "/audit?format=csv", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	mockService.On("List", mock.Anything, mock.Anything).Return(testEntries, nil)
+
+	err := handler.ListAudit(c)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.True(t, strings.HasPrefix(rec.Header().Get(echo.HeaderContentType), "text/csv"))
+
+	records, err := csv.NewReader(rec.Body).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, records, 2)
+	assert.Equal(t, "actor", records[0][2])
+	assert.Equal(t, []string{"1", "2025-01-02T03:04:05Z", "apikey:ab12cd34", "team.deactivate", "backend", "req-1", "", `{"deactivated_users":["u1"]}`}, records[1])
+}
+
+func TestListAudit_InvalidTime(t *testing.T) {
+	e := echo.New()
+	handler := NewHandler(new(MockAuditService), embedlog.NewLogger(false, false))
+
+	req := httptest.NewRequest(http.MethodGet, "/audit?from=yesterday", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	err := handler.ListAudit(c)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestListAudit_InvalidFormat(t *testing.T) {
+	e := echo.New()
+	handler := NewHandler(new(MockAuditService), embedlog.NewLogger(false, false))
+
+	req := httptest.NewRequest(http.MethodGet, "/audit?format=xml", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	err := handler.ListAudit(c)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
@@ -0,0 +1,11 @@
+package audit
+
+import (
+	"github.com/labstack/echo/v4"
+	"github.com/ssokov/pr-reviewer-service/internal/http/middleware"
+	"github.com/ssokov/pr-reviewer-service/internal/model/domain"
+)
+
+func RegisterRoutes(g *echo.Group, handler *Handler) {
+	g.GET("/audit", handler.ListAudit, middleware.RequireScope(domain.ScopeAuditRead))
+}
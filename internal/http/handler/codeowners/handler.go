@@ -0,0 +1,96 @@
+package codeowners
+
+import (
+	"io"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/ssokov/pr-reviewer-service/internal/http/mapper"
+	"github.com/ssokov/pr-reviewer-service/internal/http/response"
+	"github.com/ssokov/pr-reviewer-service/internal/model/domain"
+	"github.com/ssokov/pr-reviewer-service/internal/model/dto"
+	"github.com/ssokov/pr-reviewer-service/internal/service"
+	"github.com/ssokov/pr-reviewer-service/internal/tracing"
+	"github.com/vmkteam/embedlog"
+)
+
+type Handler struct {
+	codeOwnersService service.CodeOwnersService
+	logger            embedlog.Logger
+}
+
+func NewHandler(codeOwnersService service.CodeOwnersService, logger embedlog.Logger) *Handler {
+	return &Handler{
+		codeOwnersService: codeOwnersService,
+		logger:            logger,
+	}
+}
+
+// UploadCodeOwners godoc
+// @Summary Upload a CODEOWNERS file
+// @Description Store the CODEOWNERS file of a repository, replacing the previous one. The body is the file as is,
+// @Description in GitHub syntax. In the preferred mode owners of the changed files are picked first, in the
+// @Description required mode every owned file must get an owner.
+// @Tags codeowners
+// @Accept plain
+// @Produce json
+// @Param repository query string true "Repository, e.g. acme/api"
+// @Param mode query string false "preferred (default) or required"
+// @Param file body string true "CODEOWNERS file"
+// @Success 200 {object} dto.UploadCodeOwnersResponse
+// @Failure 400 {object} dto.ErrorResponse "Invalid lines"
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /codeowners/upload [post]
+func (h *Handler) UploadCodeOwners(c echo.Context) error {
+	content, err := io.ReadAll(c.Request().Body)
+	if err != nil {
+		return response.Error(c, http.StatusBadRequest, "INVALID_INPUT", "failed to read request body")
+	}
+
+	ctx := c.Request().Context()
+	saved, unknown, err := h.codeOwnersService.Upload(ctx, &domain.CodeOwners{
+		Repository: c.QueryParam("repository"),
+		Mode:       domain.CodeOwnersMode(c.QueryParam("mode")),
+		Content:    string(content),
+	})
+	if err != nil {
+		tracing.Logger(c.Request().Context(), h.logger).Errorf("failed to upload CODEOWNERS: %v", err)
+		return response.HandleError(c, err)
+	}
+
+	if unknown == nil {
+		unknown = []string{}
+	}
+	return c.JSON(http.StatusOK, dto.UploadCodeOwnersResponse{
+		CodeOwners:    mapper.CodeOwnersToResponse(saved),
+		UnknownOwners: unknown,
+	})
+}
+
+// GetCodeOwners godoc
+// @Summary Get the CODEOWNERS file of a repository
+// @Tags codeowners
+// @Produce json
+// @Param repository query string true "Repository, e.g. acme/api"
+// @Success 200 {object} dto.CodeOwnersResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /codeowners/get [get]
+func (h *Handler) GetCodeOwners(c echo.Context) error {
+	ctx := c.Request().Context()
+	file, err := h.codeOwnersService.Get(ctx, c.QueryParam("repository"))
+	if err != nil {
+		return response.HandleError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, mapper.CodeOwnersToResponse(file))
+}
@@ -0,0 +1,99 @@
+package codeowners
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/ssokov/pr-reviewer-service/internal/apperror"
+	"github.com/ssokov/pr-reviewer-service/internal/model/domain"
+	"github.com/ssokov/pr-reviewer-service/internal/model/dto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/vmkteam/embedlog"
+)
+
+type MockCodeOwnersService struct {
+	mock.Mock
+}
+
+func (m *MockCodeOwnersService) Upload(ctx context.Context, file *domain.CodeOwners) (*domain.CodeOwners, []string, error) {
+	args := m.Called(ctx, file)
+	if args.Get(0) == nil {
+		return nil, nil, args.Error(2)
+	}
+	return args.Get(0).(*domain.CodeOwners), args.Get(1).([]string), args.Error(2)
+}
+
+func (m *MockCodeOwnersService) Get(ctx context.Context, repository string) (*domain.CodeOwners, error) {
+	args := m.Called(ctx, repository)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.CodeOwners), args.Error(1)
+}
+
+func TestUploadCodeOwners(t *testing.T) {
+	const content = "* @u1\n/internal/ @acme/backend @ghost\n"
+
+	e := echo.New()
+	mockService := new(MockCodeOwnersService)
+	handler := NewHandler(mockService, embedlog.NewLogger(false, false))
+
+	req := httptest.NewRequest(http.MethodPost, "/codeowners/upload?repository=acme/api&mode=required", strings.NewReader(content))
+	req.Header.Set(echo.HeaderContentType, echo.MIMETextPlain)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	mockService.On("Upload", mock.Anything, &domain.CodeOwners{
+		Repository: "acme/api",
+		Mode:       domain.CodeOwnersRequired,
+		Content:    content,
+	}).Return(&domain.CodeOwners{Repository: "acme/api", Mode: domain.CodeOwnersRequired, Content: content}, []string{"@ghost"}, nil)
+
+	require.NoError(t, handler.UploadCodeOwners(c))
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var resp dto.UploadCodeOwnersResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	assert.Equal(t, "required", resp.CodeOwners.Mode)
+	assert.Equal(t, []string{"@ghost"}, resp.UnknownOwners)
+	mockService.AssertExpectations(t)
+}
+
+func TestUploadCodeOwners_InvalidLines(t *testing.T) {
+	e := echo.New()
+	mockService := new(MockCodeOwnersService)
+	handler := NewHandler(mockService, embedlog.NewLogger(false, false))
+
+	req := httptest.NewRequest(http.MethodPost, "/codeowners/upload?repository=acme/api", strings.NewReader("!*.md @u1\n"))
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	mockService.On("Upload", mock.Anything, mock.Anything).
+		Return(nil, nil, apperror.NewInvalidInputError("invalid CODEOWNERS: line 1: negation pattern \"!*.md\" is not supported"))
+
+	require.NoError(t, handler.UploadCodeOwners(c))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Contains(t, rec.Body.String(), "line 1")
+}
+
+func TestGetCodeOwners_NotFound(t *testing.T) {
+	e := echo.New()
+	mockService := new(MockCodeOwnersService)
+	handler := NewHandler(mockService, embedlog.NewLogger(false, false))
+
+	req := httptest.NewRequest(http.MethodGet, "/codeowners/get?repository=acme/ghost", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	mockService.On("Get", mock.Anything, "acme/ghost").Return(nil, apperror.NewNotFoundError("CODEOWNERS for 'acme/ghost'"))
+
+	require.NoError(t, handler.GetCodeOwners(c))
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
@@ -0,0 +1,12 @@
+package codeowners
+
+import (
+	"github.com/labstack/echo/v4"
+	"github.com/ssokov/pr-reviewer-service/internal/http/middleware"
+	"github.com/ssokov/pr-reviewer-service/internal/model/domain"
+)
+
+func RegisterRoutes(g *echo.Group, handler *Handler) {
+	g.POST("/codeowners/upload", handler.UploadCodeOwners, middleware.RequireScope(domain.ScopeTeamAdmin))
+	g.GET("/codeowners/get", handler.GetCodeOwners, middleware.RequireScope(domain.ScopeTeamRead))
+}
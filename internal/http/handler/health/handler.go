@@ -0,0 +1,65 @@
+package health
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/ssokov/pr-reviewer-service/internal/buildinfo"
+	"github.com/ssokov/pr-reviewer-service/internal/http/mapper"
+	"github.com/ssokov/pr-reviewer-service/internal/model/domain"
+	"github.com/ssokov/pr-reviewer-service/internal/model/dto"
+	"github.com/ssokov/pr-reviewer-service/internal/service"
+	"github.com/vmkteam/embedlog"
+)
+
+type Handler struct {
+	healthService service.HealthService
+	logger        embedlog.Logger
+}
+
+func NewHandler(healthService service.HealthService, logger embedlog.Logger) *Handler {
+	return &Handler{
+		healthService: healthService,
+		logger:        logger,
+	}
+}
+
+// Healthz godoc
+// @Summary Liveness probe
+// @Description Returns 200 while the process is up, without checking dependencies
+// @Tags health
+// @Produce json
+// @Success 200 {object} dto.HealthResponse
+// @Router /healthz [get]
+func (h *Handler) Healthz(c echo.Context) error {
+	return c.JSON(http.StatusOK, dto.HealthResponse{Status: string(domain.HealthStatusOK)})
+}
+
+// Readyz godoc
+// @Summary Readiness probe
+// @Description Checks the database, the migration version and background workers
+// @Tags health
+// @Produce json
+// @Success 200 {object} dto.ReadinessResponse
+// @Failure 503 {object} dto.ReadinessResponse
+// @Router /readyz [get]
+func (h *Handler) Readyz(c echo.Context) error {
+	readiness := h.healthService.Ready(c.Request().Context())
+
+	status := http.StatusOK
+	if readiness.Status != domain.HealthStatusOK {
+		status = http.StatusServiceUnavailable
+	}
+	return c.JSON(status, mapper.ReadinessToResponse(readiness))
+}
+
+// Version godoc
+// @Summary Build information
+// @Description Returns the version, commit and build date of the running binary
+// @Tags health
+// @Produce json
+// @Success 200 {object} dto.VersionResponse
+// @Router /version [get]
+func (h *Handler) Version(c echo.Context) error {
+	return c.JSON(http.StatusOK, mapper.BuildInfoToResponse(buildinfo.Get()))
+}
@@ -0,0 +1,100 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/ssokov/pr-reviewer-service/internal/model/domain"
+	"github.com/ssokov/pr-reviewer-service/internal/model/dto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/vmkteam/embedlog"
+)
+
+type MockHealthService struct {
+	mock.Mock
+}
+
+func (m *MockHealthService) Ready(ctx context.Context) domain.Readiness {
+	args := m.Called(ctx)
+	return args.Get(0).(domain.Readiness)
+}
+
+func newServer(svc *MockHealthService) *echo.Echo {
+	e := echo.New()
+	RegisterRoutes(e, NewHandler(svc, embedlog.NewLogger(false, false)))
+	return e
+}
+
+func TestHandler_Healthz(t *testing.T) {
+	e := newServer(new(MockHealthService))
+
+	req := httptest.NewRequest(http.MethodGet, "/healthz", nil)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"status":"ok"}`, rec.Body.String())
+}
+
+func TestHandler_Readyz(t *testing.T) {
+	t.Run("ready", func(t *testing.T) {
+		svc := new(MockHealthService)
+		svc.On("Ready", mock.Anything).Return(domain.Readiness{
+			Status: domain.HealthStatusOK,
+			Dependencies: []domain.DependencyHealth{
+				{Name: "database", Status: domain.HealthStatusOK, Duration: 3 * time.Millisecond},
+			},
+		})
+
+		req := httptest.NewRequest(http.MethodGet, "/readyz", nil)
+		rec := httptest.NewRecorder()
+		newServer(svc).ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		var resp dto.ReadinessResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+		assert.Equal(t, []dto.DependencyStatus{{Name: "database", Status: "ok", DurationMs: 3}}, resp.Dependencies)
+	})
+
+	t.Run("not ready", func(t *testing.T) {
+		svc := new(MockHealthService)
+		svc.On("Ready", mock.Anything).Return(domain.Readiness{
+			Status: domain.HealthStatusFail,
+			Dependencies: []domain.DependencyHealth{
+				{Name: "database", Status: domain.HealthStatusOK},
+				{Name: "migrations", Status: domain.HealthStatusFail, Error: "database is at migration 4, expected 5"},
+			},
+		})
+
+		req := httptest.NewRequest(http.MethodGet, "/readyz", nil)
+		rec := httptest.NewRecorder()
+		newServer(svc).ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+		var resp dto.ReadinessResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+		assert.Equal(t, "fail", resp.Status)
+		assert.Equal(t, "database is at migration 4, expected 5", resp.Dependencies[1].Error)
+	})
+}
+
+func TestHandler_Version(t *testing.T) {
+	e := newServer(new(MockHealthService))
+
+	req := httptest.NewRequest(http.MethodGet, "/version", nil)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var resp dto.VersionResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	assert.Equal(t, "dev", resp.Version)
+	assert.NotEmpty(t, resp.GoVersion)
+}
@@ -0,0 +1,13 @@
+package health
+
+import (
+	"github.com/labstack/echo/v4"
+)
+
+// RegisterRoutes registers the probes on the root router: they are called by the orchestrator, without
+// credentials, and must not be rate limited.
+func RegisterRoutes(e *echo.Echo, handler *Handler) {
+	e.GET("/healthz", handler.Healthz)
+	e.GET("/readyz", handler.Readyz)
+	e.GET("/version", handler.Version)
+}
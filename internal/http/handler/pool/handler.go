@@ -0,0 +1,156 @@
+package pool
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/ssokov/pr-reviewer-service/internal/http/mapper"
+	"github.com/ssokov/pr-reviewer-service/internal/http/response"
+	"github.com/ssokov/pr-reviewer-service/internal/model/dto"
+	"github.com/ssokov/pr-reviewer-service/internal/service"
+	"github.com/ssokov/pr-reviewer-service/internal/tracing"
+	"github.com/vmkteam/embedlog"
+)
+
+type Handler struct {
+	poolService service.PoolService
+	logger      embedlog.Logger
+}
+
+func NewHandler(poolService service.PoolService, logger embedlog.Logger) *Handler {
+	return &Handler{
+		poolService: poolService,
+		logger:      logger,
+	}
+}
+
+// SetPool godoc
+// @Summary Create or replace a reviewer pool
+// @Description Store a named set of reviewers that may span teams. Teams use pools in their fallback chains.
+// @Tags pool
+// @Accept json
+// @Produce json
+// @Param request body dto.SetPoolRequest true "Pool name and member user IDs"
+// @Success 200 {object} dto.PoolResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse "User not found"
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /pools/set [post]
+func (h *Handler) SetPool(c echo.Context) error {
+	var req dto.SetPoolRequest
+	if err := c.Bind(&req); err != nil {
+		tracing.Logger(c.Request().Context(), h.logger).Errorf("failed to bind request: %v", err)
+		return response.Error(c, http.StatusBadRequest, "INVALID_INPUT", "invalid request body")
+	}
+
+	pool, err := h.poolService.SetPool(c.Request().Context(), mapper.SetPoolRequestToDomain(req))
+	if err != nil {
+		tracing.Logger(c.Request().Context(), h.logger).Errorf("failed to set pool: %v", err)
+		return response.HandleError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, mapper.PoolToResponse(pool))
+}
+
+// GetPool godoc
+// @Summary Get a reviewer pool
+// @Tags pool
+// @Produce json
+// @Param pool_name query string true "Pool name"
+// @Success 200 {object} dto.PoolResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /pools/get [get]
+func (h *Handler) GetPool(c echo.Context) error {
+	pool, err := h.poolService.GetPool(c.Request().Context(), c.QueryParam("pool_name"))
+	if err != nil {
+		return response.HandleError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, mapper.PoolToResponse(pool))
+}
+
+// ListPools godoc
+// @Summary List reviewer pools
+// @Tags pool
+// @Produce json
+// @Success 200 {object} dto.ListPoolsResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /pools/list [get]
+func (h *Handler) ListPools(c echo.Context) error {
+	pools, err := h.poolService.ListPools(c.Request().Context())
+	if err != nil {
+		return response.HandleError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, mapper.PoolsToResponse(pools))
+}
+
+// SetFallback godoc
+// @Summary Set the fallback chain of a team
+// @Description Replace the ordered list of teams and pools reviewers are taken from when the team cannot fill the
+// @Description reviewer count. Each step names either team_name or pool_name. An empty list removes the chain.
+// @Tags pool
+// @Accept json
+// @Produce json
+// @Param request body dto.FallbackChain true "Team and its fallback steps"
+// @Success 200 {object} dto.FallbackChain
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse "Team or pool not found"
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /fallback/set [post]
+func (h *Handler) SetFallback(c echo.Context) error {
+	var req dto.FallbackChain
+	if err := c.Bind(&req); err != nil {
+		tracing.Logger(c.Request().Context(), h.logger).Errorf("failed to bind request: %v", err)
+		return response.Error(c, http.StatusBadRequest, "INVALID_INPUT", "invalid request body")
+	}
+
+	chain, err := h.poolService.SetFallback(c.Request().Context(), mapper.FallbackChainToDomain(req))
+	if err != nil {
+		tracing.Logger(c.Request().Context(), h.logger).Errorf("failed to set fallback chain: %v", err)
+		return response.HandleError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, mapper.FallbackChainToResponse(chain))
+}
+
+// GetFallback godoc
+// @Summary Get the fallback chain of a team
+// @Tags pool
+// @Produce json
+// @Param team_name query string true "Team name"
+// @Success 200 {object} dto.FallbackChain
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse "Team not found"
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /fallback/get [get]
+func (h *Handler) GetFallback(c echo.Context) error {
+	chain, err := h.poolService.GetFallback(c.Request().Context(), c.QueryParam("team_name"))
+	if err != nil {
+		return response.HandleError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, mapper.FallbackChainToResponse(chain))
+}
@@ -0,0 +1,125 @@
+package pool
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/ssokov/pr-reviewer-service/internal/apperror"
+	"github.com/ssokov/pr-reviewer-service/internal/model/domain"
+	"github.com/ssokov/pr-reviewer-service/internal/model/dto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/vmkteam/embedlog"
+)
+
+type MockPoolService struct {
+	mock.Mock
+}
+
+func (m *MockPoolService) SetPool(ctx context.Context, pool *domain.ReviewerPool) (*domain.ReviewerPool, error) {
+	args := m.Called(ctx, pool)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.ReviewerPool), args.Error(1)
+}
+
+func (m *MockPoolService) GetPool(ctx context.Context, name string) (*domain.ReviewerPool, error) {
+	args := m.Called(ctx, name)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.ReviewerPool), args.Error(1)
+}
+
+func (m *MockPoolService) ListPools(ctx context.Context) ([]domain.ReviewerPool, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.ReviewerPool), args.Error(1)
+}
+
+func (m *MockPoolService) SetFallback(ctx context.Context, chain *domain.FallbackChain) (*domain.FallbackChain, error) {
+	args := m.Called(ctx, chain)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.FallbackChain), args.Error(1)
+}
+
+func (m *MockPoolService) GetFallback(ctx context.Context, teamName string) (*domain.FallbackChain, error) {
+	args := m.Called(ctx, teamName)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.FallbackChain), args.Error(1)
+}
+
+func TestSetPool(t *testing.T) {
+	e := echo.New()
+	mockService := new(MockPoolService)
+	handler := NewHandler(mockService, embedlog.NewLogger(false, false))
+
+	body, _ := json.Marshal(dto.SetPoolRequest{PoolName: "oncall", Members: []string{"u1", "u5"}})
+	req := httptest.NewRequest(http.MethodPost, "/pools/set", bytes.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	mockService.On("SetPool", mock.Anything, &domain.ReviewerPool{
+		Name:    "oncall",
+		Members: []domain.User{{UserID: "u1"}, {UserID: "u5"}},
+	}).Return(&domain.ReviewerPool{Name: "oncall", Members: []domain.User{
+		{UserID: "u1", Username: "Alice", TeamName: "backend", IsActive: true},
+		{UserID: "u5", Username: "Eve", TeamName: "platform", IsActive: true},
+	}}, nil)
+
+	require.NoError(t, handler.SetPool(c))
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var resp dto.PoolResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	assert.Equal(t, "platform", resp.Members[1].TeamName)
+	mockService.AssertExpectations(t)
+}
+
+func TestSetFallback(t *testing.T) {
+	e := echo.New()
+	mockService := new(MockPoolService)
+	handler := NewHandler(mockService, embedlog.NewLogger(false, false))
+
+	body := `{"team_name":"mobile","steps":[{"team_name":"platform"},{"pool_name":"oncall"}]}`
+	req := httptest.NewRequest(http.MethodPost, "/fallback/set", bytes.NewReader([]byte(body)))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	chain := &domain.FallbackChain{TeamName: "mobile", Steps: []domain.FallbackStep{{TeamName: "platform"}, {PoolName: "oncall"}}}
+	mockService.On("SetFallback", mock.Anything, chain).Return(chain, nil)
+
+	require.NoError(t, handler.SetFallback(c))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, body, rec.Body.String())
+}
+
+func TestGetFallback_TeamNotFound(t *testing.T) {
+	e := echo.New()
+	mockService := new(MockPoolService)
+	handler := NewHandler(mockService, embedlog.NewLogger(false, false))
+
+	req := httptest.NewRequest(http.MethodGet, "/fallback/get?team_name=ghost", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	mockService.On("GetFallback", mock.Anything, "ghost").Return(nil, apperror.NewTeamNotFoundError("ghost"))
+
+	require.NoError(t, handler.GetFallback(c))
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
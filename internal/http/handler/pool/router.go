@@ -0,0 +1,15 @@
+package pool
+
+import (
+	"github.com/labstack/echo/v4"
+	"github.com/ssokov/pr-reviewer-service/internal/http/middleware"
+	"github.com/ssokov/pr-reviewer-service/internal/model/domain"
+)
+
+func RegisterRoutes(g *echo.Group, handler *Handler) {
+	g.POST("/pools/set", handler.SetPool, middleware.RequireScope(domain.ScopeTeamAdmin))
+	g.GET("/pools/get", handler.GetPool, middleware.RequireScope(domain.ScopeTeamRead))
+	g.GET("/pools/list", handler.ListPools, middleware.RequireScope(domain.ScopeTeamRead))
+	g.POST("/fallback/set", handler.SetFallback, middleware.RequireScope(domain.ScopeTeamAdmin))
+	g.GET("/fallback/get", handler.GetFallback, middleware.RequireScope(domain.ScopeTeamRead))
+}
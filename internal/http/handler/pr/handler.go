@@ -2,44 +2,59 @@ package pr
 
 import (
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/labstack/echo/v4"
+	"github.com/ssokov/pr-reviewer-service/internal/dryrun"
 	"github.com/ssokov/pr-reviewer-service/internal/http/mapper"
 	"github.com/ssokov/pr-reviewer-service/internal/http/response"
+	"github.com/ssokov/pr-reviewer-service/internal/model/domain"
 	"github.com/ssokov/pr-reviewer-service/internal/model/dto"
 	"github.com/ssokov/pr-reviewer-service/internal/service"
+	"github.com/ssokov/pr-reviewer-service/internal/tracing"
 	"github.com/vmkteam/embedlog"
 )
 
 type PRHandler struct {
-	prService service.PRService
-	logger    embedlog.Logger
+	prService    service.PRService
+	skillService service.SkillService
+	logger       embedlog.Logger
 }
 
-func NewHandler(service service.PRService, logger embedlog.Logger) *PRHandler {
+func NewHandler(service service.PRService, skillService service.SkillService, logger embedlog.Logger) *PRHandler {
 	return &PRHandler{
-		prService: service,
-		logger:    logger,
+		prService:    service,
+		skillService: skillService,
+		logger:       logger,
 	}
 }
 
 // CreatePR godoc
 // @Summary Create a new pull request
-// @Description Create a new pull request and automatically assign reviewers
+// @Description Create a new pull request and automatically assign reviewers. When nobody can review it, the request
+// @Description fails with NO_CANDIDATE, or, if the organization's at_capacity is queue, the PR is created with
+// @Description pending_reviewers and assigned later
 // @Tags pullRequest
 // @Accept json
 // @Produce json
 // @Param request body dto.CreatePRRequest true "Pull request data"
+// @Param dry_run query bool false "Run in a rolled-back transaction and return what would change"
 // @Success 201 {object} dto.CreatePRResponse
+// @Success 200 {object} dto.CreatePRResponse "Dry run"
 // @Failure 400 {object} dto.ErrorResponse
 // @Failure 404 {object} dto.ErrorResponse "Author or team not found"
-// @Failure 409 {object} dto.ErrorResponse "PR already exists"
+// @Failure 409 {object} dto.ErrorResponse "PR already exists or no reviewer available (NO_CANDIDATE)"
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
 // @Failure 500 {object} dto.ErrorResponse
+// @Security ApiKeyAuth
+// @Security BearerAuth
 // @Router /pullRequest/create [post]
 func (p *PRHandler) CreatePR(c echo.Context) error {
 	var req dto.CreatePRRequest
 	if err := c.Bind(&req); err != nil {
-		p.logger.Errorf("failed to bind request: %v", err)
+		tracing.Logger(c.Request().Context(), p.logger).Errorf("failed to bind request: %v", err)
 		return response.Error(c, http.StatusBadRequest, "INVALID_INPUT", "invalid request body")
 	}
 
@@ -48,12 +63,19 @@ func (p *PRHandler) CreatePR(c echo.Context) error {
 	ctx := c.Request().Context()
 	createdPR, err := p.prService.CreatePR(ctx, req.AuthorID, domainPR)
 	if err != nil {
-		p.logger.Errorf("failed to create PR: %v", err)
+		tracing.Logger(c.Request().Context(), p.logger).Errorf("failed to create PR: %v", err)
 		return response.HandleError(c, err)
 	}
 
-	return c.JSON(http.StatusCreated, dto.CreatePRResponse{
-		PR: mapper.PullRequestToResponse(createdPR),
+	// Nothing is created on a dry run.
+	status := http.StatusCreated
+	if dryrun.Enabled(ctx) {
+		status = http.StatusOK
+	}
+
+	return c.JSON(status, dto.CreatePRResponse{
+		PR:     mapper.PullRequestToResponse(createdPR),
+		DryRun: dryrun.Enabled(ctx),
 	})
 }
 
@@ -68,19 +90,23 @@ func (p *PRHandler) CreatePR(c echo.Context) error {
 // @Failure 400 {object} dto.ErrorResponse
 // @Failure 404 {object} dto.ErrorResponse "PR not found"
 // @Failure 409 {object} dto.ErrorResponse "PR already merged"
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
 // @Failure 500 {object} dto.ErrorResponse
+// @Security ApiKeyAuth
+// @Security BearerAuth
 // @Router /pullRequest/merge [post]
 func (p *PRHandler) MergePR(c echo.Context) error {
 	var req dto.MergePRRequest
 	if err := c.Bind(&req); err != nil {
-		p.logger.Errorf("failed to bind request: %v", err)
+		tracing.Logger(c.Request().Context(), p.logger).Errorf("failed to bind request: %v", err)
 		return response.Error(c, http.StatusBadRequest, "INVALID_INPUT", "invalid request body")
 	}
 
 	ctx := c.Request().Context()
 	mergedPR, err := p.prService.MergePR(ctx, req.PullRequestID)
 	if err != nil {
-		p.logger.Errorf("failed to merge PR: %v", err)
+		tracing.Logger(c.Request().Context(), p.logger).Errorf("failed to merge PR: %v", err)
 		return response.HandleError(c, err)
 	}
 
@@ -89,35 +115,247 @@ func (p *PRHandler) MergePR(c echo.Context) error {
 	})
 }
 
+// ListPRs godoc
+// @Summary List pull requests
+// @Description List pull requests, oldest first. older_than selects PRs created more than the given duration ago, e.g. 72h
+// @Tags pullRequest
+// @Produce json
+// @Param status query string false "OPEN or MERGED"
+// @Param older_than query string false "Go duration, e.g. 72h"
+// @Param pending query bool false "Only PRs waiting for reviewers"
+// @Param limit query int false "Max PRs to return (default 100, max 1000)"
+// @Success 200 {object} dto.ListPRsResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /pullRequest/list [get]
+func (p *PRHandler) ListPRs(c echo.Context) error {
+	filter := domain.PRFilter{Status: domain.PRStatus(c.QueryParam("status"))}
+
+	if raw := c.QueryParam("older_than"); raw != "" {
+		age, err := time.ParseDuration(raw)
+		if err != nil || age < 0 {
+			return response.Error(c, http.StatusBadRequest, "INVALID_INPUT", "older_than must be a duration like 72h")
+		}
+		before := time.Now().Add(-age)
+		filter.CreatedBefore = &before
+	}
+
+	if raw := c.QueryParam("pending"); raw != "" {
+		pending, err := strconv.ParseBool(raw)
+		if err != nil {
+			return response.Error(c, http.StatusBadRequest, "INVALID_INPUT", "pending must be true or false")
+		}
+		filter.Pending = pending
+	}
+
+	if raw := c.QueryParam("limit"); raw != "" {
+		limit, err := strconv.Atoi(raw)
+		if err != nil {
+			return response.Error(c, http.StatusBadRequest, "INVALID_INPUT", "limit must be an integer")
+		}
+		filter.Limit = limit
+	}
+
+	ctx := c.Request().Context()
+	prs, err := p.prService.ListPRs(ctx, filter)
+	if err != nil {
+		tracing.Logger(c.Request().Context(), p.logger).Errorf("failed to list PRs: %v", err)
+		return response.HandleError(c, err)
+	}
+
+	resp := dto.ListPRsResponse{PullRequests: make([]dto.PullRequestResponse, 0, len(prs))}
+	for i := range prs {
+		resp.PullRequests = append(resp.PullRequests, mapper.PullRequestToResponse(&prs[i]))
+	}
+	return c.JSON(http.StatusOK, resp)
+}
+
 // ReassignReviewer godoc
 // @Summary Reassign a reviewer
-// @Description Replace a reviewer with another active team member
+// @Description Replace a reviewer with another active team member. Pinned reviewers cannot be replaced
 // @Tags pullRequest
 // @Accept json
 // @Produce json
 // @Param request body dto.ReassignRequest true "Reassign data"
+// @Param dry_run query bool false "Run in a rolled-back transaction and return what would change"
 // @Success 200 {object} dto.ReassignResponse
 // @Failure 400 {object} dto.ErrorResponse
 // @Failure 404 {object} dto.ErrorResponse "PR or user not found"
-// @Failure 409 {object} dto.ErrorResponse "User not assigned to PR"
+// @Failure 409 {object} dto.ErrorResponse "User not assigned to PR, reviewer pinned (REVIEWER_PINNED) or no replacement available (NO_CANDIDATE)"
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
 // @Failure 500 {object} dto.ErrorResponse
+// @Security ApiKeyAuth
+// @Security BearerAuth
 // @Router /pullRequest/reassign [post]
 func (p *PRHandler) ReassignReviewer(c echo.Context) error {
 	var req dto.ReassignRequest
 	if err := c.Bind(&req); err != nil {
-		p.logger.Errorf("failed to bind request: %v", err)
+		tracing.Logger(c.Request().Context(), p.logger).Errorf("failed to bind request: %v", err)
 		return response.Error(c, http.StatusBadRequest, "INVALID_INPUT", "invalid request body")
 	}
 
 	ctx := c.Request().Context()
 	pr, newReviewerID, err := p.prService.ReassignReviewer(ctx, req.PullRequestID, req.OldUserID)
 	if err != nil {
-		p.logger.Errorf("failed to reassign reviewer: %v", err)
+		tracing.Logger(c.Request().Context(), p.logger).Errorf("failed to reassign reviewer: %v", err)
 		return response.HandleError(c, err)
 	}
 
 	return c.JSON(http.StatusOK, dto.ReassignResponse{
 		PR:         mapper.PullRequestToResponse(pr),
 		ReplacedBy: newReviewerID,
+		DryRun:     dryrun.Enabled(ctx),
+	})
+}
+
+// AddReviewer godoc
+// @Summary Add a reviewer
+// @Description Assign a reviewer picked by hand, regardless of the reviewer count and capacity limits. With pin the
+// @Description reviewer is never replaced by reassignment or deprovisioning; pinning an assigned reviewer only sets the pin
+// @Tags pullRequest
+// @Accept json
+// @Produce json
+// @Param request body dto.AddReviewerRequest true "Reviewer data"
+// @Param dry_run query bool false "Run in a rolled-back transaction and return what would change"
+// @Success 200 {object} dto.ReviewerResponse
+// @Failure 400 {object} dto.ErrorResponse "The user is the author, inactive or a trainee"
+// @Failure 404 {object} dto.ErrorResponse "PR or user not found"
+// @Failure 409 {object} dto.ErrorResponse "PR already merged"
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /pullRequest/addReviewer [post]
+func (p *PRHandler) AddReviewer(c echo.Context) error {
+	var req dto.AddReviewerRequest
+	if err := c.Bind(&req); err != nil {
+		tracing.Logger(c.Request().Context(), p.logger).Errorf("failed to bind request: %v", err)
+		return response.Error(c, http.StatusBadRequest, "INVALID_INPUT", "invalid request body")
+	}
+
+	ctx := c.Request().Context()
+	pr, err := p.prService.AddReviewer(ctx, req.PullRequestID, req.UserID, req.Pin)
+	if err != nil {
+		tracing.Logger(c.Request().Context(), p.logger).Errorf("failed to add reviewer: %v", err)
+		return response.HandleError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, dto.ReviewerResponse{
+		PR:     mapper.PullRequestToResponse(pr),
+		DryRun: dryrun.Enabled(ctx),
+	})
+}
+
+// RemoveReviewer godoc
+// @Summary Remove a reviewer
+// @Description Unassign a reviewer, pinned or not, without picking a replacement
+// @Tags pullRequest
+// @Accept json
+// @Produce json
+// @Param request body dto.RemoveReviewerRequest true "Reviewer data"
+// @Param dry_run query bool false "Run in a rolled-back transaction and return what would change"
+// @Success 200 {object} dto.ReviewerResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse "PR not found"
+// @Failure 409 {object} dto.ErrorResponse "PR already merged or user not assigned to PR"
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /pullRequest/removeReviewer [post]
+func (p *PRHandler) RemoveReviewer(c echo.Context) error {
+	var req dto.RemoveReviewerRequest
+	if err := c.Bind(&req); err != nil {
+		tracing.Logger(c.Request().Context(), p.logger).Errorf("failed to bind request: %v", err)
+		return response.Error(c, http.StatusBadRequest, "INVALID_INPUT", "invalid request body")
+	}
+
+	ctx := c.Request().Context()
+	pr, err := p.prService.RemoveReviewer(ctx, req.PullRequestID, req.UserID)
+	if err != nil {
+		tracing.Logger(c.Request().Context(), p.logger).Errorf("failed to remove reviewer: %v", err)
+		return response.HandleError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, dto.ReviewerResponse{
+		PR:     mapper.PullRequestToResponse(pr),
+		DryRun: dryrun.Enabled(ctx),
 	})
 }
+
+// SetLabels godoc
+// @Summary Set pull request labels
+// @Description Replace the labels of an open pull request. Labels naming a user skill ask for a reviewer with it;
+// @Description assigned reviewers stay and later reassignments prefer matching skills
+// @Tags pullRequest
+// @Accept json
+// @Produce json
+// @Param request body dto.SetLabelsRequest true "Pull request labels"
+// @Success 200 {object} dto.SetLabelsResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse "PR not found"
+// @Failure 409 {object} dto.ErrorResponse "PR already merged"
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /pullRequest/setLabels [post]
+func (p *PRHandler) SetLabels(c echo.Context) error {
+	var req dto.SetLabelsRequest
+	if err := c.Bind(&req); err != nil {
+		tracing.Logger(c.Request().Context(), p.logger).Errorf("failed to bind request: %v", err)
+		return response.Error(c, http.StatusBadRequest, "INVALID_INPUT", "invalid request body")
+	}
+
+	ctx := c.Request().Context()
+	pr, err := p.skillService.SetPRLabels(ctx, req.PullRequestID, req.Labels)
+	if err != nil {
+		tracing.Logger(c.Request().Context(), p.logger).Errorf("failed to set PR labels: %v", err)
+		return response.HandleError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, dto.SetLabelsResponse{
+		PR: mapper.PullRequestToResponse(pr),
+	})
+}
+
+// GetSLA godoc
+// @Summary Get review SLA of a pull request
+// @Description Get the review timer of each assigned reviewer. Timers run from the reviewer's assignment until merge and count only
+// @Description the reviewer's working hours; the deadline is review_sla_hours of working time, taken from the author's
+// @Description team, its parents or the organization
+// @Tags pullRequest
+// @Produce json
+// @Param pull_request_id query string true "Pull request ID"
+// @Success 200 {object} dto.ReviewSLAResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse "PR not found"
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /pullRequest/sla [get]
+func (p *PRHandler) GetSLA(c echo.Context) error {
+	prID := c.QueryParam("pull_request_id")
+	if prID == "" {
+		return response.Error(c, http.StatusBadRequest, "INVALID_INPUT", "pull_request_id is required")
+	}
+
+	ctx := c.Request().Context()
+	sla, err := p.prService.GetSLA(ctx, prID)
+	if err != nil {
+		tracing.Logger(c.Request().Context(), p.logger).Errorf("failed to get PR SLA: %v", err)
+		return response.HandleError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, mapper.ReviewSLAToResponse(sla))
+}
@@ -7,9 +7,12 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/labstack/echo/v4"
 	"github.com/ssokov/pr-reviewer-service/internal/apperror"
+	"github.com/ssokov/pr-reviewer-service/internal/dryrun"
+	"github.com/ssokov/pr-reviewer-service/internal/http/middleware"
 	"github.com/ssokov/pr-reviewer-service/internal/model/domain"
 	"github.com/ssokov/pr-reviewer-service/internal/model/dto"
 	"github.com/stretchr/testify/assert"
@@ -45,11 +48,79 @@ func (m *MockPRService) ReassignReviewer(ctx context.Context, prID, oldUserID st
 	return args.Get(0).(*domain.PullRequest), args.String(1), args.Error(2)
 }
 
+func (m *MockPRService) ListPRs(ctx context.Context, filter domain.PRFilter) ([]domain.PullRequest, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.PullRequest), args.Error(1)
+}
+
+func (m *MockPRService) GetSLA(ctx context.Context, prID string) (*domain.ReviewSLA, error) {
+	args := m.Called(ctx, prID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.ReviewSLA), args.Error(1)
+}
+
+func (m *MockPRService) AssignPendingReviewers(ctx context.Context, prID string) (*domain.PullRequest, error) {
+	args := m.Called(ctx, prID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.PullRequest), args.Error(1)
+}
+
+func (m *MockPRService) AddReviewer(ctx context.Context, prID, userID string, pin bool) (*domain.PullRequest, error) {
+	args := m.Called(ctx, prID, userID, pin)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.PullRequest), args.Error(1)
+}
+
+func (m *MockPRService) RemoveReviewer(ctx context.Context, prID, userID string) (*domain.PullRequest, error) {
+	args := m.Called(ctx, prID, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.PullRequest), args.Error(1)
+}
+
+type MockSkillService struct {
+	mock.Mock
+}
+
+func (m *MockSkillService) SetUserSkills(ctx context.Context, userID string, skills []string) ([]string, error) {
+	args := m.Called(ctx, userID, skills)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *MockSkillService) GetUserSkills(ctx context.Context, userID string) ([]string, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *MockSkillService) SetPRLabels(ctx context.Context, prID string, labels []string) (*domain.PullRequest, error) {
+	args := m.Called(ctx, prID, labels)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.PullRequest), args.Error(1)
+}
+
 func TestCreatePR_Success(t *testing.T) {
 	e := echo.New()
 	mockService := new(MockPRService)
 	logger := embedlog.NewLogger(false, false)
-	handler := NewHandler(mockService, logger)
+	handler := NewHandler(mockService, nil, logger)
 
 	reqBody := dto.CreatePRRequest{
 		PullRequestID:   "pr-1",
@@ -79,11 +150,39 @@ func TestCreatePR_Success(t *testing.T) {
 	mockService.AssertExpectations(t)
 }
 
+func TestCreatePR_DryRun(t *testing.T) {
+	e := echo.New()
+	mockService := new(MockPRService)
+	logger := embedlog.NewLogger(false, false)
+	handler := NewHandler(mockService, nil, logger)
+
+	body, _ := json.Marshal(dto.CreatePRRequest{PullRequestID: "pr-1", PullRequestName: "Test PR", AuthorID: "u1"})
+	req := httptest.NewRequest(http.MethodPost, "/pullRequest/create?dry_run=true", bytes.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	mockService.On("CreatePR", mock.MatchedBy(dryrun.Enabled), "u1", mock.Anything).
+		Return(&domain.PullRequest{PullRequestID: "pr-1", AssignedReviewers: []string{"u2", "u3"}}, nil)
+
+	err := middleware.DryRun()(handler.CreatePR)(c)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "true", rec.Header().Get(middleware.HeaderDryRun))
+
+	var resp dto.CreatePRResponse
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	assert.True(t, resp.DryRun)
+	assert.Equal(t, []string{"u2", "u3"}, resp.PR.AssignedReviewers)
+	mockService.AssertExpectations(t)
+}
+
 func TestMergePR_Success(t *testing.T) {
 	e := echo.New()
 	mockService := new(MockPRService)
 	logger := embedlog.NewLogger(false, false)
-	handler := NewHandler(mockService, logger)
+	handler := NewHandler(mockService, nil, logger)
 
 	reqBody := dto.MergePRRequest{PullRequestID: "pr-1"}
 	body, _ := json.Marshal(reqBody)
@@ -109,7 +208,7 @@ func TestReassignReviewer_Success(t *testing.T) {
 	e := echo.New()
 	mockService := new(MockPRService)
 	logger := embedlog.NewLogger(false, false)
-	handler := NewHandler(mockService, logger)
+	handler := NewHandler(mockService, nil, logger)
 
 	reqBody := dto.ReassignRequest{
 		PullRequestID: "pr-1",
@@ -138,7 +237,7 @@ func TestReassignReviewer_PRMerged(t *testing.T) {
 	e := echo.New()
 	mockService := new(MockPRService)
 	logger := embedlog.NewLogger(false, false)
-	handler := NewHandler(mockService, logger)
+	handler := NewHandler(mockService, nil, logger)
 
 	reqBody := dto.ReassignRequest{PullRequestID: "pr-1", OldUserID: "u2"}
 	body, _ := json.Marshal(reqBody)
@@ -155,3 +254,195 @@ func TestReassignReviewer_PRMerged(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, http.StatusConflict, rec.Code)
 }
+
+func TestAddReviewer(t *testing.T) {
+	e := echo.New()
+	mockService := new(MockPRService)
+	handler := NewHandler(mockService, nil, embedlog.NewLogger(false, false))
+
+	body, _ := json.Marshal(dto.AddReviewerRequest{PullRequestID: "pr-1", UserID: "u3", Pin: true})
+	req := httptest.NewRequest(http.MethodPost, "/pullRequest/addReviewer", bytes.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	mockService.On("AddReviewer", mock.Anything, "pr-1", "u3", true).Return(&domain.PullRequest{
+		PullRequestID:     "pr-1",
+		AssignedReviewers: []string{"u2", "u3"},
+		PinnedReviewers:   []string{"u3"},
+	}, nil)
+
+	err := handler.AddReviewer(c)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var resp dto.ReviewerResponse
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	assert.Equal(t, []string{"u3"}, resp.PR.PinnedReviewers)
+}
+
+func TestAddReviewer_Author(t *testing.T) {
+	e := echo.New()
+	mockService := new(MockPRService)
+	handler := NewHandler(mockService, nil, embedlog.NewLogger(false, false))
+
+	body, _ := json.Marshal(dto.AddReviewerRequest{PullRequestID: "pr-1", UserID: "u1"})
+	req := httptest.NewRequest(http.MethodPost, "/pullRequest/addReviewer", bytes.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	mockService.On("AddReviewer", mock.Anything, "pr-1", "u1", false).Return(nil, apperror.NewInvalidInputError("the author cannot review their own pull request"))
+
+	err := handler.AddReviewer(c)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestRemoveReviewer_NotAssigned(t *testing.T) {
+	e := echo.New()
+	mockService := new(MockPRService)
+	handler := NewHandler(mockService, nil, embedlog.NewLogger(false, false))
+
+	body, _ := json.Marshal(dto.RemoveReviewerRequest{PullRequestID: "pr-1", UserID: "u9"})
+	req := httptest.NewRequest(http.MethodPost, "/pullRequest/removeReviewer", bytes.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	mockService.On("RemoveReviewer", mock.Anything, "pr-1", "u9").Return(nil, apperror.NewNotAssignedError("u9", "pr-1"))
+
+	err := handler.RemoveReviewer(c)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusConflict, rec.Code)
+}
+
+func TestReassignReviewer_Pinned(t *testing.T) {
+	e := echo.New()
+	mockService := new(MockPRService)
+	handler := NewHandler(mockService, nil, embedlog.NewLogger(false, false))
+
+	body, _ := json.Marshal(dto.ReassignRequest{PullRequestID: "pr-1", OldUserID: "u2"})
+	req := httptest.NewRequest(http.MethodPost, "/pullRequest/reassign", bytes.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	mockService.On("ReassignReviewer", mock.Anything, "pr-1", "u2").Return(nil, "", apperror.NewPinnedError("u2", "pr-1"))
+
+	err := handler.ReassignReviewer(c)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusConflict, rec.Code)
+	assert.Contains(t, rec.Body.String(), "REVIEWER_PINNED")
+}
+
+func TestListPRs(t *testing.T) {
+	e := echo.New()
+	mockService := new(MockPRService)
+	handler := NewHandler(mockService, nil, embedlog.NewLogger(false, false))
+
+	req := httptest.NewRequest(http.MethodGet, "/pullRequest/list?status=OPEN&older_than=72h&limit=5", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	mockService.On("ListPRs", mock.Anything, mock.MatchedBy(func(f domain.PRFilter) bool {
+		return f.Status == domain.PRStatusOpen && f.Limit == 5 &&
+			f.CreatedBefore != nil && time.Since(*f.CreatedBefore) >= 72*time.Hour
+	})).Return([]domain.PullRequest{{PullRequestID: "pr-1", Status: domain.PRStatusOpen}}, nil)
+
+	err := handler.ListPRs(c)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var resp dto.ListPRsResponse
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	assert.Len(t, resp.PullRequests, 1)
+	mockService.AssertExpectations(t)
+}
+
+func TestListPRs_Pending(t *testing.T) {
+	e := echo.New()
+	mockService := new(MockPRService)
+	handler := NewHandler(mockService, nil, embedlog.NewLogger(false, false))
+
+	req := httptest.NewRequest(http.MethodGet, "/pullRequest/list?pending=true", nil)
+	rec := httptest.NewRecorder()
+
+	mockService.On("ListPRs", mock.Anything, domain.PRFilter{Pending: true}).
+		Return([]domain.PullRequest{{PullRequestID: "pr-1", Status: domain.PRStatusOpen, PendingReviewers: true}}, nil)
+
+	assert.NoError(t, handler.ListPRs(e.NewContext(req, rec)))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"pending_reviewers":true`)
+
+	req = httptest.NewRequest(http.MethodGet, "/pullRequest/list?pending=maybe", nil)
+	rec = httptest.NewRecorder()
+	assert.NoError(t, handler.ListPRs(e.NewContext(req, rec)))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestListPRs_InvalidOlderThan(t *testing.T) {
+	e := echo.New()
+	handler := NewHandler(new(MockPRService), nil, embedlog.NewLogger(false, false))
+
+	req := httptest.NewRequest(http.MethodGet, "/pullRequest/list?older_than=3days", nil)
+	rec := httptest.NewRecorder()
+
+	err := handler.ListPRs(e.NewContext(req, rec))
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestGetSLA(t *testing.T) {
+	e := echo.New()
+	mockService := new(MockPRService)
+	handler := NewHandler(mockService, nil, embedlog.NewLogger(false, false))
+
+	req := httptest.NewRequest(http.MethodGet, "/pullRequest/sla?pull_request_id=pr-1", nil)
+	rec := httptest.NewRecorder()
+
+	dueAt := time.Date(2025, time.March, 10, 14, 0, 0, 0, time.UTC)
+	mockService.On("GetSLA", mock.Anything, "pr-1").Return(&domain.ReviewSLA{
+		PullRequestID: "pr-1",
+		Status:        domain.PRStatusOpen,
+		SLA:           8 * time.Hour,
+		Reviewers: []domain.ReviewerSLA{
+			{UserID: "u2", Timezone: "Europe/Berlin", Elapsed: 4*time.Hour + 20*time.Minute, DueAt: dueAt},
+		},
+	}, nil)
+
+	err := handler.GetSLA(e.NewContext(req, rec))
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"pull_request_id":"pr-1","status":"OPEN","sla_hours":8,"reviewers":[
+		{"user_id":"u2","timezone":"Europe/Berlin","elapsed_hours":4.33,"due_at":"2025-03-10T14:00:00Z","overdue":false}]}`, rec.Body.String())
+}
+
+func TestSetLabels(t *testing.T) {
+	e := echo.New()
+	mockSkillService := new(MockSkillService)
+	handler := NewHandler(new(MockPRService), mockSkillService, embedlog.NewLogger(false, false))
+
+	body, _ := json.Marshal(dto.SetLabelsRequest{PullRequestID: "pr-1", Labels: []string{"security"}})
+	req := httptest.NewRequest(http.MethodPost, "/pullRequest/setLabels", bytes.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	mockSkillService.On("SetPRLabels", mock.Anything, "pr-1", []string{"security"}).
+		Return(&domain.PullRequest{PullRequestID: "pr-1", Status: domain.PRStatusOpen, Labels: []string{"security"}}, nil)
+
+	err := handler.SetLabels(c)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var resp dto.SetLabelsResponse
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	assert.Equal(t, []string{"security"}, resp.PR.Labels)
+}
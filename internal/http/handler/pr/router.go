@@ -1,12 +1,21 @@
 package pr
 
-import "github.com/labstack/echo/v4"
+import (
+	"github.com/labstack/echo/v4"
+	"github.com/ssokov/pr-reviewer-service/internal/http/middleware"
+	"github.com/ssokov/pr-reviewer-service/internal/model/domain"
+)
 
-func RegisterRoutes(e *echo.Echo, p *PRHandler) {
-	prGroup := e.Group("/pullRequest")
+func RegisterRoutes(g *echo.Group, p *PRHandler) {
+	prGroup := g.Group("/pullRequest")
 	{
-		prGroup.POST("/create", p.CreatePR)
-		prGroup.POST("/merge", p.MergePR)
-		prGroup.POST("/reassign", p.ReassignReviewer)
+		prGroup.POST("/create", p.CreatePR, middleware.RequireScope(domain.ScopePRWrite), middleware.DryRun())
+		prGroup.POST("/merge", p.MergePR, middleware.RequireScope(domain.ScopePRWrite))
+		prGroup.POST("/reassign", p.ReassignReviewer, middleware.RequireScope(domain.ScopePRWrite), middleware.DryRun())
+		prGroup.POST("/addReviewer", p.AddReviewer, middleware.RequireScope(domain.ScopePRWrite), middleware.DryRun())
+		prGroup.POST("/removeReviewer", p.RemoveReviewer, middleware.RequireScope(domain.ScopePRWrite), middleware.DryRun())
+		prGroup.GET("/list", p.ListPRs, middleware.RequireScope(domain.ScopePRRead))
+		prGroup.GET("/sla", p.GetSLA, middleware.RequireScope(domain.ScopePRRead))
+		prGroup.POST("/setLabels", p.SetLabels, middleware.RequireScope(domain.ScopePRWrite))
 	}
 }
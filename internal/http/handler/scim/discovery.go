@@ -0,0 +1,58 @@
+package scim
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/ssokov/pr-reviewer-service/internal/model/dto"
+)
+
+// ServiceProviderConfig godoc
+// @Summary SCIM service provider configuration
+// @Tags scim
+// @Produce json
+// @Success 200 {object} dto.SCIMServiceProviderConfig
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /scim/v2/ServiceProviderConfig [get]
+func (h *SCIMHandler) ServiceProviderConfig(c echo.Context) error {
+	return writeJSON(c, http.StatusOK, dto.SCIMServiceProviderConfig{
+		Schemas:        []string{dto.SCIMSchemaServiceProviderConfig},
+		Patch:          dto.SCIMSupported{Supported: true},
+		Bulk:           dto.SCIMBulkSupport{Supported: false},
+		Filter:         dto.SCIMFilterSupport{Supported: true, MaxResults: maxResults},
+		ChangePassword: dto.SCIMSupported{Supported: false},
+		Sort:           dto.SCIMSupported{Supported: false},
+		ETag:           dto.SCIMSupported{Supported: false},
+		AuthenticationSchemes: []dto.SCIMAuthenticationScheme{{
+			Type:        "oauthbearertoken",
+			Name:        "API key",
+			Description: "API key with the directory:write scope, sent as a bearer token or in X-API-Key",
+		}},
+	})
+}
+
+// ResourceTypes godoc
+// @Summary SCIM resource types
+// @Tags scim
+// @Produce json
+// @Success 200 {object} dto.SCIMListResponse
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /scim/v2/ResourceTypes [get]
+func (h *SCIMHandler) ResourceTypes(c echo.Context) error {
+	resourceType := func(name, endpoint, schema string) dto.SCIMResourceType {
+		return dto.SCIMResourceType{
+			Schemas:  []string{dto.SCIMSchemaResourceType},
+			ID:       name,
+			Name:     name,
+			Endpoint: endpoint,
+			Schema:   schema,
+			Meta:     &dto.SCIMMeta{ResourceType: "ResourceType", Location: baseURL(c) + "/ResourceTypes/" + name},
+		}
+	}
+	return listResponse(c, []dto.SCIMResourceType{
+		resourceType("User", "/Users", dto.SCIMSchemaUser),
+		resourceType("Group", "/Groups", dto.SCIMSchemaGroup),
+	})
+}
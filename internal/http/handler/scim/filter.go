@@ -0,0 +1,48 @@
+package scim
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// filterPattern matches the only filter identity providers need for provisioning: one attribute compared with
+// a quoted string, e.g. userName eq "u1".
+var filterPattern = regexp.MustCompile(`(?i)^\s*([A-Za-z][\w.]*)\s+eq\s+("(?:[^"\\]|\\.)*")\s*$`)
+
+type filter struct {
+	attribute string
+	value     string
+}
+
+// parseFilter parses an "attribute eq value" filter. Attribute names are case-insensitive (RFC 7644,
+// section 3.4.2.2); they are returned lowercased and must be one of allowed.
+func parseFilter(raw string, allowed ...string) (*filter, error) {
+	if raw == "" {
+		return nil, nil
+	}
+
+	m := filterPattern.FindStringSubmatch(raw)
+	if m == nil {
+		return nil, fmt.Errorf("unsupported filter %q, only 'attribute eq \"value\"' is supported", raw)
+	}
+
+	attribute := strings.ToLower(m[1])
+	supported := false
+	for _, a := range allowed {
+		if strings.ToLower(a) == attribute {
+			supported = true
+			break
+		}
+	}
+	if !supported {
+		return nil, fmt.Errorf("filtering by %s is not supported, use one of %s", m[1], strings.Join(allowed, ", "))
+	}
+
+	value, err := strconv.Unquote(m[2])
+	if err != nil {
+		return nil, fmt.Errorf("invalid filter value %s", m[2])
+	}
+	return &filter{attribute: attribute, value: value}, nil
+}
@@ -0,0 +1,230 @@
+package scim
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/ssokov/pr-reviewer-service/internal/apperror"
+	"github.com/ssokov/pr-reviewer-service/internal/http/mapper"
+	"github.com/ssokov/pr-reviewer-service/internal/model/domain"
+	"github.com/ssokov/pr-reviewer-service/internal/model/dto"
+	"github.com/ssokov/pr-reviewer-service/internal/tracing"
+)
+
+// ListGroups godoc
+// @Summary List SCIM groups
+// @Description List teams as groups, optionally filtered with displayName eq "..." or id eq "..."
+// @Tags scim
+// @Produce json
+// @Param filter query string false "SCIM filter"
+// @Param startIndex query int false "1-based index of the first result"
+// @Param count query int false "Page size, at most 200"
+// @Success 200 {object} dto.SCIMListResponse
+// @Failure 400 {object} dto.SCIMError
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /scim/v2/Groups [get]
+func (h *SCIMHandler) ListGroups(c echo.Context) error {
+	f, err := parseFilter(c.QueryParam("filter"), "displayName", "id")
+	if err != nil {
+		return writeError(c, http.StatusBadRequest, scimTypeInvalidFilter, err.Error())
+	}
+
+	ctx := c.Request().Context()
+	var teams []domain.Team
+	if f != nil {
+		// displayName and id are both the team name.
+		team, err := h.directoryService.GetGroup(ctx, f.value)
+		if err != nil && !apperror.Is(err, apperror.ErrCodeTeamNotFound) {
+			tracing.Logger(c.Request().Context(), h.logger).Errorf("failed to get scim group: %v", err)
+			return handleError(c, err)
+		}
+		if team != nil {
+			teams = append(teams, *team)
+		}
+	} else {
+		teams, err = h.directoryService.ListGroups(ctx)
+		if err != nil {
+			tracing.Logger(c.Request().Context(), h.logger).Errorf("failed to list scim groups: %v", err)
+			return handleError(c, err)
+		}
+	}
+
+	resources := make([]dto.SCIMGroup, 0, len(teams))
+	for i := range teams {
+		resources = append(resources, mapper.TeamToSCIM(&teams[i], baseURL(c)))
+	}
+	return listResponse(c, resources)
+}
+
+// GetGroup godoc
+// @Summary Get a SCIM group
+// @Tags scim
+// @Produce json
+// @Param id path string true "Team name"
+// @Success 200 {object} dto.SCIMGroup
+// @Failure 404 {object} dto.SCIMError
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /scim/v2/Groups/{id} [get]
+func (h *SCIMHandler) GetGroup(c echo.Context) error {
+	team, err := h.directoryService.GetGroup(c.Request().Context(), resourceID(c))
+	if err != nil {
+		tracing.Logger(c.Request().Context(), h.logger).Errorf("failed to get scim group: %v", err)
+		return handleError(c, err)
+	}
+	return writeJSON(c, http.StatusOK, mapper.TeamToSCIM(team, baseURL(c)))
+}
+
+// CreateGroup godoc
+// @Summary Provision a SCIM group
+// @Description Create a team named displayName and move the listed users into it
+// @Tags scim
+// @Accept json
+// @Produce json
+// @Param request body dto.SCIMGroup true "Group"
+// @Success 201 {object} dto.SCIMGroup
+// @Failure 400 {object} dto.SCIMError
+// @Failure 409 {object} dto.SCIMError "Team exists"
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /scim/v2/Groups [post]
+func (h *SCIMHandler) CreateGroup(c echo.Context) error {
+	var req dto.SCIMGroup
+	if err := decode(c, &req); err != nil {
+		return err
+	}
+
+	team, err := h.directoryService.CreateGroup(c.Request().Context(), req.DisplayName, mapper.SCIMMemberIDs(req.Members))
+	if err != nil {
+		tracing.Logger(c.Request().Context(), h.logger).Errorf("failed to create scim group: %v", err)
+		return handleError(c, err)
+	}
+	return writeJSON(c, http.StatusCreated, mapper.TeamToSCIM(team, baseURL(c)))
+}
+
+// ReplaceGroup godoc
+// @Summary Replace a SCIM group
+// @Description Make members the exact member list of the team. Users taken out of the team are left without a team.
+// @Tags scim
+// @Accept json
+// @Produce json
+// @Param id path string true "Team name"
+// @Param request body dto.SCIMGroup true "Group"
+// @Success 200 {object} dto.SCIMGroup
+// @Failure 400 {object} dto.SCIMError
+// @Failure 404 {object} dto.SCIMError
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /scim/v2/Groups/{id} [put]
+func (h *SCIMHandler) ReplaceGroup(c echo.Context) error {
+	var req dto.SCIMGroup
+	if err := decode(c, &req); err != nil {
+		return err
+	}
+
+	teamName := resourceID(c)
+	if req.DisplayName != "" && req.DisplayName != teamName {
+		return writeError(c, http.StatusBadRequest, scimTypeMutability, "teams cannot be renamed")
+	}
+
+	team, err := h.directoryService.ReplaceGroupMembers(c.Request().Context(), teamName, mapper.SCIMMemberIDs(req.Members))
+	if err != nil {
+		tracing.Logger(c.Request().Context(), h.logger).Errorf("failed to replace scim group: %v", err)
+		return handleError(c, err)
+	}
+	return writeJSON(c, http.StatusOK, mapper.TeamToSCIM(team, baseURL(c)))
+}
+
+// PatchGroup godoc
+// @Summary Patch a SCIM group
+// @Description Add, remove or replace members
+// @Tags scim
+// @Accept json
+// @Produce json
+// @Param id path string true "Team name"
+// @Param request body dto.SCIMPatchRequest true "Patch operations"
+// @Success 200 {object} dto.SCIMGroup
+// @Failure 400 {object} dto.SCIMError
+// @Failure 404 {object} dto.SCIMError
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /scim/v2/Groups/{id} [patch]
+func (h *SCIMHandler) PatchGroup(c echo.Context) error {
+	var req dto.SCIMPatchRequest
+	if err := decode(c, &req); err != nil {
+		return err
+	}
+
+	ctx := c.Request().Context()
+	team, err := h.directoryService.GetGroup(ctx, resourceID(c))
+	if err != nil {
+		tracing.Logger(c.Request().Context(), h.logger).Errorf("failed to get scim group: %v", err)
+		return handleError(c, err)
+	}
+
+	members, err := applyGroupPatch(team, req.Operations)
+	if err != nil {
+		var pErr *patchError
+		if errors.As(err, &pErr) {
+			return writeError(c, http.StatusBadRequest, pErr.scimType, pErr.detail)
+		}
+		return handleError(c, err)
+	}
+
+	var add, remove []string
+	current := make(map[string]bool, len(team.Members))
+	for _, m := range team.Members {
+		current[m.UserID] = true
+	}
+	target := make(map[string]bool, len(members))
+	for _, id := range members {
+		target[id] = true
+		if !current[id] {
+			add = append(add, id)
+		}
+	}
+	for _, m := range team.Members {
+		if !target[m.UserID] {
+			remove = append(remove, m.UserID)
+		}
+	}
+
+	updated, err := h.directoryService.UpdateGroupMembers(ctx, team.TeamName, add, remove)
+	if err != nil {
+		tracing.Logger(c.Request().Context(), h.logger).Errorf("failed to patch scim group: %v", err)
+		return handleError(c, err)
+	}
+	return writeJSON(c, http.StatusOK, mapper.TeamToSCIM(updated, baseURL(c)))
+}
+
+// DeleteGroup godoc
+// @Summary Deprovision a SCIM group
+// @Description Take every member out of the team. Teams are not deleted; the empty team stays listed.
+// @Tags scim
+// @Param id path string true "Team name"
+// @Success 204
+// @Failure 404 {object} dto.SCIMError
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /scim/v2/Groups/{id} [delete]
+func (h *SCIMHandler) DeleteGroup(c echo.Context) error {
+	if _, err := h.directoryService.ReplaceGroupMembers(c.Request().Context(), resourceID(c), nil); err != nil {
+		tracing.Logger(c.Request().Context(), h.logger).Errorf("failed to deprovision scim group: %v", err)
+		return handleError(c, err)
+	}
+	return c.NoContent(http.StatusNoContent)
+}
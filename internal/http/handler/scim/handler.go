@@ -0,0 +1,130 @@
+package scim
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"github.com/ssokov/pr-reviewer-service/internal/apperror"
+	"github.com/ssokov/pr-reviewer-service/internal/http/response"
+	"github.com/ssokov/pr-reviewer-service/internal/model/dto"
+	"github.com/ssokov/pr-reviewer-service/internal/service"
+	"github.com/vmkteam/embedlog"
+)
+
+const (
+	// MIMEApplicationSCIM is the media type of SCIM requests and responses (RFC 7644, section 3.1).
+	MIMEApplicationSCIM = "application/scim+json"
+
+	BasePath = "/scim/v2"
+
+	maxResults = 200
+)
+
+// SCIM error types from RFC 7644, section 3.12.
+const (
+	scimTypeInvalidFilter = "invalidFilter"
+	scimTypeInvalidPath   = "invalidPath"
+	scimTypeInvalidSyntax = "invalidSyntax"
+	scimTypeInvalidValue  = "invalidValue"
+	scimTypeUniqueness    = "uniqueness"
+	scimTypeMutability    = "mutability"
+)
+
+type SCIMHandler struct {
+	directoryService service.DirectoryService
+	logger           embedlog.Logger
+}
+
+func NewHandler(directoryService service.DirectoryService, logger embedlog.Logger) *SCIMHandler {
+	return &SCIMHandler{
+		directoryService: directoryService,
+		logger:           logger,
+	}
+}
+
+func writeJSON(c echo.Context, status int, v any) error {
+	c.Response().Header().Set(echo.HeaderContentType, MIMEApplicationSCIM)
+	return c.JSON(status, v)
+}
+
+func writeError(c echo.Context, status int, scimType, detail string) error {
+	return writeJSON(c, status, dto.SCIMError{
+		Schemas:  []string{dto.SCIMSchemaError},
+		Status:   strconv.Itoa(status),
+		SCIMType: scimType,
+		Detail:   detail,
+	})
+}
+
+// handleError is response.HandleError in the SCIM error format. Existing users and teams are reported as
+// uniqueness conflicts, which identity providers use to link an existing resource instead of failing.
+func handleError(c echo.Context, err error) error {
+	var appErr *apperror.AppError
+	if !errors.As(err, &appErr) {
+		return writeError(c, http.StatusInternalServerError, "", "internal server error")
+	}
+
+	switch appErr.Code {
+	case apperror.ErrCodeUserExists, apperror.ErrCodeTeamExists:
+		return writeError(c, http.StatusConflict, scimTypeUniqueness, appErr.Message)
+	case apperror.ErrCodeInvalidInput:
+		return writeError(c, http.StatusBadRequest, scimTypeInvalidValue, appErr.Message)
+	}
+	return writeError(c, response.HTTPStatus(err), "", appErr.Message)
+}
+
+func decode(c echo.Context, v any) error {
+	if err := json.NewDecoder(c.Request().Body).Decode(v); err != nil {
+		return writeError(c, http.StatusBadRequest, scimTypeInvalidSyntax, "invalid request body: "+err.Error())
+	}
+	return nil
+}
+
+// resourceID returns the unescaped :id path parameter; team names may contain spaces.
+func resourceID(c echo.Context) string {
+	id := c.Param("id")
+	if unescaped, err := url.PathUnescape(id); err == nil {
+		return unescaped
+	}
+	return id
+}
+
+func baseURL(c echo.Context) string {
+	return c.Scheme() + "://" + c.Request().Host + BasePath
+}
+
+// listResponse pages resources with the 1-based startIndex and count query parameters.
+func listResponse[T any](c echo.Context, resources []T) error {
+	startIndex, err := queryInt(c, "startIndex", 1)
+	if err != nil {
+		return writeError(c, http.StatusBadRequest, scimTypeInvalidValue, "startIndex must be an integer")
+	}
+	count, err := queryInt(c, "count", maxResults)
+	if err != nil {
+		return writeError(c, http.StatusBadRequest, scimTypeInvalidValue, "count must be an integer")
+	}
+	startIndex = max(startIndex, 1)
+	count = min(max(count, 0), maxResults)
+
+	from := min(startIndex-1, len(resources))
+	to := min(from+count, len(resources))
+	return writeJSON(c, http.StatusOK, dto.SCIMListResponse{
+		Schemas:      []string{dto.SCIMSchemaListResponse},
+		TotalResults: len(resources),
+		StartIndex:   startIndex,
+		ItemsPerPage: to - from,
+		Resources:    resources[from:to],
+	})
+}
+
+func queryInt(c echo.Context, name string, def int) (int, error) {
+	raw := c.QueryParam(name)
+	if raw == "" {
+		return def, nil
+	}
+	return strconv.Atoi(raw)
+}
@@ -0,0 +1,431 @@
+package scim
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/ssokov/pr-reviewer-service/internal/apperror"
+	"github.com/ssokov/pr-reviewer-service/internal/http/middleware"
+	"github.com/ssokov/pr-reviewer-service/internal/model/domain"
+	"github.com/ssokov/pr-reviewer-service/internal/model/dto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/vmkteam/embedlog"
+)
+
+type MockDirectoryService struct {
+	mock.Mock
+}
+
+func (m *MockDirectoryService) ListUsers(ctx context.Context) ([]domain.User, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.User), args.Error(1)
+}
+
+func (m *MockDirectoryService) GetUser(ctx context.Context, userID string) (*domain.User, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.User), args.Error(1)
+}
+
+func (m *MockDirectoryService) CreateUser(ctx context.Context, user *domain.User) (*domain.User, error) {
+	args := m.Called(ctx, user)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.User), args.Error(1)
+}
+
+func (m *MockDirectoryService) UpdateUser(ctx context.Context, user *domain.User) (*domain.User, error) {
+	args := m.Called(ctx, user)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.User), args.Error(1)
+}
+
+func (m *MockDirectoryService) DeprovisionUser(ctx context.Context, userID string) (*domain.User, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.User), args.Error(1)
+}
+
+func (m *MockDirectoryService) ListGroups(ctx context.Context) ([]domain.Team, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.Team), args.Error(1)
+}
+
+func (m *MockDirectoryService) GetGroup(ctx context.Context, teamName string) (*domain.Team, error) {
+	args := m.Called(ctx, teamName)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Team), args.Error(1)
+}
+
+func (m *MockDirectoryService) CreateGroup(ctx context.Context, teamName string, memberIDs []string) (*domain.Team, error) {
+	args := m.Called(ctx, teamName, memberIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Team), args.Error(1)
+}
+
+func (m *MockDirectoryService) UpdateGroupMembers(ctx context.Context, teamName string, add, remove []string) (*domain.Team, error) {
+	args := m.Called(ctx, teamName, add, remove)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Team), args.Error(1)
+}
+
+func (m *MockDirectoryService) ReplaceGroupMembers(ctx context.Context, teamName string, memberIDs []string) (*domain.Team, error) {
+	args := m.Called(ctx, teamName, memberIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Team), args.Error(1)
+}
+
+func backendTeam() *domain.Team {
+	return &domain.Team{ID: 1, TeamName: "backend", Members: []domain.User{
+		{UserID: "u1", Username: "Alice", TeamID: 1, IsActive: true},
+		{UserID: "u4", Username: "Dan", TeamID: 1, IsActive: true},
+	}}
+}
+
+func bob() *domain.User {
+	return &domain.User{UserID: "u2", Username: "Bob", TeamID: 1, TeamName: "backend", IsActive: true}
+}
+
+// serve sends the testdata fixture, if any, through the SCIM routes.
+func serve(t *testing.T, svc *MockDirectoryService, method, target, fixture string) *httptest.ResponseRecorder {
+	t.Helper()
+
+	var body []byte
+	if fixture != "" {
+		var err error
+		body, err = os.ReadFile(filepath.Join("testdata", fixture))
+		require.NoError(t, err)
+	}
+
+	e := echo.New()
+	g := e.Group("")
+	g.Use(middleware.NoAuth())
+	RegisterRoutes(g, NewHandler(svc, embedlog.NewLogger(false, false)))
+
+	req := httptest.NewRequest(method, target, bytes.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, MIMEApplicationSCIM)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	return rec
+}
+
+// assertResource checks the attributes RFC 7643 requires on every resource.
+func assertResource(t *testing.T, rec *httptest.ResponseRecorder, schema, resourceType string) map[string]any {
+	t.Helper()
+
+	assert.Equal(t, MIMEApplicationSCIM, rec.Header().Get(echo.HeaderContentType))
+	var resource map[string]any
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resource))
+	assert.Contains(t, resource["schemas"], schema)
+	assert.NotEmpty(t, resource["id"])
+	meta, ok := resource["meta"].(map[string]any)
+	require.True(t, ok, "meta is required")
+	assert.Equal(t, resourceType, meta["resourceType"])
+	assert.NotEmpty(t, meta["location"])
+	return resource
+}
+
+func assertSCIMError(t *testing.T, rec *httptest.ResponseRecorder, status int, scimType string) {
+	t.Helper()
+
+	assert.Equal(t, status, rec.Code)
+	assert.Equal(t, MIMEApplicationSCIM, rec.Header().Get(echo.HeaderContentType))
+	var resp dto.SCIMError
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	assert.Equal(t, []string{dto.SCIMSchemaError}, resp.Schemas)
+	assert.Equal(t, strconv.Itoa(status), resp.Status)
+	assert.Equal(t, scimType, resp.SCIMType)
+	assert.NotEmpty(t, resp.Detail)
+}
+
+func TestSCIM_CreateUser(t *testing.T) {
+	tests := []struct {
+		fixture string
+		want    *domain.User
+	}{
+		{fixture: "azure_create_user.json", want: &domain.User{UserID: "u7", Username: "Grace Hopper", IsActive: true}},
+		{fixture: "okta_create_user.json", want: &domain.User{UserID: "u8", Username: "Alan Turing", IsActive: true}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.fixture, func(t *testing.T) {
+			svc := new(MockDirectoryService)
+			svc.On("CreateUser", mock.Anything, tt.want).Return(tt.want, nil).Once()
+
+			rec := serve(t, svc, http.MethodPost, "/scim/v2/Users", tt.fixture)
+
+			assert.Equal(t, http.StatusCreated, rec.Code)
+			resource := assertResource(t, rec, dto.SCIMSchemaUser, "User")
+			assert.Equal(t, tt.want.UserID, resource["userName"])
+			assert.Equal(t, true, resource["active"])
+			svc.AssertExpectations(t)
+		})
+	}
+
+	t.Run("existing user is a uniqueness conflict", func(t *testing.T) {
+		svc := new(MockDirectoryService)
+		svc.On("CreateUser", mock.Anything, mock.Anything).Return(nil, apperror.NewUserExistsError("u7"))
+
+		rec := serve(t, svc, http.MethodPost, "/scim/v2/Users", "azure_create_user.json")
+
+		assertSCIMError(t, rec, http.StatusConflict, scimTypeUniqueness)
+	})
+}
+
+func TestSCIM_DeprovisionUser(t *testing.T) {
+	inactive := &domain.User{UserID: "u2", Username: "Bob", TeamID: 1, TeamName: "backend", IsActive: false}
+
+	for _, fixture := range []string{"azure_patch_user_deactivate.json", "okta_patch_user_deactivate.json"} {
+		t.Run(fixture, func(t *testing.T) {
+			svc := new(MockDirectoryService)
+			svc.On("GetUser", mock.Anything, "u2").Return(bob(), nil)
+			svc.On("UpdateUser", mock.Anything, inactive).Return(inactive, nil).Once()
+
+			rec := serve(t, svc, http.MethodPatch, "/scim/v2/Users/u2", fixture)
+
+			assert.Equal(t, http.StatusOK, rec.Code)
+			resource := assertResource(t, rec, dto.SCIMSchemaUser, "User")
+			assert.Equal(t, false, resource["active"])
+			svc.AssertExpectations(t)
+		})
+	}
+
+	t.Run("okta_put_user_deactivate.json", func(t *testing.T) {
+		svc := new(MockDirectoryService)
+		svc.On("UpdateUser", mock.Anything, &domain.User{UserID: "u2", Username: "Bob Smith", IsActive: false}).Return(inactive, nil).Once()
+
+		rec := serve(t, svc, http.MethodPut, "/scim/v2/Users/u2", "okta_put_user_deactivate.json")
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assertResource(t, rec, dto.SCIMSchemaUser, "User")
+		svc.AssertExpectations(t)
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		svc := new(MockDirectoryService)
+		svc.On("DeprovisionUser", mock.Anything, "u2").Return(inactive, nil).Once()
+
+		rec := serve(t, svc, http.MethodDelete, "/scim/v2/Users/u2", "")
+
+		assert.Equal(t, http.StatusNoContent, rec.Code)
+		svc.AssertExpectations(t)
+	})
+
+	t.Run("delete unknown user", func(t *testing.T) {
+		svc := new(MockDirectoryService)
+		svc.On("DeprovisionUser", mock.Anything, "ghost").Return(nil, apperror.NewUserNotFoundError("ghost"))
+
+		rec := serve(t, svc, http.MethodDelete, "/scim/v2/Users/ghost", "")
+
+		assertSCIMError(t, rec, http.StatusNotFound, "")
+	})
+}
+
+func TestSCIM_PatchUser_IgnoresUnknownAttributes(t *testing.T) {
+	svc := new(MockDirectoryService)
+	renamed := bob()
+	renamed.Username = "Robert Smith"
+	svc.On("GetUser", mock.Anything, "u2").Return(bob(), nil)
+	svc.On("UpdateUser", mock.Anything, renamed).Return(renamed, nil).Once()
+
+	rec := serve(t, svc, http.MethodPatch, "/scim/v2/Users/u2", "azure_patch_user_attributes.json")
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	resource := assertResource(t, rec, dto.SCIMSchemaUser, "User")
+	assert.Equal(t, "Robert Smith", resource["displayName"])
+	svc.AssertExpectations(t)
+}
+
+func TestSCIM_PatchUser_RejectsRemovingStoredAttributes(t *testing.T) {
+	svc := new(MockDirectoryService)
+	svc.On("GetUser", mock.Anything, "u2").Return(bob(), nil)
+
+	rec := serve(t, svc, http.MethodPatch, "/scim/v2/Users/u2", "azure_patch_user_remove_active.json")
+
+	assertSCIMError(t, rec, http.StatusBadRequest, "invalidPath")
+	svc.AssertNotCalled(t, "UpdateUser", mock.Anything, mock.Anything)
+}
+
+func TestSCIM_ListUsers(t *testing.T) {
+	t.Run("filter by userName", func(t *testing.T) {
+		svc := new(MockDirectoryService)
+		svc.On("GetUser", mock.Anything, "u2").Return(bob(), nil)
+
+		rec := serve(t, svc, http.MethodGet, `/scim/v2/Users?filter=userName+eq+%22u2%22`, "")
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		var resp dto.SCIMListResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+		assert.Equal(t, []string{dto.SCIMSchemaListResponse}, resp.Schemas)
+		assert.Equal(t, 1, resp.TotalResults)
+		assert.Len(t, resp.Resources, 1)
+	})
+
+	t.Run("no match is an empty list", func(t *testing.T) {
+		svc := new(MockDirectoryService)
+		svc.On("GetUser", mock.Anything, "ghost").Return(nil, apperror.NewUserNotFoundError("ghost"))
+
+		rec := serve(t, svc, http.MethodGet, `/scim/v2/Users?filter=USERNAME+eq+%22ghost%22`, "")
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		var resp dto.SCIMListResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+		assert.Zero(t, resp.TotalResults)
+		assert.Empty(t, resp.Resources)
+	})
+
+	t.Run("pagination", func(t *testing.T) {
+		svc := new(MockDirectoryService)
+		svc.On("ListUsers", mock.Anything).Return([]domain.User{{UserID: "u1"}, {UserID: "u2"}, {UserID: "u3"}}, nil)
+
+		rec := serve(t, svc, http.MethodGet, "/scim/v2/Users?startIndex=2&count=1", "")
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		var resp struct {
+			TotalResults int            `json:"totalResults"`
+			StartIndex   int            `json:"startIndex"`
+			ItemsPerPage int            `json:"itemsPerPage"`
+			Resources    []dto.SCIMUser `json:"Resources"`
+		}
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+		assert.Equal(t, 3, resp.TotalResults)
+		assert.Equal(t, 2, resp.StartIndex)
+		assert.Equal(t, 1, resp.ItemsPerPage)
+		require.Len(t, resp.Resources, 1)
+		assert.Equal(t, "u2", resp.Resources[0].ID)
+	})
+
+	t.Run("unsupported filter", func(t *testing.T) {
+		rec := serve(t, new(MockDirectoryService), http.MethodGet, `/scim/v2/Users?filter=emails+co+%22example%22`, "")
+
+		assertSCIMError(t, rec, http.StatusBadRequest, scimTypeInvalidFilter)
+	})
+}
+
+func TestSCIM_Groups(t *testing.T) {
+	t.Run("create_group.json", func(t *testing.T) {
+		svc := new(MockDirectoryService)
+		svc.On("CreateGroup", mock.Anything, "mobile", []string{"u5"}).
+			Return(&domain.Team{ID: 3, TeamName: "mobile", Members: []domain.User{{UserID: "u5", Username: "Eve"}}}, nil).Once()
+
+		rec := serve(t, svc, http.MethodPost, "/scim/v2/Groups", "create_group.json")
+
+		assert.Equal(t, http.StatusCreated, rec.Code)
+		resource := assertResource(t, rec, dto.SCIMSchemaGroup, "Group")
+		assert.Equal(t, "mobile", resource["displayName"])
+		assert.Len(t, resource["members"], 1)
+		svc.AssertExpectations(t)
+	})
+
+	t.Run("azure_patch_group_add_member.json", func(t *testing.T) {
+		svc := new(MockDirectoryService)
+		svc.On("GetGroup", mock.Anything, "backend").Return(backendTeam(), nil)
+		svc.On("UpdateGroupMembers", mock.Anything, "backend", []string{"u2"}, []string(nil)).Return(backendTeam(), nil).Once()
+
+		rec := serve(t, svc, http.MethodPatch, "/scim/v2/Groups/backend", "azure_patch_group_add_member.json")
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assertResource(t, rec, dto.SCIMSchemaGroup, "Group")
+		svc.AssertExpectations(t)
+	})
+
+	t.Run("azure_patch_group_remove_member.json", func(t *testing.T) {
+		svc := new(MockDirectoryService)
+		svc.On("GetGroup", mock.Anything, "backend").Return(backendTeam(), nil)
+		svc.On("UpdateGroupMembers", mock.Anything, "backend", []string(nil), []string{"u4"}).Return(backendTeam(), nil).Once()
+
+		rec := serve(t, svc, http.MethodPatch, "/scim/v2/Groups/backend", "azure_patch_group_remove_member.json")
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		svc.AssertExpectations(t)
+	})
+
+	t.Run("okta_patch_group_rename.json", func(t *testing.T) {
+		svc := new(MockDirectoryService)
+		svc.On("GetGroup", mock.Anything, "backend").Return(backendTeam(), nil)
+
+		rec := serve(t, svc, http.MethodPatch, "/scim/v2/Groups/backend", "okta_patch_group_rename.json")
+
+		assertSCIMError(t, rec, http.StatusBadRequest, scimTypeMutability)
+		svc.AssertNotCalled(t, "UpdateGroupMembers", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("okta_put_group.json", func(t *testing.T) {
+		svc := new(MockDirectoryService)
+		svc.On("ReplaceGroupMembers", mock.Anything, "backend", []string{"u1", "u2"}).Return(backendTeam(), nil).Once()
+
+		rec := serve(t, svc, http.MethodPut, "/scim/v2/Groups/backend", "okta_put_group.json")
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assertResource(t, rec, dto.SCIMSchemaGroup, "Group")
+		svc.AssertExpectations(t)
+	})
+
+	t.Run("existing team is a uniqueness conflict", func(t *testing.T) {
+		svc := new(MockDirectoryService)
+		svc.On("CreateGroup", mock.Anything, "mobile", []string{"u5"}).Return(nil, apperror.NewTeamExistsError("mobile"))
+
+		rec := serve(t, svc, http.MethodPost, "/scim/v2/Groups", "create_group.json")
+
+		assertSCIMError(t, rec, http.StatusConflict, scimTypeUniqueness)
+	})
+
+	t.Run("delete empties the team", func(t *testing.T) {
+		svc := new(MockDirectoryService)
+		svc.On("ReplaceGroupMembers", mock.Anything, "backend", []string(nil)).Return(&domain.Team{TeamName: "backend"}, nil).Once()
+
+		rec := serve(t, svc, http.MethodDelete, "/scim/v2/Groups/backend", "")
+
+		assert.Equal(t, http.StatusNoContent, rec.Code)
+		svc.AssertExpectations(t)
+	})
+}
+
+func TestSCIM_Discovery(t *testing.T) {
+	rec := serve(t, new(MockDirectoryService), http.MethodGet, "/scim/v2/ServiceProviderConfig", "")
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var config dto.SCIMServiceProviderConfig
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &config))
+	assert.True(t, config.Patch.Supported)
+	assert.True(t, config.Filter.Supported)
+	assert.False(t, config.Bulk.Supported)
+
+	rec = serve(t, new(MockDirectoryService), http.MethodGet, "/scim/v2/ResourceTypes", "")
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var resp dto.SCIMListResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	assert.Equal(t, 2, resp.TotalResults)
+}
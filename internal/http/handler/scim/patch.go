@@ -0,0 +1,218 @@
+package scim
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/ssokov/pr-reviewer-service/internal/model/domain"
+	"github.com/ssokov/pr-reviewer-service/internal/model/dto"
+)
+
+// Identity providers differ in how they write PATCH requests: Azure AD capitalizes op names and sends booleans
+// as "True"/"False" strings, Okta sends a path-less replace with an object value. Both are accepted. Attributes
+// the service does not store, such as emails or externalId, are ignored rather than rejected so provisioning of
+// the remaining attributes still succeeds. Removing a stored user attribute is rejected with invalidPath: every one
+// of them is required.
+
+// patchError is a PATCH request the service cannot apply; scimType is reported to the client.
+type patchError struct {
+	scimType string
+	detail   string
+}
+
+func (e *patchError) Error() string { return e.detail }
+
+func invalidValue(format string, args ...any) *patchError {
+	return &patchError{scimType: scimTypeInvalidValue, detail: fmt.Sprintf(format, args...)}
+}
+
+// memberFilterPattern matches the path Azure AD uses to remove one member: members[value eq "u1"].
+var memberFilterPattern = regexp.MustCompile(`(?i)^members\[\s*value\s+eq\s+("(?:[^"\\]|\\.)*")\s*\]$`)
+
+func patchOp(op dto.SCIMPatchOperation) (string, error) {
+	name := strings.ToLower(op.Op)
+	switch name {
+	case "add", "replace", "remove":
+		return name, nil
+	}
+	return "", invalidValue("unsupported patch op %q", op.Op)
+}
+
+// applyUserPatch applies the operations to user in place.
+func applyUserPatch(user *domain.User, ops []dto.SCIMPatchOperation) error {
+	for _, op := range ops {
+		name, err := patchOp(op)
+		if err != nil {
+			return err
+		}
+		if name == "remove" {
+			if op.Path == "" || slices.Contains(storedUserAttributes, strings.ToLower(op.Path)) {
+				return &patchError{scimType: scimTypeInvalidPath, detail: fmt.Sprintf("attribute %q cannot be removed", op.Path)}
+			}
+			continue
+		}
+
+		if op.Path == "" {
+			var values map[string]json.RawMessage
+			if err := json.Unmarshal(op.Value, &values); err != nil {
+				return invalidValue("value of a patch without path must be an object")
+			}
+			for attr, value := range values {
+				if err := setUserAttribute(user, attr, value); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+		if err := setUserAttribute(user, op.Path, op.Value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// storedUserAttributes are the lower-cased user attributes setUserAttribute applies.
+var storedUserAttributes = []string{"active", "displayname", "name", "name.formatted", "username"}
+
+func setUserAttribute(user *domain.User, attr string, value json.RawMessage) error {
+	switch strings.ToLower(attr) {
+	case "active":
+		active, err := parseBool(value)
+		if err != nil {
+			return err
+		}
+		user.IsActive = active
+	case "displayname", "name.formatted":
+		var name string
+		if err := json.Unmarshal(value, &name); err != nil {
+			return invalidValue("%s must be a string", attr)
+		}
+		user.Username = name
+	case "name":
+		var name dto.SCIMName
+		if err := json.Unmarshal(value, &name); err != nil {
+			return invalidValue("name must be an object")
+		}
+		if name.Formatted != "" {
+			user.Username = name.Formatted
+		}
+	case "username":
+		var userName string
+		if err := json.Unmarshal(value, &userName); err != nil {
+			return invalidValue("userName must be a string")
+		}
+		if userName != user.UserID {
+			return &patchError{scimType: scimTypeMutability, detail: "userName is the user_id and cannot be changed"}
+		}
+	}
+	return nil
+}
+
+// applyGroupPatch returns the member list of the team after the operations.
+func applyGroupPatch(team *domain.Team, ops []dto.SCIMPatchOperation) ([]string, error) {
+	members := make([]string, 0, len(team.Members))
+	for _, m := range team.Members {
+		members = append(members, m.UserID)
+	}
+
+	for _, op := range ops {
+		name, err := patchOp(op)
+		if err != nil {
+			return nil, err
+		}
+
+		if m := memberFilterPattern.FindStringSubmatch(op.Path); m != nil && name == "remove" {
+			userID, err := strconv.Unquote(m[1])
+			if err != nil {
+				return nil, invalidValue("invalid member filter %s", op.Path)
+			}
+			members = slices.DeleteFunc(members, func(id string) bool { return id == userID })
+			continue
+		}
+
+		switch strings.ToLower(op.Path) {
+		case "members":
+			var value []dto.SCIMMember
+			if len(op.Value) > 0 {
+				if err := json.Unmarshal(op.Value, &value); err != nil {
+					return nil, invalidValue("members must be a list of {\"value\": user_id}")
+				}
+			}
+			members = patchMembers(members, name, value)
+		case "displayname":
+			if err := checkDisplayName(team, op.Value); err != nil {
+				return nil, err
+			}
+		case "":
+			var value struct {
+				DisplayName json.RawMessage   `json:"displayName"`
+				Members     *[]dto.SCIMMember `json:"members"`
+			}
+			if err := json.Unmarshal(op.Value, &value); err != nil {
+				return nil, invalidValue("value of a patch without path must be an object")
+			}
+			if value.DisplayName != nil {
+				if err := checkDisplayName(team, value.DisplayName); err != nil {
+					return nil, err
+				}
+			}
+			if value.Members != nil {
+				members = patchMembers(members, name, *value.Members)
+			}
+		}
+	}
+	return members, nil
+}
+
+func patchMembers(members []string, op string, value []dto.SCIMMember) []string {
+	switch op {
+	case "replace":
+		members = members[:0]
+		fallthrough
+	case "add":
+		for _, m := range value {
+			if !slices.Contains(members, m.Value) {
+				members = append(members, m.Value)
+			}
+		}
+	case "remove":
+		if len(value) == 0 {
+			return members[:0]
+		}
+		for _, m := range value {
+			members = slices.DeleteFunc(members, func(id string) bool { return id == m.Value })
+		}
+	}
+	return members
+}
+
+// checkDisplayName rejects renames: the group id is the team name, which cannot be changed.
+func checkDisplayName(team *domain.Team, value json.RawMessage) error {
+	var name string
+	if err := json.Unmarshal(value, &name); err != nil {
+		return invalidValue("displayName must be a string")
+	}
+	if name != team.TeamName {
+		return &patchError{scimType: scimTypeMutability, detail: "teams cannot be renamed"}
+	}
+	return nil
+}
+
+// parseBool accepts JSON booleans and the "True"/"False" strings sent by Azure AD.
+func parseBool(value json.RawMessage) (bool, error) {
+	var b bool
+	if err := json.Unmarshal(value, &b); err == nil {
+		return b, nil
+	}
+	var s string
+	if err := json.Unmarshal(value, &s); err == nil {
+		if b, err := strconv.ParseBool(s); err == nil {
+			return b, nil
+		}
+	}
+	return false, invalidValue("active must be a boolean")
+}
@@ -0,0 +1,29 @@
+package scim
+
+import (
+	"github.com/labstack/echo/v4"
+	"github.com/ssokov/pr-reviewer-service/internal/http/middleware"
+	"github.com/ssokov/pr-reviewer-service/internal/model/domain"
+)
+
+func RegisterRoutes(g *echo.Group, h *SCIMHandler) {
+	scimGroup := g.Group(BasePath, middleware.RequireScope(domain.ScopeDirectoryWrite))
+	{
+		scimGroup.GET("/ServiceProviderConfig", h.ServiceProviderConfig)
+		scimGroup.GET("/ResourceTypes", h.ResourceTypes)
+
+		scimGroup.GET("/Users", h.ListUsers)
+		scimGroup.POST("/Users", h.CreateUser)
+		scimGroup.GET("/Users/:id", h.GetUser)
+		scimGroup.PUT("/Users/:id", h.ReplaceUser)
+		scimGroup.PATCH("/Users/:id", h.PatchUser)
+		scimGroup.DELETE("/Users/:id", h.DeleteUser)
+
+		scimGroup.GET("/Groups", h.ListGroups)
+		scimGroup.POST("/Groups", h.CreateGroup)
+		scimGroup.GET("/Groups/:id", h.GetGroup)
+		scimGroup.PUT("/Groups/:id", h.ReplaceGroup)
+		scimGroup.PATCH("/Groups/:id", h.PatchGroup)
+		scimGroup.DELETE("/Groups/:id", h.DeleteGroup)
+	}
+}
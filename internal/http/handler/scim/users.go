@@ -0,0 +1,211 @@
+package scim
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/ssokov/pr-reviewer-service/internal/apperror"
+	"github.com/ssokov/pr-reviewer-service/internal/http/mapper"
+	"github.com/ssokov/pr-reviewer-service/internal/model/domain"
+	"github.com/ssokov/pr-reviewer-service/internal/model/dto"
+	"github.com/ssokov/pr-reviewer-service/internal/tracing"
+)
+
+// ListUsers godoc
+// @Summary List SCIM users
+// @Description List users, optionally filtered with userName eq "..." or id eq "..."
+// @Tags scim
+// @Produce json
+// @Param filter query string false "SCIM filter"
+// @Param startIndex query int false "1-based index of the first result"
+// @Param count query int false "Page size, at most 200"
+// @Success 200 {object} dto.SCIMListResponse
+// @Failure 400 {object} dto.SCIMError
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /scim/v2/Users [get]
+func (h *SCIMHandler) ListUsers(c echo.Context) error {
+	f, err := parseFilter(c.QueryParam("filter"), "userName", "id")
+	if err != nil {
+		return writeError(c, http.StatusBadRequest, scimTypeInvalidFilter, err.Error())
+	}
+
+	ctx := c.Request().Context()
+	var users []domain.User
+	if f != nil {
+		// userName and id are both the user_id.
+		user, err := h.directoryService.GetUser(ctx, f.value)
+		if err != nil && !apperror.Is(err, apperror.ErrCodeUserNotFound) {
+			tracing.Logger(c.Request().Context(), h.logger).Errorf("failed to get scim user: %v", err)
+			return handleError(c, err)
+		}
+		if user != nil {
+			users = append(users, *user)
+		}
+	} else {
+		users, err = h.directoryService.ListUsers(ctx)
+		if err != nil {
+			tracing.Logger(c.Request().Context(), h.logger).Errorf("failed to list scim users: %v", err)
+			return handleError(c, err)
+		}
+	}
+
+	resources := make([]dto.SCIMUser, 0, len(users))
+	for i := range users {
+		resources = append(resources, mapper.UserToSCIM(&users[i], baseURL(c)))
+	}
+	return listResponse(c, resources)
+}
+
+// GetUser godoc
+// @Summary Get a SCIM user
+// @Tags scim
+// @Produce json
+// @Param id path string true "User ID"
+// @Success 200 {object} dto.SCIMUser
+// @Failure 404 {object} dto.SCIMError
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /scim/v2/Users/{id} [get]
+func (h *SCIMHandler) GetUser(c echo.Context) error {
+	user, err := h.directoryService.GetUser(c.Request().Context(), resourceID(c))
+	if err != nil {
+		tracing.Logger(c.Request().Context(), h.logger).Errorf("failed to get scim user: %v", err)
+		return handleError(c, err)
+	}
+	return writeJSON(c, http.StatusOK, mapper.UserToSCIM(user, baseURL(c)))
+}
+
+// CreateUser godoc
+// @Summary Provision a SCIM user
+// @Description Create a user outside of any team. userName becomes the user_id; add the user to a group to put it in a team.
+// @Tags scim
+// @Accept json
+// @Produce json
+// @Param request body dto.SCIMUser true "User"
+// @Success 201 {object} dto.SCIMUser
+// @Failure 400 {object} dto.SCIMError
+// @Failure 409 {object} dto.SCIMError "User exists"
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /scim/v2/Users [post]
+func (h *SCIMHandler) CreateUser(c echo.Context) error {
+	var req dto.SCIMUser
+	if err := decode(c, &req); err != nil {
+		return err
+	}
+
+	user, err := h.directoryService.CreateUser(c.Request().Context(), mapper.SCIMToUser(req))
+	if err != nil {
+		tracing.Logger(c.Request().Context(), h.logger).Errorf("failed to create scim user: %v", err)
+		return handleError(c, err)
+	}
+	return writeJSON(c, http.StatusCreated, mapper.UserToSCIM(user, baseURL(c)))
+}
+
+// ReplaceUser godoc
+// @Summary Replace a SCIM user
+// @Description Update the display name and active flag. Setting active to false deprovisions the user.
+// @Tags scim
+// @Accept json
+// @Produce json
+// @Param id path string true "User ID"
+// @Param request body dto.SCIMUser true "User"
+// @Success 200 {object} dto.SCIMUser
+// @Failure 400 {object} dto.SCIMError
+// @Failure 404 {object} dto.SCIMError
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /scim/v2/Users/{id} [put]
+func (h *SCIMHandler) ReplaceUser(c echo.Context) error {
+	var req dto.SCIMUser
+	if err := decode(c, &req); err != nil {
+		return err
+	}
+
+	userID := resourceID(c)
+	if req.UserName != "" && req.UserName != userID {
+		return writeError(c, http.StatusBadRequest, scimTypeMutability, "userName is the user_id and cannot be changed")
+	}
+	user := mapper.SCIMToUser(req)
+	user.UserID = userID
+
+	return h.updateUser(c, user)
+}
+
+// PatchUser godoc
+// @Summary Patch a SCIM user
+// @Description Supports active, displayName and name.formatted. Setting active to false deprovisions the user.
+// @Tags scim
+// @Accept json
+// @Produce json
+// @Param id path string true "User ID"
+// @Param request body dto.SCIMPatchRequest true "Patch operations"
+// @Success 200 {object} dto.SCIMUser
+// @Failure 400 {object} dto.SCIMError
+// @Failure 404 {object} dto.SCIMError
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /scim/v2/Users/{id} [patch]
+func (h *SCIMHandler) PatchUser(c echo.Context) error {
+	var req dto.SCIMPatchRequest
+	if err := decode(c, &req); err != nil {
+		return err
+	}
+
+	user, err := h.directoryService.GetUser(c.Request().Context(), resourceID(c))
+	if err != nil {
+		tracing.Logger(c.Request().Context(), h.logger).Errorf("failed to get scim user: %v", err)
+		return handleError(c, err)
+	}
+
+	if err := applyUserPatch(user, req.Operations); err != nil {
+		var pErr *patchError
+		if errors.As(err, &pErr) {
+			return writeError(c, http.StatusBadRequest, pErr.scimType, pErr.detail)
+		}
+		return handleError(c, err)
+	}
+
+	return h.updateUser(c, user)
+}
+
+func (h *SCIMHandler) updateUser(c echo.Context, user *domain.User) error {
+	updated, err := h.directoryService.UpdateUser(c.Request().Context(), user)
+	if err != nil {
+		tracing.Logger(c.Request().Context(), h.logger).Errorf("failed to update scim user: %v", err)
+		return handleError(c, err)
+	}
+	return writeJSON(c, http.StatusOK, mapper.UserToSCIM(updated, baseURL(c)))
+}
+
+// DeleteUser godoc
+// @Summary Deprovision a SCIM user
+// @Description Deactivate the user and reassign its open reviews. The user is kept, inactive, because pull requests refer to it.
+// @Tags scim
+// @Param id path string true "User ID"
+// @Success 204
+// @Failure 404 {object} dto.SCIMError
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /scim/v2/Users/{id} [delete]
+func (h *SCIMHandler) DeleteUser(c echo.Context) error {
+	if _, err := h.directoryService.DeprovisionUser(c.Request().Context(), resourceID(c)); err != nil {
+		tracing.Logger(c.Request().Context(), h.logger).Errorf("failed to deprovision scim user: %v", err)
+		return handleError(c, err)
+	}
+	return c.NoContent(http.StatusNoContent)
+}
@@ -2,6 +2,7 @@ package stats
 
 import (
 	"net/http"
+	"strconv"
 
 	"github.com/labstack/echo/v4"
 	"github.com/ssokov/pr-reviewer-service/internal/http/response"
@@ -28,7 +29,11 @@ func NewHandler(statsService service.StatsService, logger embedlog.Logger) *Hand
 // @Tags stats
 // @Produce json
 // @Success 200 {object} dto.StatsResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
 // @Failure 500 {object} dto.ErrorResponse
+// @Security ApiKeyAuth
+// @Security BearerAuth
 // @Router /stats [get]
 func (h *Handler) GetStats(c echo.Context) error {
 	ctx := c.Request().Context()
@@ -41,3 +46,68 @@ func (h *Handler) GetStats(c echo.Context) error {
 
 	return c.JSON(http.StatusOK, stats)
 }
+
+// GetPairings godoc
+// @Summary Get author-reviewer pairings of a team
+// @Description Get how many PRs by each member of a team every reviewer was assigned to, over the last days
+// @Description (the organization's pairing window by default). top_share close to 1 means one reviewer takes
+// @Description almost all of an author's reviews.
+// @Tags stats
+// @Produce json
+// @Param team_name query string true "Team name"
+// @Param days query int false "Window in days"
+// @Success 200 {object} dto.PairingsResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse "Team not found"
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /stats/pairings [get]
+func (h *Handler) GetPairings(c echo.Context) error {
+	days := 0
+	if raw := c.QueryParam("days"); raw != "" {
+		n, err := strconv.Atoi(raw)
+		if err != nil {
+			return response.Error(c, http.StatusBadRequest, "INVALID_INPUT", "days must be an integer")
+		}
+		days = n
+	}
+
+	ctx := c.Request().Context()
+	pairings, err := h.statsService.GetPairings(ctx, c.QueryParam("team_name"), days)
+	if err != nil {
+		h.logger.Print(ctx, "failed to get pairings", "error", err)
+		return response.HandleError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, pairings)
+}
+
+// GetTeamStats godoc
+// @Summary Get team statistics
+// @Description Get member and PR counts per team. Each team has its own counts and the counts of its whole subtree;
+// @Description PRs are counted by the team of their author.
+// @Tags stats
+// @Produce json
+// @Param team_name query string false "Root team; all root teams when omitted"
+// @Success 200 {object} dto.TeamStatsResponse
+// @Failure 404 {object} dto.ErrorResponse "Team not found"
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /stats/teams [get]
+func (h *Handler) GetTeamStats(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	stats, err := h.statsService.GetTeamStats(ctx, c.QueryParam("team_name"))
+	if err != nil {
+		h.logger.Print(ctx, "failed to get team stats", "error", err)
+		return response.HandleError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, stats)
+}
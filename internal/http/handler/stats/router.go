@@ -1,7 +1,13 @@
 package stats
 
-import "github.com/labstack/echo/v4"
+import (
+	"github.com/labstack/echo/v4"
+	"github.com/ssokov/pr-reviewer-service/internal/http/middleware"
+	"github.com/ssokov/pr-reviewer-service/internal/model/domain"
+)
 
-func RegisterRoutes(e *echo.Echo, handler *Handler) {
-	e.GET("/stats", handler.GetStats)
+func RegisterRoutes(g *echo.Group, handler *Handler) {
+	g.GET("/stats", handler.GetStats, middleware.RequireScope(domain.ScopeStatsRead))
+	g.GET("/stats/teams", handler.GetTeamStats, middleware.RequireScope(domain.ScopeStatsRead))
+	g.GET("/stats/pairings", handler.GetPairings, middleware.RequireScope(domain.ScopeStatsRead))
 }
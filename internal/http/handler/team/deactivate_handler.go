@@ -4,8 +4,11 @@ import (
 	"net/http"
 
 	"github.com/labstack/echo/v4"
+	"github.com/ssokov/pr-reviewer-service/internal/dryrun"
+	"github.com/ssokov/pr-reviewer-service/internal/http/mapper"
 	"github.com/ssokov/pr-reviewer-service/internal/http/response"
 	"github.com/ssokov/pr-reviewer-service/internal/model/dto"
+	"github.com/ssokov/pr-reviewer-service/internal/tracing"
 )
 
 // DeactivateTeam godoc
@@ -16,17 +19,22 @@ import (
 // @Accept json
 // @Produce json
 // @Param request body dto.DeactivateTeamRequest true "Team deactivation request"
+// @Param dry_run query bool false "Run in a rolled-back transaction and return what would change"
 // @Success 200 {object} dto.DeactivateTeamResponse
 // @Failure 400 {object} dto.ErrorResponse
 // @Failure 404 {object} dto.ErrorResponse "Team not found"
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
 // @Failure 500 {object} dto.ErrorResponse
+// @Security ApiKeyAuth
+// @Security BearerAuth
 // @Router /team/deactivate [post]
 func (t *TeamHandler) DeactivateTeam(c echo.Context) error {
 	ctx := c.Request().Context()
 
 	var req dto.DeactivateTeamRequest
 	if err := c.Bind(&req); err != nil {
-		t.logger.Errorf("failed to bind request: %v", err)
+		tracing.Logger(c.Request().Context(), t.logger).Errorf("failed to bind request: %v", err)
 		return response.Error(c, http.StatusBadRequest, "INVALID_INPUT", "invalid request body")
 	}
 
@@ -36,34 +44,11 @@ func (t *TeamHandler) DeactivateTeam(c echo.Context) error {
 
 	deactivatedUsers, openPRs, err := t.teamService.DeactivateTeam(ctx, req.TeamName)
 	if err != nil {
-		t.logger.Errorf("failed to deactivate team: %v", err)
-		return response.Error(c, http.StatusInternalServerError, "INTERNAL_ERROR", "failed to deactivate team")
-	}
-
-	usersInfo := make([]dto.DeactivatedUserInfo, len(deactivatedUsers))
-	userPRCount := make(map[string]int)
-
-	for _, pr := range openPRs {
-		if pr.AssignedReviewers != nil {
-			for _, reviewerID := range pr.AssignedReviewers {
-				userPRCount[reviewerID]++
-			}
-		}
-	}
-
-	for i, user := range deactivatedUsers {
-		usersInfo[i] = dto.DeactivatedUserInfo{
-			UserID:       user.UserID,
-			Username:     user.Username,
-			OpenPRsCount: userPRCount[user.UserID],
-		}
-	}
-
-	resp := dto.DeactivateTeamResponse{
-		DeactivatedUsers: len(deactivatedUsers),
-		ReassignedPRs:    len(openPRs),
-		Users:            usersInfo,
+		tracing.Logger(c.Request().Context(), t.logger).Errorf("failed to deactivate team: %v", err)
+		return response.HandleError(c, err)
 	}
 
+	resp := mapper.DeactivationToResponse(deactivatedUsers, openPRs)
+	resp.DryRun = dryrun.Enabled(ctx)
 	return c.JSON(http.StatusOK, resp)
 }
@@ -8,18 +8,23 @@ import (
 	"github.com/ssokov/pr-reviewer-service/internal/http/response"
 	"github.com/ssokov/pr-reviewer-service/internal/model/dto"
 	"github.com/ssokov/pr-reviewer-service/internal/service"
+	"github.com/ssokov/pr-reviewer-service/internal/tracing"
 	"github.com/vmkteam/embedlog"
 )
 
 type TeamHandler struct {
-	teamService service.TeamService
-	logger      embedlog.Logger
+	teamService      service.TeamService
+	rosterService    service.RosterService
+	hierarchyService service.HierarchyService
+	logger           embedlog.Logger
 }
 
-func NewHandler(service service.TeamService, logger embedlog.Logger) *TeamHandler {
+func NewHandler(teamService service.TeamService, rosterService service.RosterService, hierarchyService service.HierarchyService, logger embedlog.Logger) *TeamHandler {
 	return &TeamHandler{
-		teamService: service,
-		logger:      logger,
+		teamService:      teamService,
+		rosterService:    rosterService,
+		hierarchyService: hierarchyService,
+		logger:           logger,
 	}
 }
 
@@ -33,12 +38,16 @@ func NewHandler(service service.TeamService, logger embedlog.Logger) *TeamHandle
 // @Success 201 {object} dto.AddTeamResponse
 // @Failure 400 {object} dto.ErrorResponse
 // @Failure 409 {object} dto.ErrorResponse "Team already exists"
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
 // @Failure 500 {object} dto.ErrorResponse
+// @Security ApiKeyAuth
+// @Security BearerAuth
 // @Router /team/add [post]
 func (t *TeamHandler) AddTeam(c echo.Context) error {
 	var req dto.AddTeamRequest
 	if err := c.Bind(&req); err != nil {
-		t.logger.Errorf("failed to bind request: %v", err)
+		tracing.Logger(c.Request().Context(), t.logger).Errorf("failed to bind request: %v", err)
 		return response.Error(c, http.StatusBadRequest, "INVALID_INPUT", "invalid request body")
 	}
 
@@ -47,7 +56,7 @@ func (t *TeamHandler) AddTeam(c echo.Context) error {
 	ctx := c.Request().Context()
 	result, err := t.teamService.AddTeam(ctx, domainTeam)
 	if err != nil {
-		t.logger.Errorf("failed to add team: %v", err)
+		tracing.Logger(c.Request().Context(), t.logger).Errorf("failed to add team: %v", err)
 		return response.HandleError(c, err)
 	}
 
@@ -66,19 +75,23 @@ func (t *TeamHandler) AddTeam(c echo.Context) error {
 // @Success 200 {object} dto.TeamResponse
 // @Failure 400 {object} dto.ErrorResponse
 // @Failure 404 {object} dto.ErrorResponse "Team not found"
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
 // @Failure 500 {object} dto.ErrorResponse
+// @Security ApiKeyAuth
+// @Security BearerAuth
 // @Router /team/get [get]
 func (t *TeamHandler) GetTeam(c echo.Context) error {
 	teamName := c.QueryParam("team_name")
 	if teamName == "" {
-		t.logger.Errorf("failed to get team: team_name is required")
+		tracing.Logger(c.Request().Context(), t.logger).Errorf("failed to get team: team_name is required")
 		return response.Error(c, http.StatusBadRequest, "INVALID_INPUT", "team_name is required")
 	}
 
 	ctx := c.Request().Context()
 	team, err := t.teamService.GetTeam(ctx, teamName)
 	if err != nil {
-		t.logger.Errorf("failed to get team: %v", err)
+		tracing.Logger(c.Request().Context(), t.logger).Errorf("failed to get team: %v", err)
 		return response.HandleError(c, err)
 	}
 
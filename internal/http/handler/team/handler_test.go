@@ -51,7 +51,7 @@ func TestAddTeam_Success(t *testing.T) {
 	e := echo.New()
 	mockService := new(MockTeamService)
 	logger := embedlog.NewLogger(false, false)
-	handler := NewHandler(mockService, logger)
+	handler := NewHandler(mockService, nil, nil, logger)
 
 	reqBody := dto.AddTeamRequest{
 		TeamName: "backend",
@@ -84,7 +84,7 @@ func TestAddTeam_TeamExists(t *testing.T) {
 	e := echo.New()
 	mockService := new(MockTeamService)
 	logger := embedlog.NewLogger(false, false)
-	handler := NewHandler(mockService, logger)
+	handler := NewHandler(mockService, nil, nil, logger)
 
 	reqBody := dto.AddTeamRequest{TeamName: "backend"}
 	body, _ := json.Marshal(reqBody)
@@ -106,7 +106,7 @@ func TestGetTeam_Success(t *testing.T) {
 	e := echo.New()
 	mockService := new(MockTeamService)
 	logger := embedlog.NewLogger(false, false)
-	handler := NewHandler(mockService, logger)
+	handler := NewHandler(mockService, nil, nil, logger)
 
 	req := httptest.NewRequest(http.MethodGet, "/team/get?team_name=backend", nil)
 	rec := httptest.NewRecorder()
@@ -130,7 +130,7 @@ func TestGetTeam_NotFound(t *testing.T) {
 	e := echo.New()
 	mockService := new(MockTeamService)
 	logger := embedlog.NewLogger(false, false)
-	handler := NewHandler(mockService, logger)
+	handler := NewHandler(mockService, nil, nil, logger)
 
 	req := httptest.NewRequest(http.MethodGet, "/team/get?team_name=unknown", nil)
 	rec := httptest.NewRecorder()
@@ -148,7 +148,7 @@ func TestGetTeam_MissingParam(t *testing.T) {
 	e := echo.New()
 	mockService := new(MockTeamService)
 	logger := embedlog.NewLogger(false, false)
-	handler := NewHandler(mockService, logger)
+	handler := NewHandler(mockService, nil, nil, logger)
 
 	req := httptest.NewRequest(http.MethodGet, "/team/get", nil)
 	rec := httptest.NewRecorder()
@@ -0,0 +1,130 @@
+package team
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/ssokov/pr-reviewer-service/internal/http/mapper"
+	"github.com/ssokov/pr-reviewer-service/internal/http/response"
+	"github.com/ssokov/pr-reviewer-service/internal/model/dto"
+	"github.com/ssokov/pr-reviewer-service/internal/tracing"
+)
+
+// SetParent godoc
+// @Summary Move a team in the hierarchy
+// @Description Put a team under a parent team, or make it a root team when parent_team_name is empty.
+// @Description Moves that would create a cycle or nest teams too deeply are rejected.
+// @Tags team
+// @Accept json
+// @Produce json
+// @Param request body dto.SetParentRequest true "Team and its new parent"
+// @Success 200 {object} dto.TeamResponse
+// @Failure 400 {object} dto.ErrorResponse "Cycle or hierarchy too deep"
+// @Failure 404 {object} dto.ErrorResponse "Team not found"
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /team/setParent [post]
+func (t *TeamHandler) SetParent(c echo.Context) error {
+	var req dto.SetParentRequest
+	if err := c.Bind(&req); err != nil {
+		tracing.Logger(c.Request().Context(), t.logger).Errorf("failed to bind request: %v", err)
+		return response.Error(c, http.StatusBadRequest, "INVALID_INPUT", "invalid request body")
+	}
+
+	ctx := c.Request().Context()
+	team, err := t.hierarchyService.SetParent(ctx, req.TeamName, req.ParentTeamName)
+	if err != nil {
+		tracing.Logger(c.Request().Context(), t.logger).Errorf("failed to set parent team: %v", err)
+		return response.HandleError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, mapper.TeamToResponse(team))
+}
+
+// GetTree godoc
+// @Summary Get the team hierarchy
+// @Description Get the subtree rooted at team_name, or all root teams with their subtrees when it is omitted
+// @Tags team
+// @Produce json
+// @Param team_name query string false "Root team"
+// @Success 200 {object} dto.TeamTreeResponse
+// @Failure 404 {object} dto.ErrorResponse "Team not found"
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /team/tree [get]
+func (t *TeamHandler) GetTree(c echo.Context) error {
+	ctx := c.Request().Context()
+	nodes, err := t.hierarchyService.GetTree(ctx, c.QueryParam("team_name"))
+	if err != nil {
+		tracing.Logger(c.Request().Context(), t.logger).Errorf("failed to get team tree: %v", err)
+		return response.HandleError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, mapper.TeamTreeToResponse(nodes))
+}
+
+// SetSettings godoc
+// @Summary Set team settings
+// @Description Replace the settings a team sets itself. Omitted settings are inherited from the nearest parent team
+// @Description that sets them, then from the organization.
+// @Tags team
+// @Accept json
+// @Produce json
+// @Param request body dto.SetTeamSettingsRequest true "Team settings"
+// @Success 200 {object} dto.TeamSettingsResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse "Team not found"
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /team/setSettings [post]
+func (t *TeamHandler) SetSettings(c echo.Context) error {
+	var req dto.SetTeamSettingsRequest
+	if err := c.Bind(&req); err != nil {
+		tracing.Logger(c.Request().Context(), t.logger).Errorf("failed to bind request: %v", err)
+		return response.Error(c, http.StatusBadRequest, "INVALID_INPUT", "invalid request body")
+	}
+
+	ctx := c.Request().Context()
+	settings, err := t.hierarchyService.SetSettings(ctx, req.TeamName, mapper.TeamSettingsToDomain(req.Settings))
+	if err != nil {
+		tracing.Logger(c.Request().Context(), t.logger).Errorf("failed to set team settings: %v", err)
+		return response.HandleError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, mapper.TeamSettingsToResponse(settings))
+}
+
+// GetSettings godoc
+// @Summary Get team settings
+// @Description Get the settings a team sets itself and the effective settings, with the teams they are inherited from
+// @Tags team
+// @Produce json
+// @Param team_name query string true "Team name"
+// @Success 200 {object} dto.TeamSettingsResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse "Team not found"
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /team/getSettings [get]
+func (t *TeamHandler) GetSettings(c echo.Context) error {
+	ctx := c.Request().Context()
+	settings, err := t.hierarchyService.GetSettings(ctx, c.QueryParam("team_name"))
+	if err != nil {
+		tracing.Logger(c.Request().Context(), t.logger).Errorf("failed to get team settings: %v", err)
+		return response.HandleError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, mapper.TeamSettingsToResponse(settings))
+}
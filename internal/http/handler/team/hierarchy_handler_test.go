@@ -0,0 +1,120 @@
+package team
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/ssokov/pr-reviewer-service/internal/apperror"
+	"github.com/ssokov/pr-reviewer-service/internal/model/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/vmkteam/embedlog"
+)
+
+type MockHierarchyService struct {
+	mock.Mock
+}
+
+func (m *MockHierarchyService) SetParent(ctx context.Context, teamName, parentName string) (*domain.Team, error) {
+	args := m.Called(ctx, teamName, parentName)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Team), args.Error(1)
+}
+
+func (m *MockHierarchyService) GetTree(ctx context.Context, rootName string) ([]domain.TeamNode, error) {
+	args := m.Called(ctx, rootName)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.TeamNode), args.Error(1)
+}
+
+func (m *MockHierarchyService) SetSettings(ctx context.Context, teamName string, settings domain.TeamSettings) (*domain.EffectiveTeamSettings, error) {
+	args := m.Called(ctx, teamName, settings)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.EffectiveTeamSettings), args.Error(1)
+}
+
+func (m *MockHierarchyService) GetSettings(ctx context.Context, teamName string) (*domain.EffectiveTeamSettings, error) {
+	args := m.Called(ctx, teamName)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.EffectiveTeamSettings), args.Error(1)
+}
+
+func TestSetParent_Cycle(t *testing.T) {
+	e := echo.New()
+	mockService := new(MockHierarchyService)
+	handler := NewHandler(nil, nil, mockService, embedlog.NewLogger(false, false))
+
+	body := `{"team_name":"engineering","parent_team_name":"payments"}`
+	req := httptest.NewRequest(http.MethodPost, "/team/setParent", bytes.NewReader([]byte(body)))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	mockService.On("SetParent", mock.Anything, "engineering", "payments").
+		Return(nil, apperror.NewInvalidInputError("moving 'engineering' under 'payments' would create a cycle"))
+
+	require.NoError(t, handler.SetParent(c))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestGetTree(t *testing.T) {
+	e := echo.New()
+	mockService := new(MockHierarchyService)
+	handler := NewHandler(nil, nil, mockService, embedlog.NewLogger(false, false))
+
+	req := httptest.NewRequest(http.MethodGet, "/team/tree?team_name=engineering", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	mockService.On("GetTree", mock.Anything, "engineering").Return([]domain.TeamNode{{
+		TeamName:     "engineering",
+		MembersCount: 1,
+		Children:     []domain.TeamNode{{TeamName: "backend", MembersCount: 2, ActiveMembers: 2, Children: []domain.TeamNode{}}},
+	}}, nil)
+
+	require.NoError(t, handler.GetTree(c))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"teams":[{"team_name":"engineering","members_count":1,"active_members":0,"children":[
+		{"team_name":"backend","members_count":2,"active_members":2,"children":[]}]}]}`, rec.Body.String())
+}
+
+func TestSetSettings(t *testing.T) {
+	e := echo.New()
+	mockService := new(MockHierarchyService)
+	handler := NewHandler(nil, nil, mockService, embedlog.NewLogger(false, false))
+
+	body := `{"team_name":"payments","settings":{"reviewer_count":3}}`
+	req := httptest.NewRequest(http.MethodPost, "/team/setSettings", bytes.NewReader([]byte(body)))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	three := 3
+	mockService.On("SetSettings", mock.Anything, "payments", domain.TeamSettings{ReviewerCount: &three}).Return(&domain.EffectiveTeamSettings{
+		TeamName:          "payments",
+		Own:               domain.TeamSettings{ReviewerCount: &three},
+		ReviewerCount:     3,
+		ReviewerCountFrom: "payments",
+		Fallback:          []domain.FallbackStep{{PoolName: "oncall"}},
+		FallbackFrom:      "engineering",
+		ReviewSLAHours:    8,
+	}, nil)
+
+	require.NoError(t, handler.SetSettings(c))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"team_name":"payments","own":{"reviewer_count":3},"effective":{
+		"reviewer_count":3,"reviewer_count_from":"payments","require_senior":false,"max_open_reviews":0,"review_sla_hours":8,"fallback":[{"pool_name":"oncall"}],"fallback_from":"engineering"}}`, rec.Body.String())
+}
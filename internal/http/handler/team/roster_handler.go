@@ -0,0 +1,108 @@
+package team
+
+import (
+	"bytes"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/ssokov/pr-reviewer-service/internal/dryrun"
+	"github.com/ssokov/pr-reviewer-service/internal/http/mapper"
+	"github.com/ssokov/pr-reviewer-service/internal/http/response"
+	"github.com/ssokov/pr-reviewer-service/internal/roster"
+	"github.com/ssokov/pr-reviewer-service/internal/tracing"
+)
+
+// ImportRoster godoc
+// @Summary Import a team roster
+// @Description Create teams and users, move users between teams and (de)activate them from a CSV, JSON or YAML roster.
+// @Description The body is a list of team_name, user_id, username and optional is_active. The response is the diff
+// @Description against the current state; it is applied in one transaction only when every line is valid.
+// @Tags team
+// @Accept text/csv
+// @Accept json
+// @Accept application/yaml
+// @Produce json
+// @Param format query string false "csv, json or yaml; taken from Content-Type when omitted"
+// @Param dry_run query bool false "Only return the diff"
+// @Success 200 {object} dto.ImportRosterResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 422 {object} dto.ImportRosterResponse "Invalid lines, nothing applied"
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /team/import [post]
+func (t *TeamHandler) ImportRoster(c echo.Context) error {
+	format, ok := roster.FormatFromContentType(c.Request().Header.Get(echo.HeaderContentType))
+	if raw := c.QueryParam("format"); raw != "" {
+		var err error
+		if format, err = roster.ParseFormat(raw); err != nil {
+			return response.Error(c, http.StatusBadRequest, "INVALID_INPUT", err.Error())
+		}
+	} else if !ok {
+		return response.Error(c, http.StatusBadRequest, "INVALID_INPUT", "set format or a text/csv, application/json or application/yaml Content-Type")
+	}
+
+	parsed, err := roster.Parse(c.Request().Body, format)
+	if err != nil {
+		return response.Error(c, http.StatusBadRequest, "INVALID_INPUT", err.Error())
+	}
+
+	ctx := c.Request().Context()
+	result, err := t.rosterService.ImportRoster(ctx, parsed)
+	if err != nil {
+		tracing.Logger(c.Request().Context(), t.logger).Errorf("failed to import roster: %v", err)
+		return response.HandleError(c, err)
+	}
+
+	status := http.StatusOK
+	if len(result.Errors) > 0 {
+		status = http.StatusUnprocessableEntity
+	}
+
+	resp := mapper.RosterImportToResponse(result)
+	resp.DryRun = dryrun.Enabled(ctx)
+	return c.JSON(status, resp)
+}
+
+// ExportRoster godoc
+// @Summary Export the team roster
+// @Description Export all teams and their members in the format accepted by /team/import
+// @Tags team
+// @Produce json
+// @Produce text/csv
+// @Produce application/yaml
+// @Param format query string false "csv, json (default) or yaml"
+// @Success 200 {string} string "Roster"
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /team/export [get]
+func (t *TeamHandler) ExportRoster(c echo.Context) error {
+	format := roster.FormatJSON
+	if raw := c.QueryParam("format"); raw != "" {
+		var err error
+		if format, err = roster.ParseFormat(raw); err != nil {
+			return response.Error(c, http.StatusBadRequest, "INVALID_INPUT", err.Error())
+		}
+	}
+
+	entries, err := t.rosterService.ExportRoster(c.Request().Context())
+	if err != nil {
+		tracing.Logger(c.Request().Context(), t.logger).Errorf("failed to export roster: %v", err)
+		return response.HandleError(c, err)
+	}
+
+	var buf bytes.Buffer
+	if err := roster.Write(&buf, format, entries); err != nil {
+		tracing.Logger(c.Request().Context(), t.logger).Errorf("failed to write roster: %v", err)
+		return response.Error(c, http.StatusInternalServerError, "INTERNAL_ERROR", "internal server error")
+	}
+
+	c.Response().Header().Set(echo.HeaderContentDisposition, `attachment; filename="roster.`+string(format)+`"`)
+	return c.Blob(http.StatusOK, format.ContentType(), buf.Bytes())
+}
@@ -0,0 +1,129 @@
+package team
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/ssokov/pr-reviewer-service/internal/model/domain"
+	"github.com/ssokov/pr-reviewer-service/internal/model/dto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/vmkteam/embedlog"
+)
+
+type MockRosterService struct {
+	mock.Mock
+}
+
+func (m *MockRosterService) ImportRoster(ctx context.Context, roster *domain.Roster) (*domain.RosterImportResult, error) {
+	args := m.Called(ctx, roster)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.RosterImportResult), args.Error(1)
+}
+
+func (m *MockRosterService) ExportRoster(ctx context.Context) ([]domain.RosterEntry, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.RosterEntry), args.Error(1)
+}
+
+func TestImportRoster_CSV(t *testing.T) {
+	e := echo.New()
+	mockService := new(MockRosterService)
+	handler := NewHandler(nil, mockService, nil, embedlog.NewLogger(false, false))
+
+	body := "team_name,user_id,username\nbackend,u1,Alice\n"
+	req := httptest.NewRequest(http.MethodPost, "/team/import", strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, "text/csv")
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	mockService.On("ImportRoster", mock.Anything, &domain.Roster{Entries: []domain.RosterEntry{
+		{Line: 2, TeamName: "backend", UserID: "u1", Username: "Alice", IsActive: true},
+	}}).Return(&domain.RosterImportResult{
+		Applied: true,
+		Changes: []domain.RosterChange{{Line: 2, Action: domain.RosterActionCreateTeam, TeamName: "backend"}},
+	}, nil)
+
+	err := handler.ImportRoster(c)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var resp dto.ImportRosterResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	assert.True(t, resp.Applied)
+	assert.Equal(t, map[string]int{"create_team": 1}, resp.Summary)
+	mockService.AssertExpectations(t)
+}
+
+func TestImportRoster_LineErrors(t *testing.T) {
+	e := echo.New()
+	mockService := new(MockRosterService)
+	handler := NewHandler(nil, mockService, nil, embedlog.NewLogger(false, false))
+
+	req := httptest.NewRequest(http.MethodPost, "/team/import?format=yaml", strings.NewReader("- {team_name: backend, user_id: u1}\n"))
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	mockService.On("ImportRoster", mock.Anything, mock.Anything).Return(&domain.RosterImportResult{
+		Changes: []domain.RosterChange{},
+		Errors:  []domain.RosterLineError{{Line: 1, Message: "username is required"}},
+	}, nil)
+
+	err := handler.ImportRoster(c)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+
+	var resp dto.ImportRosterResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	assert.False(t, resp.Applied)
+	assert.Equal(t, []dto.RosterLineErrorResponse{{Line: 1, Message: "username is required"}}, resp.Errors)
+}
+
+func TestImportRoster_UnknownFormat(t *testing.T) {
+	e := echo.New()
+	handler := NewHandler(nil, new(MockRosterService), nil, embedlog.NewLogger(false, false))
+
+	req := httptest.NewRequest(http.MethodPost, "/team/import", strings.NewReader("team_name\tuser_id\n"))
+	req.Header.Set(echo.HeaderContentType, "text/plain")
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	err := handler.ImportRoster(c)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestExportRoster_CSV(t *testing.T) {
+	e := echo.New()
+	mockService := new(MockRosterService)
+	handler := NewHandler(nil, mockService, nil, embedlog.NewLogger(false, false))
+
+	req := httptest.NewRequest(http.MethodGet, "/team/export?format=csv", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	mockService.On("ExportRoster", mock.Anything).Return([]domain.RosterEntry{
+		{TeamName: "backend", UserID: "u1", Username: "Alice", IsActive: true},
+	}, nil)
+
+	err := handler.ExportRoster(c)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "text/csv; charset=utf-8", rec.Header().Get(echo.HeaderContentType))
+	assert.Equal(t, "team_name,user_id,username,is_active\nbackend,u1,Alice,true\n", rec.Body.String())
+}
@@ -1,9 +1,19 @@
 package team
 
-import "github.com/labstack/echo/v4"
+import (
+	"github.com/labstack/echo/v4"
+	"github.com/ssokov/pr-reviewer-service/internal/http/middleware"
+	"github.com/ssokov/pr-reviewer-service/internal/model/domain"
+)
 
-func RegisterRoutes(e *echo.Echo, handler *TeamHandler) {
-	e.POST("/team/add", handler.AddTeam)
-	e.GET("/team/get", handler.GetTeam)
-	e.POST("/team/deactivate", handler.DeactivateTeam)
+func RegisterRoutes(g *echo.Group, handler *TeamHandler) {
+	g.POST("/team/add", handler.AddTeam, middleware.RequireScope(domain.ScopeTeamWrite))
+	g.GET("/team/get", handler.GetTeam, middleware.RequireScope(domain.ScopeTeamRead))
+	g.POST("/team/import", handler.ImportRoster, middleware.RequireScope(domain.ScopeTeamAdmin), middleware.DryRun())
+	g.GET("/team/export", handler.ExportRoster, middleware.RequireScope(domain.ScopeTeamRead))
+	g.POST("/team/deactivate", handler.DeactivateTeam, middleware.RequireScope(domain.ScopeTeamAdmin), middleware.DryRun())
+	g.POST("/team/setParent", handler.SetParent, middleware.RequireScope(domain.ScopeTeamAdmin))
+	g.GET("/team/tree", handler.GetTree, middleware.RequireScope(domain.ScopeTeamRead))
+	g.POST("/team/setSettings", handler.SetSettings, middleware.RequireScope(domain.ScopeTeamAdmin))
+	g.GET("/team/getSettings", handler.GetSettings, middleware.RequireScope(domain.ScopeTeamRead))
 }
@@ -4,22 +4,28 @@ import (
 	"net/http"
 
 	"github.com/labstack/echo/v4"
+	"github.com/ssokov/pr-reviewer-service/internal/dryrun"
 	"github.com/ssokov/pr-reviewer-service/internal/http/mapper"
 	"github.com/ssokov/pr-reviewer-service/internal/http/response"
+	"github.com/ssokov/pr-reviewer-service/internal/model/domain"
 	"github.com/ssokov/pr-reviewer-service/internal/model/dto"
 	"github.com/ssokov/pr-reviewer-service/internal/service"
+	"github.com/ssokov/pr-reviewer-service/internal/tracing"
+	"github.com/ssokov/pr-reviewer-service/internal/workhours"
 	"github.com/vmkteam/embedlog"
 )
 
 type UserHandler struct {
-	userService service.UserService
-	logger      embedlog.Logger
+	userService  service.UserService
+	skillService service.SkillService
+	logger       embedlog.Logger
 }
 
-func NewHandler(service service.UserService, logger embedlog.Logger) *UserHandler {
+func NewHandler(service service.UserService, skillService service.SkillService, logger embedlog.Logger) *UserHandler {
 	return &UserHandler{
-		userService: service,
-		logger:      logger,
+		userService:  service,
+		skillService: skillService,
+		logger:       logger,
 	}
 }
 
@@ -30,27 +36,161 @@ func NewHandler(service service.UserService, logger embedlog.Logger) *UserHandle
 // @Accept json
 // @Produce json
 // @Param request body dto.SetIsActiveRequest true "User active status"
+// @Param dry_run query bool false "Run in a rolled-back transaction and return what would change"
 // @Success 200 {object} dto.SetIsActiveResponse
 // @Failure 400 {object} dto.ErrorResponse
 // @Failure 404 {object} dto.ErrorResponse "User not found"
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
 // @Failure 500 {object} dto.ErrorResponse
+// @Security ApiKeyAuth
+// @Security BearerAuth
 // @Router /user/setIsActive [post]
 func (h *UserHandler) SetIsActive(c echo.Context) error {
 	var req dto.SetIsActiveRequest
 	if err := c.Bind(&req); err != nil {
-		h.logger.Errorf("failed to bind request: %v", err)
+		tracing.Logger(c.Request().Context(), h.logger).Errorf("failed to bind request: %v", err)
 		return response.Error(c, http.StatusBadRequest, "INVALID_INPUT", "invalid request body")
 	}
 
 	ctx := c.Request().Context()
 	user, err := h.userService.SetIsActive(ctx, req.UserID, req.IsActive)
 	if err != nil {
-		h.logger.Errorf("failed to set user active status: %v", err)
+		tracing.Logger(c.Request().Context(), h.logger).Errorf("failed to set user active status: %v", err)
 		return response.HandleError(c, err)
 	}
 
 	return c.JSON(http.StatusOK, dto.SetIsActiveResponse{
-		User: mapper.UserToResponse(user),
+		User:   mapper.UserToResponse(user),
+		DryRun: dryrun.Enabled(ctx),
+	})
+}
+
+// SetTeamRole godoc
+// @Summary Set user team role
+// @Description Set the role of a user within their team: lead, senior, member or trainee. Teams with the
+// @Description require_senior policy get a lead or senior on every PR; trainees only shadow reviews
+// @Tags user
+// @Accept json
+// @Produce json
+// @Param request body dto.SetTeamRoleRequest true "User team role"
+// @Param dry_run query bool false "Run in a rolled-back transaction and return what would change"
+// @Success 200 {object} dto.SetTeamRoleResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse "User not found"
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /users/setTeamRole [post]
+func (h *UserHandler) SetTeamRole(c echo.Context) error {
+	var req dto.SetTeamRoleRequest
+	if err := c.Bind(&req); err != nil {
+		tracing.Logger(c.Request().Context(), h.logger).Errorf("failed to bind request: %v", err)
+		return response.Error(c, http.StatusBadRequest, "INVALID_INPUT", "invalid request body")
+	}
+
+	ctx := c.Request().Context()
+	user, err := h.userService.SetTeamRole(ctx, req.UserID, domain.TeamRole(req.TeamRole))
+	if err != nil {
+		tracing.Logger(c.Request().Context(), h.logger).Errorf("failed to set user team role: %v", err)
+		return response.HandleError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, dto.SetTeamRoleResponse{
+		User:   mapper.UserToResponse(user),
+		DryRun: dryrun.Enabled(ctx),
+	})
+}
+
+// SetWorkingHours godoc
+// @Summary Set user working hours
+// @Description Set the timezone and working hours of a user, Monday to Friday. Reviewers within their working hours,
+// @Description or about to start them, are preferred, and review SLAs count only working hours. Users can set their
+// @Description own working hours; an empty timezone clears them
+// @Tags user
+// @Accept json
+// @Produce json
+// @Param request body dto.SetWorkingHoursRequest true "User working hours"
+// @Param dry_run query bool false "Run in a rolled-back transaction and return what would change"
+// @Success 200 {object} dto.SetWorkingHoursResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse "User not found"
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /users/setWorkingHours [post]
+func (h *UserHandler) SetWorkingHours(c echo.Context) error {
+	var req dto.SetWorkingHoursRequest
+	if err := c.Bind(&req); err != nil {
+		tracing.Logger(c.Request().Context(), h.logger).Errorf("failed to bind request: %v", err)
+		return response.Error(c, http.StatusBadRequest, "INVALID_INPUT", "invalid request body")
+	}
+
+	workStart, workEnd := domain.DefaultWorkStart, domain.DefaultWorkEnd
+	var err error
+	if req.WorkStart != "" {
+		if workStart, err = workhours.ParseClock(req.WorkStart); err != nil {
+			return response.Error(c, http.StatusBadRequest, "INVALID_INPUT", "work_start: "+err.Error())
+		}
+	}
+	if req.WorkEnd != "" {
+		if workEnd, err = workhours.ParseClock(req.WorkEnd); err != nil {
+			return response.Error(c, http.StatusBadRequest, "INVALID_INPUT", "work_end: "+err.Error())
+		}
+	}
+
+	ctx := c.Request().Context()
+	user, err := h.userService.SetWorkingHours(ctx, req.UserID, req.Timezone, workStart, workEnd)
+	if err != nil {
+		tracing.Logger(c.Request().Context(), h.logger).Errorf("failed to set user working hours: %v", err)
+		return response.HandleError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, dto.SetWorkingHoursResponse{
+		User:   mapper.UserToResponse(user),
+		DryRun: dryrun.Enabled(ctx),
+	})
+}
+
+// SetMaxOpenReviews godoc
+// @Summary Set user review capacity
+// @Description Set how many open PRs a user reviews at once. Users at capacity are skipped by assignment and
+// @Description reassignment. Null falls back to the team default, 0 means no limit
+// @Tags user
+// @Accept json
+// @Produce json
+// @Param request body dto.SetMaxOpenReviewsRequest true "User review capacity"
+// @Param dry_run query bool false "Run in a rolled-back transaction and return what would change"
+// @Success 200 {object} dto.SetMaxOpenReviewsResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse "User not found"
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /users/setMaxOpenReviews [post]
+func (h *UserHandler) SetMaxOpenReviews(c echo.Context) error {
+	var req dto.SetMaxOpenReviewsRequest
+	if err := c.Bind(&req); err != nil {
+		tracing.Logger(c.Request().Context(), h.logger).Errorf("failed to bind request: %v", err)
+		return response.Error(c, http.StatusBadRequest, "INVALID_INPUT", "invalid request body")
+	}
+
+	ctx := c.Request().Context()
+	user, err := h.userService.SetMaxOpenReviews(ctx, req.UserID, req.MaxOpenReviews)
+	if err != nil {
+		tracing.Logger(c.Request().Context(), h.logger).Errorf("failed to set user max open reviews: %v", err)
+		return response.HandleError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, dto.SetMaxOpenReviewsResponse{
+		User:   mapper.UserToResponse(user),
+		DryRun: dryrun.Enabled(ctx),
 	})
 }
 
@@ -64,19 +204,23 @@ func (h *UserHandler) SetIsActive(c echo.Context) error {
 // @Success 200 {object} dto.GetReviewResponse
 // @Failure 400 {object} dto.ErrorResponse
 // @Failure 404 {object} dto.ErrorResponse "User not found"
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
 // @Failure 500 {object} dto.ErrorResponse
+// @Security ApiKeyAuth
+// @Security BearerAuth
 // @Router /user/getReview [get]
 func (h *UserHandler) GetReview(c echo.Context) error {
 	userID := c.QueryParam("user_id")
 	if userID == "" {
-		h.logger.Errorf("failed to get review: user_id is required")
+		tracing.Logger(c.Request().Context(), h.logger).Errorf("failed to get review: user_id is required")
 		return response.Error(c, http.StatusBadRequest, "INVALID_INPUT", "user_id is required")
 	}
 
 	ctx := c.Request().Context()
 	pullRequests, err := h.userService.GetReview(ctx, userID)
 	if err != nil {
-		h.logger.Errorf("failed to get review: %v", err)
+		tracing.Logger(c.Request().Context(), h.logger).Errorf("failed to get review: %v", err)
 		return response.HandleError(c, err)
 	}
 
@@ -87,3 +231,63 @@ func (h *UserHandler) GetReview(c echo.Context) error {
 		PullRequests: prShorts,
 	})
 }
+
+// SetSkills godoc
+// @Summary Set user skills
+// @Description Replace the skills of a user, e.g. go, sql, frontend, security. PR labels naming a skill ask for a
+// @Description reviewer with it. Members can set their own skills, team leads those of their team
+// @Tags user
+// @Accept json
+// @Produce json
+// @Param request body dto.SetSkillsRequest true "User skills"
+// @Success 200 {object} dto.UserSkillsResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse "User not found"
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /users/setSkills [post]
+func (h *UserHandler) SetSkills(c echo.Context) error {
+	var req dto.SetSkillsRequest
+	if err := c.Bind(&req); err != nil {
+		tracing.Logger(c.Request().Context(), h.logger).Errorf("failed to bind request: %v", err)
+		return response.Error(c, http.StatusBadRequest, "INVALID_INPUT", "invalid request body")
+	}
+
+	ctx := c.Request().Context()
+	skills, err := h.skillService.SetUserSkills(ctx, req.UserID, req.Skills)
+	if err != nil {
+		tracing.Logger(c.Request().Context(), h.logger).Errorf("failed to set user skills: %v", err)
+		return response.HandleError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, mapper.UserSkillsToResponse(req.UserID, skills))
+}
+
+// GetSkills godoc
+// @Summary Get user skills
+// @Tags user
+// @Produce json
+// @Param user_id query string true "User ID"
+// @Success 200 {object} dto.UserSkillsResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse "User not found"
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /users/getSkills [get]
+func (h *UserHandler) GetSkills(c echo.Context) error {
+	userID := c.QueryParam("user_id")
+
+	ctx := c.Request().Context()
+	skills, err := h.skillService.GetUserSkills(ctx, userID)
+	if err != nil {
+		return response.HandleError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, mapper.UserSkillsToResponse(userID, skills))
+}
@@ -29,6 +29,30 @@ func (m *MockUserService) SetIsActive(ctx context.Context, userID string, isActi
 	return args.Get(0).(*domain.User), args.Error(1)
 }
 
+func (m *MockUserService) SetTeamRole(ctx context.Context, userID string, role domain.TeamRole) (*domain.User, error) {
+	args := m.Called(ctx, userID, role)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.User), args.Error(1)
+}
+
+func (m *MockUserService) SetWorkingHours(ctx context.Context, userID, timezone string, workStart, workEnd int) (*domain.User, error) {
+	args := m.Called(ctx, userID, timezone, workStart, workEnd)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.User), args.Error(1)
+}
+
+func (m *MockUserService) SetMaxOpenReviews(ctx context.Context, userID string, limit *int) (*domain.User, error) {
+	args := m.Called(ctx, userID, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.User), args.Error(1)
+}
+
 func (m *MockUserService) GetReview(ctx context.Context, userID string) ([]domain.PullRequest, error) {
 	args := m.Called(ctx, userID)
 	if args.Get(0) == nil {
@@ -37,11 +61,39 @@ func (m *MockUserService) GetReview(ctx context.Context, userID string) ([]domai
 	return args.Get(0).([]domain.PullRequest), args.Error(1)
 }
 
+type MockSkillService struct {
+	mock.Mock
+}
+
+func (m *MockSkillService) SetUserSkills(ctx context.Context, userID string, skills []string) ([]string, error) {
+	args := m.Called(ctx, userID, skills)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *MockSkillService) GetUserSkills(ctx context.Context, userID string) ([]string, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *MockSkillService) SetPRLabels(ctx context.Context, prID string, labels []string) (*domain.PullRequest, error) {
+	args := m.Called(ctx, prID, labels)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.PullRequest), args.Error(1)
+}
+
 func TestSetIsActive_Success(t *testing.T) {
 	e := echo.New()
 	mockService := new(MockUserService)
 	logger := embedlog.NewLogger(false, false)
-	handler := NewHandler(mockService, logger)
+	handler := NewHandler(mockService, nil, logger)
 
 	reqBody := dto.SetIsActiveRequest{UserID: "u1", IsActive: false}
 	body, _ := json.Marshal(reqBody)
@@ -65,7 +117,7 @@ func TestSetIsActive_UserNotFound(t *testing.T) {
 	e := echo.New()
 	mockService := new(MockUserService)
 	logger := embedlog.NewLogger(false, false)
-	handler := NewHandler(mockService, logger)
+	handler := NewHandler(mockService, nil, logger)
 
 	reqBody := dto.SetIsActiveRequest{UserID: "unknown", IsActive: false}
 	body, _ := json.Marshal(reqBody)
@@ -83,11 +135,82 @@ func TestSetIsActive_UserNotFound(t *testing.T) {
 	assert.Equal(t, http.StatusNotFound, rec.Code)
 }
 
+func TestSetTeamRole(t *testing.T) {
+	e := echo.New()
+	mockService := new(MockUserService)
+	handler := NewHandler(mockService, nil, embedlog.NewLogger(false, false))
+
+	body := `{"user_id":"u1","team_role":"senior"}`
+	req := httptest.NewRequest(http.MethodPost, "/users/setTeamRole", bytes.NewReader([]byte(body)))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	mockService.On("SetTeamRole", mock.Anything, "u1", domain.TeamRoleSenior).
+		Return(&domain.User{UserID: "u1", Username: "Alice", TeamName: "backend", TeamRole: domain.TeamRoleSenior, IsActive: true}, nil)
+
+	assert.NoError(t, handler.SetTeamRole(c))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"user":{"user_id":"u1","username":"Alice","team_name":"backend","is_active":true,"team_role":"senior"}}`, rec.Body.String())
+}
+
+func TestSetWorkingHours(t *testing.T) {
+	e := echo.New()
+	mockService := new(MockUserService)
+	handler := NewHandler(mockService, nil, embedlog.NewLogger(false, false))
+
+	body := `{"user_id":"u1","timezone":"Asia/Almaty","work_start":"10:00"}`
+	req := httptest.NewRequest(http.MethodPost, "/users/setWorkingHours", bytes.NewReader([]byte(body)))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+
+	mockService.On("SetWorkingHours", mock.Anything, "u1", "Asia/Almaty", 600, domain.DefaultWorkEnd).
+		Return(&domain.User{UserID: "u1", Username: "Alice", TeamRole: domain.TeamRoleMember, IsActive: true, Timezone: "Asia/Almaty", WorkStart: 600, WorkEnd: domain.DefaultWorkEnd}, nil)
+
+	assert.NoError(t, handler.SetWorkingHours(e.NewContext(req, rec)))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"user":{"user_id":"u1","username":"Alice","team_name":"","is_active":true,"team_role":"member",
+		"timezone":"Asia/Almaty","work_start":"10:00","work_end":"18:00"}}`, rec.Body.String())
+}
+
+func TestSetWorkingHours_InvalidTime(t *testing.T) {
+	e := echo.New()
+	handler := NewHandler(new(MockUserService), nil, embedlog.NewLogger(false, false))
+
+	body := `{"user_id":"u1","timezone":"Asia/Almaty","work_end":"7pm"}`
+	req := httptest.NewRequest(http.MethodPost, "/users/setWorkingHours", bytes.NewReader([]byte(body)))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+
+	assert.NoError(t, handler.SetWorkingHours(e.NewContext(req, rec)))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestSetMaxOpenReviews(t *testing.T) {
+	e := echo.New()
+	mockService := new(MockUserService)
+	handler := NewHandler(mockService, nil, embedlog.NewLogger(false, false))
+	limit := 2
+
+	body := `{"user_id":"u1","max_open_reviews":2}`
+	req := httptest.NewRequest(http.MethodPost, "/users/setMaxOpenReviews", bytes.NewReader([]byte(body)))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+
+	mockService.On("SetMaxOpenReviews", mock.Anything, "u1", &limit).
+		Return(&domain.User{UserID: "u1", Username: "Alice", TeamRole: domain.TeamRoleMember, IsActive: true, MaxOpenReviews: &limit}, nil)
+
+	assert.NoError(t, handler.SetMaxOpenReviews(e.NewContext(req, rec)))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"user":{"user_id":"u1","username":"Alice","team_name":"","is_active":true,"team_role":"member",
+		"max_open_reviews":2}}`, rec.Body.String())
+}
+
 func TestGetReview_Success(t *testing.T) {
 	e := echo.New()
 	mockService := new(MockUserService)
 	logger := embedlog.NewLogger(false, false)
-	handler := NewHandler(mockService, logger)
+	handler := NewHandler(mockService, nil, logger)
 
 	req := httptest.NewRequest(http.MethodGet, "/users/getReview?user_id=u1", nil)
 	rec := httptest.NewRecorder()
@@ -109,7 +232,7 @@ func TestGetReview_MissingParam(t *testing.T) {
 	e := echo.New()
 	mockService := new(MockUserService)
 	logger := embedlog.NewLogger(false, false)
-	handler := NewHandler(mockService, logger)
+	handler := NewHandler(mockService, nil, logger)
 
 	req := httptest.NewRequest(http.MethodGet, "/users/getReview", nil)
 	rec := httptest.NewRecorder()
@@ -120,3 +243,43 @@ func TestGetReview_MissingParam(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, http.StatusBadRequest, rec.Code)
 }
+
+func TestSetSkills(t *testing.T) {
+	e := echo.New()
+	mockSkillService := new(MockSkillService)
+	handler := NewHandler(new(MockUserService), mockSkillService, embedlog.NewLogger(false, false))
+
+	body, _ := json.Marshal(dto.SetSkillsRequest{UserID: "u1", Skills: []string{"Go", "sql"}})
+	req := httptest.NewRequest(http.MethodPost, "/users/setSkills", bytes.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	mockSkillService.On("SetUserSkills", mock.Anything, "u1", []string{"Go", "sql"}).Return([]string{"go", "sql"}, nil)
+
+	err := handler.SetSkills(c)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var resp dto.UserSkillsResponse
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	assert.Equal(t, []string{"go", "sql"}, resp.Skills)
+}
+
+func TestGetSkills_UserNotFound(t *testing.T) {
+	e := echo.New()
+	mockSkillService := new(MockSkillService)
+	handler := NewHandler(new(MockUserService), mockSkillService, embedlog.NewLogger(false, false))
+
+	req := httptest.NewRequest(http.MethodGet, "/users/getSkills?user_id=ghost", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	mockSkillService.On("GetUserSkills", mock.Anything, "ghost").Return(nil, apperror.NewUserNotFoundError("ghost"))
+
+	err := handler.GetSkills(c)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
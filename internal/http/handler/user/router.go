@@ -1,11 +1,20 @@
 package user
 
-import "github.com/labstack/echo/v4"
+import (
+	"github.com/labstack/echo/v4"
+	"github.com/ssokov/pr-reviewer-service/internal/http/middleware"
+	"github.com/ssokov/pr-reviewer-service/internal/model/domain"
+)
 
-func RegisterRoutes(e *echo.Echo, h *UserHandler) {
-	userGroup := e.Group("/users")
+func RegisterRoutes(g *echo.Group, h *UserHandler) {
+	userGroup := g.Group("/users")
 	{
-		userGroup.POST("/setIsActive", h.SetIsActive)
-		userGroup.GET("/getReview", h.GetReview)
+		userGroup.POST("/setIsActive", h.SetIsActive, middleware.RequireScope(domain.ScopeUserWrite), middleware.DryRun())
+		userGroup.POST("/setTeamRole", h.SetTeamRole, middleware.RequireScope(domain.ScopeUserWrite), middleware.DryRun())
+		userGroup.POST("/setWorkingHours", h.SetWorkingHours, middleware.RequireScope(domain.ScopeUserWrite), middleware.DryRun())
+		userGroup.POST("/setMaxOpenReviews", h.SetMaxOpenReviews, middleware.RequireScope(domain.ScopeUserWrite), middleware.DryRun())
+		userGroup.GET("/getReview", h.GetReview, middleware.RequireScope(domain.ScopeUserRead))
+		userGroup.POST("/setSkills", h.SetSkills, middleware.RequireScope(domain.ScopeUserWrite))
+		userGroup.GET("/getSkills", h.GetSkills, middleware.RequireScope(domain.ScopeUserRead))
 	}
 }
@@ -0,0 +1,23 @@
+package mapper
+
+import (
+	"github.com/ssokov/pr-reviewer-service/internal/model/domain"
+	"github.com/ssokov/pr-reviewer-service/internal/model/dto"
+)
+
+func AuditEntriesToResponse(entries []domain.AuditEntry) []dto.AuditEntryResponse {
+	result := make([]dto.AuditEntryResponse, 0, len(entries))
+	for _, e := range entries {
+		result = append(result, dto.AuditEntryResponse{
+			ID:        e.ID,
+			Actor:     e.Actor,
+			Action:    string(e.Action),
+			Target:    e.Target,
+			Before:    e.Before,
+			After:     e.After,
+			RequestID: e.RequestID,
+			CreatedAt: e.CreatedAt,
+		})
+	}
+	return result
+}
@@ -0,0 +1,40 @@
+package mapper
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/ssokov/pr-reviewer-service/internal/model/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuditEntriesToResponse(t *testing.T) {
+	now := time.Now()
+	entries := []domain.AuditEntry{
+		{
+			ID:        1,
+			Actor:     "user:u1",
+			Action:    domain.AuditActionPRMerge,
+			Target:    "pr-1",
+			After:     json.RawMessage(`{"Status":"MERGED"}`),
+			RequestID: "req-1",
+			CreatedAt: now,
+		},
+	}
+
+	result := AuditEntriesToResponse(entries)
+
+	assert.Len(t, result, 1)
+	assert.Equal(t, "pr.merge", result[0].Action)
+	assert.Equal(t, "user:u1", result[0].Actor)
+	assert.Nil(t, result[0].Before)
+	assert.Equal(t, now, result[0].CreatedAt)
+}
+
+func TestAuditEntriesToResponse_Empty(t *testing.T) {
+	result := AuditEntriesToResponse(nil)
+
+	assert.NotNil(t, result)
+	assert.Len(t, result, 0)
+}
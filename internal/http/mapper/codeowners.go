@@ -0,0 +1,15 @@
+package mapper
+
+import (
+	"github.com/ssokov/pr-reviewer-service/internal/model/domain"
+	"github.com/ssokov/pr-reviewer-service/internal/model/dto"
+)
+
+func CodeOwnersToResponse(file *domain.CodeOwners) dto.CodeOwnersResponse {
+	return dto.CodeOwnersResponse{
+		Repository: file.Repository,
+		Mode:       string(file.Mode),
+		Content:    file.Content,
+		UpdatedAt:  file.UpdatedAt,
+	}
+}
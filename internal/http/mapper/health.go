@@ -0,0 +1,32 @@
+package mapper
+
+import (
+	"github.com/ssokov/pr-reviewer-service/internal/buildinfo"
+	"github.com/ssokov/pr-reviewer-service/internal/model/domain"
+	"github.com/ssokov/pr-reviewer-service/internal/model/dto"
+)
+
+func ReadinessToResponse(r domain.Readiness) dto.ReadinessResponse {
+	deps := make([]dto.DependencyStatus, 0, len(r.Dependencies))
+	for _, d := range r.Dependencies {
+		deps = append(deps, dto.DependencyStatus{
+			Name:       d.Name,
+			Status:     string(d.Status),
+			Error:      d.Error,
+			DurationMs: d.Duration.Milliseconds(),
+		})
+	}
+	return dto.ReadinessResponse{
+		Status:       string(r.Status),
+		Dependencies: deps,
+	}
+}
+
+func BuildInfoToResponse(info buildinfo.Info) dto.VersionResponse {
+	return dto.VersionResponse{
+		Version:   info.Version,
+		Commit:    info.Commit,
+		BuildDate: info.BuildDate,
+		GoVersion: info.GoVersion,
+	}
+}
@@ -0,0 +1,63 @@
+package mapper
+
+import (
+	"github.com/ssokov/pr-reviewer-service/internal/model/domain"
+	"github.com/ssokov/pr-reviewer-service/internal/model/dto"
+)
+
+func SetPoolRequestToDomain(req dto.SetPoolRequest) *domain.ReviewerPool {
+	members := make([]domain.User, len(req.Members))
+	for i, userID := range req.Members {
+		members[i] = domain.User{UserID: userID}
+	}
+	return &domain.ReviewerPool{
+		Name:    req.PoolName,
+		Members: members,
+	}
+}
+
+func PoolToResponse(pool *domain.ReviewerPool) dto.PoolResponse {
+	members := make([]dto.PoolMember, len(pool.Members))
+	for i, m := range pool.Members {
+		members[i] = dto.PoolMember{
+			UserID:   m.UserID,
+			Username: m.Username,
+			TeamName: m.TeamName,
+			IsActive: m.IsActive,
+		}
+	}
+	return dto.PoolResponse{
+		PoolName: pool.Name,
+		Members:  members,
+	}
+}
+
+func PoolsToResponse(pools []domain.ReviewerPool) dto.ListPoolsResponse {
+	result := make([]dto.PoolResponse, len(pools))
+	for i := range pools {
+		result[i] = PoolToResponse(&pools[i])
+	}
+	return dto.ListPoolsResponse{Pools: result}
+}
+
+func FallbackChainToDomain(req dto.FallbackChain) *domain.FallbackChain {
+	steps := make([]domain.FallbackStep, len(req.Steps))
+	for i, step := range req.Steps {
+		steps[i] = domain.FallbackStep{TeamName: step.TeamName, PoolName: step.PoolName}
+	}
+	return &domain.FallbackChain{
+		TeamName: req.TeamName,
+		Steps:    steps,
+	}
+}
+
+func FallbackChainToResponse(chain *domain.FallbackChain) dto.FallbackChain {
+	steps := make([]dto.FallbackStep, len(chain.Steps))
+	for i, step := range chain.Steps {
+		steps[i] = dto.FallbackStep{TeamName: step.TeamName, PoolName: step.PoolName}
+	}
+	return dto.FallbackChain{
+		TeamName: chain.TeamName,
+		Steps:    steps,
+	}
+}
@@ -1,6 +1,9 @@
 package mapper
 
 import (
+	"math"
+	"time"
+
 	"github.com/ssokov/pr-reviewer-service/internal/model/domain"
 	"github.com/ssokov/pr-reviewer-service/internal/model/dto"
 )
@@ -11,6 +14,9 @@ func CreatePRRequestToDomain(req dto.CreatePRRequest) *domain.PullRequest {
 		PullRequestName: req.PullRequestName,
 		AuthorID:        req.AuthorID,
 		Status:          domain.PRStatusOpen,
+		Labels:          req.Labels,
+		Repository:      req.Repository,
+		ChangedFiles:    req.ChangedFiles,
 	}
 }
 
@@ -21,7 +27,55 @@ func PullRequestToResponse(pr *domain.PullRequest) dto.PullRequestResponse {
 		AuthorID:          pr.AuthorID,
 		Status:            string(pr.Status),
 		AssignedReviewers: pr.AssignedReviewers,
+		ShadowReviewers:   pr.ShadowReviewers,
+		PinnedReviewers:   pr.PinnedReviewers,
 		CreatedAt:         &pr.CreatedAt,
 		MergedAt:          pr.MergedAt,
+		Labels:            pr.Labels,
+		Selections:        selectionsToResponse(pr.Selections),
+		PendingReviewers:  pr.PendingReviewers,
+	}
+}
+
+func selectionsToResponse(selections []domain.ReviewerSelection) []dto.ReviewerSelectionResponse {
+	if len(selections) == 0 {
+		return nil
+	}
+	result := make([]dto.ReviewerSelectionResponse, len(selections))
+	for i, s := range selections {
+		result[i] = dto.ReviewerSelectionResponse{
+			UserID:  s.UserID,
+			Rule:    string(s.Rule),
+			Pattern: s.Pattern,
+			Line:    s.Line,
+			Skill:   s.Skill,
+			Team:    s.Team,
+			Pool:    s.Pool,
+		}
 	}
+	return result
+}
+
+func ReviewSLAToResponse(sla *domain.ReviewSLA) dto.ReviewSLAResponse {
+	resp := dto.ReviewSLAResponse{
+		PullRequestID: sla.PullRequestID,
+		Status:        string(sla.Status),
+		SLAHours:      hours(sla.SLA),
+		Reviewers:     make([]dto.ReviewerSLAResponse, 0, len(sla.Reviewers)),
+	}
+	for _, r := range sla.Reviewers {
+		resp.Reviewers = append(resp.Reviewers, dto.ReviewerSLAResponse{
+			UserID:       r.UserID,
+			Timezone:     r.Timezone,
+			ElapsedHours: hours(r.Elapsed),
+			DueAt:        r.DueAt,
+			Overdue:      r.Overdue,
+		})
+	}
+	return resp
+}
+
+// hours rounds d to hundredths of an hour.
+func hours(d time.Duration) float64 {
+	return math.Round(d.Hours()*100) / 100
 }
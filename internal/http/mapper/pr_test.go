@@ -45,6 +45,25 @@ func TestPullRequestToResponse(t *testing.T) {
 	assert.Len(t, result.AssignedReviewers, 2)
 	assert.NotNil(t, result.CreatedAt)
 	assert.Nil(t, result.MergedAt)
+	assert.Nil(t, result.Selections)
+}
+
+func TestPullRequestToResponse_Selections(t *testing.T) {
+	pr := &domain.PullRequest{
+		PullRequestID:     "pr-1",
+		AssignedReviewers: []string{"u2", "u3"},
+		Selections: []domain.ReviewerSelection{
+			{UserID: "u2", Rule: domain.SelectionRuleCodeOwners, Pattern: "/internal/", Line: 4},
+			{UserID: "u3", Rule: domain.SelectionRuleTeam},
+		},
+	}
+
+	result := PullRequestToResponse(pr)
+
+	assert.Equal(t, []dto.ReviewerSelectionResponse{
+		{UserID: "u2", Rule: "codeowners", Pattern: "/internal/", Line: 4},
+		{UserID: "u3", Rule: "team"},
+	}, result.Selections)
 }
 
 func TestPullRequestsToShort(t *testing.T) {
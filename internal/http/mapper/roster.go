@@ -0,0 +1,32 @@
+package mapper
+
+import (
+	"github.com/ssokov/pr-reviewer-service/internal/model/domain"
+	"github.com/ssokov/pr-reviewer-service/internal/model/dto"
+)
+
+func RosterImportToResponse(result *domain.RosterImportResult) dto.ImportRosterResponse {
+	resp := dto.ImportRosterResponse{
+		Applied: result.Applied,
+		Summary: make(map[string]int),
+		Changes: make([]dto.RosterChangeResponse, len(result.Changes)),
+		Errors:  make([]dto.RosterLineErrorResponse, len(result.Errors)),
+	}
+
+	for i, c := range result.Changes {
+		resp.Summary[string(c.Action)]++
+		resp.Changes[i] = dto.RosterChangeResponse{
+			Line:     c.Line,
+			Action:   string(c.Action),
+			TeamName: c.TeamName,
+			UserID:   c.UserID,
+			From:     c.From,
+			To:       c.To,
+		}
+	}
+	for i, e := range result.Errors {
+		resp.Errors[i] = dto.RosterLineErrorResponse{Line: e.Line, Message: e.Message}
+	}
+
+	return resp
+}
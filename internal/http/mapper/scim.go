@@ -0,0 +1,84 @@
+package mapper
+
+import (
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/ssokov/pr-reviewer-service/internal/model/domain"
+	"github.com/ssokov/pr-reviewer-service/internal/model/dto"
+)
+
+// SCIM ids are the user_id of a user and the name of a team, so resources can be addressed with the same
+// identifiers as the rest of the API. baseURL is the /scim/v2 prefix used to build meta.location.
+
+func UserToSCIM(user *domain.User, baseURL string) dto.SCIMUser {
+	active := user.IsActive
+	resp := dto.SCIMUser{
+		Schemas:     []string{dto.SCIMSchemaUser},
+		ID:          user.UserID,
+		UserName:    user.UserID,
+		Name:        &dto.SCIMName{Formatted: user.Username},
+		DisplayName: user.Username,
+		Active:      &active,
+		Meta: &dto.SCIMMeta{
+			ResourceType: "User",
+			Created:      scimTime(user.CreatedAt),
+			Location:     baseURL + "/Users/" + url.PathEscape(user.UserID),
+		},
+	}
+	if user.TeamName != "" {
+		resp.Groups = []dto.SCIMMember{{Value: user.TeamName, Display: user.TeamName, Ref: baseURL + "/Groups/" + url.PathEscape(user.TeamName)}}
+	}
+	return resp
+}
+
+// SCIMToUser takes the display name from displayName, then name.formatted, then given and family name.
+// A user without the active attribute is active.
+func SCIMToUser(user dto.SCIMUser) *domain.User {
+	result := &domain.User{
+		UserID:   user.UserName,
+		Username: user.DisplayName,
+		IsActive: user.Active == nil || *user.Active,
+	}
+	if result.Username == "" && user.Name != nil {
+		result.Username = user.Name.Formatted
+		if result.Username == "" {
+			result.Username = strings.TrimSpace(user.Name.GivenName + " " + user.Name.FamilyName)
+		}
+	}
+	return result
+}
+
+func TeamToSCIM(team *domain.Team, baseURL string) dto.SCIMGroup {
+	members := make([]dto.SCIMMember, 0, len(team.Members))
+	for _, m := range team.Members {
+		members = append(members, dto.SCIMMember{Value: m.UserID, Display: m.Username, Ref: baseURL + "/Users/" + url.PathEscape(m.UserID)})
+	}
+	return dto.SCIMGroup{
+		Schemas:     []string{dto.SCIMSchemaGroup},
+		ID:          team.TeamName,
+		DisplayName: team.TeamName,
+		Members:     members,
+		Meta: &dto.SCIMMeta{
+			ResourceType: "Group",
+			Created:      scimTime(team.CreatedAt),
+			Location:     baseURL + "/Groups/" + url.PathEscape(team.TeamName),
+		},
+	}
+}
+
+func SCIMMemberIDs(members []dto.SCIMMember) []string {
+	ids := make([]string, 0, len(members))
+	for _, m := range members {
+		ids = append(ids, m.Value)
+	}
+	return ids
+}
+
+func scimTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
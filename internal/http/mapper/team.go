@@ -11,6 +11,7 @@ func AddTeamRequestToDomain(req dto.AddTeamRequest) *domain.Team {
 		members[i] = domain.User{
 			UserID:   m.UserID,
 			Username: m.Username,
+			TeamRole: domain.TeamRole(m.TeamRole),
 			IsActive: m.IsActive,
 		}
 	}
@@ -26,11 +27,97 @@ func TeamToResponse(team *domain.Team) dto.TeamResponse {
 		members[i] = dto.TeamMember{
 			UserID:   m.UserID,
 			Username: m.Username,
+			TeamRole: string(m.TeamRole),
 			IsActive: m.IsActive,
 		}
 	}
 	return dto.TeamResponse{
-		TeamName: team.TeamName,
-		Members:  members,
+		TeamName:       team.TeamName,
+		ParentTeamName: team.ParentName,
+		Members:        members,
+	}
+}
+
+func TeamTreeToResponse(nodes []domain.TeamNode) dto.TeamTreeResponse {
+	return dto.TeamTreeResponse{Teams: teamNodesToResponse(nodes)}
+}
+
+func teamNodesToResponse(nodes []domain.TeamNode) []dto.TeamTreeNode {
+	result := make([]dto.TeamTreeNode, len(nodes))
+	for i, node := range nodes {
+		result[i] = dto.TeamTreeNode{
+			TeamName:      node.TeamName,
+			MembersCount:  node.MembersCount,
+			ActiveMembers: node.ActiveMembers,
+			Children:      teamNodesToResponse(node.Children),
+		}
+	}
+	return result
+}
+
+func TeamSettingsToDomain(settings dto.TeamSettings) domain.TeamSettings {
+	return domain.TeamSettings{
+		ReviewerCount:  settings.ReviewerCount,
+		RequireSenior:  settings.RequireSenior,
+		MaxOpenReviews: settings.MaxOpenReviews,
+		ReviewSLAHours: settings.ReviewSLAHours,
+	}
+}
+
+func TeamSettingsToResponse(settings *domain.EffectiveTeamSettings) dto.TeamSettingsResponse {
+	fallback := make([]dto.FallbackStep, len(settings.Fallback))
+	for i, step := range settings.Fallback {
+		fallback[i] = dto.FallbackStep{TeamName: step.TeamName, PoolName: step.PoolName}
+	}
+	return dto.TeamSettingsResponse{
+		TeamName: settings.TeamName,
+		Own: dto.TeamSettings{
+			ReviewerCount:  settings.Own.ReviewerCount,
+			RequireSenior:  settings.Own.RequireSenior,
+			MaxOpenReviews: settings.Own.MaxOpenReviews,
+			ReviewSLAHours: settings.Own.ReviewSLAHours,
+		},
+		Effective: dto.EffectiveTeamSettings{
+			ReviewerCount:      settings.ReviewerCount,
+			ReviewerCountFrom:  settings.ReviewerCountFrom,
+			RequireSenior:      settings.RequireSenior,
+			RequireSeniorFrom:  settings.RequireSeniorFrom,
+			MaxOpenReviews:     settings.MaxOpenReviews,
+			MaxOpenReviewsFrom: settings.MaxOpenReviewsFrom,
+			ReviewSLAHours:     settings.ReviewSLAHours,
+			ReviewSLAHoursFrom: settings.ReviewSLAHoursFrom,
+			Fallback:           fallback,
+			FallbackFrom:       settings.FallbackFrom,
+		},
+	}
+}
+
+func DeactivationToResponse(users []domain.User, openPRs []domain.PullRequest) dto.DeactivateTeamResponse {
+	userPRCount := make(map[string]int)
+	for _, pr := range openPRs {
+		for _, reviewerID := range pr.AssignedReviewers {
+			userPRCount[reviewerID]++
+		}
+	}
+
+	prIDs := make([]string, len(openPRs))
+	for i, pr := range openPRs {
+		prIDs[i] = pr.PullRequestID
+	}
+
+	usersInfo := make([]dto.DeactivatedUserInfo, len(users))
+	for i, user := range users {
+		usersInfo[i] = dto.DeactivatedUserInfo{
+			UserID:       user.UserID,
+			Username:     user.Username,
+			OpenPRsCount: userPRCount[user.UserID],
+		}
+	}
+
+	return dto.DeactivateTeamResponse{
+		DeactivatedUsers: len(users),
+		ReassignedPRs:    len(openPRs),
+		Users:            usersInfo,
+		PullRequests:     prIDs,
 	}
 }
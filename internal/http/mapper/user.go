@@ -3,15 +3,25 @@ package mapper
 import (
 	"github.com/ssokov/pr-reviewer-service/internal/model/domain"
 	"github.com/ssokov/pr-reviewer-service/internal/model/dto"
+	"github.com/ssokov/pr-reviewer-service/internal/workhours"
 )
 
 func UserToResponse(user *domain.User) dto.UserResponse {
-	return dto.UserResponse{
+	resp := dto.UserResponse{
 		UserID:   user.UserID,
 		Username: user.Username,
 		TeamName: user.TeamName,
 		IsActive: user.IsActive,
+		TeamRole: string(user.TeamRole),
+
+		MaxOpenReviews: user.MaxOpenReviews,
+	}
+	if user.HasWorkingHours() {
+		resp.Timezone = user.Timezone
+		resp.WorkStart = workhours.FormatClock(user.WorkStart)
+		resp.WorkEnd = workhours.FormatClock(user.WorkEnd)
 	}
+	return resp
 }
 
 func PullRequestsToShort(prs []domain.PullRequest) []dto.PullRequestShort {
@@ -26,3 +36,10 @@ func PullRequestsToShort(prs []domain.PullRequest) []dto.PullRequestShort {
 	}
 	return result
 }
+
+func UserSkillsToResponse(userID string, skills []string) dto.UserSkillsResponse {
+	if skills == nil {
+		skills = []string{}
+	}
+	return dto.UserSkillsResponse{UserID: userID, Skills: skills}
+}
@@ -0,0 +1,106 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	"github.com/ssokov/pr-reviewer-service/internal/auth"
+	"github.com/ssokov/pr-reviewer-service/internal/http/response"
+	"github.com/ssokov/pr-reviewer-service/internal/model/domain"
+	"github.com/ssokov/pr-reviewer-service/internal/service"
+	"github.com/vmkteam/embedlog"
+)
+
+const HeaderAPIKey = "X-API-Key"
+
+const bearerPrefix = "Bearer "
+
+// TokenValidator validates a bearer token and returns the user it was issued to.
+type TokenValidator interface {
+	Validate(ctx context.Context, token string) (*auth.Principal, error)
+}
+
+// Authenticate resolves the principal from a bearer token or the X-API-Key header and stores it in the request context.
+// An API key may also be sent as the bearer token.
+// tokenValidator may be nil when bearer tokens are not accepted.
+// Mutating requests are logged together with the principal that performed them.
+func Authenticate(apiKeyService service.APIKeyService, tokenValidator TokenValidator, logger embedlog.Logger) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			ctx := c.Request().Context()
+
+			var principal *auth.Principal
+			authHeader := c.Request().Header.Get(echo.HeaderAuthorization)
+			rawKey := c.Request().Header.Get(HeaderAPIKey)
+			if bearer := strings.TrimPrefix(authHeader, bearerPrefix); rawKey == "" && bearer != authHeader && service.IsAPIKey(bearer) {
+				// Identity providers send the SCIM credential as a bearer token.
+				rawKey, authHeader = bearer, ""
+			}
+
+			switch {
+			case strings.HasPrefix(authHeader, bearerPrefix) && tokenValidator != nil:
+				p, err := tokenValidator.Validate(ctx, strings.TrimPrefix(authHeader, bearerPrefix))
+				if err != nil {
+					logger.Print(ctx, "bearer token rejected", "error", err)
+					return response.Error(c, http.StatusUnauthorized, "UNAUTHORIZED", "invalid bearer token")
+				}
+				principal = p
+			case rawKey != "":
+				key, err := apiKeyService.Authenticate(ctx, rawKey)
+				if err != nil {
+					return response.HandleError(c, err)
+				}
+				principal = &auth.Principal{
+					Subject:        "apikey:" + key.Prefix,
+					APIKeyID:       key.ID,
+					Scopes:         key.Scopes,
+					OrganizationID: key.OrganizationID,
+				}
+			default:
+				return response.Error(c, http.StatusUnauthorized, "UNAUTHORIZED", "missing credentials")
+			}
+
+			c.SetRequest(c.Request().WithContext(auth.WithPrincipal(ctx, principal)))
+
+			err := next(c)
+			if c.Request().Method != http.MethodGet {
+				logger.Print(ctx, "mutation performed",
+					"actor", principal.Subject,
+					"method", c.Request().Method,
+					"path", c.Path(),
+					"status", c.Response().Status,
+				)
+			}
+			return err
+		}
+	}
+}
+
+// NoAuth marks every request as anonymous with full access. Used when auth is disabled in the config.
+func NoAuth() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			ctx := auth.WithPrincipal(c.Request().Context(), auth.Anonymous)
+			c.SetRequest(c.Request().WithContext(ctx))
+			return next(c)
+		}
+	}
+}
+
+// RequireScope rejects requests whose principal lacks the given scope.
+func RequireScope(scope domain.Scope) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			principal := auth.FromContext(c.Request().Context())
+			if principal == nil {
+				return response.Error(c, http.StatusUnauthorized, "UNAUTHORIZED", "authentication required")
+			}
+			if !principal.HasScope(scope) {
+				return response.Error(c, http.StatusForbidden, "FORBIDDEN", "missing scope "+string(scope))
+			}
+			return next(c)
+		}
+	}
+}
@@ -0,0 +1,219 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/ssokov/pr-reviewer-service/internal/apperror"
+	"github.com/ssokov/pr-reviewer-service/internal/auth"
+	"github.com/ssokov/pr-reviewer-service/internal/model/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/vmkteam/embedlog"
+)
+
+type MockAPIKeyService struct {
+	mock.Mock
+}
+
+func (m *MockAPIKeyService) CreateKey(ctx context.Context, name string, scopes []domain.Scope) (*domain.APIKey, string, error) {
+	args := m.Called(ctx, name, scopes)
+	if args.Get(0) == nil {
+		return nil, "", args.Error(2)
+	}
+	return args.Get(0).(*domain.APIKey), args.String(1), args.Error(2)
+}
+
+func (m *MockAPIKeyService) Authenticate(ctx context.Context, rawKey string) (*domain.APIKey, error) {
+	args := m.Called(ctx, rawKey)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.APIKey), args.Error(1)
+}
+
+func (m *MockAPIKeyService) RevokeKey(ctx context.Context, prefix string) (*domain.APIKey, error) {
+	args := m.Called(ctx, prefix)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.APIKey), args.Error(1)
+}
+
+func (m *MockAPIKeyService) ListKeys(ctx context.Context) ([]domain.APIKey, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.APIKey), args.Error(1)
+}
+
+func newAuthServer(svc *MockAPIKeyService) *echo.Echo {
+	e := echo.New()
+	e.Use(Authenticate(svc, nil, embedlog.NewLogger(false, false)))
+	e.POST("/team/deactivate", func(c echo.Context) error {
+		return c.String(http.StatusOK, auth.Actor(c.Request().Context()))
+	}, RequireScope(domain.ScopeTeamAdmin))
+	return e
+}
+
+func TestAuthenticate_MissingCredentials(t *testing.T) {
+	e := newAuthServer(new(MockAPIKeyService))
+
+	req := httptest.NewRequest(http.MethodPost, "/team/deactivate", nil)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+}
+
+func TestAuthenticate_InvalidAPIKey(t *testing.T) {
+	svc := new(MockAPIKeyService)
+	svc.On("Authenticate", mock.Anything, "prr_bad_key").Return(nil, apperror.NewUnauthorizedError("invalid api key"))
+	e := newAuthServer(svc)
+
+	req := httptest.NewRequest(http.MethodPost, "/team/deactivate", nil)
+	req.Header.Set(HeaderAPIKey, "prr_bad_key")
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+}
+
+func TestAuthenticate_MissingScope(t *testing.T) {
+	svc := new(MockAPIKeyService)
+	svc.On("Authenticate", mock.Anything, "prr_ab12cd34_secret").Return(&domain.APIKey{
+		ID:     1,
+		Prefix: "ab12cd34",
+		Scopes: []domain.Scope{domain.ScopePRWrite},
+	}, nil)
+	e := newAuthServer(svc)
+
+	req := httptest.NewRequest(http.MethodPost, "/team/deactivate", nil)
+	req.Header.Set(HeaderAPIKey, "prr_ab12cd34_secret")
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+}
+
+func TestAuthenticate_APIKey(t *testing.T) {
+	svc := new(MockAPIKeyService)
+	svc.On("Authenticate", mock.Anything, "prr_ab12cd34_secret").Return(&domain.APIKey{
+		ID:     1,
+		Prefix: "ab12cd34",
+		Scopes: []domain.Scope{domain.ScopeTeamAdmin},
+	}, nil)
+	e := newAuthServer(svc)
+
+	req := httptest.NewRequest(http.MethodPost, "/team/deactivate", nil)
+	req.Header.Set(HeaderAPIKey, "prr_ab12cd34_secret")
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "apikey:ab12cd34", rec.Body.String())
+}
+
+func TestRequireScope_NoPrincipal(t *testing.T) {
+	e := echo.New()
+	e.GET("/stats", func(c echo.Context) error {
+		return c.NoContent(http.StatusOK)
+	}, RequireScope(domain.ScopeStatsRead))
+
+	req := httptest.NewRequest(http.MethodGet, "/stats", nil)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+}
+
+func TestNoAuth(t *testing.T) {
+	e := echo.New()
+	e.Use(NoAuth())
+	e.GET("/stats", func(c echo.Context) error {
+		return c.NoContent(http.StatusOK)
+	}, RequireScope(domain.ScopeStatsRead))
+
+	req := httptest.NewRequest(http.MethodGet, "/stats", nil)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
+
+type stubTokenValidator struct {
+	principal *auth.Principal
+	err       error
+}
+
+func (v stubTokenValidator) Validate(_ context.Context, _ string) (*auth.Principal, error) {
+	return v.principal, v.err
+}
+
+func TestAuthenticate_BearerToken(t *testing.T) {
+	validator := stubTokenValidator{principal: &auth.Principal{
+		Subject: "user:u1",
+		UserID:  "u1",
+		Roles:   []domain.Role{domain.RoleAdmin},
+		Scopes:  auth.ScopesForRoles([]domain.Role{domain.RoleAdmin}),
+	}}
+
+	e := echo.New()
+	e.Use(Authenticate(new(MockAPIKeyService), validator, embedlog.NewLogger(false, false)))
+	e.POST("/team/deactivate", func(c echo.Context) error {
+		return c.String(http.StatusOK, auth.Actor(c.Request().Context()))
+	}, RequireScope(domain.ScopeTeamAdmin))
+
+	req := httptest.NewRequest(http.MethodPost, "/team/deactivate", nil)
+	req.Header.Set(echo.HeaderAuthorization, "Bearer token")
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "user:u1", rec.Body.String())
+}
+
+func TestAuthenticate_InvalidBearerToken(t *testing.T) {
+	validator := stubTokenValidator{err: errors.New("token expired")}
+
+	e := echo.New()
+	e.Use(Authenticate(new(MockAPIKeyService), validator, embedlog.NewLogger(false, false)))
+	e.GET("/stats", func(c echo.Context) error {
+		return c.NoContent(http.StatusOK)
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/stats", nil)
+	req.Header.Set(echo.HeaderAuthorization, "Bearer token")
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+}
+
+func TestAuthenticate_APIKeyAsBearerToken(t *testing.T) {
+	svc := new(MockAPIKeyService)
+	svc.On("Authenticate", mock.Anything, "prr_ab12cd34_secret").Return(&domain.APIKey{
+		ID:     1,
+		Prefix: "ab12cd34",
+		Scopes: []domain.Scope{domain.ScopeTeamAdmin},
+	}, nil)
+
+	e := echo.New()
+	e.Use(Authenticate(svc, stubTokenValidator{err: errors.New("not a jwt")}, embedlog.NewLogger(false, false)))
+	e.POST("/team/deactivate", func(c echo.Context) error {
+		return c.String(http.StatusOK, auth.Actor(c.Request().Context()))
+	}, RequireScope(domain.ScopeTeamAdmin))
+
+	req := httptest.NewRequest(http.MethodPost, "/team/deactivate", nil)
+	req.Header.Set(echo.HeaderAuthorization, "Bearer prr_ab12cd34_secret")
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "apikey:ab12cd34", rec.Body.String())
+}
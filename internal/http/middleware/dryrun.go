@@ -0,0 +1,38 @@
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"github.com/ssokov/pr-reviewer-service/internal/dryrun"
+	"github.com/ssokov/pr-reviewer-service/internal/http/response"
+)
+
+const (
+	QueryDryRun  = "dry_run"
+	HeaderDryRun = "X-Dry-Run"
+)
+
+// DryRun marks the request context as a dry run when the dry_run query parameter is true.
+// The response then carries the X-Dry-Run header, so previews are easy to tell apart in logs and proxies.
+func DryRun() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			raw := c.QueryParam(QueryDryRun)
+			if raw == "" {
+				return next(c)
+			}
+
+			enabled, err := strconv.ParseBool(raw)
+			if err != nil {
+				return response.Error(c, http.StatusBadRequest, "INVALID_INPUT", "dry_run must be a boolean")
+			}
+			if enabled {
+				c.SetRequest(c.Request().WithContext(dryrun.With(c.Request().Context())))
+				c.Response().Header().Set(HeaderDryRun, "true")
+			}
+			return next(c)
+		}
+	}
+}
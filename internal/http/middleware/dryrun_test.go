@@ -0,0 +1,45 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/ssokov/pr-reviewer-service/internal/dryrun"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDryRun(t *testing.T) {
+	tests := []struct {
+		name       string
+		query      string
+		wantStatus int
+		wantDryRun bool
+	}{
+		{name: "absent", query: "", wantStatus: http.StatusOK},
+		{name: "enabled", query: "?dry_run=true", wantStatus: http.StatusOK, wantDryRun: true},
+		{name: "disabled", query: "?dry_run=0", wantStatus: http.StatusOK},
+		{name: "invalid", query: "?dry_run=maybe", wantStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodPost, "/team/deactivate"+tt.query, nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			var gotDryRun bool
+			err := DryRun()(func(c echo.Context) error {
+				gotDryRun = dryrun.Enabled(c.Request().Context())
+				return c.NoContent(http.StatusOK)
+			})(c)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			assert.Equal(t, tt.wantDryRun, gotDryRun)
+			assert.Equal(t, tt.wantDryRun, rec.Header().Get(HeaderDryRun) == "true")
+		})
+	}
+}
@@ -0,0 +1,29 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/ssokov/pr-reviewer-service/internal/metrics"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMetrics(t *testing.T) {
+	e := echo.New()
+	e.Use(Metrics())
+	e.GET("/team/get", func(c echo.Context) error {
+		return echo.NewHTTPError(http.StatusNotFound, "team not found")
+	})
+
+	before := testutil.CollectAndCount(metrics.HTTPRequestDuration)
+
+	req := httptest.NewRequest(http.MethodGet, "/team/get?team_name=ghost", nil)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	assert.Equal(t, before+1, testutil.CollectAndCount(metrics.HTTPRequestDuration))
+}
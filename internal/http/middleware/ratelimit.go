@@ -0,0 +1,229 @@
+package middleware
+
+import (
+	"fmt"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/ssokov/pr-reviewer-service/internal/apperror"
+	"github.com/ssokov/pr-reviewer-service/internal/auth"
+	"github.com/ssokov/pr-reviewer-service/internal/http/response"
+	"github.com/ssokov/pr-reviewer-service/internal/metrics"
+	"github.com/ssokov/pr-reviewer-service/internal/tenant"
+	"golang.org/x/time/rate"
+)
+
+const defaultIdleTTL = 10 * time.Minute
+
+// Limit is a token bucket per client and the maximum request body size. Zero values disable the limit.
+type Limit struct {
+	RequestsPerSecond float64
+	Burst             int
+	MaxBodyBytes      int64
+}
+
+type RateLimitConfig struct {
+	Default Limit
+	// Routes override the default for a route path, e.g. "/pullRequest/create". Zero fields inherit the default.
+	// Overridden routes get their own bucket per client; all other routes share one.
+	Routes map[string]Limit
+	// PerIP is a bucket per client IP checked before authentication, so requests with missing or invalid credentials
+	// are throttled too. Only its rate fields are used.
+	PerIP Limit
+	// IdleTTL is how long the bucket of an inactive client is kept.
+	IdleTTL time.Duration
+}
+
+type bucket struct {
+	limiter  *rate.Limiter
+	lastSeen time.Time
+}
+
+// RateLimiter keeps token buckets per client and route.
+type RateLimiter struct {
+	cfg RateLimitConfig
+	now func() time.Time
+
+	mu        sync.Mutex
+	buckets   map[string]*bucket
+	lastSweep time.Time
+}
+
+func NewRateLimiter(cfg RateLimitConfig) *RateLimiter {
+	if cfg.IdleTTL <= 0 {
+		cfg.IdleTTL = defaultIdleTTL
+	}
+	for path, limit := range cfg.Routes {
+		cfg.Routes[path] = inheritLimit(limit, cfg.Default)
+	}
+
+	exportLimit(metrics.RouteDefault, cfg.Default)
+	for path, limit := range cfg.Routes {
+		exportLimit(path, limit)
+	}
+	exportLimit(metrics.RoutePerIP, cfg.PerIP)
+
+	return &RateLimiter{
+		cfg:     cfg,
+		now:     time.Now,
+		buckets: make(map[string]*bucket),
+	}
+}
+
+// IPExtractor returns how c.RealIP finds the client address. Without trusted proxies only the peer address is used,
+// so clients cannot pick their rate limit bucket with X-Forwarded-For or X-Real-IP. With trusted proxies the
+// X-Forwarded-For chain is followed back through them and no other network is trusted.
+func IPExtractor(trustedProxies []*net.IPNet) echo.IPExtractor {
+	if len(trustedProxies) == 0 {
+		return echo.ExtractIPDirect()
+	}
+	opts := []echo.TrustOption{echo.TrustLoopback(false), echo.TrustLinkLocal(false), echo.TrustPrivateNet(false)}
+	for _, ipNet := range trustedProxies {
+		opts = append(opts, echo.TrustIPRange(ipNet))
+	}
+	return echo.ExtractIPFromXFFHeader(opts...)
+}
+
+// RateLimitIP enforces the per-IP request rate limit. It runs before authentication, so floods of requests with
+// bad credentials are rejected before they reach the API key store or the token validator.
+func RateLimitIP(limiter *RateLimiter) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			limit := limiter.cfg.PerIP
+			if limit.RequestsPerSecond > 0 {
+				if ok, retryAfter := limiter.allow(metrics.RoutePerIP, "ip:"+c.RealIP(), limit); !ok {
+					metrics.RateLimitRejected.WithLabelValues(metrics.RoutePerIP, "ip").Inc()
+					return rateLimited(c, retryAfter)
+				}
+			}
+			return next(c)
+		}
+	}
+}
+
+// RateLimit enforces the body size and request rate limits. It must run after authentication and tenant
+// resolution: clients are identified by their API key or user within the organization, anonymous ones by IP.
+func RateLimit(limiter *RateLimiter) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			route, limit := limiter.limitFor(c.Path())
+			req := c.Request()
+
+			if limit.MaxBodyBytes > 0 {
+				if req.ContentLength > limit.MaxBodyBytes {
+					metrics.BodyLimitRejected.WithLabelValues(route).Inc()
+					return response.HandleError(c, apperror.NewRequestTooLargeError(limit.MaxBodyBytes))
+				}
+				req.Body = http.MaxBytesReader(c.Response(), req.Body, limit.MaxBodyBytes)
+			}
+
+			if limit.RequestsPerSecond > 0 {
+				client, kind := clientKey(c)
+				if ok, retryAfter := limiter.allow(route, client, limit); !ok {
+					metrics.RateLimitRejected.WithLabelValues(route, kind).Inc()
+					return rateLimited(c, retryAfter)
+				}
+			}
+
+			return next(c)
+		}
+	}
+}
+
+func rateLimited(c echo.Context, retryAfter time.Duration) error {
+	c.Response().Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+	return response.HandleError(c, apperror.NewRateLimitedError())
+}
+
+func (l *RateLimiter) limitFor(path string) (string, Limit) {
+	if limit, ok := l.cfg.Routes[path]; ok {
+		return path, limit
+	}
+	return metrics.RouteDefault, l.cfg.Default
+}
+
+// allow takes a token from the bucket of the client and reports how long to wait when it is empty.
+func (l *RateLimiter) allow(route, client string, limit Limit) (bool, time.Duration) {
+	now := l.now()
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.sweep(now)
+
+	key := route + "|" + client
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &bucket{limiter: rate.NewLimiter(rate.Limit(limit.RequestsPerSecond), burstFor(limit))}
+		l.buckets[key] = b
+	}
+	b.lastSeen = now
+
+	r := b.limiter.ReserveN(now, 1)
+	if !r.OK() {
+		return false, time.Second
+	}
+	if delay := r.DelayFrom(now); delay > 0 {
+		r.CancelAt(now)
+		return false, delay
+	}
+	return true, 0
+}
+
+// sweep drops buckets of clients that have been idle for IdleTTL, so the map does not grow without bound.
+func (l *RateLimiter) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < l.cfg.IdleTTL {
+		return
+	}
+	for key, b := range l.buckets {
+		if now.Sub(b.lastSeen) >= l.cfg.IdleTTL {
+			delete(l.buckets, key)
+		}
+	}
+	l.lastSweep = now
+}
+
+func clientKey(c echo.Context) (string, string) {
+	ctx := c.Request().Context()
+	principal := auth.FromContext(ctx)
+
+	switch {
+	case principal != nil && principal.APIKeyID != 0:
+		return principal.Subject, "apikey"
+	case principal != nil && principal.IsUser():
+		return fmt.Sprintf("%d/%s", tenant.OrganizationID(ctx), principal.Subject), "user"
+	default:
+		return "ip:" + c.RealIP(), "ip"
+	}
+}
+
+func inheritLimit(limit, def Limit) Limit {
+	if limit.RequestsPerSecond == 0 {
+		limit.RequestsPerSecond = def.RequestsPerSecond
+		if limit.Burst == 0 {
+			limit.Burst = def.Burst
+		}
+	}
+	if limit.MaxBodyBytes == 0 {
+		limit.MaxBodyBytes = def.MaxBodyBytes
+	}
+	return limit
+}
+
+func burstFor(limit Limit) int {
+	if limit.Burst > 0 {
+		return limit.Burst
+	}
+	return max(1, int(math.Ceil(limit.RequestsPerSecond)))
+}
+
+func exportLimit(route string, limit Limit) {
+	metrics.RateLimitRequestsPerSecond.WithLabelValues(route).Set(limit.RequestsPerSecond)
+	metrics.RateLimitBurst.WithLabelValues(route).Set(float64(burstFor(limit)))
+	metrics.BodyLimitBytes.WithLabelValues(route).Set(float64(limit.MaxBodyBytes))
+}
@@ -0,0 +1,190 @@
+package middleware
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/ssokov/pr-reviewer-service/internal/auth"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newRateLimitServer(limiter *RateLimiter, principal *auth.Principal) *echo.Echo {
+	e := echo.New()
+	e.Use(func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if principal != nil {
+				c.SetRequest(c.Request().WithContext(auth.WithPrincipal(c.Request().Context(), principal)))
+			}
+			return next(c)
+		}
+	})
+	e.Use(RateLimit(limiter))
+	handler := func(c echo.Context) error {
+		return c.NoContent(http.StatusOK)
+	}
+	e.POST("/pullRequest/create", handler)
+	e.GET("/stats", handler)
+	return e
+}
+
+func doRateLimitRequest(e *echo.Echo, method, path, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	req.RemoteAddr = "10.0.0.1:1234"
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestRateLimit_Default(t *testing.T) {
+	limiter := NewRateLimiter(RateLimitConfig{Default: Limit{RequestsPerSecond: 1, Burst: 2}})
+	now := time.Now()
+	limiter.now = func() time.Time { return now }
+	e := newRateLimitServer(limiter, nil)
+
+	assert.Equal(t, http.StatusOK, doRateLimitRequest(e, http.MethodGet, "/stats", "").Code)
+	assert.Equal(t, http.StatusOK, doRateLimitRequest(e, http.MethodGet, "/stats", "").Code)
+
+	rec := doRateLimitRequest(e, http.MethodGet, "/stats", "")
+	assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+	assert.Equal(t, "1", rec.Header().Get("Retry-After"))
+	assert.Contains(t, rec.Body.String(), "RATE_LIMITED")
+
+	now = now.Add(time.Second)
+	assert.Equal(t, http.StatusOK, doRateLimitRequest(e, http.MethodGet, "/stats", "").Code)
+}
+
+func TestRateLimit_RouteOverride(t *testing.T) {
+	limiter := NewRateLimiter(RateLimitConfig{
+		Default: Limit{RequestsPerSecond: 100},
+		Routes: map[string]Limit{
+			"/pullRequest/create": {RequestsPerSecond: 0.1, Burst: 1},
+		},
+	})
+	e := newRateLimitServer(limiter, nil)
+
+	assert.Equal(t, http.StatusOK, doRateLimitRequest(e, http.MethodPost, "/pullRequest/create", "{}").Code)
+
+	rec := doRateLimitRequest(e, http.MethodPost, "/pullRequest/create", "{}")
+	assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+	assert.Equal(t, "10", rec.Header().Get("Retry-After"))
+
+	assert.Equal(t, http.StatusOK, doRateLimitRequest(e, http.MethodGet, "/stats", "").Code)
+}
+
+func TestRateLimit_SeparateBucketsPerClient(t *testing.T) {
+	limiter := NewRateLimiter(RateLimitConfig{Default: Limit{RequestsPerSecond: 1, Burst: 1}})
+
+	first := newRateLimitServer(limiter, &auth.Principal{Subject: "apikey:aaaa", APIKeyID: 1})
+	second := newRateLimitServer(limiter, &auth.Principal{Subject: "apikey:bbbb", APIKeyID: 2})
+
+	assert.Equal(t, http.StatusOK, doRateLimitRequest(first, http.MethodGet, "/stats", "").Code)
+	assert.Equal(t, http.StatusTooManyRequests, doRateLimitRequest(first, http.MethodGet, "/stats", "").Code)
+	assert.Equal(t, http.StatusOK, doRateLimitRequest(second, http.MethodGet, "/stats", "").Code)
+}
+
+func TestRateLimitIP_BeforeAuthentication(t *testing.T) {
+	limiter := NewRateLimiter(RateLimitConfig{PerIP: Limit{RequestsPerSecond: 1, Burst: 1}})
+	e := echo.New()
+	e.Use(RateLimitIP(limiter))
+	e.Use(func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			return c.NoContent(http.StatusUnauthorized)
+		}
+	})
+	e.GET("/stats", func(c echo.Context) error { return c.NoContent(http.StatusOK) })
+
+	assert.Equal(t, http.StatusUnauthorized, doRateLimitRequest(e, http.MethodGet, "/stats", "").Code)
+	rec := doRateLimitRequest(e, http.MethodGet, "/stats", "")
+	assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+	assert.Equal(t, "1", rec.Header().Get("Retry-After"))
+
+	req := httptest.NewRequest(http.MethodGet, "/stats", nil)
+	req.RemoteAddr = "10.0.0.2:1234"
+	other := httptest.NewRecorder()
+	e.ServeHTTP(other, req)
+	assert.Equal(t, http.StatusUnauthorized, other.Code)
+}
+
+func TestRateLimitIP_SpoofedForwardedFor(t *testing.T) {
+	limiter := NewRateLimiter(RateLimitConfig{PerIP: Limit{RequestsPerSecond: 1, Burst: 1}})
+	e := echo.New()
+	e.IPExtractor = IPExtractor(nil)
+	e.Use(RateLimitIP(limiter))
+	e.GET("/stats", func(c echo.Context) error { return c.NoContent(http.StatusOK) })
+
+	do := func(forwardedFor string) int {
+		req := httptest.NewRequest(http.MethodGet, "/stats", nil)
+		req.RemoteAddr = "203.0.113.7:1234"
+		req.Header.Set(echo.HeaderXForwardedFor, forwardedFor)
+		req.Header.Set(echo.HeaderXRealIP, forwardedFor)
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	assert.Equal(t, http.StatusOK, do("198.51.100.1"))
+	assert.Equal(t, http.StatusTooManyRequests, do("198.51.100.2"))
+}
+
+func TestRateLimitIP_TrustedProxy(t *testing.T) {
+	_, proxies, err := net.ParseCIDR("10.0.0.0/24")
+	require.NoError(t, err)
+	limiter := NewRateLimiter(RateLimitConfig{PerIP: Limit{RequestsPerSecond: 1, Burst: 1}})
+	e := echo.New()
+	e.IPExtractor = IPExtractor([]*net.IPNet{proxies})
+	e.Use(RateLimitIP(limiter))
+	e.GET("/stats", func(c echo.Context) error { return c.NoContent(http.StatusOK) })
+
+	do := func(remoteAddr, forwardedFor string) int {
+		req := httptest.NewRequest(http.MethodGet, "/stats", nil)
+		req.RemoteAddr = remoteAddr
+		req.Header.Set(echo.HeaderXForwardedFor, forwardedFor)
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	// Clients behind the trusted proxy get their own buckets.
+	assert.Equal(t, http.StatusOK, do("10.0.0.1:1234", "198.51.100.1"))
+	assert.Equal(t, http.StatusOK, do("10.0.0.1:1234", "198.51.100.2"))
+	assert.Equal(t, http.StatusTooManyRequests, do("10.0.0.1:1234", "198.51.100.1"))
+
+	// A direct client cannot prepend a fake address to the chain.
+	assert.Equal(t, http.StatusOK, do("203.0.113.7:1234", "198.51.100.3"))
+	assert.Equal(t, http.StatusTooManyRequests, do("203.0.113.7:1234", "198.51.100.4"))
+}
+
+func TestRateLimit_BodyLimit(t *testing.T) {
+	limiter := NewRateLimiter(RateLimitConfig{
+		Default: Limit{MaxBodyBytes: 1024},
+		Routes: map[string]Limit{
+			"/pullRequest/create": {MaxBodyBytes: 8},
+		},
+	})
+	e := newRateLimitServer(limiter, nil)
+
+	rec := doRateLimitRequest(e, http.MethodPost, "/pullRequest/create", `{"pull_request_id":"pr-1"}`)
+	assert.Equal(t, http.StatusRequestEntityTooLarge, rec.Code)
+	assert.Contains(t, rec.Body.String(), "REQUEST_TOO_LARGE")
+
+	assert.Equal(t, http.StatusOK, doRateLimitRequest(e, http.MethodPost, "/pullRequest/create", "{}").Code)
+}
+
+func TestRateLimiter_SweepsIdleBuckets(t *testing.T) {
+	limiter := NewRateLimiter(RateLimitConfig{Default: Limit{RequestsPerSecond: 1}, IdleTTL: time.Minute})
+	now := time.Now()
+	limiter.now = func() time.Time { return now }
+
+	limiter.allow("*", "ip:10.0.0.1", limiter.cfg.Default)
+	assert.Len(t, limiter.buckets, 1)
+
+	now = now.Add(2 * time.Minute)
+	limiter.allow("*", "ip:10.0.0.2", limiter.cfg.Default)
+	assert.Len(t, limiter.buckets, 1)
+}
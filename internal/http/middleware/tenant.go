@@ -0,0 +1,52 @@
+package middleware
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	"github.com/ssokov/pr-reviewer-service/internal/auth"
+	"github.com/ssokov/pr-reviewer-service/internal/http/response"
+	"github.com/ssokov/pr-reviewer-service/internal/model/domain"
+	"github.com/ssokov/pr-reviewer-service/internal/service"
+	"github.com/ssokov/pr-reviewer-service/internal/tenant"
+)
+
+const HeaderOrganization = "X-Organization"
+
+// ResolveTenant stores the organization of the request in its context. It must run after authentication.
+// Every principal is bound to one organization: API keys to their own, tokens to the one in the organization
+// claim, and tokens without the claim and requests with authentication disabled to the default one. The
+// X-Organization header cannot switch organizations; when set, it must name the principal's organization.
+func ResolveTenant(organizationService service.OrganizationService) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			ctx := c.Request().Context()
+			requested := strings.TrimSpace(c.Request().Header.Get(HeaderOrganization))
+			principal := auth.FromContext(ctx)
+
+			var (
+				org *domain.Organization
+				err error
+			)
+			switch {
+			case principal != nil && principal.OrganizationID != 0:
+				org, err = organizationService.GetByID(ctx, principal.OrganizationID)
+			case principal != nil && principal.OrganizationSlug != "":
+				org, err = organizationService.GetBySlug(ctx, principal.OrganizationSlug)
+			default:
+				org, err = organizationService.GetBySlug(ctx, domain.DefaultOrganizationSlug)
+			}
+			if err != nil {
+				return response.HandleError(c, err)
+			}
+
+			if requested != "" && requested != org.Slug {
+				return response.Error(c, http.StatusForbidden, "FORBIDDEN", "credentials belong to another organization")
+			}
+
+			c.SetRequest(c.Request().WithContext(tenant.WithOrganization(ctx, org)))
+			return next(c)
+		}
+	}
+}
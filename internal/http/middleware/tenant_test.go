@@ -0,0 +1,164 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/ssokov/pr-reviewer-service/internal/apperror"
+	"github.com/ssokov/pr-reviewer-service/internal/auth"
+	"github.com/ssokov/pr-reviewer-service/internal/model/domain"
+	"github.com/ssokov/pr-reviewer-service/internal/tenant"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockOrganizationService struct {
+	mock.Mock
+}
+
+func (m *MockOrganizationService) CreateOrganization(ctx context.Context, slug, name string, settings domain.OrganizationSettings) (*domain.Organization, error) {
+	args := m.Called(ctx, slug, name, settings)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Organization), args.Error(1)
+}
+
+func (m *MockOrganizationService) GetBySlug(ctx context.Context, slug string) (*domain.Organization, error) {
+	args := m.Called(ctx, slug)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Organization), args.Error(1)
+}
+
+func (m *MockOrganizationService) GetByID(ctx context.Context, id int64) (*domain.Organization, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Organization), args.Error(1)
+}
+
+func (m *MockOrganizationService) ListOrganizations(ctx context.Context) ([]domain.Organization, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.Organization), args.Error(1)
+}
+
+func (m *MockOrganizationService) UpdateSettings(ctx context.Context, slug string, settings domain.OrganizationSettings) (*domain.Organization, error) {
+	args := m.Called(ctx, slug, settings)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Organization), args.Error(1)
+}
+
+var (
+	defaultOrg = &domain.Organization{ID: 1, Slug: "default"}
+	acmeOrg    = &domain.Organization{ID: 2, Slug: "acme"}
+)
+
+func newTenantServer(svc *MockOrganizationService, principal *auth.Principal) *echo.Echo {
+	e := echo.New()
+	e.Use(func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			c.SetRequest(c.Request().WithContext(auth.WithPrincipal(c.Request().Context(), principal)))
+			return next(c)
+		}
+	})
+	e.Use(ResolveTenant(svc))
+	e.GET("/stats", func(c echo.Context) error {
+		return c.String(http.StatusOK, tenant.FromContext(c.Request().Context()).Slug)
+	})
+	return e
+}
+
+func doTenantRequest(e *echo.Echo, org string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, "/stats", nil)
+	if org != "" {
+		req.Header.Set(HeaderOrganization, org)
+	}
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestResolveTenant_DefaultOrganization(t *testing.T) {
+	svc := new(MockOrganizationService)
+	svc.On("GetBySlug", mock.Anything, "default").Return(defaultOrg, nil)
+
+	rec := doTenantRequest(newTenantServer(svc, auth.Anonymous), "")
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "default", rec.Body.String())
+}
+
+func TestResolveTenant_HeaderCannotSwitchOrganization(t *testing.T) {
+	svc := new(MockOrganizationService)
+	svc.On("GetBySlug", mock.Anything, "default").Return(defaultOrg, nil)
+
+	tests := []struct {
+		name      string
+		principal *auth.Principal
+	}{
+		{name: "authentication disabled", principal: auth.Anonymous},
+		{name: "token without organization claim", principal: &auth.Principal{Subject: "user:u1", UserID: "u1"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := doTenantRequest(newTenantServer(svc, tt.principal), "acme")
+			assert.Equal(t, http.StatusForbidden, rec.Code)
+
+			rec = doTenantRequest(newTenantServer(svc, tt.principal), "default")
+			assert.Equal(t, http.StatusOK, rec.Code)
+			assert.Equal(t, "default", rec.Body.String())
+		})
+	}
+	svc.AssertNotCalled(t, "GetBySlug", mock.Anything, "acme")
+}
+
+func TestResolveTenant_UnknownOrganization(t *testing.T) {
+	svc := new(MockOrganizationService)
+	svc.On("GetBySlug", mock.Anything, "ghost").Return(nil, apperror.NewNotFoundError("organization 'ghost'"))
+	principal := &auth.Principal{Subject: "user:u1", UserID: "u1", OrganizationSlug: "ghost"}
+
+	rec := doTenantRequest(newTenantServer(svc, principal), "")
+
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func TestResolveTenant_APIKeyBoundToOrganization(t *testing.T) {
+	svc := new(MockOrganizationService)
+	svc.On("GetByID", mock.Anything, int64(2)).Return(acmeOrg, nil)
+	principal := &auth.Principal{Subject: "apikey:ab12cd34", OrganizationID: 2}
+
+	t.Run("organization of the key", func(t *testing.T) {
+		rec := doTenantRequest(newTenantServer(svc, principal), "")
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "acme", rec.Body.String())
+	})
+
+	t.Run("error - another organization requested", func(t *testing.T) {
+		rec := doTenantRequest(newTenantServer(svc, principal), "default")
+
+		assert.Equal(t, http.StatusForbidden, rec.Code)
+	})
+}
+
+func TestResolveTenant_TokenOrganizationClaim(t *testing.T) {
+	svc := new(MockOrganizationService)
+	svc.On("GetBySlug", mock.Anything, "acme").Return(acmeOrg, nil)
+	principal := &auth.Principal{Subject: "user:u1", UserID: "u1", OrganizationSlug: "acme"}
+
+	rec := doTenantRequest(newTenantServer(svc, principal), "")
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "acme", rec.Body.String())
+}
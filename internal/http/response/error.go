@@ -10,16 +10,22 @@ import (
 )
 
 var errorStatusMap = map[apperror.ErrorCode]int{
-	apperror.ErrCodeTeamExists:   http.StatusBadRequest,
-	apperror.ErrCodeInvalidInput: http.StatusBadRequest,
-	apperror.ErrCodePRExists:     http.StatusConflict,
-	apperror.ErrCodePRMerged:     http.StatusConflict,
-	apperror.ErrCodeNotAssigned:  http.StatusConflict,
-	apperror.ErrCodeNoCandidate:  http.StatusConflict,
-	apperror.ErrCodeTeamNotFound: http.StatusNotFound,
-	apperror.ErrCodeUserNotFound: http.StatusNotFound,
-	apperror.ErrCodePRNotFound:   http.StatusNotFound,
-	apperror.ErrCodeNotFound:     http.StatusNotFound,
+	apperror.ErrCodeTeamExists:      http.StatusBadRequest,
+	apperror.ErrCodeInvalidInput:    http.StatusBadRequest,
+	apperror.ErrCodeUserExists:      http.StatusConflict,
+	apperror.ErrCodePRExists:        http.StatusConflict,
+	apperror.ErrCodePRMerged:        http.StatusConflict,
+	apperror.ErrCodeNotAssigned:     http.StatusConflict,
+	apperror.ErrCodeNoCandidate:     http.StatusConflict,
+	apperror.ErrCodePinned:          http.StatusConflict,
+	apperror.ErrCodeTeamNotFound:    http.StatusNotFound,
+	apperror.ErrCodeUserNotFound:    http.StatusNotFound,
+	apperror.ErrCodePRNotFound:      http.StatusNotFound,
+	apperror.ErrCodeNotFound:        http.StatusNotFound,
+	apperror.ErrCodeUnauthorized:    http.StatusUnauthorized,
+	apperror.ErrCodeForbidden:       http.StatusForbidden,
+	apperror.ErrCodeRateLimited:     http.StatusTooManyRequests,
+	apperror.ErrCodeRequestTooLarge: http.StatusRequestEntityTooLarge,
 }
 
 func HandleError(c echo.Context, err error) error {
@@ -32,6 +38,11 @@ func HandleError(c echo.Context, err error) error {
 	return Error(c, statusCode, string(appErr.Code), appErr.Message)
 }
 
+// HTTPStatus returns the status HandleError would respond with for err.
+func HTTPStatus(err error) int {
+	return getHTTPStatus(apperror.CodeOf(err))
+}
+
 func getHTTPStatus(code apperror.ErrorCode) int {
 	if status, ok := errorStatusMap[code]; ok {
 		return status
@@ -2,15 +2,24 @@ package http
 
 import (
 	"github.com/labstack/echo/v4"
-	"github.com/labstack/echo/v4/middleware"
+	echomw "github.com/labstack/echo/v4/middleware"
 	_ "github.com/ssokov/pr-reviewer-service/docs"
+	"github.com/ssokov/pr-reviewer-service/internal/http/handler/audit"
+	"github.com/ssokov/pr-reviewer-service/internal/http/handler/codeowners"
+	"github.com/ssokov/pr-reviewer-service/internal/http/handler/health"
+	"github.com/ssokov/pr-reviewer-service/internal/http/handler/pool"
 	"github.com/ssokov/pr-reviewer-service/internal/http/handler/pr"
+	"github.com/ssokov/pr-reviewer-service/internal/http/handler/scim"
 	"github.com/ssokov/pr-reviewer-service/internal/http/handler/stats"
 	"github.com/ssokov/pr-reviewer-service/internal/http/handler/team"
 	"github.com/ssokov/pr-reviewer-service/internal/http/handler/user"
+	"github.com/ssokov/pr-reviewer-service/internal/http/middleware"
+	"github.com/ssokov/pr-reviewer-service/internal/metrics"
+	"github.com/ssokov/pr-reviewer-service/internal/requestid"
 	"github.com/ssokov/pr-reviewer-service/internal/service"
 	echoSwagger "github.com/swaggo/echo-swagger"
 	"github.com/vmkteam/embedlog"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/labstack/echo/otelecho"
 )
 
 func NewServer(
@@ -18,12 +27,40 @@ func NewServer(
 	userService service.UserService,
 	prService service.PRService,
 	teamService service.TeamService,
+	rosterService service.RosterService,
+	hierarchyService service.HierarchyService,
+	directoryService service.DirectoryService,
+	codeOwnersService service.CodeOwnersService,
+	skillService service.SkillService,
+	poolService service.PoolService,
 	statsService service.StatsService,
+	auditService service.AuditService,
+	apiKeyService service.APIKeyService,
+	organizationService service.OrganizationService,
+	healthService service.HealthService,
+	tokenValidator middleware.TokenValidator,
+	authEnabled bool,
+	rateLimiter *middleware.RateLimiter,
+	ipExtractor echo.IPExtractor,
 ) *echo.Echo {
 	e := echo.New()
+	e.IPExtractor = ipExtractor
 
-	e.Use(middleware.Recover())
-	e.Use(middleware.Logger())
+	e.Use(echomw.Recover())
+	e.Use(otelecho.Middleware("pr-reviewer-service", otelecho.WithSkipper(func(c echo.Context) bool {
+		switch c.Path() {
+		case "/metrics", "/healthz", "/readyz":
+			return true
+		}
+		return false
+	})))
+	e.Use(echomw.RequestIDWithConfig(echomw.RequestIDConfig{
+		RequestIDHandler: func(c echo.Context, id string) {
+			c.SetRequest(c.Request().WithContext(requestid.WithID(c.Request().Context(), id)))
+		},
+	}))
+	e.Use(echomw.Logger())
+	e.Use(middleware.Metrics())
 
 	e.Use(func(next echo.HandlerFunc) echo.HandlerFunc {
 		return func(c echo.Context) error {
@@ -33,16 +70,40 @@ func NewServer(
 	})
 
 	e.GET("/swagger/*", echoSwagger.WrapHandler)
+	e.GET("/metrics", echo.WrapHandler(metrics.Handler()))
+	health.RegisterRoutes(e, health.NewHandler(healthService, logger))
+
+	api := e.Group("")
+	if rateLimiter != nil {
+		api.Use(middleware.RateLimitIP(rateLimiter))
+	}
+	if authEnabled {
+		api.Use(middleware.Authenticate(apiKeyService, tokenValidator, logger))
+	} else {
+		api.Use(middleware.NoAuth())
+	}
+	api.Use(middleware.ResolveTenant(organizationService))
+	if rateLimiter != nil {
+		api.Use(middleware.RateLimit(rateLimiter))
+	}
 
-	userHandler := user.NewHandler(userService, logger)
-	prHandler := pr.NewHandler(prService, logger)
-	teamHandler := team.NewHandler(teamService, logger)
+	userHandler := user.NewHandler(userService, skillService, logger)
+	prHandler := pr.NewHandler(prService, skillService, logger)
+	teamHandler := team.NewHandler(teamService, rosterService, hierarchyService, logger)
 	statsHandler := stats.NewHandler(statsService, logger)
+	auditHandler := audit.NewHandler(auditService, logger)
+	scimHandler := scim.NewHandler(directoryService, logger)
+	codeOwnersHandler := codeowners.NewHandler(codeOwnersService, logger)
+	poolHandler := pool.NewHandler(poolService, logger)
 
-	user.RegisterRoutes(e, userHandler)
-	pr.RegisterRoutes(e, prHandler)
-	team.RegisterRoutes(e, teamHandler)
-	stats.RegisterRoutes(e, statsHandler)
+	user.RegisterRoutes(api, userHandler)
+	pr.RegisterRoutes(api, prHandler)
+	team.RegisterRoutes(api, teamHandler)
+	stats.RegisterRoutes(api, statsHandler)
+	audit.RegisterRoutes(api, auditHandler)
+	scim.RegisterRoutes(api, scimHandler)
+	codeowners.RegisterRoutes(api, codeOwnersHandler)
+	pool.RegisterRoutes(api, poolHandler)
 
 	return e
 }
@@ -0,0 +1,110 @@
+package metrics
+
+import (
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "pr_reviewer"
+
+// RouteDefault labels limits that apply to routes without an override.
+const RouteDefault = "*"
+
+// RoutePerIP labels the per-IP limit applied before authentication.
+const RoutePerIP = "per_ip"
+
+var (
+	RateLimitRequestsPerSecond = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "rate_limit",
+		Name:      "requests_per_second",
+		Help:      "Configured token bucket refill rate per client.",
+	}, []string{"route"})
+
+	RateLimitBurst = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "rate_limit",
+		Name:      "burst",
+		Help:      "Configured token bucket size per client.",
+	}, []string{"route"})
+
+	RateLimitRejected = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "rate_limit",
+		Name:      "rejected_total",
+		Help:      "Requests rejected with 429, by route and client kind (apikey, user, ip).",
+	}, []string{"route", "client"})
+
+	BodyLimitBytes = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "body_limit",
+		Name:      "bytes",
+		Help:      "Configured maximum request body size.",
+	}, []string{"route"})
+
+	BodyLimitRejected = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "body_limit",
+		Name:      "rejected_total",
+		Help:      "Requests rejected with 413 because of the body size.",
+	}, []string{"route"})
+
+	HTTPRequestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "http",
+		Name:      "request_duration_seconds",
+		Help:      "HTTP request latency by route and status.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "route", "status"})
+
+	PRsCreated = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "pr",
+		Name:      "created_total",
+		Help:      "Pull requests created.",
+	}, []string{"organization"})
+
+	PRsMerged = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "pr",
+		Name:      "merged_total",
+		Help:      "Pull requests merged.",
+	}, []string{"organization"})
+
+	ReviewersReassigned = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "pr",
+		Name:      "reassigned_total",
+		Help:      "Reviewers reassigned on open pull requests.",
+	}, []string{"organization"})
+
+	AssignmentFailures = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "pr",
+		Name:      "assignment_failures_total",
+		Help:      "Failed PR creations and reassignments by operation and error code.",
+	}, []string{"operation", "code"})
+
+	ReviewersPerPR = promauto.NewHistogram(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "pr",
+		Name:      "reviewers",
+		Help:      "Number of reviewers assigned to a new pull request.",
+		Buckets:   []float64{0, 1, 2, 3, 5, 8},
+	})
+
+	OpenPRsByTeam = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "pr",
+		Name:      "open",
+		Help:      "Open pull requests by the team of their author, refreshed periodically.",
+	}, []string{"organization", "team"})
+)
+
+// Handler serves the default registry in the Prometheus text format.
+func Handler() http.Handler {
+	return promhttp.Handler()
+}
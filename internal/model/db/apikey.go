@@ -0,0 +1,15 @@
+package db
+
+import "time"
+
+type APIKey struct {
+	ID             int64
+	OrganizationID int64
+	Name           string
+	KeyPrefix      string
+	KeyHash        string
+	Scopes         []string
+	LastUsedAt     *time.Time
+	RevokedAt      *time.Time
+	CreatedAt      time.Time
+}
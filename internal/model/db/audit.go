@@ -0,0 +1,14 @@
+package db
+
+import "time"
+
+type AuditEntry struct {
+	ID        int64
+	Actor     string
+	Action    string
+	Target    string
+	Before    []byte
+	After     []byte
+	RequestID *string
+	CreatedAt time.Time
+}
@@ -0,0 +1,11 @@
+package db
+
+import "time"
+
+type CodeOwners struct {
+	OrganizationID int64
+	Repository     string
+	Mode           string
+	Content        string
+	UpdatedAt      time.Time
+}
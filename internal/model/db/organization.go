@@ -0,0 +1,11 @@
+package db
+
+import "time"
+
+type Organization struct {
+	ID        int64
+	Slug      string
+	Name      string
+	Settings  []byte
+	CreatedAt time.Time
+}
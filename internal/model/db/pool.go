@@ -0,0 +1,10 @@
+package db
+
+import "time"
+
+type ReviewerPool struct {
+	ID             int64
+	OrganizationID int64
+	Name           string
+	CreatedAt      time.Time
+}
@@ -3,13 +3,14 @@ package db
 import "time"
 
 type PullRequest struct {
-	ID              int64
-	PullRequestID   string
-	PullRequestName string
-	AuthorID        int64
-	StatusID        int
-	CreatedAt       time.Time
-	MergedAt        *time.Time
+	ID               int64
+	PullRequestID    string
+	PullRequestName  string
+	AuthorID         int64
+	StatusID         int
+	CreatedAt        time.Time
+	MergedAt         *time.Time
+	PendingReviewers bool
 }
 
 type PRReviewer struct {
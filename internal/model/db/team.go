@@ -3,7 +3,9 @@ package db
 import "time"
 
 type Team struct {
-	ID        int64
-	TeamName  string
-	CreatedAt time.Time
+	ID         int64
+	TeamName   string
+	ParentID   *int64
+	ParentName *string
+	CreatedAt  time.Time
 }
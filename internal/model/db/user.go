@@ -3,10 +3,15 @@ package db
 import "time"
 
 type User struct {
-	ID        int64
-	UserID    string
-	Username  string
-	TeamID    int64
-	IsActive  bool
-	CreatedAt time.Time
+	ID             int64
+	UserID         string
+	Username       string
+	TeamID         int64
+	TeamRole       string
+	Timezone       string
+	WorkStart      int
+	WorkEnd        int
+	MaxOpenReviews *int
+	IsActive       bool
+	CreatedAt      time.Time
 }
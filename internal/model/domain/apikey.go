@@ -0,0 +1,58 @@
+package domain
+
+import "time"
+
+type Scope string
+
+const (
+	ScopeAll       Scope = "*"
+	ScopePRRead    Scope = "pr:read"
+	ScopePRWrite   Scope = "pr:write"
+	ScopeTeamRead  Scope = "team:read"
+	ScopeTeamWrite Scope = "team:write"
+	ScopeTeamAdmin Scope = "team:admin"
+	ScopeUserRead  Scope = "user:read"
+	ScopeUserWrite Scope = "user:write"
+	ScopeStatsRead Scope = "stats:read"
+	ScopeAuditRead Scope = "audit:read"
+
+	ScopeDirectoryWrite Scope = "directory:write"
+)
+
+var KnownScopes = []Scope{
+	ScopeAll,
+	ScopePRRead,
+	ScopePRWrite,
+	ScopeTeamRead,
+	ScopeTeamWrite,
+	ScopeTeamAdmin,
+	ScopeUserRead,
+	ScopeUserWrite,
+	ScopeStatsRead,
+	ScopeAuditRead,
+	ScopeDirectoryWrite,
+}
+
+func IsKnownScope(scope Scope) bool {
+	for _, s := range KnownScopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+type APIKey struct {
+	ID             int64
+	OrganizationID int64
+	Name           string
+	Prefix         string
+	Scopes         []Scope
+	LastUsedAt     *time.Time
+	RevokedAt      *time.Time
+	CreatedAt      time.Time
+}
+
+func (k *APIKey) IsRevoked() bool {
+	return k.RevokedAt != nil
+}
@@ -0,0 +1,53 @@
+package domain
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type AuditAction string
+
+const (
+	AuditActionTeamAdd          AuditAction = "team.add"
+	AuditActionTeamDeactivate   AuditAction = "team.deactivate"
+	AuditActionTeamImport       AuditAction = "team.import"
+	AuditActionUserSetActive    AuditAction = "user.set_is_active"
+	AuditActionUserSetSkills    AuditAction = "user.set_skills"
+	AuditActionUserSetRole      AuditAction = "user.set_team_role"
+	AuditActionUserSetHours     AuditAction = "user.set_working_hours"
+	AuditActionUserSetLimit     AuditAction = "user.set_max_open_reviews"
+	AuditActionPRCreate         AuditAction = "pr.create"
+	AuditActionPRMerge          AuditAction = "pr.merge"
+	AuditActionPRReassign       AuditAction = "pr.reassign"
+	AuditActionPRAssign         AuditAction = "pr.assign_pending"
+	AuditActionPRAddReviewer    AuditAction = "pr.add_reviewer"
+	AuditActionPRRemoveReviewer AuditAction = "pr.remove_reviewer"
+	AuditActionPRSetLabels      AuditAction = "pr.set_labels"
+
+	AuditActionCodeOwnersUpload AuditAction = "codeowners.upload"
+	AuditActionPoolSet          AuditAction = "pool.set"
+	AuditActionTeamSetFallback  AuditAction = "team.set_fallback"
+	AuditActionTeamSetParent    AuditAction = "team.set_parent"
+	AuditActionTeamSetSettings  AuditAction = "team.set_settings"
+)
+
+type AuditEntry struct {
+	ID        int64
+	Actor     string
+	Action    AuditAction
+	Target    string
+	Before    json.RawMessage
+	After     json.RawMessage
+	RequestID string
+	CreatedAt time.Time
+}
+
+type AuditFilter struct {
+	Actor  string
+	Action AuditAction
+	Target string
+	From   *time.Time
+	To     *time.Time
+	Limit  int
+	Offset int
+}
@@ -0,0 +1,25 @@
+package domain
+
+import "time"
+
+// CodeOwnersMode decides how owners of the changed files take part in reviewer selection.
+type CodeOwnersMode string
+
+const (
+	// CodeOwnersPreferred picks owners first and fills the remaining slots from the author's team.
+	CodeOwnersPreferred CodeOwnersMode = "preferred"
+	// CodeOwnersRequired assigns an owner for every owned file, even beyond the reviewer count.
+	CodeOwnersRequired CodeOwnersMode = "required"
+)
+
+func (m CodeOwnersMode) IsValid() bool {
+	return m == CodeOwnersPreferred || m == CodeOwnersRequired
+}
+
+// CodeOwners is the CODEOWNERS file uploaded for a repository.
+type CodeOwners struct {
+	Repository string
+	Mode       CodeOwnersMode
+	Content    string
+	UpdatedAt  time.Time
+}
@@ -0,0 +1,23 @@
+package domain
+
+import "time"
+
+type HealthStatus string
+
+const (
+	HealthStatusOK   HealthStatus = "ok"
+	HealthStatusFail HealthStatus = "fail"
+)
+
+// DependencyHealth is the result of checking a single readiness dependency.
+type DependencyHealth struct {
+	Name     string
+	Status   HealthStatus
+	Error    string
+	Duration time.Duration
+}
+
+type Readiness struct {
+	Status       HealthStatus
+	Dependencies []DependencyHealth
+}
@@ -0,0 +1,88 @@
+package domain
+
+import "time"
+
+// DefaultOrganizationID is the organization seeded by the migrations; data created before multi-tenancy belongs to it.
+const (
+	DefaultOrganizationID   int64 = 1
+	DefaultOrganizationSlug       = "default"
+)
+
+const (
+	defaultTopReviewersLimit = 10
+	defaultPairingWindowDays = 30
+	defaultAvailabilityHours = 2
+	defaultReviewSLAHours    = 8
+)
+
+// AtCapacity is what happens to a new PR when nobody can review it: every candidate reviewer is inactive or at
+// capacity.
+type AtCapacity string
+
+const (
+	// AtCapacityReject fails the PR with NO_CANDIDATE.
+	AtCapacityReject AtCapacity = "reject"
+	// AtCapacityQueue creates the PR without reviewers, marked as pending until the assignment worker finds some.
+	AtCapacityQueue AtCapacity = "queue"
+)
+
+// OrganizationSettings tune reviewer assignment and statistics per organization. Zero values mean the service defaults.
+type OrganizationSettings struct {
+	// ReviewerCount caps the number of reviewers assigned to a new PR; 0 assigns every active teammate.
+	ReviewerCount int `json:"reviewer_count,omitempty"`
+	// RequireSenior makes every new PR get at least one lead or senior reviewer.
+	RequireSenior bool `json:"require_senior,omitempty"`
+	// TopReviewersLimit is the length of the top reviewers list in /stats.
+	TopReviewersLimit int `json:"top_reviewers_limit,omitempty"`
+	// PairingWindowDays is how far back earlier reviews of the same author count against a reviewer.
+	PairingWindowDays int `json:"pairing_window_days,omitempty"`
+	// AvailabilityWindowHours is how soon a reviewer's working day must start for them to count as nearly available.
+	// Reviewers within their working hours are preferred, then those starting within the window.
+	AvailabilityWindowHours int `json:"availability_window_hours,omitempty"`
+	// ReviewSLAHours is the time reviewers have to review a PR, counted in their working hours.
+	ReviewSLAHours int `json:"review_sla_hours,omitempty"`
+	// MaxOpenReviews caps the open PRs a user reviews at once unless the user or their team sets a limit; 0 means no
+	// limit. Users at capacity are skipped by assignment and reassignment.
+	MaxOpenReviews int `json:"max_open_reviews,omitempty"`
+	// AtCapacity is reject or queue; empty means reject.
+	AtCapacity AtCapacity `json:"at_capacity,omitempty"`
+}
+
+func (s OrganizationSettings) TopReviewers() int {
+	if s.TopReviewersLimit > 0 {
+		return s.TopReviewersLimit
+	}
+	return defaultTopReviewersLimit
+}
+
+func (s OrganizationSettings) PairingWindow() time.Duration {
+	days := defaultPairingWindowDays
+	if s.PairingWindowDays > 0 {
+		days = s.PairingWindowDays
+	}
+	return time.Duration(days) * 24 * time.Hour
+}
+
+func (s OrganizationSettings) AvailabilityWindow() time.Duration {
+	hours := defaultAvailabilityHours
+	if s.AvailabilityWindowHours > 0 {
+		hours = s.AvailabilityWindowHours
+	}
+	return time.Duration(hours) * time.Hour
+}
+
+func (s OrganizationSettings) ReviewSLA() time.Duration {
+	hours := defaultReviewSLAHours
+	if s.ReviewSLAHours > 0 {
+		hours = s.ReviewSLAHours
+	}
+	return time.Duration(hours) * time.Hour
+}
+
+type Organization struct {
+	ID        int64
+	Slug      string
+	Name      string
+	Settings  OrganizationSettings
+	CreatedAt time.Time
+}
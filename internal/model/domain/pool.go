@@ -0,0 +1,24 @@
+package domain
+
+import "time"
+
+// ReviewerPool is a named set of reviewers that may span teams. Teams use pools as fallback steps.
+type ReviewerPool struct {
+	ID        int64
+	Name      string
+	Members   []User
+	CreatedAt time.Time
+}
+
+// FallbackStep is one step of a team's fallback chain: another team or a reviewer pool. Exactly one of TeamName
+// and PoolName is set.
+type FallbackStep struct {
+	TeamName string
+	PoolName string
+}
+
+// FallbackChain lists where reviewers come from, in order, when a team cannot fill the reviewer count itself.
+type FallbackChain struct {
+	TeamName string
+	Steps    []FallbackStep
+}
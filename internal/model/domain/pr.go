@@ -16,6 +16,71 @@ type PullRequest struct {
 	AuthorID          string
 	Status            PRStatus
 	AssignedReviewers []string
-	CreatedAt         time.Time
-	MergedAt          *time.Time
+	// ShadowReviewers are trainees following the review. They do not count toward the reviewer count and cannot
+	// satisfy any reviewer requirement.
+	ShadowReviewers []string
+	// PinnedReviewers are the assigned reviewers set explicitly with a pin. Reassignment never replaces them.
+	PinnedReviewers []string
+	CreatedAt       time.Time
+	MergedAt        *time.Time
+	Labels          []string
+	// PendingReviewers marks an open PR that is waiting for reviewers because everyone was at capacity.
+	PendingReviewers bool
+
+	// Repository and ChangedFiles only steer reviewer selection when the PR is created; they are not stored.
+	Repository   string
+	ChangedFiles []string
+	// Selections explain why each reviewer was assigned. CreatePR fills them in; they are not stored.
+	Selections []ReviewerSelection
+}
+
+type SelectionRule string
+
+const (
+	SelectionRuleCodeOwners SelectionRule = "codeowners"
+	SelectionRuleSkill      SelectionRule = "skill"
+	SelectionRuleTeam       SelectionRule = "team"
+	SelectionRuleFallback   SelectionRule = "fallback"
+	SelectionRuleSenior     SelectionRule = "senior"
+)
+
+// ReviewerSelection records the rule that picked a reviewer. For CODEOWNERS selections Pattern and Line point at
+// the matching line of the file, for skill selections Skill is the PR label the reviewer covers. Fallback
+// selections name the team or pool of the fallback chain the reviewer came from.
+type ReviewerSelection struct {
+	UserID  string
+	Rule    SelectionRule
+	Pattern string
+	Line    int
+	Skill   string
+	Team    string
+	Pool    string
+}
+
+// PRFilter selects pull requests for listing; empty fields do not filter.
+type PRFilter struct {
+	Status        PRStatus
+	CreatedBefore *time.Time
+	// Pending selects PRs still waiting for reviewers.
+	Pending bool
+	Limit   int
+}
+
+// ReviewSLA is the review deadline of each assigned reviewer of a PR. The timers start when the PR is created and
+// stop when it is merged; they only run within each reviewer's working hours.
+type ReviewSLA struct {
+	PullRequestID string
+	Status        PRStatus
+	SLA           time.Duration
+	Reviewers     []ReviewerSLA
+}
+
+// ReviewerSLA is the timer of one reviewer. Reviewers without working hours are timed on the wall clock and have
+// an empty Timezone.
+type ReviewerSLA struct {
+	UserID   string
+	Timezone string
+	Elapsed  time.Duration
+	DueAt    time.Time
+	Overdue  bool
 }
@@ -0,0 +1,18 @@
+package domain
+
+type Role string
+
+const (
+	RoleAdmin    Role = "admin"
+	RoleTeamLead Role = "team-lead"
+	RoleMember   Role = "member"
+)
+
+func IsKnownRole(role Role) bool {
+	switch role {
+	case RoleAdmin, RoleTeamLead, RoleMember:
+		return true
+	default:
+		return false
+	}
+}
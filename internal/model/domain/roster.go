@@ -0,0 +1,51 @@
+package domain
+
+// RosterEntry is one line of a team roster: a user and the team they belong to.
+// Line is the position in the source file and is used in error reports.
+type RosterEntry struct {
+	Line     int
+	TeamName string
+	UserID   string
+	Username string
+	IsActive bool
+}
+
+// Roster is a parsed roster file. Errors holds the lines that could not be parsed.
+type Roster struct {
+	Entries []RosterEntry
+	Errors  []RosterLineError
+}
+
+type RosterAction string
+
+const (
+	RosterActionCreateTeam     RosterAction = "create_team"
+	RosterActionCreateUser     RosterAction = "create_user"
+	RosterActionMoveUser       RosterAction = "move_user"
+	RosterActionRenameUser     RosterAction = "rename_user"
+	RosterActionActivateUser   RosterAction = "activate_user"
+	RosterActionDeactivateUser RosterAction = "deactivate_user"
+)
+
+// RosterChange is a single difference between a roster and the current state. From and To hold the old and new
+// team or username for moves and renames.
+type RosterChange struct {
+	Line     int
+	Action   RosterAction
+	TeamName string
+	UserID   string
+	From     string
+	To       string
+}
+
+type RosterLineError struct {
+	Line    int
+	Message string
+}
+
+// RosterImportResult is the diff of an import. A roster with errors is not applied.
+type RosterImportResult struct {
+	Changes []RosterChange
+	Errors  []RosterLineError
+	Applied bool
+}
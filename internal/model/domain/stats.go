@@ -7,3 +7,28 @@ type ReviewerStats struct {
 	CompletedCount int
 	ActiveCount    int
 }
+
+// Pairing is the number of PRs by an author that a reviewer was assigned to, shadow reviews aside.
+type Pairing struct {
+	AuthorID   string
+	ReviewerID string
+	Count      int
+}
+
+// TeamPairings are the pairings of a team's members as authors, with reviewers from any team.
+type TeamPairings struct {
+	TeamName string
+	Members  []string
+	Pairings []Pairing
+}
+
+// TeamStats are the counts of one team, not including its child teams. PRs are counted by the team of their author.
+type TeamStats struct {
+	TeamID        int64
+	ParentID      int64
+	TeamName      string
+	Members       int
+	ActiveMembers int
+	OpenPRs       int
+	MergedPRs     int
+}
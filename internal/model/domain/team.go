@@ -2,9 +2,51 @@ package domain
 
 import "time"
 
+// MaxTeamDepth limits how deep the team hierarchy may nest.
+const MaxTeamDepth = 10
+
 type Team struct {
-	ID        int64
-	TeamName  string
-	Members   []User
-	CreatedAt time.Time
+	ID         int64
+	TeamName   string
+	ParentID   int64
+	ParentName string
+	Settings   TeamSettings
+	Members    []User
+	CreatedAt  time.Time
+}
+
+// TeamSettings override the organization settings for a team and its subteams. Nil fields are inherited from the
+// nearest ancestor that sets them, then from the organization.
+type TeamSettings struct {
+	ReviewerCount *int  `json:"reviewer_count,omitempty"`
+	RequireSenior *bool `json:"require_senior,omitempty"`
+	// MaxOpenReviews is the default capacity of the team's members; see OrganizationSettings.MaxOpenReviews.
+	MaxOpenReviews *int `json:"max_open_reviews,omitempty"`
+	// ReviewSLAHours is the review deadline of the team's PRs; see OrganizationSettings.ReviewSLAHours.
+	ReviewSLAHours *int `json:"review_sla_hours,omitempty"`
+}
+
+// TeamNode is a team in the hierarchy together with its subteams.
+type TeamNode struct {
+	TeamName      string
+	MembersCount  int
+	ActiveMembers int
+	Children      []TeamNode
+}
+
+// EffectiveTeamSettings are the settings a team works with after inheritance. The *From fields name the team each
+// value comes from; an empty name means the organization default.
+type EffectiveTeamSettings struct {
+	TeamName           string
+	Own                TeamSettings
+	ReviewerCount      int
+	ReviewerCountFrom  string
+	RequireSenior      bool
+	RequireSeniorFrom  string
+	MaxOpenReviews     int
+	MaxOpenReviewsFrom string
+	ReviewSLAHours     int
+	ReviewSLAHoursFrom string
+	Fallback           []FallbackStep
+	FallbackFrom       string
 }
@@ -2,12 +2,64 @@ package domain
 
 import "time"
 
+// TeamRole is the seniority of a user within its team. Leads and seniors can satisfy the require_senior policy;
+// trainees only review as shadow reviewers.
+type TeamRole string
+
+const (
+	TeamRoleLead    TeamRole = "lead"
+	TeamRoleSenior  TeamRole = "senior"
+	TeamRoleMember  TeamRole = "member"
+	TeamRoleTrainee TeamRole = "trainee"
+)
+
+func IsKnownTeamRole(role TeamRole) bool {
+	switch role {
+	case TeamRoleLead, TeamRoleSenior, TeamRoleMember, TeamRoleTrainee:
+		return true
+	default:
+		return false
+	}
+}
+
+// IsSenior reports whether the role satisfies the require_senior policy.
+func (r TeamRole) IsSenior() bool {
+	return r == TeamRoleLead || r == TeamRoleSenior
+}
+
+// Working hours of users without an explicit schedule, in minutes after local midnight.
+const (
+	DefaultWorkStart = 9 * 60
+	DefaultWorkEnd   = 18 * 60
+)
+
 type User struct {
-	ID        int64
-	UserID    string
-	Username  string
-	TeamID    int64
-	TeamName  string
-	IsActive  bool
-	CreatedAt time.Time
+	ID       int64
+	UserID   string
+	Username string
+	TeamID   int64
+	TeamName string
+	// TeamRole is always set on stored users. Left empty on create or update, it defaults to member or keeps the
+	// stored role.
+	TeamRole TeamRole
+	// Timezone is an IANA name such as Europe/Berlin. Empty means no working hours are set: the user counts as always
+	// available and review SLAs run on wall-clock time. Working hours are WorkStart to WorkEnd minutes after local
+	// midnight, Monday to Friday; they are only written by SetWorkingHours.
+	Timezone  string
+	WorkStart int
+	WorkEnd   int
+	// MaxOpenReviews caps the open PRs the user reviews at once; 0 means no limit and nil the team default.
+	MaxOpenReviews *int
+	IsActive       bool
+	CreatedAt      time.Time
+}
+
+// CanReview reports whether the user can be picked as a regular reviewer.
+func (u *User) CanReview() bool {
+	return u.IsActive && u.TeamRole != TeamRoleTrainee
+}
+
+// HasWorkingHours reports whether the user has a timezone and working hours set.
+func (u *User) HasWorkingHours() bool {
+	return u.Timezone != ""
 }
@@ -0,0 +1,21 @@
+package dto
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type AuditEntryResponse struct {
+	ID        int64           `json:"id"`
+	Actor     string          `json:"actor"`
+	Action    string          `json:"action"`
+	Target    string          `json:"target"`
+	Before    json.RawMessage `json:"before,omitempty" swaggertype:"object"`
+	After     json.RawMessage `json:"after,omitempty" swaggertype:"object"`
+	RequestID string          `json:"request_id,omitempty"`
+	CreatedAt time.Time       `json:"created_at"`
+}
+
+type AuditListResponse struct {
+	Entries []AuditEntryResponse `json:"entries"`
+}
@@ -0,0 +1,16 @@
+package dto
+
+import "time"
+
+type CodeOwnersResponse struct {
+	Repository string    `json:"repository"`
+	Mode       string    `json:"mode" enums:"preferred,required"`
+	Content    string    `json:"content"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+type UploadCodeOwnersResponse struct {
+	CodeOwners CodeOwnersResponse `json:"codeowners"`
+	// UnknownOwners match no user or team; their rules select nobody until they are provisioned.
+	UnknownOwners []string `json:"unknown_owners"`
+}
@@ -0,0 +1,33 @@
+package dto
+
+type SetPoolRequest struct {
+	PoolName string   `json:"pool_name" validate:"required"`
+	Members  []string `json:"members" validate:"required,min=1"`
+}
+
+type PoolMember struct {
+	UserID   string `json:"user_id"`
+	Username string `json:"username"`
+	TeamName string `json:"team_name,omitempty"`
+	IsActive bool   `json:"is_active"`
+}
+
+type PoolResponse struct {
+	PoolName string       `json:"pool_name"`
+	Members  []PoolMember `json:"members"`
+}
+
+type ListPoolsResponse struct {
+	Pools []PoolResponse `json:"pools"`
+}
+
+// FallbackStep names either a team or a pool.
+type FallbackStep struct {
+	TeamName string `json:"team_name,omitempty"`
+	PoolName string `json:"pool_name,omitempty"`
+}
+
+type FallbackChain struct {
+	TeamName string         `json:"team_name" validate:"required"`
+	Steps    []FallbackStep `json:"steps"`
+}
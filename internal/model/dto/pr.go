@@ -6,6 +6,11 @@ type CreatePRRequest struct {
 	PullRequestID   string `json:"pull_request_id" validate:"required"`
 	PullRequestName string `json:"pull_request_name" validate:"required"`
 	AuthorID        string `json:"author_id" validate:"required"`
+	// Labels naming a user skill ask for a reviewer with that skill.
+	Labels []string `json:"labels,omitempty"`
+	// Repository and ChangedFiles let CODEOWNERS of the repository pick reviewers.
+	Repository   string   `json:"repository,omitempty"`
+	ChangedFiles []string `json:"changed_files,omitempty"`
 }
 
 type MergePRRequest struct {
@@ -17,18 +22,51 @@ type ReassignRequest struct {
 	OldUserID     string `json:"old_user_id" validate:"required"`
 }
 
+type AddReviewerRequest struct {
+	PullRequestID string `json:"pull_request_id" validate:"required"`
+	UserID        string `json:"user_id" validate:"required"`
+	// Pin keeps the reviewer on the PR through reassignment and deprovisioning.
+	Pin bool `json:"pin,omitempty"`
+}
+
+type RemoveReviewerRequest struct {
+	PullRequestID string `json:"pull_request_id" validate:"required"`
+	UserID        string `json:"user_id" validate:"required"`
+}
+
 type PullRequestResponse struct {
-	PullRequestID     string     `json:"pull_request_id"`
-	PullRequestName   string     `json:"pull_request_name"`
-	AuthorID          string     `json:"author_id"`
-	Status            string     `json:"status"`
-	AssignedReviewers []string   `json:"assigned_reviewers"`
-	CreatedAt         *time.Time `json:"createdAt,omitempty"`
-	MergedAt          *time.Time `json:"mergedAt,omitempty"`
+	PullRequestID     string   `json:"pull_request_id"`
+	PullRequestName   string   `json:"pull_request_name"`
+	AuthorID          string   `json:"author_id"`
+	Status            string   `json:"status"`
+	AssignedReviewers []string `json:"assigned_reviewers"`
+	// ShadowReviewers are trainees following the review; they do not count as reviewers.
+	ShadowReviewers []string `json:"shadow_reviewers,omitempty"`
+	// PinnedReviewers are the assigned reviewers that reassignment never replaces.
+	PinnedReviewers []string   `json:"pinned_reviewers,omitempty"`
+	CreatedAt       *time.Time `json:"createdAt,omitempty"`
+	MergedAt        *time.Time `json:"mergedAt,omitempty"`
+	Labels          []string   `json:"labels,omitempty"`
+	// PendingReviewers is set on open PRs waiting for reviewers because everyone was at capacity.
+	PendingReviewers bool `json:"pending_reviewers,omitempty"`
+	// Selections are returned on creation only.
+	Selections []ReviewerSelectionResponse `json:"selections,omitempty"`
+}
+
+type ReviewerSelectionResponse struct {
+	UserID  string `json:"user_id"`
+	Rule    string `json:"rule" enums:"codeowners,skill,team,fallback,senior"`
+	Pattern string `json:"pattern,omitempty"`
+	Line    int    `json:"line,omitempty"`
+	Skill   string `json:"skill,omitempty"`
+	// Team or Pool is the fallback step the reviewer came from.
+	Team string `json:"team,omitempty"`
+	Pool string `json:"pool,omitempty"`
 }
 
 type CreatePRResponse struct {
-	PR PullRequestResponse `json:"pr"`
+	PR     PullRequestResponse `json:"pr"`
+	DryRun bool                `json:"dry_run,omitempty"`
 }
 
 type MergePRResponse struct {
@@ -38,4 +76,40 @@ type MergePRResponse struct {
 type ReassignResponse struct {
 	PR         PullRequestResponse `json:"pr"`
 	ReplacedBy string              `json:"replaced_by"`
+	DryRun     bool                `json:"dry_run,omitempty"`
+}
+
+type ReviewerResponse struct {
+	PR     PullRequestResponse `json:"pr"`
+	DryRun bool                `json:"dry_run,omitempty"`
+}
+
+type SetLabelsRequest struct {
+	PullRequestID string   `json:"pull_request_id" validate:"required"`
+	Labels        []string `json:"labels"`
+}
+
+type SetLabelsResponse struct {
+	PR PullRequestResponse `json:"pr"`
+}
+
+type ListPRsResponse struct {
+	PullRequests []PullRequestResponse `json:"pull_requests"`
+}
+
+type ReviewSLAResponse struct {
+	PullRequestID string                `json:"pull_request_id"`
+	Status        string                `json:"status"`
+	SLAHours      float64               `json:"sla_hours"`
+	Reviewers     []ReviewerSLAResponse `json:"reviewers"`
+}
+
+// ReviewerSLAResponse is the review timer of one reviewer. Elapsed hours and the deadline count only the reviewer's
+// working hours; reviewers without a timezone are timed on the wall clock.
+type ReviewerSLAResponse struct {
+	UserID       string    `json:"user_id"`
+	Timezone     string    `json:"timezone,omitempty"`
+	ElapsedHours float64   `json:"elapsed_hours"`
+	DueAt        time.Time `json:"due_at"`
+	Overdue      bool      `json:"overdue"`
 }
@@ -0,0 +1,23 @@
+package dto
+
+type RosterChangeResponse struct {
+	Line     int    `json:"line"`
+	Action   string `json:"action"`
+	TeamName string `json:"team_name"`
+	UserID   string `json:"user_id,omitempty"`
+	From     string `json:"from,omitempty"`
+	To       string `json:"to,omitempty"`
+}
+
+type RosterLineErrorResponse struct {
+	Line    int    `json:"line"`
+	Message string `json:"message"`
+}
+
+type ImportRosterResponse struct {
+	Applied bool                      `json:"applied"`
+	DryRun  bool                      `json:"dry_run,omitempty"`
+	Summary map[string]int            `json:"summary"`
+	Changes []RosterChangeResponse    `json:"changes"`
+	Errors  []RosterLineErrorResponse `json:"errors"`
+}
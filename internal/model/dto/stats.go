@@ -20,3 +20,37 @@ type StatsResponse struct {
 	PRsByStatus  []PRStatsItem   `json:"prs_by_status"`
 	TopReviewers []UserStatsItem `json:"top_reviewers"`
 }
+
+type TeamStatsCounts struct {
+	Members       int `json:"members"`
+	ActiveMembers int `json:"active_members"`
+	OpenPRs       int `json:"open_prs"`
+	MergedPRs     int `json:"merged_prs"`
+}
+
+// TeamStatsNode holds the counts of a team itself (own) and of the team with all its descendants (subtree).
+type TeamStatsNode struct {
+	TeamName string          `json:"team_name"`
+	Own      TeamStatsCounts `json:"own"`
+	Subtree  TeamStatsCounts `json:"subtree"`
+	Children []TeamStatsNode `json:"children"`
+}
+
+type TeamStatsResponse struct {
+	Teams []TeamStatsNode `json:"teams"`
+}
+
+// PairingRow counts the PRs by an author each reviewer was assigned to. TopShare is the share of them taken by the
+// most frequent reviewer; values close to 1 point at a knowledge silo.
+type PairingRow struct {
+	AuthorID  string         `json:"author_id"`
+	Reviews   int            `json:"reviews"`
+	TopShare  float64        `json:"top_share"`
+	Reviewers map[string]int `json:"reviewers"`
+}
+
+type PairingsResponse struct {
+	TeamName   string       `json:"team_name"`
+	WindowDays int          `json:"window_days"`
+	Authors    []PairingRow `json:"authors"`
+}
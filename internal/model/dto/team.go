@@ -3,6 +3,8 @@ package dto
 type TeamMember struct {
 	UserID   string `json:"user_id" validate:"required"`
 	Username string `json:"username" validate:"required"`
+	// TeamRole is lead, senior, member or trainee; omitted on /team/add it keeps the user's current role.
+	TeamRole string `json:"team_role,omitempty" enums:"lead,senior,member,trainee"`
 	IsActive bool   `json:"is_active"`
 }
 
@@ -12,10 +14,64 @@ type AddTeamRequest struct {
 }
 
 type TeamResponse struct {
-	TeamName string       `json:"team_name"`
-	Members  []TeamMember `json:"members"`
+	TeamName       string       `json:"team_name"`
+	ParentTeamName string       `json:"parent_team_name,omitempty"`
+	Members        []TeamMember `json:"members"`
 }
 
 type AddTeamResponse struct {
 	Team TeamResponse `json:"team"`
 }
+
+type SetParentRequest struct {
+	TeamName string `json:"team_name" validate:"required"`
+	// ParentTeamName is empty to make the team a root team.
+	ParentTeamName string `json:"parent_team_name"`
+}
+
+type TeamTreeNode struct {
+	TeamName      string         `json:"team_name"`
+	MembersCount  int            `json:"members_count"`
+	ActiveMembers int            `json:"active_members"`
+	Children      []TeamTreeNode `json:"children"`
+}
+
+type TeamTreeResponse struct {
+	Teams []TeamTreeNode `json:"teams"`
+}
+
+// TeamSettings are the settings a team sets itself; omitted fields are inherited from the parent team.
+type TeamSettings struct {
+	ReviewerCount *int  `json:"reviewer_count,omitempty"`
+	RequireSenior *bool `json:"require_senior,omitempty"`
+	// MaxOpenReviews is the default max_open_reviews of the team's members, 0 for no limit.
+	MaxOpenReviews *int `json:"max_open_reviews,omitempty"`
+	// ReviewSLAHours is the review deadline of PRs by the team's members, in working hours.
+	ReviewSLAHours *int `json:"review_sla_hours,omitempty"`
+}
+
+type SetTeamSettingsRequest struct {
+	TeamName string       `json:"team_name" validate:"required"`
+	Settings TeamSettings `json:"settings"`
+}
+
+// EffectiveTeamSettings are the settings in force for a team. The *_from fields name the team a value is
+// inherited from; they are empty for organization defaults.
+type EffectiveTeamSettings struct {
+	ReviewerCount      int            `json:"reviewer_count"`
+	ReviewerCountFrom  string         `json:"reviewer_count_from,omitempty"`
+	RequireSenior      bool           `json:"require_senior"`
+	RequireSeniorFrom  string         `json:"require_senior_from,omitempty"`
+	MaxOpenReviews     int            `json:"max_open_reviews"`
+	MaxOpenReviewsFrom string         `json:"max_open_reviews_from,omitempty"`
+	ReviewSLAHours     int            `json:"review_sla_hours"`
+	ReviewSLAHoursFrom string         `json:"review_sla_hours_from,omitempty"`
+	Fallback           []FallbackStep `json:"fallback"`
+	FallbackFrom       string         `json:"fallback_from,omitempty"`
+}
+
+type TeamSettingsResponse struct {
+	TeamName  string                `json:"team_name"`
+	Own       TeamSettings          `json:"own"`
+	Effective EffectiveTeamSettings `json:"effective"`
+}
@@ -8,6 +8,8 @@ type DeactivateTeamResponse struct {
 	DeactivatedUsers int                   `json:"deactivated_users"`
 	ReassignedPRs    int                   `json:"reassigned_prs"`
 	Users            []DeactivatedUserInfo `json:"users"`
+	PullRequests     []string              `json:"pull_requests"`
+	DryRun           bool                  `json:"dry_run,omitempty"`
 }
 
 type DeactivatedUserInfo struct {
@@ -10,10 +10,54 @@ type UserResponse struct {
 	Username string `json:"username"`
 	TeamName string `json:"team_name"`
 	IsActive bool   `json:"is_active"`
+	TeamRole string `json:"team_role,omitempty"`
+	// Timezone and working hours are only set for users who have them.
+	Timezone  string `json:"timezone,omitempty"`
+	WorkStart string `json:"work_start,omitempty"`
+	WorkEnd   string `json:"work_end,omitempty"`
+	// MaxOpenReviews is only set for users with their own limit.
+	MaxOpenReviews *int `json:"max_open_reviews,omitempty"`
 }
 
 type SetIsActiveResponse struct {
-	User UserResponse `json:"user"`
+	User   UserResponse `json:"user"`
+	DryRun bool         `json:"dry_run,omitempty"`
+}
+
+type SetTeamRoleRequest struct {
+	UserID   string `json:"user_id" validate:"required"`
+	TeamRole string `json:"team_role" validate:"required" enums:"lead,senior,member,trainee"`
+}
+
+type SetTeamRoleResponse struct {
+	User   UserResponse `json:"user"`
+	DryRun bool         `json:"dry_run,omitempty"`
+}
+
+// SetWorkingHoursRequest sets the working hours of a user, Monday to Friday. Times are HH:MM in the user's timezone
+// and default to 09:00-18:00; an empty timezone clears the working hours.
+type SetWorkingHoursRequest struct {
+	UserID    string `json:"user_id" validate:"required"`
+	Timezone  string `json:"timezone" example:"Europe/Berlin"`
+	WorkStart string `json:"work_start,omitempty" example:"09:00"`
+	WorkEnd   string `json:"work_end,omitempty" example:"18:00"`
+}
+
+type SetWorkingHoursResponse struct {
+	User   UserResponse `json:"user"`
+	DryRun bool         `json:"dry_run,omitempty"`
+}
+
+// SetMaxOpenReviewsRequest sets how many open PRs a user reviews at once. Omitted or null falls back to the team
+// default, 0 means no limit.
+type SetMaxOpenReviewsRequest struct {
+	UserID         string `json:"user_id" validate:"required"`
+	MaxOpenReviews *int   `json:"max_open_reviews"`
+}
+
+type SetMaxOpenReviewsResponse struct {
+	User   UserResponse `json:"user"`
+	DryRun bool         `json:"dry_run,omitempty"`
 }
 
 type PullRequestShort struct {
@@ -27,3 +71,13 @@ type GetReviewResponse struct {
 	UserID       string             `json:"user_id"`
 	PullRequests []PullRequestShort `json:"pull_requests"`
 }
+
+type SetSkillsRequest struct {
+	UserID string   `json:"user_id" validate:"required"`
+	Skills []string `json:"skills"`
+}
+
+type UserSkillsResponse struct {
+	UserID string   `json:"user_id"`
+	Skills []string `json:"skills"`
+}
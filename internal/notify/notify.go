@@ -0,0 +1,105 @@
+// Package notify delivers notifications about changes the service makes on its own, such as reviewers assigned to
+// a PR that waited for them, to an external system that forwards them to the users they name.
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/ssokov/pr-reviewer-service/internal/model/domain"
+	"github.com/ssokov/pr-reviewer-service/internal/tenant"
+	"github.com/vmkteam/embedlog"
+)
+
+// EventReviewersAssigned is sent when reviewers are assigned to a PR that was created without them.
+const EventReviewersAssigned = "pull_request.reviewers_assigned"
+
+// Event is the JSON body of a webhook call. Recipients are the users to notify.
+type Event struct {
+	Event             string    `json:"event"`
+	Organization      string    `json:"organization"`
+	Recipients        []string  `json:"recipients"`
+	PullRequestID     string    `json:"pull_request_id"`
+	PullRequestName   string    `json:"pull_request_name"`
+	AuthorID          string    `json:"author_id"`
+	AssignedReviewers []string  `json:"assigned_reviewers"`
+	SentAt            time.Time `json:"sent_at"`
+}
+
+// Webhook posts events as JSON to a URL, e.g. a chat bot.
+type Webhook struct {
+	url    string
+	client *http.Client
+}
+
+func NewWebhook(url string, client *http.Client) *Webhook {
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+	return &Webhook{
+		url:    url,
+		client: client,
+	}
+}
+
+// ReviewersAssigned tells the author of pr who reviews it.
+func (w *Webhook) ReviewersAssigned(ctx context.Context, pr *domain.PullRequest) error {
+	return w.send(ctx, reviewersAssigned(ctx, pr))
+}
+
+func (w *Webhook) send(ctx context.Context, event Event) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send %s: %w", event.Event, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("failed to send %s: unexpected status %d", event.Event, resp.StatusCode)
+	}
+	return nil
+}
+
+// Log writes events to the service log; it is used when no webhook is configured.
+type Log struct {
+	logger embedlog.Logger
+}
+
+func NewLog(logger embedlog.Logger) *Log {
+	return &Log{logger: logger}
+}
+
+func (l *Log) ReviewersAssigned(ctx context.Context, pr *domain.PullRequest) error {
+	event := reviewersAssigned(ctx, pr)
+	l.logger.Print(ctx, "notification", "event", event.Event, "recipients", event.Recipients, "pr_id", event.PullRequestID,
+		"reviewers", event.AssignedReviewers)
+	return nil
+}
+
+func reviewersAssigned(ctx context.Context, pr *domain.PullRequest) Event {
+	return Event{
+		Event:             EventReviewersAssigned,
+		Organization:      tenant.Slug(ctx),
+		Recipients:        []string{pr.AuthorID},
+		PullRequestID:     pr.PullRequestID,
+		PullRequestName:   pr.PullRequestName,
+		AuthorID:          pr.AuthorID,
+		AssignedReviewers: pr.AssignedReviewers,
+		SentAt:            time.Now().UTC(),
+	}
+}
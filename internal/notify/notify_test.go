@@ -0,0 +1,45 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ssokov/pr-reviewer-service/internal/model/domain"
+	"github.com/ssokov/pr-reviewer-service/internal/tenant"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebhook_ReviewersAssigned(t *testing.T) {
+	ctx := tenant.WithOrganization(context.Background(), &domain.Organization{ID: 2, Slug: "acme"})
+	pr := &domain.PullRequest{PullRequestID: "pr-1", PullRequestName: "Fix", AuthorID: "u1", AssignedReviewers: []string{"u2", "u3"}}
+
+	var got Event
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&got))
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	require.NoError(t, NewWebhook(server.URL, nil).ReviewersAssigned(ctx, pr))
+	assert.Equal(t, EventReviewersAssigned, got.Event)
+	assert.Equal(t, "acme", got.Organization)
+	assert.Equal(t, []string{"u1"}, got.Recipients)
+	assert.Equal(t, []string{"u2", "u3"}, got.AssignedReviewers)
+	assert.False(t, got.SentAt.IsZero())
+}
+
+func TestWebhook_ErrorStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	err := NewWebhook(server.URL, nil).ReviewersAssigned(context.Background(), &domain.PullRequest{PullRequestID: "pr-1"})
+	assert.ErrorContains(t, err, "unexpected status 502")
+}
@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/ssokov/pr-reviewer-service/internal/model/domain"
 )
@@ -11,7 +12,11 @@ type UserRepository interface {
 	Update(ctx context.Context, user *domain.User) (*domain.User, error)
 	GetByUserID(ctx context.Context, userID string) (*domain.User, error)
 	GetByTeamID(ctx context.Context, teamID int64) ([]domain.User, error)
+	List(ctx context.Context) ([]domain.User, error)
 	SetIsActive(ctx context.Context, userID string, isActive bool) (*domain.User, error)
+	SetTeamRole(ctx context.Context, userID string, role domain.TeamRole) (*domain.User, error)
+	SetWorkingHours(ctx context.Context, userID, timezone string, workStart, workEnd int) (*domain.User, error)
+	SetMaxOpenReviews(ctx context.Context, userID string, limit *int) (*domain.User, error)
 	GetByReviewerID(ctx context.Context, userID string) ([]domain.PullRequest, error)
 	DeactivateByTeamID(ctx context.Context, teamID int64) ([]domain.User, error)
 }
@@ -20,6 +25,12 @@ type TeamRepository interface {
 	Create(ctx context.Context, team *domain.Team) (*domain.Team, error)
 	GetByName(ctx context.Context, teamName string) (*domain.Team, error)
 	ExistsByName(ctx context.Context, teamName string) (bool, error)
+	List(ctx context.Context) ([]domain.Team, error)
+	// LockHierarchy locks the organization's teams until the surrounding transaction ends.
+	LockHierarchy(ctx context.Context) error
+	SetParent(ctx context.Context, teamID int64, parentID int64) error
+	UpdateSettings(ctx context.Context, teamID int64, settings domain.TeamSettings) error
+	GetAncestors(ctx context.Context, teamID int64) ([]domain.Team, error)
 }
 
 type PRRepository interface {
@@ -28,6 +39,15 @@ type PRRepository interface {
 	GetByPRID(ctx context.Context, prID string) (*domain.PullRequest, error)
 	GetByReviewerID(ctx context.Context, reviewerID string) ([]domain.PullRequest, error)
 	GetOpenPRsByUserIDs(ctx context.Context, userIDs []string) ([]domain.PullRequest, error)
+	List(ctx context.Context, filter domain.PRFilter) ([]domain.PullRequest, error)
+	// GetPairCounts returns how many PRs by authorID created since since each reviewer was assigned to.
+	GetPairCounts(ctx context.Context, authorID string, since time.Time) (map[string]int, error)
+	// GetOpenReviewCounts returns how many open PRs each user reviews; shadow reviews do not count.
+	GetOpenReviewCounts(ctx context.Context, userIDs []string) (map[string]int, error)
+	// GetOpenShadowCounts returns how many open PRs each user shadows.
+	GetOpenShadowCounts(ctx context.Context, userIDs []string) (map[string]int, error)
+	// GetReviewerAssignedAt returns when each reviewer of the PR was assigned; shadow reviewers are left out.
+	GetReviewerAssignedAt(ctx context.Context, prID string) (map[string]time.Time, error)
 }
 
 type StatsRepository interface {
@@ -36,4 +56,69 @@ type StatsRepository interface {
 	GetActiveUsers(ctx context.Context) (int, error)
 	GetPRsByStatus(ctx context.Context) (map[string]int, error)
 	GetTopReviewers(ctx context.Context, limit int) ([]domain.ReviewerStats, error)
+	GetOpenPRsByTeam(ctx context.Context) (map[string]int, error)
+	GetTeamStats(ctx context.Context) ([]domain.TeamStats, error)
+	// GetTeamPairings returns the pairings of PRs created since since whose authors are in the team, or nil when
+	// the team does not exist.
+	GetTeamPairings(ctx context.Context, teamName string, since time.Time) (*domain.TeamPairings, error)
+}
+
+type APIKeyRepository interface {
+	Create(ctx context.Context, key *domain.APIKey, keyHash string) (*domain.APIKey, error)
+	GetByHash(ctx context.Context, keyHash string) (*domain.APIKey, error)
+	List(ctx context.Context) ([]domain.APIKey, error)
+	Revoke(ctx context.Context, prefix string) (*domain.APIKey, error)
+	TouchLastUsed(ctx context.Context, id int64) error
+}
+
+type AuditRepository interface {
+	Create(ctx context.Context, entry *domain.AuditEntry) error
+	List(ctx context.Context, filter domain.AuditFilter) ([]domain.AuditEntry, error)
+}
+
+type OrganizationRepository interface {
+	Create(ctx context.Context, org *domain.Organization) (*domain.Organization, error)
+	GetByID(ctx context.Context, id int64) (*domain.Organization, error)
+	GetBySlug(ctx context.Context, slug string) (*domain.Organization, error)
+	List(ctx context.Context) ([]domain.Organization, error)
+	UpdateSettings(ctx context.Context, slug string, settings domain.OrganizationSettings) (*domain.Organization, error)
+}
+
+type CodeOwnersRepository interface {
+	Upsert(ctx context.Context, file *domain.CodeOwners) (*domain.CodeOwners, error)
+	GetByRepository(ctx context.Context, repository string) (*domain.CodeOwners, error)
+}
+
+// SkillRepository stores user skills and PR labels. Both are lowercase tags; a label naming a skill asks for a
+// reviewer with that skill.
+type SkillRepository interface {
+	SetUserSkills(ctx context.Context, userID string, skills []string) error
+	GetSkillsByUserIDs(ctx context.Context, userIDs []string) (map[string][]string, error)
+	ListSkills(ctx context.Context) ([]string, error)
+	SetPRLabels(ctx context.Context, prID string, labels []string) error
+}
+
+// PoolRepository stores reviewer pools and the fallback chains of teams.
+type PoolRepository interface {
+	// Upsert creates the pool or replaces the members of an existing one.
+	Upsert(ctx context.Context, pool *domain.ReviewerPool) (*domain.ReviewerPool, error)
+	GetByName(ctx context.Context, name string) (*domain.ReviewerPool, error)
+	List(ctx context.Context) ([]domain.ReviewerPool, error)
+	// SetFallback replaces the fallback chain of a team. Steps refer to teams and pools by name.
+	SetFallback(ctx context.Context, teamID int64, steps []domain.FallbackStep) error
+	GetFallback(ctx context.Context, teamID int64) ([]domain.FallbackStep, error)
+}
+
+type HealthRepository interface {
+	Ping(ctx context.Context) error
+	MigrationVersion(ctx context.Context) (version uint, dirty bool, err error)
+}
+
+// Transactor runs a unit of work in one database transaction. Repositories called with the ctx passed to fn
+// take part in it.
+type Transactor interface {
+	// WithinTx commits the transaction when fn returns nil and rolls it back otherwise.
+	WithinTx(ctx context.Context, fn func(ctx context.Context) error) error
+	// WithinRolledBackTx always rolls the transaction back, so fn sees its own writes but nothing is persisted.
+	WithinRolledBackTx(ctx context.Context, fn func(ctx context.Context) error) error
 }
@@ -0,0 +1,150 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/ssokov/pr-reviewer-service/internal/model/db"
+	"github.com/ssokov/pr-reviewer-service/internal/model/domain"
+	"github.com/ssokov/pr-reviewer-service/internal/repository"
+	"github.com/ssokov/pr-reviewer-service/internal/repository/postgres/mappers"
+	"github.com/ssokov/pr-reviewer-service/internal/tenant"
+)
+
+type apiKeyRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewAPIKeyRepository(dbPool *pgxpool.Pool) repository.APIKeyRepository {
+	return &apiKeyRepo{
+		db: dbPool,
+	}
+}
+
+func (r *apiKeyRepo) Create(ctx context.Context, key *domain.APIKey, keyHash string) (*domain.APIKey, error) {
+	query := `
+		INSERT INTO pr_system.api_keys (name, key_prefix, key_hash, scopes, organization_id)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id, organization_id, name, key_prefix, key_hash, scopes, last_used_at, revoked_at, created_at
+	`
+
+	var dbKey db.APIKey
+	err := conn(ctx, r.db).QueryRow(ctx, query, key.Name, key.Prefix, keyHash, mappers.ScopesToStrings(key.Scopes), tenant.OrganizationID(ctx)).Scan(
+		&dbKey.ID,
+		&dbKey.OrganizationID,
+		&dbKey.Name,
+		&dbKey.KeyPrefix,
+		&dbKey.KeyHash,
+		&dbKey.Scopes,
+		&dbKey.LastUsedAt,
+		&dbKey.RevokedAt,
+		&dbKey.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return mappers.APIKeyDBToDomain(&dbKey), nil
+}
+
+func (r *apiKeyRepo) GetByHash(ctx context.Context, keyHash string) (*domain.APIKey, error) {
+	query := `
+		SELECT id, organization_id, name, key_prefix, key_hash, scopes, last_used_at, revoked_at, created_at
+		FROM pr_system.api_keys
+		WHERE key_hash = $1
+	`
+
+	var dbKey db.APIKey
+	err := conn(ctx, r.db).QueryRow(ctx, query, keyHash).Scan(
+		&dbKey.ID,
+		&dbKey.OrganizationID,
+		&dbKey.Name,
+		&dbKey.KeyPrefix,
+		&dbKey.KeyHash,
+		&dbKey.Scopes,
+		&dbKey.LastUsedAt,
+		&dbKey.RevokedAt,
+		&dbKey.CreatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return mappers.APIKeyDBToDomain(&dbKey), nil
+}
+
+func (r *apiKeyRepo) List(ctx context.Context) ([]domain.APIKey, error) {
+	query := `
+		SELECT id, organization_id, name, key_prefix, key_hash, scopes, last_used_at, revoked_at, created_at
+		FROM pr_system.api_keys
+		WHERE organization_id = $1
+		ORDER BY created_at
+	`
+
+	rows, err := conn(ctx, r.db).Query(ctx, query, tenant.OrganizationID(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var keys []domain.APIKey
+	for rows.Next() {
+		var dbKey db.APIKey
+		if err := rows.Scan(
+			&dbKey.ID,
+			&dbKey.OrganizationID,
+			&dbKey.Name,
+			&dbKey.KeyPrefix,
+			&dbKey.KeyHash,
+			&dbKey.Scopes,
+			&dbKey.LastUsedAt,
+			&dbKey.RevokedAt,
+			&dbKey.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		keys = append(keys, *mappers.APIKeyDBToDomain(&dbKey))
+	}
+
+	return keys, rows.Err()
+}
+
+func (r *apiKeyRepo) Revoke(ctx context.Context, prefix string) (*domain.APIKey, error) {
+	query := `
+		UPDATE pr_system.api_keys
+		SET revoked_at = COALESCE(revoked_at, NOW())
+		WHERE key_prefix = $1 AND organization_id = $2
+		RETURNING id, organization_id, name, key_prefix, key_hash, scopes, last_used_at, revoked_at, created_at
+	`
+
+	var dbKey db.APIKey
+	err := conn(ctx, r.db).QueryRow(ctx, query, prefix, tenant.OrganizationID(ctx)).Scan(
+		&dbKey.ID,
+		&dbKey.OrganizationID,
+		&dbKey.Name,
+		&dbKey.KeyPrefix,
+		&dbKey.KeyHash,
+		&dbKey.Scopes,
+		&dbKey.LastUsedAt,
+		&dbKey.RevokedAt,
+		&dbKey.CreatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return mappers.APIKeyDBToDomain(&dbKey), nil
+}
+
+func (r *apiKeyRepo) TouchLastUsed(ctx context.Context, id int64) error {
+	_, err := conn(ctx, r.db).Exec(ctx, `UPDATE pr_system.api_keys SET last_used_at = NOW() WHERE id = $1`, id)
+	return err
+}
@@ -0,0 +1,73 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/ssokov/pr-reviewer-service/internal/model/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func cleanupAPIKeys(t *testing.T, pool *pgxpool.Pool) {
+	ctx := context.Background()
+	_, err := pool.Exec(ctx, "TRUNCATE TABLE pr_system.api_keys CASCADE")
+	require.NoError(t, err)
+}
+
+func TestAPIKeyRepo_CreateAndGetByHash(t *testing.T) {
+	pool := setupTestDB(t)
+	repo := NewAPIKeyRepository(pool)
+	cleanupAPIKeys(t, pool)
+
+	ctx := context.Background()
+
+	t.Run("create and find key", func(t *testing.T) {
+		key := &domain.APIKey{
+			Name:   "ci",
+			Prefix: "ab12cd34",
+			Scopes: []domain.Scope{domain.ScopePRWrite},
+		}
+
+		created, err := repo.Create(ctx, key, "hash-1")
+		require.NoError(t, err)
+		assert.NotZero(t, created.ID)
+		assert.Equal(t, []domain.Scope{domain.ScopePRWrite}, created.Scopes)
+
+		found, err := repo.GetByHash(ctx, "hash-1")
+		require.NoError(t, err)
+		require.NotNil(t, found)
+		assert.Equal(t, "ab12cd34", found.Prefix)
+	})
+
+	t.Run("unknown hash", func(t *testing.T) {
+		found, err := repo.GetByHash(ctx, "missing")
+		require.NoError(t, err)
+		assert.Nil(t, found)
+	})
+}
+
+func TestAPIKeyRepo_Revoke(t *testing.T) {
+	pool := setupTestDB(t)
+	repo := NewAPIKeyRepository(pool)
+	cleanupAPIKeys(t, pool)
+
+	ctx := context.Background()
+
+	_, err := repo.Create(ctx, &domain.APIKey{Name: "ops", Prefix: "ff00ff00"}, "hash-2")
+	require.NoError(t, err)
+
+	revoked, err := repo.Revoke(ctx, "ff00ff00")
+	require.NoError(t, err)
+	require.NotNil(t, revoked)
+	assert.True(t, revoked.IsRevoked())
+
+	missing, err := repo.Revoke(ctx, "00000000")
+	require.NoError(t, err)
+	assert.Nil(t, missing)
+
+	keys, err := repo.List(ctx)
+	require.NoError(t, err)
+	assert.Len(t, keys, 1)
+}
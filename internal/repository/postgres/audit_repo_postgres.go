@@ -0,0 +1,125 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/ssokov/pr-reviewer-service/internal/model/db"
+	"github.com/ssokov/pr-reviewer-service/internal/model/domain"
+	"github.com/ssokov/pr-reviewer-service/internal/repository"
+	"github.com/ssokov/pr-reviewer-service/internal/repository/postgres/mappers"
+	"github.com/ssokov/pr-reviewer-service/internal/tenant"
+)
+
+type auditRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewAuditRepository(dbPool *pgxpool.Pool) repository.AuditRepository {
+	return &auditRepo{
+		db: dbPool,
+	}
+}
+
+func (r *auditRepo) Create(ctx context.Context, entry *domain.AuditEntry) error {
+	query := `
+		INSERT INTO pr_system.audit_log (actor, action, target, before, after, request_id, organization_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`
+
+	_, err := conn(ctx, r.db).Exec(ctx, query,
+		entry.Actor,
+		string(entry.Action),
+		entry.Target,
+		nullJSON(entry.Before),
+		nullJSON(entry.After),
+		nullString(entry.RequestID),
+		tenant.OrganizationID(ctx),
+	)
+	return err
+}
+
+func (r *auditRepo) List(ctx context.Context, filter domain.AuditFilter) ([]domain.AuditEntry, error) {
+	var (
+		conditions []string
+		args       []any
+	)
+	addCondition := func(cond string, arg any) {
+		args = append(args, arg)
+		conditions = append(conditions, fmt.Sprintf(cond, len(args)))
+	}
+
+	addCondition("organization_id = $%d", tenant.OrganizationID(ctx))
+	if filter.Actor != "" {
+		addCondition("actor = $%d", filter.Actor)
+	}
+	if filter.Action != "" {
+		addCondition("action = $%d", string(filter.Action))
+	}
+	if filter.Target != "" {
+		addCondition("target = $%d", filter.Target)
+	}
+	if filter.From != nil {
+		addCondition("created_at >= $%d", *filter.From)
+	}
+	if filter.To != nil {
+		addCondition("created_at < $%d", *filter.To)
+	}
+
+	query := `
+		SELECT id, actor, action, target, before, after, request_id, created_at
+		FROM pr_system.audit_log
+	`
+	query += " WHERE " + strings.Join(conditions, " AND ")
+	query += " ORDER BY created_at DESC, id DESC"
+	if filter.Limit > 0 {
+		args = append(args, filter.Limit)
+		query += fmt.Sprintf(" LIMIT $%d", len(args))
+	}
+	if filter.Offset > 0 {
+		args = append(args, filter.Offset)
+		query += fmt.Sprintf(" OFFSET $%d", len(args))
+	}
+
+	rows, err := conn(ctx, r.db).Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var entries []domain.AuditEntry
+	for rows.Next() {
+		var dbEntry db.AuditEntry
+		if err := rows.Scan(
+			&dbEntry.ID,
+			&dbEntry.Actor,
+			&dbEntry.Action,
+			&dbEntry.Target,
+			&dbEntry.Before,
+			&dbEntry.After,
+			&dbEntry.RequestID,
+			&dbEntry.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		entries = append(entries, *mappers.AuditEntryDBToDomain(&dbEntry))
+	}
+
+	return entries, rows.Err()
+}
+
+func nullJSON(val []byte) any {
+	if len(val) == 0 {
+		return nil
+	}
+	return string(val)
+}
+
+func nullString(val string) *string {
+	if val == "" {
+		return nil
+	}
+	return &val
+}
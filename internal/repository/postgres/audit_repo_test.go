@@ -0,0 +1,68 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/ssokov/pr-reviewer-service/internal/model/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func cleanupAuditLog(t *testing.T, pool *pgxpool.Pool) {
+	ctx := context.Background()
+	_, err := pool.Exec(ctx, "TRUNCATE TABLE pr_system.audit_log")
+	require.NoError(t, err)
+}
+
+func TestAuditRepo_CreateAndList(t *testing.T) {
+	pool := setupTestDB(t)
+	repo := NewAuditRepository(pool)
+	cleanupAuditLog(t, pool)
+
+	ctx := context.Background()
+
+	require.NoError(t, repo.Create(ctx, &domain.AuditEntry{
+		Actor:     "apikey:ab12cd34",
+		Action:    domain.AuditActionTeamDeactivate,
+		Target:    "backend",
+		After:     json.RawMessage(`{"users":2}`),
+		RequestID: "req-1",
+	}))
+	require.NoError(t, repo.Create(ctx, &domain.AuditEntry{
+		Actor:  "user:u1",
+		Action: domain.AuditActionUserSetActive,
+		Target: "u2",
+	}))
+
+	t.Run("list all", func(t *testing.T) {
+		entries, err := repo.List(ctx, domain.AuditFilter{})
+		require.NoError(t, err)
+		assert.Len(t, entries, 2)
+	})
+
+	t.Run("filter by action", func(t *testing.T) {
+		entries, err := repo.List(ctx, domain.AuditFilter{Action: domain.AuditActionTeamDeactivate})
+		require.NoError(t, err)
+		require.Len(t, entries, 1)
+		assert.Equal(t, "backend", entries[0].Target)
+		assert.Equal(t, "req-1", entries[0].RequestID)
+		assert.JSONEq(t, `{"users":2}`, string(entries[0].After))
+	})
+
+	t.Run("filter by time range", func(t *testing.T) {
+		future := time.Now().Add(time.Hour)
+		entries, err := repo.List(ctx, domain.AuditFilter{From: &future})
+		require.NoError(t, err)
+		assert.Empty(t, entries)
+	})
+
+	t.Run("limit", func(t *testing.T) {
+		entries, err := repo.List(ctx, domain.AuditFilter{Limit: 1})
+		require.NoError(t, err)
+		assert.Len(t, entries, 1)
+	})
+}
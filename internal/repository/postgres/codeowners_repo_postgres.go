@@ -0,0 +1,73 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/ssokov/pr-reviewer-service/internal/model/db"
+	"github.com/ssokov/pr-reviewer-service/internal/model/domain"
+	"github.com/ssokov/pr-reviewer-service/internal/repository"
+	"github.com/ssokov/pr-reviewer-service/internal/repository/postgres/mappers"
+	"github.com/ssokov/pr-reviewer-service/internal/tenant"
+)
+
+type codeOwnersRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewCodeOwnersRepository(dbPool *pgxpool.Pool) repository.CodeOwnersRepository {
+	return &codeOwnersRepo{
+		db: dbPool,
+	}
+}
+
+func (r *codeOwnersRepo) Upsert(ctx context.Context, file *domain.CodeOwners) (*domain.CodeOwners, error) {
+	query := `
+		INSERT INTO pr_system.codeowners (organization_id, repository, mode, content)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (organization_id, repository)
+		DO UPDATE SET mode = EXCLUDED.mode, content = EXCLUDED.content, updated_at = NOW()
+		RETURNING organization_id, repository, mode, content, updated_at
+	`
+
+	var dbFile db.CodeOwners
+	err := conn(ctx, r.db).QueryRow(ctx, query, tenant.OrganizationID(ctx), file.Repository, string(file.Mode), file.Content).Scan(
+		&dbFile.OrganizationID,
+		&dbFile.Repository,
+		&dbFile.Mode,
+		&dbFile.Content,
+		&dbFile.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return mappers.CodeOwnersDBToDomain(&dbFile), nil
+}
+
+func (r *codeOwnersRepo) GetByRepository(ctx context.Context, repository string) (*domain.CodeOwners, error) {
+	query := `
+		SELECT organization_id, repository, mode, content, updated_at
+		FROM pr_system.codeowners
+		WHERE organization_id = $1 AND repository = $2
+	`
+
+	var dbFile db.CodeOwners
+	err := conn(ctx, r.db).QueryRow(ctx, query, tenant.OrganizationID(ctx), repository).Scan(
+		&dbFile.OrganizationID,
+		&dbFile.Repository,
+		&dbFile.Mode,
+		&dbFile.Content,
+		&dbFile.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return mappers.CodeOwnersDBToDomain(&dbFile), nil
+}
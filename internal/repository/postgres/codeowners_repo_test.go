@@ -0,0 +1,46 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/ssokov/pr-reviewer-service/internal/model/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func cleanupCodeOwners(t *testing.T, pool *pgxpool.Pool) {
+	ctx := context.Background()
+	_, err := pool.Exec(ctx, "TRUNCATE TABLE pr_system.codeowners")
+	require.NoError(t, err)
+}
+
+func TestCodeOwnersRepo_UpsertAndGet(t *testing.T) {
+	pool := setupTestDB(t)
+	repo := NewCodeOwnersRepository(pool)
+	cleanupCodeOwners(t, pool)
+
+	ctx := context.Background()
+
+	t.Run("upsert replaces the file", func(t *testing.T) {
+		_, err := repo.Upsert(ctx, &domain.CodeOwners{Repository: "acme/api", Mode: domain.CodeOwnersPreferred, Content: "* @u1\n"})
+		require.NoError(t, err)
+
+		updated, err := repo.Upsert(ctx, &domain.CodeOwners{Repository: "acme/api", Mode: domain.CodeOwnersRequired, Content: "* @u2\n"})
+		require.NoError(t, err)
+		assert.Equal(t, domain.CodeOwnersRequired, updated.Mode)
+
+		found, err := repo.GetByRepository(ctx, "acme/api")
+		require.NoError(t, err)
+		require.NotNil(t, found)
+		assert.Equal(t, "* @u2\n", found.Content)
+		assert.False(t, found.UpdatedAt.IsZero())
+	})
+
+	t.Run("unknown repository", func(t *testing.T) {
+		found, err := repo.GetByRepository(ctx, "acme/ghost")
+		require.NoError(t, err)
+		assert.Nil(t, found)
+	})
+}
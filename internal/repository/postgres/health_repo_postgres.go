@@ -0,0 +1,33 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/ssokov/pr-reviewer-service/internal/repository"
+)
+
+type healthRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewHealthRepository(dbPool *pgxpool.Pool) repository.HealthRepository {
+	return &healthRepo{
+		db: dbPool,
+	}
+}
+
+func (r *healthRepo) Ping(ctx context.Context) error {
+	return r.db.Ping(ctx)
+}
+
+// MigrationVersion reads the state golang-migrate keeps in schema_migrations.
+func (r *healthRepo) MigrationVersion(ctx context.Context) (uint, bool, error) {
+	var version int64
+	var dirty bool
+	err := r.db.QueryRow(ctx, `SELECT version, dirty FROM schema_migrations LIMIT 1`).Scan(&version, &dirty)
+	if err != nil {
+		return 0, false, err
+	}
+	return uint(version), dirty, nil
+}
@@ -0,0 +1,31 @@
+package mappers
+
+import (
+	"github.com/ssokov/pr-reviewer-service/internal/model/db"
+	"github.com/ssokov/pr-reviewer-service/internal/model/domain"
+)
+
+func APIKeyDBToDomain(dbKey *db.APIKey) *domain.APIKey {
+	scopes := make([]domain.Scope, len(dbKey.Scopes))
+	for i, s := range dbKey.Scopes {
+		scopes[i] = domain.Scope(s)
+	}
+	return &domain.APIKey{
+		ID:             dbKey.ID,
+		OrganizationID: dbKey.OrganizationID,
+		Name:           dbKey.Name,
+		Prefix:         dbKey.KeyPrefix,
+		Scopes:         scopes,
+		LastUsedAt:     dbKey.LastUsedAt,
+		RevokedAt:      dbKey.RevokedAt,
+		CreatedAt:      dbKey.CreatedAt,
+	}
+}
+
+func ScopesToStrings(scopes []domain.Scope) []string {
+	result := make([]string, len(scopes))
+	for i, s := range scopes {
+		result[i] = string(s)
+	}
+	return result
+}
@@ -0,0 +1,39 @@
+package mappers
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ssokov/pr-reviewer-service/internal/model/db"
+	"github.com/ssokov/pr-reviewer-service/internal/model/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAPIKeyDBToDomain(t *testing.T) {
+	now := time.Now()
+	dbKey := &db.APIKey{
+		ID:             1,
+		OrganizationID: 2,
+		Name:           "ci",
+		KeyPrefix:      "ab12cd34",
+		KeyHash:        "hash",
+		Scopes:         []string{"pr:write", "stats:read"},
+		CreatedAt:      now,
+	}
+
+	result := APIKeyDBToDomain(dbKey)
+
+	assert.Equal(t, int64(1), result.ID)
+	assert.Equal(t, int64(2), result.OrganizationID)
+	assert.Equal(t, "ci", result.Name)
+	assert.Equal(t, "ab12cd34", result.Prefix)
+	assert.Equal(t, []domain.Scope{domain.ScopePRWrite, domain.ScopeStatsRead}, result.Scopes)
+	assert.False(t, result.IsRevoked())
+	assert.Equal(t, now, result.CreatedAt)
+}
+
+func TestScopesToStrings(t *testing.T) {
+	result := ScopesToStrings([]domain.Scope{domain.ScopeTeamAdmin, domain.ScopeUserWrite})
+
+	assert.Equal(t, []string{"team:admin", "user:write"}, result)
+}
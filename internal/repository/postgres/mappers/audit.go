@@ -0,0 +1,23 @@
+package mappers
+
+import (
+	"github.com/ssokov/pr-reviewer-service/internal/model/db"
+	"github.com/ssokov/pr-reviewer-service/internal/model/domain"
+)
+
+func AuditEntryDBToDomain(dbEntry *db.AuditEntry) *domain.AuditEntry {
+	requestID := ""
+	if dbEntry.RequestID != nil {
+		requestID = *dbEntry.RequestID
+	}
+	return &domain.AuditEntry{
+		ID:        dbEntry.ID,
+		Actor:     dbEntry.Actor,
+		Action:    domain.AuditAction(dbEntry.Action),
+		Target:    dbEntry.Target,
+		Before:    dbEntry.Before,
+		After:     dbEntry.After,
+		RequestID: requestID,
+		CreatedAt: dbEntry.CreatedAt,
+	}
+}
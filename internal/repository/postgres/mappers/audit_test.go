@@ -0,0 +1,41 @@
+package mappers
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/ssokov/pr-reviewer-service/internal/model/db"
+	"github.com/ssokov/pr-reviewer-service/internal/model/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuditEntryDBToDomain(t *testing.T) {
+	now := time.Now()
+	requestID := "req-1"
+	dbEntry := &db.AuditEntry{
+		ID:        1,
+		Actor:     "apikey:ab12cd34",
+		Action:    "team.deactivate",
+		Target:    "backend",
+		After:     []byte(`{"users":2}`),
+		RequestID: &requestID,
+		CreatedAt: now,
+	}
+
+	result := AuditEntryDBToDomain(dbEntry)
+
+	assert.Equal(t, int64(1), result.ID)
+	assert.Equal(t, domain.AuditActionTeamDeactivate, result.Action)
+	assert.Equal(t, "backend", result.Target)
+	assert.Nil(t, result.Before)
+	assert.Equal(t, json.RawMessage(`{"users":2}`), result.After)
+	assert.Equal(t, "req-1", result.RequestID)
+	assert.Equal(t, now, result.CreatedAt)
+}
+
+func TestAuditEntryDBToDomain_NoRequestID(t *testing.T) {
+	result := AuditEntryDBToDomain(&db.AuditEntry{Action: "pr.merge"})
+
+	assert.Equal(t, "", result.RequestID)
+}
@@ -0,0 +1,15 @@
+package mappers
+
+import (
+	"github.com/ssokov/pr-reviewer-service/internal/model/db"
+	"github.com/ssokov/pr-reviewer-service/internal/model/domain"
+)
+
+func CodeOwnersDBToDomain(dbFile *db.CodeOwners) *domain.CodeOwners {
+	return &domain.CodeOwners{
+		Repository: dbFile.Repository,
+		Mode:       domain.CodeOwnersMode(dbFile.Mode),
+		Content:    dbFile.Content,
+		UpdatedAt:  dbFile.UpdatedAt,
+	}
+}
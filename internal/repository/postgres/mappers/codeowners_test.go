@@ -0,0 +1,28 @@
+package mappers
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ssokov/pr-reviewer-service/internal/model/db"
+	"github.com/ssokov/pr-reviewer-service/internal/model/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCodeOwnersDBToDomain(t *testing.T) {
+	now := time.Now()
+	dbFile := &db.CodeOwners{
+		OrganizationID: 1,
+		Repository:     "acme/api",
+		Mode:           "required",
+		Content:        "* @u1\n",
+		UpdatedAt:      now,
+	}
+
+	result := CodeOwnersDBToDomain(dbFile)
+
+	assert.Equal(t, "acme/api", result.Repository)
+	assert.Equal(t, domain.CodeOwnersRequired, result.Mode)
+	assert.Equal(t, "* @u1\n", result.Content)
+	assert.Equal(t, now, result.UpdatedAt)
+}
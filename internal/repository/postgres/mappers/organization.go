@@ -0,0 +1,24 @@
+package mappers
+
+import (
+	"encoding/json"
+
+	"github.com/ssokov/pr-reviewer-service/internal/model/db"
+	"github.com/ssokov/pr-reviewer-service/internal/model/domain"
+)
+
+func OrganizationDBToDomain(dbOrg *db.Organization) (*domain.Organization, error) {
+	var settings domain.OrganizationSettings
+	if len(dbOrg.Settings) > 0 {
+		if err := json.Unmarshal(dbOrg.Settings, &settings); err != nil {
+			return nil, err
+		}
+	}
+	return &domain.Organization{
+		ID:        dbOrg.ID,
+		Slug:      dbOrg.Slug,
+		Name:      dbOrg.Name,
+		Settings:  settings,
+		CreatedAt: dbOrg.CreatedAt,
+	}, nil
+}
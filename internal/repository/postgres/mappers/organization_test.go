@@ -0,0 +1,44 @@
+package mappers
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ssokov/pr-reviewer-service/internal/model/db"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOrganizationDBToDomain(t *testing.T) {
+	now := time.Now()
+	dbOrg := &db.Organization{
+		ID:        2,
+		Slug:      "acme",
+		Name:      "Acme",
+		Settings:  []byte(`{"reviewer_count":2,"top_reviewers_limit":5}`),
+		CreatedAt: now,
+	}
+
+	result, err := OrganizationDBToDomain(dbOrg)
+	require.NoError(t, err)
+
+	assert.Equal(t, int64(2), result.ID)
+	assert.Equal(t, "acme", result.Slug)
+	assert.Equal(t, "Acme", result.Name)
+	assert.Equal(t, 2, result.Settings.ReviewerCount)
+	assert.Equal(t, 5, result.Settings.TopReviewersLimit)
+	assert.Equal(t, now, result.CreatedAt)
+}
+
+func TestOrganizationDBToDomain_EmptySettings(t *testing.T) {
+	result, err := OrganizationDBToDomain(&db.Organization{Slug: "default", Settings: []byte(`{}`)})
+	require.NoError(t, err)
+
+	assert.Zero(t, result.Settings.ReviewerCount)
+	assert.Equal(t, 10, result.Settings.TopReviewers())
+}
+
+func TestOrganizationDBToDomain_InvalidSettings(t *testing.T) {
+	_, err := OrganizationDBToDomain(&db.Organization{Settings: []byte(`not json`)})
+	assert.Error(t, err)
+}
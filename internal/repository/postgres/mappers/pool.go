@@ -0,0 +1,15 @@
+package mappers
+
+import (
+	"github.com/ssokov/pr-reviewer-service/internal/model/db"
+	"github.com/ssokov/pr-reviewer-service/internal/model/domain"
+)
+
+func PoolDBToDomain(dbPool *db.ReviewerPool, members []domain.User) *domain.ReviewerPool {
+	return &domain.ReviewerPool{
+		ID:        dbPool.ID,
+		Name:      dbPool.Name,
+		Members:   members,
+		CreatedAt: dbPool.CreatedAt,
+	}
+}
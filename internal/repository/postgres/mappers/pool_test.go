@@ -0,0 +1,23 @@
+package mappers
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ssokov/pr-reviewer-service/internal/model/db"
+	"github.com/ssokov/pr-reviewer-service/internal/model/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPoolDBToDomain(t *testing.T) {
+	now := time.Now()
+	dbPool := &db.ReviewerPool{ID: 3, OrganizationID: 1, Name: "platform-oncall", CreatedAt: now}
+	members := []domain.User{{UserID: "u1", IsActive: true}}
+
+	result := PoolDBToDomain(dbPool, members)
+
+	assert.Equal(t, int64(3), result.ID)
+	assert.Equal(t, "platform-oncall", result.Name)
+	assert.Equal(t, members, result.Members)
+	assert.Equal(t, now, result.CreatedAt)
+}
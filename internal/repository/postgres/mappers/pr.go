@@ -15,18 +15,20 @@ func PRDBToDomain(dbPR *db.PullRequest, authorUserID string, status domain.PRSta
 		AssignedReviewers: reviewers,
 		CreatedAt:         dbPR.CreatedAt,
 		MergedAt:          dbPR.MergedAt,
+		PendingReviewers:  dbPR.PendingReviewers,
 	}
 }
 
 func PRDomainToDB(domainPR *domain.PullRequest, authorInternalID int64, statusID int) *db.PullRequest {
 	return &db.PullRequest{
-		ID:              domainPR.ID,
-		PullRequestID:   domainPR.PullRequestID,
-		PullRequestName: domainPR.PullRequestName,
-		AuthorID:        authorInternalID,
-		StatusID:        statusID,
-		CreatedAt:       domainPR.CreatedAt,
-		MergedAt:        domainPR.MergedAt,
+		ID:               domainPR.ID,
+		PullRequestID:    domainPR.PullRequestID,
+		PullRequestName:  domainPR.PullRequestName,
+		AuthorID:         authorInternalID,
+		StatusID:         statusID,
+		CreatedAt:        domainPR.CreatedAt,
+		MergedAt:         domainPR.MergedAt,
+		PendingReviewers: domainPR.PendingReviewers,
 	}
 }
 
@@ -6,18 +6,29 @@ import (
 )
 
 func TeamDBToDomain(dbTeam *db.Team, members []domain.User) *domain.Team {
-	return &domain.Team{
+	team := &domain.Team{
 		ID:        dbTeam.ID,
 		TeamName:  dbTeam.TeamName,
 		Members:   members,
 		CreatedAt: dbTeam.CreatedAt,
 	}
+	if dbTeam.ParentID != nil {
+		team.ParentID = *dbTeam.ParentID
+	}
+	if dbTeam.ParentName != nil {
+		team.ParentName = *dbTeam.ParentName
+	}
+	return team
 }
 
 func TeamDomainToDB(domainTeam *domain.Team) *db.Team {
-	return &db.Team{
+	dbTeam := &db.Team{
 		ID:        domainTeam.ID,
 		TeamName:  domainTeam.TeamName,
 		CreatedAt: domainTeam.CreatedAt,
 	}
+	if domainTeam.ParentID != 0 {
+		dbTeam.ParentID = &domainTeam.ParentID
+	}
+	return dbTeam
 }
@@ -49,6 +49,17 @@ func TestTeamDBToDomain_EmptyMembers(t *testing.T) {
 	assert.Empty(t, result.Members)
 }
 
+func TestTeamDBToDomain_Parent(t *testing.T) {
+	parentID := int64(7)
+	parentName := "Engineering"
+	dbTeam := &db.Team{ID: 3, TeamName: "Payments", ParentID: &parentID, ParentName: &parentName}
+
+	result := TeamDBToDomain(dbTeam, nil)
+
+	assert.Equal(t, int64(7), result.ParentID)
+	assert.Equal(t, "Engineering", result.ParentName)
+}
+
 func TestTeamDomainToDB(t *testing.T) {
 	now := time.Now()
 	domainTeam := &domain.Team{
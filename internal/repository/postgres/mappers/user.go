@@ -7,23 +7,33 @@ import (
 
 func UserDBToDomain(dbUser *db.User, teamName string) *domain.User {
 	return &domain.User{
-		ID:        dbUser.ID,
-		UserID:    dbUser.UserID,
-		Username:  dbUser.Username,
-		TeamID:    dbUser.TeamID,
-		TeamName:  teamName,
-		IsActive:  dbUser.IsActive,
-		CreatedAt: dbUser.CreatedAt,
+		ID:             dbUser.ID,
+		UserID:         dbUser.UserID,
+		Username:       dbUser.Username,
+		TeamID:         dbUser.TeamID,
+		TeamName:       teamName,
+		TeamRole:       domain.TeamRole(dbUser.TeamRole),
+		Timezone:       dbUser.Timezone,
+		WorkStart:      dbUser.WorkStart,
+		WorkEnd:        dbUser.WorkEnd,
+		MaxOpenReviews: dbUser.MaxOpenReviews,
+		IsActive:       dbUser.IsActive,
+		CreatedAt:      dbUser.CreatedAt,
 	}
 }
 
 func UserDomainToDB(domainUser *domain.User) *db.User {
 	return &db.User{
-		ID:        domainUser.ID,
-		UserID:    domainUser.UserID,
-		Username:  domainUser.Username,
-		TeamID:    domainUser.TeamID,
-		IsActive:  domainUser.IsActive,
-		CreatedAt: domainUser.CreatedAt,
+		ID:             domainUser.ID,
+		UserID:         domainUser.UserID,
+		Username:       domainUser.Username,
+		TeamID:         domainUser.TeamID,
+		TeamRole:       string(domainUser.TeamRole),
+		Timezone:       domainUser.Timezone,
+		WorkStart:      domainUser.WorkStart,
+		WorkEnd:        domainUser.WorkEnd,
+		MaxOpenReviews: domainUser.MaxOpenReviews,
+		IsActive:       domainUser.IsActive,
+		CreatedAt:      domainUser.CreatedAt,
 	}
 }
@@ -0,0 +1,129 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/ssokov/pr-reviewer-service/internal/model/db"
+	"github.com/ssokov/pr-reviewer-service/internal/model/domain"
+	"github.com/ssokov/pr-reviewer-service/internal/repository"
+	"github.com/ssokov/pr-reviewer-service/internal/repository/postgres/mappers"
+)
+
+type organizationRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewOrganizationRepository(dbPool *pgxpool.Pool) repository.OrganizationRepository {
+	return &organizationRepo{
+		db: dbPool,
+	}
+}
+
+func (r *organizationRepo) Create(ctx context.Context, org *domain.Organization) (*domain.Organization, error) {
+	settings, err := json.Marshal(org.Settings)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `
+		INSERT INTO pr_system.organizations (slug, name, settings)
+		VALUES ($1, $2, $3)
+		RETURNING id, slug, name, settings, created_at
+	`
+
+	var dbOrg db.Organization
+	err = conn(ctx, r.db).QueryRow(ctx, query, org.Slug, org.Name, string(settings)).Scan(
+		&dbOrg.ID,
+		&dbOrg.Slug,
+		&dbOrg.Name,
+		&dbOrg.Settings,
+		&dbOrg.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return mappers.OrganizationDBToDomain(&dbOrg)
+}
+
+func (r *organizationRepo) GetByID(ctx context.Context, id int64) (*domain.Organization, error) {
+	return r.getOne(ctx, `
+		SELECT id, slug, name, settings, created_at
+		FROM pr_system.organizations
+		WHERE id = $1
+	`, id)
+}
+
+func (r *organizationRepo) GetBySlug(ctx context.Context, slug string) (*domain.Organization, error) {
+	return r.getOne(ctx, `
+		SELECT id, slug, name, settings, created_at
+		FROM pr_system.organizations
+		WHERE slug = $1
+	`, slug)
+}
+
+func (r *organizationRepo) List(ctx context.Context) ([]domain.Organization, error) {
+	query := `
+		SELECT id, slug, name, settings, created_at
+		FROM pr_system.organizations
+		ORDER BY id
+	`
+
+	rows, err := conn(ctx, r.db).Query(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var orgs []domain.Organization
+	for rows.Next() {
+		var dbOrg db.Organization
+		if err := rows.Scan(&dbOrg.ID, &dbOrg.Slug, &dbOrg.Name, &dbOrg.Settings, &dbOrg.CreatedAt); err != nil {
+			return nil, err
+		}
+		org, err := mappers.OrganizationDBToDomain(&dbOrg)
+		if err != nil {
+			return nil, err
+		}
+		orgs = append(orgs, *org)
+	}
+
+	return orgs, rows.Err()
+}
+
+func (r *organizationRepo) UpdateSettings(ctx context.Context, slug string, settings domain.OrganizationSettings) (*domain.Organization, error) {
+	raw, err := json.Marshal(settings)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.getOne(ctx, `
+		UPDATE pr_system.organizations
+		SET settings = $2
+		WHERE slug = $1
+		RETURNING id, slug, name, settings, created_at
+	`, slug, string(raw))
+}
+
+func (r *organizationRepo) getOne(ctx context.Context, query string, args ...any) (*domain.Organization, error) {
+	var dbOrg db.Organization
+	err := conn(ctx, r.db).QueryRow(ctx, query, args...).Scan(
+		&dbOrg.ID,
+		&dbOrg.Slug,
+		&dbOrg.Name,
+		&dbOrg.Settings,
+		&dbOrg.CreatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return mappers.OrganizationDBToDomain(&dbOrg)
+}
@@ -0,0 +1,102 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/ssokov/pr-reviewer-service/internal/model/domain"
+	"github.com/ssokov/pr-reviewer-service/internal/tenant"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// cleanupOrganizations removes every organization except the seeded default one, together with its data.
+func cleanupOrganizations(t *testing.T, pool *pgxpool.Pool) {
+	ctx := context.Background()
+	_, err := pool.Exec(ctx, "TRUNCATE TABLE pr_system.teams, pr_system.users, pr_system.audit_log, pr_system.api_keys, pr_system.codeowners, pr_system.reviewer_pools CASCADE")
+	require.NoError(t, err)
+	_, err = pool.Exec(ctx, "DELETE FROM pr_system.organizations WHERE id <> $1", domain.DefaultOrganizationID)
+	require.NoError(t, err)
+}
+
+func TestOrganizationRepo_CreateAndGet(t *testing.T) {
+	pool := setupTestDB(t)
+	repo := NewOrganizationRepository(pool)
+	cleanupOrganizations(t, pool)
+
+	ctx := context.Background()
+
+	t.Run("default organization is seeded", func(t *testing.T) {
+		org, err := repo.GetByID(ctx, domain.DefaultOrganizationID)
+		require.NoError(t, err)
+		require.NotNil(t, org)
+		assert.Equal(t, domain.DefaultOrganizationSlug, org.Slug)
+	})
+
+	t.Run("create and update settings", func(t *testing.T) {
+		created, err := repo.Create(ctx, &domain.Organization{
+			Slug:     "acme",
+			Name:     "Acme",
+			Settings: domain.OrganizationSettings{ReviewerCount: 2},
+		})
+		require.NoError(t, err)
+		assert.NotZero(t, created.ID)
+		assert.Equal(t, 2, created.Settings.ReviewerCount)
+
+		updated, err := repo.UpdateSettings(ctx, "acme", domain.OrganizationSettings{TopReviewersLimit: 5})
+		require.NoError(t, err)
+		require.NotNil(t, updated)
+		assert.Zero(t, updated.Settings.ReviewerCount)
+		assert.Equal(t, 5, updated.Settings.TopReviewersLimit)
+
+		found, err := repo.GetBySlug(ctx, "acme")
+		require.NoError(t, err)
+		require.NotNil(t, found)
+		assert.Equal(t, created.ID, found.ID)
+	})
+
+	t.Run("unknown slug", func(t *testing.T) {
+		found, err := repo.GetBySlug(ctx, "ghost")
+		require.NoError(t, err)
+		assert.Nil(t, found)
+	})
+}
+
+func TestOrganizationRepo_TenantIsolation(t *testing.T) {
+	pool := setupTestDB(t)
+	orgRepo := NewOrganizationRepository(pool)
+	teamRepo := NewTeamRepository(pool)
+	userRepo := NewUserRepository(pool)
+	cleanupOrganizations(t, pool)
+
+	acme, err := orgRepo.Create(context.Background(), &domain.Organization{Slug: "acme", Name: "Acme"})
+	require.NoError(t, err)
+
+	defaultCtx := context.Background()
+	acmeCtx := tenant.WithOrganization(context.Background(), acme)
+
+	t.Run("same team name and user id in two organizations", func(t *testing.T) {
+		for _, ctx := range []context.Context{defaultCtx, acmeCtx} {
+			_, err := userRepo.Create(ctx, &domain.User{UserID: "u1", Username: "Alice", IsActive: true})
+			require.NoError(t, err)
+			_, err = teamRepo.Create(ctx, &domain.Team{TeamName: "backend", Members: []domain.User{{UserID: "u1"}}})
+			require.NoError(t, err)
+		}
+	})
+
+	t.Run("organizations do not see each other's data", func(t *testing.T) {
+		_, err := userRepo.SetIsActive(acmeCtx, "u1", false)
+		require.NoError(t, err)
+
+		defaultUser, err := userRepo.GetByUserID(defaultCtx, "u1")
+		require.NoError(t, err)
+		require.NotNil(t, defaultUser)
+		assert.True(t, defaultUser.IsActive)
+
+		acmeUser, err := userRepo.GetByUserID(acmeCtx, "u1")
+		require.NoError(t, err)
+		require.NotNil(t, acmeUser)
+		assert.False(t, acmeUser.IsActive)
+	})
+}
@@ -0,0 +1,289 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/ssokov/pr-reviewer-service/internal/model/db"
+	"github.com/ssokov/pr-reviewer-service/internal/model/domain"
+	"github.com/ssokov/pr-reviewer-service/internal/repository"
+	"github.com/ssokov/pr-reviewer-service/internal/repository/postgres/mappers"
+	"github.com/ssokov/pr-reviewer-service/internal/tenant"
+)
+
+type poolRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewPoolRepository(dbPool *pgxpool.Pool) repository.PoolRepository {
+	return &poolRepo{
+		db: dbPool,
+	}
+}
+
+func (r *poolRepo) Upsert(ctx context.Context, pool *domain.ReviewerPool) (*domain.ReviewerPool, error) {
+	tx, err := conn(ctx, r.db).Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx) //nolint:errcheck
+
+	query := `
+		INSERT INTO pr_system.reviewer_pools (organization_id, name)
+		VALUES ($1, $2)
+		ON CONFLICT (organization_id, name)
+		DO UPDATE SET name = EXCLUDED.name
+		RETURNING id, organization_id, name, created_at
+	`
+
+	orgID := tenant.OrganizationID(ctx)
+
+	var dbPool db.ReviewerPool
+	err = tx.QueryRow(ctx, query, orgID, pool.Name).Scan(
+		&dbPool.ID,
+		&dbPool.OrganizationID,
+		&dbPool.Name,
+		&dbPool.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err = tx.Exec(ctx, `DELETE FROM pr_system.reviewer_pool_members WHERE pool_id = $1`, dbPool.ID); err != nil {
+		return nil, err
+	}
+
+	userIDs := make([]string, 0, len(pool.Members))
+	for _, member := range pool.Members {
+		userIDs = append(userIDs, member.UserID)
+	}
+	if len(userIDs) > 0 {
+		_, err = tx.Exec(ctx, `
+			INSERT INTO pr_system.reviewer_pool_members (pool_id, user_id)
+			SELECT $1, id FROM pr_system.users
+			WHERE user_id = ANY($2) AND organization_id = $3
+		`, dbPool.ID, userIDs, orgID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	members, err := poolMembers(ctx, tx, dbPool.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	return mappers.PoolDBToDomain(&dbPool, members), nil
+}
+
+func (r *poolRepo) GetByName(ctx context.Context, name string) (*domain.ReviewerPool, error) {
+	query := `
+		SELECT id, organization_id, name, created_at
+		FROM pr_system.reviewer_pools
+		WHERE organization_id = $1 AND name = $2
+	`
+
+	var dbPool db.ReviewerPool
+	err := conn(ctx, r.db).QueryRow(ctx, query, tenant.OrganizationID(ctx), name).Scan(
+		&dbPool.ID,
+		&dbPool.OrganizationID,
+		&dbPool.Name,
+		&dbPool.CreatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	members, err := poolMembers(ctx, conn(ctx, r.db), dbPool.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return mappers.PoolDBToDomain(&dbPool, members), nil
+}
+
+// List returns all pools of the organization with their members, ordered by name.
+func (r *poolRepo) List(ctx context.Context) ([]domain.ReviewerPool, error) {
+	query := `
+		SELECT p.id, p.organization_id, p.name, p.created_at,
+			u.id, u.user_id, u.username, u.team_role, u.is_active, u.team_id, t.name, u.created_at
+		FROM pr_system.reviewer_pools p
+		LEFT JOIN pr_system.reviewer_pool_members m ON m.pool_id = p.id
+		LEFT JOIN pr_system.users u ON u.id = m.user_id
+		LEFT JOIN pr_system.teams t ON t.id = u.team_id
+		WHERE p.organization_id = $1
+		ORDER BY p.name, u.user_id
+	`
+
+	rows, err := conn(ctx, r.db).Query(ctx, query, tenant.OrganizationID(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	pools := []domain.ReviewerPool{}
+	for rows.Next() {
+		var dbPool db.ReviewerPool
+		var (
+			userInternalID *int64
+			userID         *string
+			username       *string
+			teamRole       *string
+			isActive       *bool
+			teamID         *int64
+			teamName       *string
+			userCreatedAt  *time.Time
+		)
+		if err := rows.Scan(
+			&dbPool.ID,
+			&dbPool.OrganizationID,
+			&dbPool.Name,
+			&dbPool.CreatedAt,
+			&userInternalID,
+			&userID,
+			&username,
+			&teamRole,
+			&isActive,
+			&teamID,
+			&teamName,
+			&userCreatedAt,
+		); err != nil {
+			return nil, err
+		}
+
+		if len(pools) == 0 || pools[len(pools)-1].ID != dbPool.ID {
+			pools = append(pools, *mappers.PoolDBToDomain(&dbPool, []domain.User{}))
+		}
+		if userInternalID != nil {
+			member := domain.User{
+				ID:        *userInternalID,
+				UserID:    *userID,
+				Username:  *username,
+				TeamRole:  domain.TeamRole(*teamRole),
+				IsActive:  *isActive,
+				CreatedAt: *userCreatedAt,
+			}
+			if teamID != nil {
+				member.TeamID = *teamID
+				member.TeamName = *teamName
+			}
+			pool := &pools[len(pools)-1]
+			pool.Members = append(pool.Members, member)
+		}
+	}
+
+	return pools, rows.Err()
+}
+
+func (r *poolRepo) SetFallback(ctx context.Context, teamID int64, steps []domain.FallbackStep) error {
+	tx, err := conn(ctx, r.db).Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx) //nolint:errcheck
+
+	if _, err = tx.Exec(ctx, `DELETE FROM pr_system.team_fallbacks WHERE team_id = $1`, teamID); err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO pr_system.team_fallbacks (team_id, position, fallback_team_id, pool_id)
+		VALUES (
+			$1, $2,
+			(SELECT id FROM pr_system.teams WHERE name = $3 AND organization_id = $5),
+			(SELECT id FROM pr_system.reviewer_pools WHERE name = $4 AND organization_id = $5)
+		)
+	`
+
+	orgID := tenant.OrganizationID(ctx)
+	for i, step := range steps {
+		if _, err = tx.Exec(ctx, query, teamID, i+1, step.TeamName, step.PoolName, orgID); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (r *poolRepo) GetFallback(ctx context.Context, teamID int64) ([]domain.FallbackStep, error) {
+	query := `
+		SELECT COALESCE(t.name, ''), COALESCE(p.name, '')
+		FROM pr_system.team_fallbacks f
+		LEFT JOIN pr_system.teams t ON t.id = f.fallback_team_id
+		LEFT JOIN pr_system.reviewer_pools p ON p.id = f.pool_id
+		WHERE f.team_id = $1
+		ORDER BY f.position
+	`
+
+	rows, err := conn(ctx, r.db).Query(ctx, query, teamID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var steps []domain.FallbackStep
+	for rows.Next() {
+		var step domain.FallbackStep
+		if err := rows.Scan(&step.TeamName, &step.PoolName); err != nil {
+			return nil, err
+		}
+		steps = append(steps, step)
+	}
+
+	return steps, rows.Err()
+}
+
+func poolMembers(ctx context.Context, q querier, poolID int64) ([]domain.User, error) {
+	query := `
+		SELECT u.id, u.user_id, u.username, u.team_role, u.timezone, u.work_start, u.work_end, u.max_open_reviews, u.is_active, u.team_id, COALESCE(t.name, ''), u.created_at
+		FROM pr_system.reviewer_pool_members m
+		INNER JOIN pr_system.users u ON u.id = m.user_id
+		LEFT JOIN pr_system.teams t ON t.id = u.team_id
+		WHERE m.pool_id = $1
+		ORDER BY u.user_id
+	`
+
+	rows, err := q.Query(ctx, query, poolID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	members := []domain.User{}
+	for rows.Next() {
+		var user domain.User
+		var teamID *int64
+		if err := rows.Scan(
+			&user.ID,
+			&user.UserID,
+			&user.Username,
+			&user.TeamRole,
+			&user.Timezone,
+			&user.WorkStart,
+			&user.WorkEnd,
+			&user.MaxOpenReviews,
+			&user.IsActive,
+			&teamID,
+			&user.TeamName,
+			&user.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		if teamID != nil {
+			user.TeamID = *teamID
+		}
+		members = append(members, user)
+	}
+
+	return members, rows.Err()
+}
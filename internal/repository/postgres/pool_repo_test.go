@@ -0,0 +1,75 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ssokov/pr-reviewer-service/internal/model/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPoolRepo(t *testing.T) {
+	pool := setupTestDB(t)
+	poolRepo := NewPoolRepository(pool)
+	userRepo := NewUserRepository(pool)
+	teamRepo := NewTeamRepository(pool)
+	cleanupPRs(t, pool)
+
+	ctx := context.Background()
+
+	_, err := pool.Exec(ctx, "TRUNCATE TABLE pr_system.reviewer_pools CASCADE")
+	require.NoError(t, err)
+
+	backend, err := teamRepo.Create(ctx, &domain.Team{TeamName: "pool-backend"})
+	require.NoError(t, err)
+	platform, err := teamRepo.Create(ctx, &domain.Team{TeamName: "pool-platform"})
+	require.NoError(t, err)
+	for _, u := range []domain.User{
+		{UserID: "p1", Username: "p1", TeamID: backend.ID, IsActive: true},
+		{UserID: "p2", Username: "p2", TeamID: platform.ID, IsActive: false},
+	} {
+		_, err = userRepo.Create(ctx, &u)
+		require.NoError(t, err)
+	}
+
+	t.Run("upsert replaces members", func(t *testing.T) {
+		_, err := poolRepo.Upsert(ctx, &domain.ReviewerPool{Name: "oncall", Members: []domain.User{{UserID: "p1"}}})
+		require.NoError(t, err)
+
+		updated, err := poolRepo.Upsert(ctx, &domain.ReviewerPool{Name: "oncall", Members: []domain.User{{UserID: "p2"}, {UserID: "ghost"}}})
+		require.NoError(t, err)
+		require.Len(t, updated.Members, 1)
+		assert.Equal(t, "p2", updated.Members[0].UserID)
+		assert.Equal(t, "pool-platform", updated.Members[0].TeamName)
+		assert.False(t, updated.Members[0].IsActive)
+
+		found, err := poolRepo.GetByName(ctx, "oncall")
+		require.NoError(t, err)
+		require.NotNil(t, found)
+		assert.Equal(t, updated.ID, found.ID)
+
+		pools, err := poolRepo.List(ctx)
+		require.NoError(t, err)
+		require.Len(t, pools, 1)
+		assert.Len(t, pools[0].Members, 1)
+
+		missing, err := poolRepo.GetByName(ctx, "ghost")
+		require.NoError(t, err)
+		assert.Nil(t, missing)
+	})
+
+	t.Run("fallback chain keeps its order", func(t *testing.T) {
+		steps := []domain.FallbackStep{{PoolName: "oncall"}, {TeamName: "pool-platform"}}
+		require.NoError(t, poolRepo.SetFallback(ctx, backend.ID, steps))
+
+		found, err := poolRepo.GetFallback(ctx, backend.ID)
+		require.NoError(t, err)
+		assert.Equal(t, steps, found)
+
+		require.NoError(t, poolRepo.SetFallback(ctx, backend.ID, nil))
+		found, err = poolRepo.GetFallback(ctx, backend.ID)
+		require.NoError(t, err)
+		assert.Empty(t, found)
+	})
+}
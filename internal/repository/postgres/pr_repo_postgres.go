@@ -3,6 +3,9 @@ package postgres
 import (
 	"context"
 	"errors"
+	"fmt"
+	"slices"
+	"time"
 
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
@@ -10,6 +13,7 @@ import (
 	"github.com/ssokov/pr-reviewer-service/internal/model/domain"
 	"github.com/ssokov/pr-reviewer-service/internal/repository"
 	"github.com/ssokov/pr-reviewer-service/internal/repository/postgres/mappers"
+	"github.com/ssokov/pr-reviewer-service/internal/tenant"
 )
 
 type prRepo struct {
@@ -23,14 +27,16 @@ func NewPRRepository(dbPool *pgxpool.Pool) repository.PRRepository {
 }
 
 func (r *prRepo) Create(ctx context.Context, pr *domain.PullRequest) (*domain.PullRequest, error) {
-	tx, err := r.db.Begin(ctx)
+	tx, err := conn(ctx, r.db).Begin(ctx)
 	if err != nil {
 		return nil, err
 	}
 	defer tx.Rollback(ctx) //nolint:errcheck
 
+	orgID := tenant.OrganizationID(ctx)
+
 	var authorInternalID int64
-	err = tx.QueryRow(ctx, `SELECT id FROM pr_system.users WHERE user_id = $1`, pr.AuthorID).Scan(&authorInternalID)
+	err = tx.QueryRow(ctx, `SELECT id FROM pr_system.users WHERE user_id = $1 AND organization_id = $2`, pr.AuthorID, orgID).Scan(&authorInternalID)
 	if err != nil {
 		return nil, err
 	}
@@ -42,18 +48,19 @@ func (r *prRepo) Create(ctx context.Context, pr *domain.PullRequest) (*domain.Pu
 	}
 
 	query := `
-		INSERT INTO pr_system.pull_requests (pull_request_id, pull_request_name, author_id, status_id, merged_at)
-		VALUES ($1, $2, $3, $4, $5)
-		RETURNING id, pull_request_id, pull_request_name, created_at, merged_at
+		INSERT INTO pr_system.pull_requests (pull_request_id, pull_request_name, author_id, status_id, merged_at, organization_id, pending_reviewers)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING id, pull_request_id, pull_request_name, created_at, merged_at, pending_reviewers
 	`
 
 	var dbPR db.PullRequest
-	err = tx.QueryRow(ctx, query, pr.PullRequestID, pr.PullRequestName, authorInternalID, statusID, pr.MergedAt).Scan(
+	err = tx.QueryRow(ctx, query, pr.PullRequestID, pr.PullRequestName, authorInternalID, statusID, pr.MergedAt, orgID, pr.PendingReviewers).Scan(
 		&dbPR.ID,
 		&dbPR.PullRequestID,
 		&dbPR.PullRequestName,
 		&dbPR.CreatedAt,
 		&dbPR.MergedAt,
+		&dbPR.PendingReviewers,
 	)
 	if err != nil {
 		return nil, err
@@ -62,29 +69,23 @@ func (r *prRepo) Create(ctx context.Context, pr *domain.PullRequest) (*domain.Pu
 	dbPR.AuthorID = authorInternalID
 	dbPR.StatusID = statusID
 
-	if len(pr.AssignedReviewers) > 0 {
-		for _, reviewerUserID := range pr.AssignedReviewers {
-			var reviewerInternalID int64
-			err = tx.QueryRow(ctx, `SELECT id FROM pr_system.users WHERE user_id = $1`, reviewerUserID).Scan(&reviewerInternalID)
-			if err != nil {
-				return nil, err
-			}
-
-			_, err = tx.Exec(ctx, `
-				INSERT INTO pr_system.pr_reviewers (pr_id, reviewer_id)
-				VALUES ($1, $2)
-			`, dbPR.ID, reviewerInternalID)
-			if err != nil {
-				return nil, err
-			}
-		}
+	if err = saveReviewers(ctx, tx, dbPR.ID, orgID, pr); err != nil {
+		return nil, err
+	}
+
+	if err = insertPRLabels(ctx, tx, dbPR.ID, pr.Labels, false); err != nil {
+		return nil, err
 	}
 
 	if err = tx.Commit(ctx); err != nil {
 		return nil, err
 	}
 
-	return mappers.PRDBToDomain(&dbPR, pr.AuthorID, pr.Status, pr.AssignedReviewers), nil
+	created := mappers.PRDBToDomain(&dbPR, pr.AuthorID, pr.Status, pr.AssignedReviewers)
+	created.ShadowReviewers = pr.ShadowReviewers
+	created.PinnedReviewers = pr.PinnedReviewers
+	created.Labels = pr.Labels
+	return created, nil
 }
 
 func (r *prRepo) GetByPRID(ctx context.Context, prID string) (*domain.PullRequest, error) {
@@ -96,17 +97,18 @@ func (r *prRepo) GetByPRID(ctx context.Context, prID string) (*domain.PullReques
 			u.user_id as author_user_id,
 			s.name as status,
 			pr.created_at,
-			pr.merged_at
+			pr.merged_at,
+			pr.pending_reviewers
 		FROM pr_system.pull_requests pr
 		INNER JOIN pr_system.users u ON pr.author_id = u.id
 		INNER JOIN pr_system.statuses s ON pr.status_id = s.id
-		WHERE pr.pull_request_id = $1
+		WHERE pr.pull_request_id = $1 AND pr.organization_id = $2
 	`
 
 	var dbPR db.PullRequest
 	var authorUserID string
 	var statusStr string
-	err := r.db.QueryRow(ctx, query, prID).Scan(
+	err := conn(ctx, r.db).QueryRow(ctx, query, prID, tenant.OrganizationID(ctx)).Scan(
 		&dbPR.ID,
 		&dbPR.PullRequestID,
 		&dbPR.PullRequestName,
@@ -114,6 +116,7 @@ func (r *prRepo) GetByPRID(ctx context.Context, prID string) (*domain.PullReques
 		&statusStr,
 		&dbPR.CreatedAt,
 		&dbPR.MergedAt,
+		&dbPR.PendingReviewers,
 	)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
@@ -123,36 +126,78 @@ func (r *prRepo) GetByPRID(ctx context.Context, prID string) (*domain.PullReques
 	}
 
 	reviewersQuery := `
-		SELECT u.user_id
+		SELECT u.user_id, rev.is_shadow, rev.is_pinned
 		FROM pr_system.pr_reviewers rev
 		INNER JOIN pr_system.users u ON rev.reviewer_id = u.id
 		WHERE rev.pr_id = $1
 	`
-	rows, err := r.db.Query(ctx, reviewersQuery, dbPR.ID)
+	rows, err := conn(ctx, r.db).Query(ctx, reviewersQuery, dbPR.ID)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
 
-	var reviewers []string
+	var reviewers, shadowReviewers, pinnedReviewers []string
 	for rows.Next() {
 		var userID string
-		if err := rows.Scan(&userID); err != nil {
+		var shadow, pinned bool
+		if err := rows.Scan(&userID, &shadow, &pinned); err != nil {
 			return nil, err
 		}
-		reviewers = append(reviewers, userID)
+		if shadow {
+			shadowReviewers = append(shadowReviewers, userID)
+		} else {
+			reviewers = append(reviewers, userID)
+		}
+		if pinned {
+			pinnedReviewers = append(pinnedReviewers, userID)
+		}
+	}
+	// Release the connection before the next query; inside a transaction it is shared.
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	labels, err := r.labels(ctx, dbPR.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	pr := mappers.PRDBToDomain(&dbPR, authorUserID, domain.PRStatus(statusStr), reviewers)
+	pr.ShadowReviewers = shadowReviewers
+	pr.PinnedReviewers = pinnedReviewers
+	pr.Labels = labels
+	return pr, nil
+}
+
+func (r *prRepo) labels(ctx context.Context, prID int64) ([]string, error) {
+	rows, err := conn(ctx, r.db).Query(ctx, `SELECT label FROM pr_system.pr_labels WHERE pr_id = $1 ORDER BY label`, prID)
+	if err != nil {
+		return nil, err
 	}
+	defer rows.Close()
 
-	return mappers.PRDBToDomain(&dbPR, authorUserID, domain.PRStatus(statusStr), reviewers), nil
+	var labels []string
+	for rows.Next() {
+		var label string
+		if err := rows.Scan(&label); err != nil {
+			return nil, err
+		}
+		labels = append(labels, label)
+	}
+	return labels, rows.Err()
 }
 
 func (r *prRepo) Update(ctx context.Context, pr *domain.PullRequest) (*domain.PullRequest, error) {
-	tx, err := r.db.Begin(ctx)
+	tx, err := conn(ctx, r.db).Begin(ctx)
 	if err != nil {
 		return nil, err
 	}
 	defer tx.Rollback(ctx) //nolint:errcheck
 
+	orgID := tenant.OrganizationID(ctx)
+
 	var statusID int
 	err = tx.QueryRow(ctx, `SELECT id FROM pr_system.statuses WHERE name = $1`, pr.Status).Scan(&statusID)
 	if err != nil {
@@ -161,18 +206,19 @@ func (r *prRepo) Update(ctx context.Context, pr *domain.PullRequest) (*domain.Pu
 
 	query := `
 		UPDATE pr_system.pull_requests
-		SET pull_request_name = $1, status_id = $2, merged_at = $3
-		WHERE pull_request_id = $4
-		RETURNING id, pull_request_id, pull_request_name, created_at, merged_at
+		SET pull_request_name = $1, status_id = $2, merged_at = $3, pending_reviewers = $6
+		WHERE pull_request_id = $4 AND organization_id = $5
+		RETURNING id, pull_request_id, pull_request_name, created_at, merged_at, pending_reviewers
 	`
 
 	var dbPR db.PullRequest
-	err = tx.QueryRow(ctx, query, pr.PullRequestName, statusID, pr.MergedAt, pr.PullRequestID).Scan(
+	err = tx.QueryRow(ctx, query, pr.PullRequestName, statusID, pr.MergedAt, pr.PullRequestID, orgID, pr.PendingReviewers).Scan(
 		&dbPR.ID,
 		&dbPR.PullRequestID,
 		&dbPR.PullRequestName,
 		&dbPR.CreatedAt,
 		&dbPR.MergedAt,
+		&dbPR.PendingReviewers,
 	)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
@@ -183,34 +229,20 @@ func (r *prRepo) Update(ctx context.Context, pr *domain.PullRequest) (*domain.Pu
 
 	dbPR.StatusID = statusID
 
-	_, err = tx.Exec(ctx, `DELETE FROM pr_system.pr_reviewers WHERE pr_id = $1`, dbPR.ID)
-	if err != nil {
+	if err = saveReviewers(ctx, tx, dbPR.ID, orgID, pr); err != nil {
 		return nil, err
 	}
 
-	if len(pr.AssignedReviewers) > 0 {
-		for _, reviewerUserID := range pr.AssignedReviewers {
-			var reviewerInternalID int64
-			err = tx.QueryRow(ctx, `SELECT id FROM pr_system.users WHERE user_id = $1`, reviewerUserID).Scan(&reviewerInternalID)
-			if err != nil {
-				return nil, err
-			}
-
-			_, err = tx.Exec(ctx, `
-				INSERT INTO pr_system.pr_reviewers (pr_id, reviewer_id)
-				VALUES ($1, $2)
-			`, dbPR.ID, reviewerInternalID)
-			if err != nil {
-				return nil, err
-			}
-		}
-	}
-
 	if err = tx.Commit(ctx); err != nil {
 		return nil, err
 	}
 
-	return mappers.PRDBToDomain(&dbPR, pr.AuthorID, pr.Status, pr.AssignedReviewers), nil
+	// Labels are not changed by Update.
+	updated := mappers.PRDBToDomain(&dbPR, pr.AuthorID, pr.Status, pr.AssignedReviewers)
+	updated.ShadowReviewers = pr.ShadowReviewers
+	updated.PinnedReviewers = pr.PinnedReviewers
+	updated.Labels = pr.Labels
+	return updated, nil
 }
 
 func (r *prRepo) GetByReviewerID(ctx context.Context, userID string) ([]domain.PullRequest, error) {
@@ -228,11 +260,11 @@ func (r *prRepo) GetByReviewerID(ctx context.Context, userID string) ([]domain.P
 		INNER JOIN pr_system.users u ON pr.author_id = u.id
 		INNER JOIN pr_system.users reviewer ON rev.reviewer_id = reviewer.id
 		INNER JOIN pr_system.statuses s ON pr.status_id = s.id
-		WHERE reviewer.user_id = $1
+		WHERE reviewer.user_id = $1 AND reviewer.organization_id = $2
 		ORDER BY pr.created_at DESC
 	`
 
-	rows, err := r.db.Query(ctx, query, userID)
+	rows, err := conn(ctx, r.db).Query(ctx, query, userID, tenant.OrganizationID(ctx))
 	if err != nil {
 		return nil, err
 	}
@@ -267,23 +299,27 @@ func (r *prRepo) GetOpenPRsByUserIDs(ctx context.Context, userIDs []string) ([]d
 	}
 
 	query := `
-		SELECT DISTINCT
+		SELECT
 			pr.id,
 			pr.pull_request_id,
 			pr.pull_request_name,
 			u.user_id as author_user_id,
 			s.name as status,
 			pr.created_at,
-			pr.merged_at
+			pr.merged_at,
+			array_agg(reviewer.user_id ORDER BY reviewer.user_id) FILTER (WHERE NOT rev.is_shadow)
 		FROM pr_system.pull_requests pr
 		INNER JOIN pr_system.pr_reviewers rev ON pr.id = rev.pr_id
 		INNER JOIN pr_system.users u ON pr.author_id = u.id
 		INNER JOIN pr_system.users reviewer ON rev.reviewer_id = reviewer.id
 		INNER JOIN pr_system.statuses s ON pr.status_id = s.id
-		WHERE reviewer.user_id = ANY($1) AND s.name = 'OPEN'
+		WHERE pr.organization_id = $2 AND s.name = 'OPEN'
+		GROUP BY pr.id, u.user_id, s.name
+		HAVING bool_or(reviewer.user_id = ANY($1) AND NOT rev.is_shadow)
+		ORDER BY pr.created_at, pr.id
 	`
 
-	rows, err := r.db.Query(ctx, query, userIDs)
+	rows, err := conn(ctx, r.db).Query(ctx, query, userIDs, tenant.OrganizationID(ctx))
 	if err != nil {
 		return nil, err
 	}
@@ -294,6 +330,7 @@ func (r *prRepo) GetOpenPRsByUserIDs(ctx context.Context, userIDs []string) ([]d
 		var dbPR db.PullRequest
 		var authorUserID string
 		var statusStr string
+		var reviewers []string
 		if err := rows.Scan(
 			&dbPR.ID,
 			&dbPR.PullRequestID,
@@ -302,11 +339,259 @@ func (r *prRepo) GetOpenPRsByUserIDs(ctx context.Context, userIDs []string) ([]d
 			&statusStr,
 			&dbPR.CreatedAt,
 			&dbPR.MergedAt,
+			&reviewers,
 		); err != nil {
 			return nil, err
 		}
-		pullRequests = append(pullRequests, *mappers.PRDBToDomain(&dbPR, authorUserID, domain.PRStatus(statusStr), nil))
+		pullRequests = append(pullRequests, *mappers.PRDBToDomain(&dbPR, authorUserID, domain.PRStatus(statusStr), reviewers))
+	}
+
+	return pullRequests, rows.Err()
+}
+
+// List returns the PRs matching filter with their reviewers, oldest first.
+func (r *prRepo) List(ctx context.Context, filter domain.PRFilter) ([]domain.PullRequest, error) {
+	query := `
+		SELECT
+			pr.id,
+			pr.pull_request_id,
+			pr.pull_request_name,
+			u.user_id as author_user_id,
+			s.name as status,
+			pr.created_at,
+			pr.merged_at,
+			pr.pending_reviewers,
+			COALESCE(array_agg(reviewer.user_id ORDER BY reviewer.user_id) FILTER (WHERE reviewer.user_id IS NOT NULL AND NOT rev.is_shadow), '{}'),
+			COALESCE(array_agg(reviewer.user_id ORDER BY reviewer.user_id) FILTER (WHERE rev.is_shadow), '{}'),
+			COALESCE(array_agg(reviewer.user_id ORDER BY reviewer.user_id) FILTER (WHERE rev.is_pinned), '{}')
+		FROM pr_system.pull_requests pr
+		INNER JOIN pr_system.users u ON pr.author_id = u.id
+		INNER JOIN pr_system.statuses s ON pr.status_id = s.id
+		LEFT JOIN pr_system.pr_reviewers rev ON pr.id = rev.pr_id
+		LEFT JOIN pr_system.users reviewer ON rev.reviewer_id = reviewer.id
+		WHERE pr.organization_id = $1
+			AND ($2 = '' OR s.name = $2)
+			AND ($3::timestamptz IS NULL OR pr.created_at < $3)
+			AND (NOT $5 OR pr.pending_reviewers)
+		GROUP BY pr.id, u.user_id, s.name
+		ORDER BY pr.created_at, pr.id
+		LIMIT $4
+	`
+
+	rows, err := conn(ctx, r.db).Query(ctx, query, tenant.OrganizationID(ctx), string(filter.Status), filter.CreatedBefore, filter.Limit, filter.Pending)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	pullRequests := []domain.PullRequest{}
+	for rows.Next() {
+		var dbPR db.PullRequest
+		var authorUserID string
+		var statusStr string
+		var reviewers, shadowReviewers, pinnedReviewers []string
+		if err := rows.Scan(
+			&dbPR.ID,
+			&dbPR.PullRequestID,
+			&dbPR.PullRequestName,
+			&authorUserID,
+			&statusStr,
+			&dbPR.CreatedAt,
+			&dbPR.MergedAt,
+			&dbPR.PendingReviewers,
+			&reviewers,
+			&shadowReviewers,
+			&pinnedReviewers,
+		); err != nil {
+			return nil, err
+		}
+		pr := mappers.PRDBToDomain(&dbPR, authorUserID, domain.PRStatus(statusStr), reviewers)
+		if len(shadowReviewers) > 0 {
+			pr.ShadowReviewers = shadowReviewers
+		}
+		if len(pinnedReviewers) > 0 {
+			pr.PinnedReviewers = pinnedReviewers
+		}
+		pullRequests = append(pullRequests, *pr)
 	}
 
 	return pullRequests, rows.Err()
 }
+
+// saveReviewers makes the reviewer rows of a PR match its assigned, shadow and pinned reviewers. Rows of reviewers
+// that stay are updated in place, so their assigned_at keeps the time they were first assigned.
+func saveReviewers(ctx context.Context, q querier, prID, orgID int64, pr *domain.PullRequest) error {
+	userIDs := append(slices.Clone(pr.AssignedReviewers), pr.ShadowReviewers...)
+	internalIDs, err := reviewerInternalIDs(ctx, q, orgID, userIDs)
+	if err != nil {
+		return err
+	}
+
+	ids := make([]int64, 0, len(userIDs))
+	shadow := make([]bool, 0, len(userIDs))
+	pinned := make([]bool, 0, len(userIDs))
+	for _, userID := range pr.AssignedReviewers {
+		ids = append(ids, internalIDs[userID])
+		shadow = append(shadow, false)
+		pinned = append(pinned, slices.Contains(pr.PinnedReviewers, userID))
+	}
+	for _, userID := range pr.ShadowReviewers {
+		ids = append(ids, internalIDs[userID])
+		shadow = append(shadow, true)
+		pinned = append(pinned, false)
+	}
+
+	_, err = q.Exec(ctx, `DELETE FROM pr_system.pr_reviewers WHERE pr_id = $1 AND reviewer_id <> ALL($2)`, prID, ids)
+	if err != nil {
+		return err
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	_, err = q.Exec(ctx, `
+		INSERT INTO pr_system.pr_reviewers (pr_id, reviewer_id, is_shadow, is_pinned)
+		SELECT $1, r.reviewer_id, r.is_shadow, r.is_pinned
+		FROM unnest($2::bigint[], $3::boolean[], $4::boolean[]) AS r(reviewer_id, is_shadow, is_pinned)
+		ON CONFLICT (pr_id, reviewer_id) DO UPDATE
+		SET is_shadow = EXCLUDED.is_shadow, is_pinned = EXCLUDED.is_pinned
+		WHERE (pr_reviewers.is_shadow, pr_reviewers.is_pinned) IS DISTINCT FROM (EXCLUDED.is_shadow, EXCLUDED.is_pinned)
+	`, prID, ids, shadow, pinned)
+	return err
+}
+
+// reviewerInternalIDs maps user_ids of the organization to the users' internal ids in one query. An unknown user_id
+// fails with pgx.ErrNoRows.
+func reviewerInternalIDs(ctx context.Context, q querier, orgID int64, userIDs []string) (map[string]int64, error) {
+	ids := make(map[string]int64, len(userIDs))
+	if len(userIDs) == 0 {
+		return ids, nil
+	}
+
+	rows, err := q.Query(ctx, `SELECT user_id, id FROM pr_system.users WHERE organization_id = $1 AND user_id = ANY($2)`, orgID, userIDs)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var userID string
+		var id int64
+		if err := rows.Scan(&userID, &id); err != nil {
+			return nil, err
+		}
+		ids[userID] = id
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	for _, userID := range userIDs {
+		if _, ok := ids[userID]; !ok {
+			return nil, fmt.Errorf("reviewer %s: %w", userID, pgx.ErrNoRows)
+		}
+	}
+	return ids, nil
+}
+
+func (r *prRepo) GetPairCounts(ctx context.Context, authorID string, since time.Time) (map[string]int, error) {
+	query := `
+		SELECT reviewer.user_id, COUNT(*)
+		FROM pr_system.pull_requests pr
+		INNER JOIN pr_system.users author ON pr.author_id = author.id
+		INNER JOIN pr_system.pr_reviewers rev ON rev.pr_id = pr.id AND NOT rev.is_shadow
+		INNER JOIN pr_system.users reviewer ON rev.reviewer_id = reviewer.id
+		WHERE author.user_id = $1 AND pr.organization_id = $2 AND pr.created_at >= $3
+		GROUP BY reviewer.user_id
+	`
+
+	rows, err := conn(ctx, r.db).Query(ctx, query, authorID, tenant.OrganizationID(ctx), since)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	counts := make(map[string]int)
+	for rows.Next() {
+		var reviewerID string
+		var count int
+		if err := rows.Scan(&reviewerID, &count); err != nil {
+			return nil, err
+		}
+		counts[reviewerID] = count
+	}
+	return counts, rows.Err()
+}
+
+// GetOpenReviewCounts returns how many open PRs each of userIDs reviews. Shadow reviews do not count; users without
+// open reviews are left out.
+func (r *prRepo) GetOpenReviewCounts(ctx context.Context, userIDs []string) (map[string]int, error) {
+	return r.openReviewCounts(ctx, userIDs, false)
+}
+
+func (r *prRepo) GetOpenShadowCounts(ctx context.Context, userIDs []string) (map[string]int, error) {
+	return r.openReviewCounts(ctx, userIDs, true)
+}
+
+// GetReviewerAssignedAt returns when each non-shadow reviewer of prID was assigned. Reviewers kept across updates
+// keep the time they were first assigned.
+func (r *prRepo) GetReviewerAssignedAt(ctx context.Context, prID string) (map[string]time.Time, error) {
+	query := `
+		SELECT reviewer.user_id, rev.assigned_at
+		FROM pr_system.pr_reviewers rev
+		INNER JOIN pr_system.pull_requests pr ON pr.id = rev.pr_id
+		INNER JOIN pr_system.users reviewer ON rev.reviewer_id = reviewer.id
+		WHERE pr.pull_request_id = $1 AND pr.organization_id = $2 AND NOT rev.is_shadow AND rev.assigned_at IS NOT NULL
+	`
+
+	rows, err := conn(ctx, r.db).Query(ctx, query, prID, tenant.OrganizationID(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	assignedAt := make(map[string]time.Time)
+	for rows.Next() {
+		var reviewerID string
+		var at time.Time
+		if err := rows.Scan(&reviewerID, &at); err != nil {
+			return nil, err
+		}
+		assignedAt[reviewerID] = at
+	}
+	return assignedAt, rows.Err()
+}
+
+// openReviewCounts counts the open PRs each user reviews, either as a reviewer or as a shadow.
+func (r *prRepo) openReviewCounts(ctx context.Context, userIDs []string, shadow bool) (map[string]int, error) {
+	counts := make(map[string]int)
+	if len(userIDs) == 0 {
+		return counts, nil
+	}
+
+	query := `
+		SELECT reviewer.user_id, COUNT(*)
+		FROM pr_system.pr_reviewers rev
+		INNER JOIN pr_system.pull_requests pr ON pr.id = rev.pr_id
+		INNER JOIN pr_system.statuses s ON pr.status_id = s.id
+		INNER JOIN pr_system.users reviewer ON rev.reviewer_id = reviewer.id
+		WHERE reviewer.user_id = ANY($1) AND reviewer.organization_id = $2 AND s.name = 'OPEN' AND rev.is_shadow = $3
+		GROUP BY reviewer.user_id
+	`
+
+	rows, err := conn(ctx, r.db).Query(ctx, query, userIDs, tenant.OrganizationID(ctx), shadow)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var reviewerID string
+		var count int
+		if err := rows.Scan(&reviewerID, &count); err != nil {
+			return nil, err
+		}
+		counts[reviewerID] = count
+	}
+	return counts, rows.Err()
+}
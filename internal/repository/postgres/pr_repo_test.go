@@ -229,3 +229,306 @@ func TestPRRepo_GetByReviewerID(t *testing.T) {
 		assert.Empty(t, prs)
 	})
 }
+
+func TestPRRepo_List(t *testing.T) {
+	pool := setupTestDB(t)
+	prRepo := NewPRRepository(pool)
+	userRepo := NewUserRepository(pool)
+	teamRepo := NewTeamRepository(pool)
+	cleanupPRs(t, pool)
+
+	ctx := context.Background()
+
+	createdTeam, err := teamRepo.Create(ctx, &domain.Team{TeamName: "test-team"})
+	require.NoError(t, err)
+
+	for _, id := range []string{"author5", "reviewer5", "reviewer6"} {
+		_, err = userRepo.Create(ctx, &domain.User{UserID: id, Username: id, TeamID: createdTeam.ID, IsActive: true})
+		require.NoError(t, err)
+	}
+
+	for _, pr := range []*domain.PullRequest{
+		{PullRequestID: "pr-005", PullRequestName: "Old", AuthorID: "author5", Status: domain.PRStatusOpen, AssignedReviewers: []string{"reviewer6", "reviewer5"}},
+		{PullRequestID: "pr-006", PullRequestName: "Merged", AuthorID: "author5", Status: domain.PRStatusMerged},
+		{PullRequestID: "pr-007", PullRequestName: "Queued", AuthorID: "author5", Status: domain.PRStatusOpen, PendingReviewers: true},
+	} {
+		_, err = prRepo.Create(ctx, pr)
+		require.NoError(t, err)
+	}
+	_, err = pool.Exec(ctx, `UPDATE pr_system.pull_requests SET created_at = now() - interval '5 days' WHERE pull_request_id = 'pr-005'`)
+	require.NoError(t, err)
+
+	t.Run("filter by status with reviewers", func(t *testing.T) {
+		prs, err := prRepo.List(ctx, domain.PRFilter{Status: domain.PRStatusOpen, Limit: 10})
+		require.NoError(t, err)
+		require.Len(t, prs, 2)
+		assert.Equal(t, "pr-005", prs[0].PullRequestID)
+		assert.Equal(t, []string{"reviewer5", "reviewer6"}, prs[0].AssignedReviewers)
+	})
+
+	t.Run("filter pending", func(t *testing.T) {
+		prs, err := prRepo.List(ctx, domain.PRFilter{Pending: true, Limit: 10})
+		require.NoError(t, err)
+		require.Len(t, prs, 1)
+		assert.Equal(t, "pr-007", prs[0].PullRequestID)
+		assert.True(t, prs[0].PendingReviewers)
+		assert.Empty(t, prs[0].AssignedReviewers)
+	})
+
+	t.Run("filter by age", func(t *testing.T) {
+		before := time.Now().Add(-72 * time.Hour)
+		prs, err := prRepo.List(ctx, domain.PRFilter{CreatedBefore: &before, Limit: 10})
+		require.NoError(t, err)
+		require.Len(t, prs, 1)
+		assert.Equal(t, "pr-005", prs[0].PullRequestID)
+	})
+
+	t.Run("no filter", func(t *testing.T) {
+		prs, err := prRepo.List(ctx, domain.PRFilter{Limit: 10})
+		require.NoError(t, err)
+		assert.Len(t, prs, 3)
+	})
+}
+
+func TestPRRepo_ShadowReviewers(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	pool := setupTestDB(t)
+	prRepo := NewPRRepository(pool)
+	userRepo := NewUserRepository(pool)
+	teamRepo := NewTeamRepository(pool)
+	cleanupPRs(t, pool)
+
+	ctx := context.Background()
+
+	createdTeam, err := teamRepo.Create(ctx, &domain.Team{TeamName: "test-team"})
+	require.NoError(t, err)
+	for _, id := range []string{"author7", "reviewer7", "trainee7"} {
+		_, err = userRepo.Create(ctx, &domain.User{UserID: id, Username: id, TeamID: createdTeam.ID, IsActive: true})
+		require.NoError(t, err)
+	}
+
+	_, err = prRepo.Create(ctx, &domain.PullRequest{
+		PullRequestID:     "pr-007",
+		PullRequestName:   "Shadowed",
+		AuthorID:          "author7",
+		Status:            domain.PRStatusOpen,
+		AssignedReviewers: []string{"reviewer7"},
+		ShadowReviewers:   []string{"trainee7"},
+	})
+	require.NoError(t, err)
+
+	pr, err := prRepo.GetByPRID(ctx, "pr-007")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"reviewer7"}, pr.AssignedReviewers)
+	assert.Equal(t, []string{"trainee7"}, pr.ShadowReviewers)
+
+	prs, err := prRepo.List(ctx, domain.PRFilter{Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, prs, 1)
+	assert.Equal(t, []string{"reviewer7"}, prs[0].AssignedReviewers)
+	assert.Equal(t, []string{"trainee7"}, prs[0].ShadowReviewers)
+
+	open, err := prRepo.GetOpenPRsByUserIDs(ctx, []string{"trainee7"})
+	require.NoError(t, err)
+	assert.Empty(t, open)
+}
+
+func TestPRRepo_GetPairCounts(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	pool := setupTestDB(t)
+	prRepo := NewPRRepository(pool)
+	userRepo := NewUserRepository(pool)
+	teamRepo := NewTeamRepository(pool)
+	cleanupPRs(t, pool)
+
+	ctx := context.Background()
+
+	createdTeam, err := teamRepo.Create(ctx, &domain.Team{TeamName: "test-team"})
+	require.NoError(t, err)
+	for _, id := range []string{"author8", "reviewer8", "reviewer9", "trainee8"} {
+		_, err = userRepo.Create(ctx, &domain.User{UserID: id, Username: id, TeamID: createdTeam.ID, IsActive: true})
+		require.NoError(t, err)
+	}
+
+	for _, pr := range []*domain.PullRequest{
+		{PullRequestID: "pr-008", AssignedReviewers: []string{"reviewer8"}, ShadowReviewers: []string{"trainee8"}},
+		{PullRequestID: "pr-009", AssignedReviewers: []string{"reviewer8", "reviewer9"}},
+		{PullRequestID: "pr-010", AssignedReviewers: []string{"reviewer9"}},
+	} {
+		pr.PullRequestName = pr.PullRequestID
+		pr.AuthorID = "author8"
+		pr.Status = domain.PRStatusOpen
+		_, err = prRepo.Create(ctx, pr)
+		require.NoError(t, err)
+	}
+	_, err = pool.Exec(ctx, `UPDATE pr_system.pull_requests SET created_at = now() - interval '60 days' WHERE pull_request_id = 'pr-010'`)
+	require.NoError(t, err)
+
+	counts, err := prRepo.GetPairCounts(ctx, "author8", time.Now().Add(-30*24*time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int{"reviewer8": 2, "reviewer9": 1}, counts)
+}
+
+func TestPRRepo_GetOpenReviewCounts(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	pool := setupTestDB(t)
+	prRepo := NewPRRepository(pool)
+	userRepo := NewUserRepository(pool)
+	teamRepo := NewTeamRepository(pool)
+	cleanupPRs(t, pool)
+
+	ctx := context.Background()
+
+	createdTeam, err := teamRepo.Create(ctx, &domain.Team{TeamName: "test-team"})
+	require.NoError(t, err)
+	for _, id := range []string{"author10", "reviewer10", "reviewer11", "trainee10"} {
+		_, err = userRepo.Create(ctx, &domain.User{UserID: id, Username: id, TeamID: createdTeam.ID, IsActive: true})
+		require.NoError(t, err)
+	}
+
+	for _, pr := range []*domain.PullRequest{
+		{PullRequestID: "pr-011", AssignedReviewers: []string{"reviewer10"}, ShadowReviewers: []string{"trainee10"}},
+		{PullRequestID: "pr-012", AssignedReviewers: []string{"reviewer10", "reviewer11"}},
+		{PullRequestID: "pr-013", AssignedReviewers: []string{"reviewer11"}},
+	} {
+		pr.PullRequestName = pr.PullRequestID
+		pr.AuthorID = "author10"
+		pr.Status = domain.PRStatusOpen
+		_, err = prRepo.Create(ctx, pr)
+		require.NoError(t, err)
+	}
+	_, err = pool.Exec(ctx, `UPDATE pr_system.pull_requests SET status_id = 2 WHERE pull_request_id = 'pr-013'`)
+	require.NoError(t, err)
+
+	counts, err := prRepo.GetOpenReviewCounts(ctx, []string{"reviewer10", "reviewer11", "trainee10", "author10"})
+	require.NoError(t, err)
+	// Merged PRs and shadow reviews do not count.
+	assert.Equal(t, map[string]int{"reviewer10": 2, "reviewer11": 1}, counts)
+
+	shadows, err := prRepo.GetOpenShadowCounts(ctx, []string{"reviewer10", "trainee10"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int{"trainee10": 1}, shadows)
+}
+
+func TestPRRepo_PinnedReviewers(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	pool := setupTestDB(t)
+	prRepo := NewPRRepository(pool)
+	userRepo := NewUserRepository(pool)
+	teamRepo := NewTeamRepository(pool)
+	cleanupPRs(t, pool)
+
+	ctx := context.Background()
+
+	createdTeam, err := teamRepo.Create(ctx, &domain.Team{TeamName: "test-team"})
+	require.NoError(t, err)
+	for _, id := range []string{"author12", "reviewer12", "reviewer13"} {
+		_, err = userRepo.Create(ctx, &domain.User{UserID: id, Username: id, TeamID: createdTeam.ID, IsActive: true})
+		require.NoError(t, err)
+	}
+
+	pr, err := prRepo.Create(ctx, &domain.PullRequest{
+		PullRequestID:     "pr-014",
+		PullRequestName:   "Pinned",
+		AuthorID:          "author12",
+		Status:            domain.PRStatusOpen,
+		AssignedReviewers: []string{"reviewer12"},
+	})
+	require.NoError(t, err)
+
+	pr.AssignedReviewers = append(pr.AssignedReviewers, "reviewer13")
+	pr.PinnedReviewers = []string{"reviewer13"}
+	_, err = prRepo.Update(ctx, pr)
+	require.NoError(t, err)
+
+	got, err := prRepo.GetByPRID(ctx, "pr-014")
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"reviewer12", "reviewer13"}, got.AssignedReviewers)
+	assert.Equal(t, []string{"reviewer13"}, got.PinnedReviewers)
+
+	prs, err := prRepo.List(ctx, domain.PRFilter{Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, prs, 1)
+	assert.Equal(t, []string{"reviewer13"}, prs[0].PinnedReviewers)
+
+	// The pin survives updates that keep the reviewer.
+	got.Status = domain.PRStatusMerged
+	_, err = prRepo.Update(ctx, got)
+	require.NoError(t, err)
+	got, err = prRepo.GetByPRID(ctx, "pr-014")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"reviewer13"}, got.PinnedReviewers)
+}
+
+func TestPRRepo_UpdateKeepsReviewerRows(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	pool := setupTestDB(t)
+	prRepo := NewPRRepository(pool)
+	userRepo := NewUserRepository(pool)
+	teamRepo := NewTeamRepository(pool)
+	cleanupPRs(t, pool)
+
+	ctx := context.Background()
+
+	createdTeam, err := teamRepo.Create(ctx, &domain.Team{TeamName: "test-team"})
+	require.NoError(t, err)
+	for _, id := range []string{"author13", "reviewer14", "reviewer15", "reviewer16"} {
+		_, err = userRepo.Create(ctx, &domain.User{UserID: id, Username: id, TeamID: createdTeam.ID, IsActive: true})
+		require.NoError(t, err)
+	}
+
+	pr, err := prRepo.Create(ctx, &domain.PullRequest{
+		PullRequestID:     "pr-015",
+		PullRequestName:   "Kept",
+		AuthorID:          "author13",
+		Status:            domain.PRStatusOpen,
+		AssignedReviewers: []string{"reviewer14", "reviewer15"},
+	})
+	require.NoError(t, err)
+
+	assignedAt := func(userID string) time.Time {
+		times, err := prRepo.GetReviewerAssignedAt(ctx, "pr-015")
+		require.NoError(t, err)
+		require.Contains(t, times, userID)
+		return times[userID]
+	}
+	before := assignedAt("reviewer14")
+
+	pr.AssignedReviewers = []string{"reviewer14", "reviewer16"}
+	pr.PinnedReviewers = []string{"reviewer14"}
+	_, err = prRepo.Update(ctx, pr)
+	require.NoError(t, err)
+
+	got, err := prRepo.GetByPRID(ctx, "pr-015")
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"reviewer14", "reviewer16"}, got.AssignedReviewers)
+	assert.Equal(t, []string{"reviewer14"}, got.PinnedReviewers)
+	assert.True(t, before.Equal(assignedAt("reviewer14")), "assigned_at of a kept reviewer must not change")
+	assert.False(t, assignedAt("reviewer16").Before(before))
+
+	got.PinnedReviewers = nil
+	_, err = prRepo.Update(ctx, got)
+	require.NoError(t, err)
+	got, err = prRepo.GetByPRID(ctx, "pr-015")
+	require.NoError(t, err)
+	assert.Empty(t, got.PinnedReviewers)
+
+	got.AssignedReviewers = []string{"ghost"}
+	_, err = prRepo.Update(ctx, got)
+	assert.Error(t, err)
+}
@@ -0,0 +1,146 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/ssokov/pr-reviewer-service/internal/repository"
+	"github.com/ssokov/pr-reviewer-service/internal/tenant"
+)
+
+type skillRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewSkillRepository(dbPool *pgxpool.Pool) repository.SkillRepository {
+	return &skillRepo{
+		db: dbPool,
+	}
+}
+
+// SetUserSkills replaces the skills of a user.
+func (r *skillRepo) SetUserSkills(ctx context.Context, userID string, skills []string) error {
+	tx, err := conn(ctx, r.db).Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx) //nolint:errcheck
+
+	var internalID int64
+	err = tx.QueryRow(ctx, `SELECT id FROM pr_system.users WHERE user_id = $1 AND organization_id = $2`, userID, tenant.OrganizationID(ctx)).Scan(&internalID)
+	if err != nil {
+		return err
+	}
+
+	if _, err = tx.Exec(ctx, `DELETE FROM pr_system.user_skills WHERE user_id = $1`, internalID); err != nil {
+		return err
+	}
+	if len(skills) > 0 {
+		_, err = tx.Exec(ctx, `
+			INSERT INTO pr_system.user_skills (user_id, skill)
+			SELECT $1, unnest($2::text[])
+		`, internalID, skills)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (r *skillRepo) GetSkillsByUserIDs(ctx context.Context, userIDs []string) (map[string][]string, error) {
+	skills := make(map[string][]string)
+	if len(userIDs) == 0 {
+		return skills, nil
+	}
+
+	query := `
+		SELECT u.user_id, us.skill
+		FROM pr_system.user_skills us
+		INNER JOIN pr_system.users u ON us.user_id = u.id
+		WHERE u.user_id = ANY($1) AND u.organization_id = $2
+		ORDER BY u.user_id, us.skill
+	`
+
+	rows, err := conn(ctx, r.db).Query(ctx, query, userIDs, tenant.OrganizationID(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var userID, skill string
+		if err := rows.Scan(&userID, &skill); err != nil {
+			return nil, err
+		}
+		skills[userID] = append(skills[userID], skill)
+	}
+
+	return skills, rows.Err()
+}
+
+// ListSkills returns the distinct skills of the organization's users.
+func (r *skillRepo) ListSkills(ctx context.Context) ([]string, error) {
+	query := `
+		SELECT DISTINCT us.skill
+		FROM pr_system.user_skills us
+		INNER JOIN pr_system.users u ON us.user_id = u.id
+		WHERE u.organization_id = $1
+		ORDER BY us.skill
+	`
+
+	rows, err := conn(ctx, r.db).Query(ctx, query, tenant.OrganizationID(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var skills []string
+	for rows.Next() {
+		var skill string
+		if err := rows.Scan(&skill); err != nil {
+			return nil, err
+		}
+		skills = append(skills, skill)
+	}
+
+	return skills, rows.Err()
+}
+
+// SetPRLabels replaces the labels of a PR.
+func (r *skillRepo) SetPRLabels(ctx context.Context, prID string, labels []string) error {
+	tx, err := conn(ctx, r.db).Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx) //nolint:errcheck
+
+	var internalID int64
+	err = tx.QueryRow(ctx, `SELECT id FROM pr_system.pull_requests WHERE pull_request_id = $1 AND organization_id = $2`, prID, tenant.OrganizationID(ctx)).Scan(&internalID)
+	if err != nil {
+		return err
+	}
+
+	if err = insertPRLabels(ctx, tx, internalID, labels, true); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+// insertPRLabels writes the labels of a PR by its internal id, replacing the current ones when replace is set.
+func insertPRLabels(ctx context.Context, q querier, prID int64, labels []string, replace bool) error {
+	if replace {
+		if _, err := q.Exec(ctx, `DELETE FROM pr_system.pr_labels WHERE pr_id = $1`, prID); err != nil {
+			return err
+		}
+	}
+	if len(labels) == 0 {
+		return nil
+	}
+	_, err := q.Exec(ctx, `
+		INSERT INTO pr_system.pr_labels (pr_id, label)
+		SELECT $1, unnest($2::text[])
+	`, prID, labels)
+	return err
+}
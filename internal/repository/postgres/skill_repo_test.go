@@ -0,0 +1,59 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ssokov/pr-reviewer-service/internal/model/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSkillRepo(t *testing.T) {
+	pool := setupTestDB(t)
+	skillRepo := NewSkillRepository(pool)
+	prRepo := NewPRRepository(pool)
+	userRepo := NewUserRepository(pool)
+	teamRepo := NewTeamRepository(pool)
+	cleanupPRs(t, pool)
+
+	ctx := context.Background()
+
+	team, err := teamRepo.Create(ctx, &domain.Team{TeamName: "skills-team"})
+	require.NoError(t, err)
+	for _, id := range []string{"s1", "s2"} {
+		_, err = userRepo.Create(ctx, &domain.User{UserID: id, Username: id, TeamID: team.ID, IsActive: true})
+		require.NoError(t, err)
+	}
+
+	t.Run("user skills are replaced", func(t *testing.T) {
+		require.NoError(t, skillRepo.SetUserSkills(ctx, "s1", []string{"go", "sql"}))
+		require.NoError(t, skillRepo.SetUserSkills(ctx, "s1", []string{"go", "security"}))
+		require.NoError(t, skillRepo.SetUserSkills(ctx, "s2", []string{"frontend"}))
+
+		skills, err := skillRepo.GetSkillsByUserIDs(ctx, []string{"s1", "s2"})
+		require.NoError(t, err)
+		assert.Equal(t, map[string][]string{"s1": {"go", "security"}, "s2": {"frontend"}}, skills)
+
+		all, err := skillRepo.ListSkills(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"frontend", "go", "security"}, all)
+	})
+
+	t.Run("PR labels are stored on create and replaced", func(t *testing.T) {
+		_, err := prRepo.Create(ctx, &domain.PullRequest{
+			PullRequestID:   "pr-labels",
+			PullRequestName: "Labels",
+			AuthorID:        "s1",
+			Status:          domain.PRStatusOpen,
+			Labels:          []string{"security"},
+		})
+		require.NoError(t, err)
+
+		require.NoError(t, skillRepo.SetPRLabels(ctx, "pr-labels", []string{"sql", "go"}))
+
+		pr, err := prRepo.GetByPRID(ctx, "pr-labels")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"go", "sql"}, pr.Labels)
+	})
+}
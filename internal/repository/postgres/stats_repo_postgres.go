@@ -2,10 +2,14 @@ package postgres
 
 import (
 	"context"
+	"errors"
+	"time"
 
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/ssokov/pr-reviewer-service/internal/model/domain"
 	"github.com/ssokov/pr-reviewer-service/internal/repository"
+	"github.com/ssokov/pr-reviewer-service/internal/tenant"
 )
 
 type statsRepo struct {
@@ -20,19 +24,19 @@ func NewStatsRepository(dbPool *pgxpool.Pool) repository.StatsRepository {
 
 func (r *statsRepo) GetTotalPRs(ctx context.Context) (int, error) {
 	var count int
-	err := r.db.QueryRow(ctx, `SELECT COUNT(*) FROM pr_system.pull_requests`).Scan(&count)
+	err := conn(ctx, r.db).QueryRow(ctx, `SELECT COUNT(*) FROM pr_system.pull_requests WHERE organization_id = $1`, tenant.OrganizationID(ctx)).Scan(&count)
 	return count, err
 }
 
 func (r *statsRepo) GetTotalUsers(ctx context.Context) (int, error) {
 	var count int
-	err := r.db.QueryRow(ctx, `SELECT COUNT(*) FROM pr_system.users`).Scan(&count)
+	err := conn(ctx, r.db).QueryRow(ctx, `SELECT COUNT(*) FROM pr_system.users WHERE organization_id = $1`, tenant.OrganizationID(ctx)).Scan(&count)
 	return count, err
 }
 
 func (r *statsRepo) GetActiveUsers(ctx context.Context) (int, error) {
 	var count int
-	err := r.db.QueryRow(ctx, `SELECT COUNT(*) FROM pr_system.users WHERE is_active = true`).Scan(&count)
+	err := conn(ctx, r.db).QueryRow(ctx, `SELECT COUNT(*) FROM pr_system.users WHERE is_active = true AND organization_id = $1`, tenant.OrganizationID(ctx)).Scan(&count)
 	return count, err
 }
 
@@ -40,11 +44,11 @@ func (r *statsRepo) GetPRsByStatus(ctx context.Context) (map[string]int, error)
 	query := `
 		SELECT s.name, COUNT(pr.id)
 		FROM pr_system.statuses s
-		LEFT JOIN pr_system.pull_requests pr ON pr.status_id = s.id
+		LEFT JOIN pr_system.pull_requests pr ON pr.status_id = s.id AND pr.organization_id = $1
 		GROUP BY s.name
 	`
 
-	rows, err := r.db.Query(ctx, query)
+	rows, err := conn(ctx, r.db).Query(ctx, query, tenant.OrganizationID(ctx))
 	if err != nil {
 		return nil, err
 	}
@@ -72,16 +76,16 @@ func (r *statsRepo) GetTopReviewers(ctx context.Context, limit int) ([]domain.Re
 			COUNT(DISTINCT CASE WHEN pr.status_id = 2 THEN pr.id END) as completed_count,
 			COUNT(DISTINCT CASE WHEN pr.status_id = 1 THEN pr.id END) as active_count
 		FROM pr_system.users u
-		LEFT JOIN pr_system.pr_reviewers prr ON prr.reviewer_id = u.id
+		LEFT JOIN pr_system.pr_reviewers prr ON prr.reviewer_id = u.id AND NOT prr.is_shadow
 		LEFT JOIN pr_system.pull_requests pr ON pr.id = prr.pr_id
-		WHERE u.is_active = true
+		WHERE u.is_active = true AND u.organization_id = $2
 		GROUP BY u.id, u.user_id, u.username
 		HAVING COUNT(DISTINCT pr.id) > 0
 		ORDER BY assigned_count DESC
 		LIMIT $1
 	`
 
-	rows, err := r.db.Query(ctx, query, limit)
+	rows, err := conn(ctx, r.db).Query(ctx, query, limit, tenant.OrganizationID(ctx))
 	if err != nil {
 		return nil, err
 	}
@@ -99,3 +103,136 @@ func (r *statsRepo) GetTopReviewers(ctx context.Context, limit int) ([]domain.Re
 
 	return result, rows.Err()
 }
+
+// GetOpenPRsByTeam counts open PRs by the team of their author.
+func (r *statsRepo) GetOpenPRsByTeam(ctx context.Context) (map[string]int, error) {
+	query := `
+		SELECT t.name, COUNT(pr.id)
+		FROM pr_system.pull_requests pr
+		INNER JOIN pr_system.statuses s ON pr.status_id = s.id
+		INNER JOIN pr_system.users u ON pr.author_id = u.id
+		INNER JOIN pr_system.teams t ON u.team_id = t.id
+		WHERE s.name = 'OPEN' AND pr.organization_id = $1
+		GROUP BY t.name
+	`
+
+	rows, err := conn(ctx, r.db).Query(ctx, query, tenant.OrganizationID(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := make(map[string]int)
+	for rows.Next() {
+		var team string
+		var count int
+		if err := rows.Scan(&team, &count); err != nil {
+			return nil, err
+		}
+		result[team] = count
+	}
+
+	return result, rows.Err()
+}
+
+// GetTeamStats returns the own counts of every team, ordered by name.
+func (r *statsRepo) GetTeamStats(ctx context.Context) ([]domain.TeamStats, error) {
+	query := `
+		SELECT t.id, t.parent_team_id, t.name,
+			(SELECT COUNT(*) FROM pr_system.users u WHERE u.team_id = t.id),
+			(SELECT COUNT(*) FROM pr_system.users u WHERE u.team_id = t.id AND u.is_active),
+			COUNT(pr.id) FILTER (WHERE s.name = 'OPEN'),
+			COUNT(pr.id) FILTER (WHERE s.name = 'MERGED')
+		FROM pr_system.teams t
+		LEFT JOIN pr_system.users u ON u.team_id = t.id
+		LEFT JOIN pr_system.pull_requests pr ON pr.author_id = u.id
+		LEFT JOIN pr_system.statuses s ON pr.status_id = s.id
+		WHERE t.organization_id = $1
+		GROUP BY t.id
+		ORDER BY t.name
+	`
+
+	rows, err := conn(ctx, r.db).Query(ctx, query, tenant.OrganizationID(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []domain.TeamStats
+	for rows.Next() {
+		var stats domain.TeamStats
+		var parentID *int64
+		if err := rows.Scan(&stats.TeamID, &parentID, &stats.TeamName, &stats.Members, &stats.ActiveMembers, &stats.OpenPRs, &stats.MergedPRs); err != nil {
+			return nil, err
+		}
+		if parentID != nil {
+			stats.ParentID = *parentID
+		}
+		result = append(result, stats)
+	}
+
+	return result, rows.Err()
+}
+
+// GetTeamPairings returns the team's members, ordered by user_id, and the pairings of the PRs they authored since
+// since, ordered by author and reviewer.
+func (r *statsRepo) GetTeamPairings(ctx context.Context, teamName string, since time.Time) (*domain.TeamPairings, error) {
+	var teamID int64
+	err := conn(ctx, r.db).QueryRow(ctx, `SELECT id FROM pr_system.teams WHERE name = $1 AND organization_id = $2`,
+		teamName, tenant.OrganizationID(ctx)).Scan(&teamID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	result := &domain.TeamPairings{TeamName: teamName, Pairings: []domain.Pairing{}}
+
+	if result.Members, err = teamMemberIDs(ctx, conn(ctx, r.db), teamID); err != nil {
+		return nil, err
+	}
+
+	query := `
+		SELECT author.user_id, reviewer.user_id, COUNT(*)
+		FROM pr_system.pull_requests pr
+		INNER JOIN pr_system.users author ON pr.author_id = author.id
+		INNER JOIN pr_system.pr_reviewers rev ON rev.pr_id = pr.id AND NOT rev.is_shadow
+		INNER JOIN pr_system.users reviewer ON rev.reviewer_id = reviewer.id
+		WHERE author.team_id = $1 AND pr.created_at >= $2
+		GROUP BY author.user_id, reviewer.user_id
+		ORDER BY author.user_id, reviewer.user_id
+	`
+	rows, err := conn(ctx, r.db).Query(ctx, query, teamID, since)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var pairing domain.Pairing
+		if err := rows.Scan(&pairing.AuthorID, &pairing.ReviewerID, &pairing.Count); err != nil {
+			return nil, err
+		}
+		result.Pairings = append(result.Pairings, pairing)
+	}
+	return result, rows.Err()
+}
+
+func teamMemberIDs(ctx context.Context, q querier, teamID int64) ([]string, error) {
+	rows, err := q.Query(ctx, `SELECT user_id FROM pr_system.users WHERE team_id = $1 ORDER BY user_id`, teamID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	userIDs := []string{}
+	for rows.Next() {
+		var userID string
+		if err := rows.Scan(&userID); err != nil {
+			return nil, err
+		}
+		userIDs = append(userIDs, userID)
+	}
+	return userIDs, rows.Err()
+}
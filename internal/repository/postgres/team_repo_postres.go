@@ -2,7 +2,9 @@ package postgres
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"time"
 
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
@@ -10,6 +12,7 @@ import (
 	"github.com/ssokov/pr-reviewer-service/internal/model/domain"
 	"github.com/ssokov/pr-reviewer-service/internal/repository"
 	"github.com/ssokov/pr-reviewer-service/internal/repository/postgres/mappers"
+	"github.com/ssokov/pr-reviewer-service/internal/tenant"
 )
 
 type teamRepo struct {
@@ -23,20 +26,22 @@ func NewTeamRepository(dbPool *pgxpool.Pool) repository.TeamRepository {
 }
 
 func (r *teamRepo) Create(ctx context.Context, team *domain.Team) (*domain.Team, error) {
-	tx, err := r.db.Begin(ctx)
+	tx, err := conn(ctx, r.db).Begin(ctx)
 	if err != nil {
 		return nil, err
 	}
 	defer tx.Rollback(ctx) //nolint:errcheck
 
 	query := `
-		INSERT INTO pr_system.teams (name)
-		VALUES ($1)
+		INSERT INTO pr_system.teams (name, organization_id)
+		VALUES ($1, $2)
 		RETURNING id, name, created_at
 	`
 
+	orgID := tenant.OrganizationID(ctx)
+
 	var dbTeam db.Team
-	err = tx.QueryRow(ctx, query, team.TeamName).Scan(
+	err = tx.QueryRow(ctx, query, team.TeamName, orgID).Scan(
 		&dbTeam.ID,
 		&dbTeam.TeamName,
 		&dbTeam.CreatedAt,
@@ -49,17 +54,17 @@ func (r *teamRepo) Create(ctx context.Context, team *domain.Team) (*domain.Team,
 		for _, member := range team.Members {
 			updateUserQuery := `
 				UPDATE pr_system.users
-				SET team_id = $1
-				WHERE user_id = $2
+				SET team_id = $1, team_role = COALESCE(NULLIF($4, ''), team_role)
+				WHERE user_id = $2 AND organization_id = $3
 			`
-			_, err = tx.Exec(ctx, updateUserQuery, dbTeam.ID, member.UserID)
+			_, err = tx.Exec(ctx, updateUserQuery, dbTeam.ID, member.UserID, orgID, string(member.TeamRole))
 			if err != nil {
 				return nil, err
 			}
 		}
 
 		membersQuery := `
-			SELECT id, user_id, username, is_active, team_id, created_at
+			SELECT id, user_id, username, team_role, timezone, work_start, work_end, max_open_reviews, is_active, team_id, created_at
 			FROM pr_system.users
 			WHERE team_id = $1
 		`
@@ -77,6 +82,11 @@ func (r *teamRepo) Create(ctx context.Context, team *domain.Team) (*domain.Team,
 				&user.ID,
 				&user.UserID,
 				&user.Username,
+				&user.TeamRole,
+				&user.Timezone,
+				&user.WorkStart,
+				&user.WorkEnd,
+				&user.MaxOpenReviews,
 				&user.IsActive,
 				&teamID,
 				&user.CreatedAt,
@@ -106,15 +116,20 @@ func (r *teamRepo) Create(ctx context.Context, team *domain.Team) (*domain.Team,
 
 func (r *teamRepo) GetByName(ctx context.Context, teamName string) (*domain.Team, error) {
 	query := `
-		SELECT id, name, created_at
-		FROM pr_system.teams
-		WHERE name = $1
+		SELECT t.id, t.name, t.parent_team_id, p.name, t.settings, t.created_at
+		FROM pr_system.teams t
+		LEFT JOIN pr_system.teams p ON p.id = t.parent_team_id
+		WHERE t.name = $1 AND t.organization_id = $2
 	`
 
 	var dbTeam db.Team
-	err := r.db.QueryRow(ctx, query, teamName).Scan(
+	var settings domain.TeamSettings
+	err := conn(ctx, r.db).QueryRow(ctx, query, teamName, tenant.OrganizationID(ctx)).Scan(
 		&dbTeam.ID,
 		&dbTeam.TeamName,
+		&dbTeam.ParentID,
+		&dbTeam.ParentName,
+		&settings,
 		&dbTeam.CreatedAt,
 	)
 	if err != nil {
@@ -125,11 +140,11 @@ func (r *teamRepo) GetByName(ctx context.Context, teamName string) (*domain.Team
 	}
 
 	membersQuery := `
-		SELECT id, user_id, username, is_active, team_id, created_at
+		SELECT id, user_id, username, team_role, timezone, work_start, work_end, max_open_reviews, is_active, team_id, created_at
 		FROM pr_system.users
 		WHERE team_id = $1
 	`
-	rows, err := r.db.Query(ctx, membersQuery, dbTeam.ID)
+	rows, err := conn(ctx, r.db).Query(ctx, membersQuery, dbTeam.ID)
 	if err != nil {
 		return nil, err
 	}
@@ -143,6 +158,11 @@ func (r *teamRepo) GetByName(ctx context.Context, teamName string) (*domain.Team
 			&user.ID,
 			&user.UserID,
 			&user.Username,
+			&user.TeamRole,
+			&user.Timezone,
+			&user.WorkStart,
+			&user.WorkEnd,
+			&user.MaxOpenReviews,
 			&user.IsActive,
 			&teamID,
 			&user.CreatedAt,
@@ -156,7 +176,9 @@ func (r *teamRepo) GetByName(ctx context.Context, teamName string) (*domain.Team
 		members = append(members, user)
 	}
 
-	return mappers.TeamDBToDomain(&dbTeam, members), nil
+	team := mappers.TeamDBToDomain(&dbTeam, members)
+	team.Settings = settings
+	return team, nil
 }
 
 func (r *teamRepo) ExistsByName(ctx context.Context, teamName string) (bool, error) {
@@ -164,15 +186,174 @@ func (r *teamRepo) ExistsByName(ctx context.Context, teamName string) (bool, err
 		SELECT EXISTS(
 			SELECT 1
 			FROM pr_system.teams
-			WHERE name = $1
+			WHERE name = $1 AND organization_id = $2
 		)
 	`
 
 	var exists bool
-	err := r.db.QueryRow(ctx, query, teamName).Scan(&exists)
+	err := conn(ctx, r.db).QueryRow(ctx, query, teamName, tenant.OrganizationID(ctx)).Scan(&exists)
 	if err != nil {
 		return false, err
 	}
 
 	return exists, nil
 }
+
+// List returns all teams of the organization with their members, ordered by name.
+func (r *teamRepo) List(ctx context.Context) ([]domain.Team, error) {
+	query := `
+		SELECT t.id, t.name, t.parent_team_id, p.name, t.settings, t.created_at,
+			u.id, u.user_id, u.username, u.team_role, u.is_active, u.created_at
+		FROM pr_system.teams t
+		LEFT JOIN pr_system.teams p ON p.id = t.parent_team_id
+		LEFT JOIN pr_system.users u ON u.team_id = t.id
+		WHERE t.organization_id = $1
+		ORDER BY t.name, u.user_id
+	`
+
+	rows, err := conn(ctx, r.db).Query(ctx, query, tenant.OrganizationID(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	teams := []domain.Team{}
+	for rows.Next() {
+		var dbTeam db.Team
+		var settings domain.TeamSettings
+		var (
+			userInternalID *int64
+			userID         *string
+			username       *string
+			teamRole       *string
+			isActive       *bool
+			userCreatedAt  *time.Time
+		)
+		if err := rows.Scan(
+			&dbTeam.ID,
+			&dbTeam.TeamName,
+			&dbTeam.ParentID,
+			&dbTeam.ParentName,
+			&settings,
+			&dbTeam.CreatedAt,
+			&userInternalID,
+			&userID,
+			&username,
+			&teamRole,
+			&isActive,
+			&userCreatedAt,
+		); err != nil {
+			return nil, err
+		}
+
+		if len(teams) == 0 || teams[len(teams)-1].ID != dbTeam.ID {
+			team := mappers.TeamDBToDomain(&dbTeam, []domain.User{})
+			team.Settings = settings
+			teams = append(teams, *team)
+		}
+		if userInternalID != nil {
+			team := &teams[len(teams)-1]
+			team.Members = append(team.Members, domain.User{
+				ID:        *userInternalID,
+				UserID:    *userID,
+				Username:  *username,
+				TeamRole:  domain.TeamRole(*teamRole),
+				IsActive:  *isActive,
+				TeamID:    dbTeam.ID,
+				TeamName:  dbTeam.TeamName,
+				CreatedAt: *userCreatedAt,
+			})
+		}
+	}
+
+	return teams, rows.Err()
+}
+
+// LockHierarchy locks every team row of the organization until the surrounding transaction ends. Hierarchy changes
+// take it before reading the tree, so they see each other's result; outside a transaction the lock is released at once.
+func (r *teamRepo) LockHierarchy(ctx context.Context) error {
+	query := `
+		SELECT id
+		FROM pr_system.teams
+		WHERE organization_id = $1
+		FOR UPDATE
+	`
+
+	_, err := conn(ctx, r.db).Exec(ctx, query, tenant.OrganizationID(ctx))
+	return err
+}
+
+// SetParent moves a team under another one; parentID 0 makes it a root team.
+func (r *teamRepo) SetParent(ctx context.Context, teamID int64, parentID int64) error {
+	query := `
+		UPDATE pr_system.teams
+		SET parent_team_id = $1
+		WHERE id = $2 AND organization_id = $3
+	`
+
+	_, err := conn(ctx, r.db).Exec(ctx, query, nullInt64(parentID), teamID, tenant.OrganizationID(ctx))
+	return err
+}
+
+func (r *teamRepo) UpdateSettings(ctx context.Context, teamID int64, settings domain.TeamSettings) error {
+	raw, err := json.Marshal(settings)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		UPDATE pr_system.teams
+		SET settings = $1
+		WHERE id = $2 AND organization_id = $3
+	`
+
+	_, err = conn(ctx, r.db).Exec(ctx, query, string(raw), teamID, tenant.OrganizationID(ctx))
+	return err
+}
+
+// GetAncestors returns the team followed by its parent, grandparent and so on up to the root, without members.
+func (r *teamRepo) GetAncestors(ctx context.Context, teamID int64) ([]domain.Team, error) {
+	query := `
+		WITH RECURSIVE chain AS (
+			SELECT id, name, parent_team_id, settings, created_at, 0 AS depth
+			FROM pr_system.teams
+			WHERE id = $1 AND organization_id = $2
+			UNION ALL
+			SELECT t.id, t.name, t.parent_team_id, t.settings, t.created_at, c.depth + 1
+			FROM pr_system.teams t
+			INNER JOIN chain c ON t.id = c.parent_team_id
+			WHERE c.depth < $3
+		)
+		SELECT c.id, c.name, c.parent_team_id, p.name, c.settings, c.created_at
+		FROM chain c
+		LEFT JOIN pr_system.teams p ON p.id = c.parent_team_id
+		ORDER BY c.depth
+	`
+
+	rows, err := conn(ctx, r.db).Query(ctx, query, teamID, tenant.OrganizationID(ctx), domain.MaxTeamDepth)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var teams []domain.Team
+	for rows.Next() {
+		var dbTeam db.Team
+		var settings domain.TeamSettings
+		if err := rows.Scan(
+			&dbTeam.ID,
+			&dbTeam.TeamName,
+			&dbTeam.ParentID,
+			&dbTeam.ParentName,
+			&settings,
+			&dbTeam.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		team := mappers.TeamDBToDomain(&dbTeam, nil)
+		team.Settings = settings
+		teams = append(teams, *team)
+	}
+
+	return teams, rows.Err()
+}
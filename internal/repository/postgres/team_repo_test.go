@@ -101,3 +101,101 @@ func TestTeamRepo_ExistsByName(t *testing.T) {
 		assert.False(t, exists)
 	})
 }
+
+func TestTeamRepo_List(t *testing.T) {
+	pool := setupTestDB(t)
+	teamRepo := NewTeamRepository(pool)
+	userRepo := NewUserRepository(pool)
+	cleanupTeams(t, pool)
+
+	ctx := context.Background()
+
+	backend, err := teamRepo.Create(ctx, &domain.Team{TeamName: "backend"})
+	require.NoError(t, err)
+	_, err = teamRepo.Create(ctx, &domain.Team{TeamName: "empty"})
+	require.NoError(t, err)
+	_, err = userRepo.Create(ctx, &domain.User{UserID: "u2", Username: "Bob", TeamID: backend.ID, IsActive: false})
+	require.NoError(t, err)
+	_, err = userRepo.Create(ctx, &domain.User{UserID: "u1", Username: "Alice", TeamID: backend.ID, IsActive: true})
+	require.NoError(t, err)
+
+	teams, err := teamRepo.List(ctx)
+	require.NoError(t, err)
+	require.Len(t, teams, 2)
+	assert.Equal(t, "backend", teams[0].TeamName)
+	require.Len(t, teams[0].Members, 2)
+	assert.Equal(t, "u1", teams[0].Members[0].UserID)
+	assert.Equal(t, "backend", teams[0].Members[0].TeamName)
+	assert.False(t, teams[0].Members[1].IsActive)
+	assert.Equal(t, "empty", teams[1].TeamName)
+	assert.Empty(t, teams[1].Members)
+}
+
+func TestTeamRepo_Hierarchy(t *testing.T) {
+	pool := setupTestDB(t)
+	repo := NewTeamRepository(pool)
+	cleanupTeams(t, pool)
+
+	ctx := context.Background()
+
+	eng, err := repo.Create(ctx, &domain.Team{TeamName: "engineering"})
+	require.NoError(t, err)
+	backend, err := repo.Create(ctx, &domain.Team{TeamName: "backend"})
+	require.NoError(t, err)
+	payments, err := repo.Create(ctx, &domain.Team{TeamName: "payments"})
+	require.NoError(t, err)
+
+	require.NoError(t, repo.SetParent(ctx, backend.ID, eng.ID))
+	require.NoError(t, repo.SetParent(ctx, payments.ID, backend.ID))
+
+	count := 1
+	require.NoError(t, repo.UpdateSettings(ctx, eng.ID, domain.TeamSettings{ReviewerCount: &count}))
+
+	t.Run("ancestors are ordered from the team up", func(t *testing.T) {
+		ancestors, err := repo.GetAncestors(ctx, payments.ID)
+		require.NoError(t, err)
+		require.Len(t, ancestors, 3)
+		assert.Equal(t, "payments", ancestors[0].TeamName)
+		assert.Equal(t, "backend", ancestors[0].ParentName)
+		assert.Equal(t, "engineering", ancestors[2].TeamName)
+		require.NotNil(t, ancestors[2].Settings.ReviewerCount)
+		assert.Equal(t, 1, *ancestors[2].Settings.ReviewerCount)
+	})
+
+	t.Run("get and list carry parent and settings", func(t *testing.T) {
+		found, err := repo.GetByName(ctx, "backend")
+		require.NoError(t, err)
+		assert.Equal(t, eng.ID, found.ParentID)
+		assert.Nil(t, found.Settings.ReviewerCount)
+
+		teams, err := repo.List(ctx)
+		require.NoError(t, err)
+		require.Len(t, teams, 3)
+		assert.Equal(t, "engineering", teams[1].TeamName)
+		assert.Zero(t, teams[1].ParentID)
+	})
+
+	t.Run("a team cannot be its own parent", func(t *testing.T) {
+		assert.Error(t, repo.SetParent(ctx, backend.ID, backend.ID))
+	})
+
+	t.Run("lock holds inside a transaction", func(t *testing.T) {
+		err := NewTransactor(pool).WithinTx(ctx, func(ctx context.Context) error {
+			if err := repo.LockHierarchy(ctx); err != nil {
+				return err
+			}
+			return repo.SetParent(ctx, payments.ID, eng.ID)
+		})
+		require.NoError(t, err)
+		found, err := repo.GetByName(ctx, "payments")
+		require.NoError(t, err)
+		assert.Equal(t, eng.ID, found.ParentID)
+	})
+
+	t.Run("detach", func(t *testing.T) {
+		require.NoError(t, repo.SetParent(ctx, payments.ID, 0))
+		ancestors, err := repo.GetAncestors(ctx, payments.ID)
+		require.NoError(t, err)
+		assert.Len(t, ancestors, 1)
+	})
+}
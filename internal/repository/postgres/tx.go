@@ -0,0 +1,63 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/ssokov/pr-reviewer-service/internal/repository"
+)
+
+type txKey struct{}
+
+// querier is implemented by both the pool and a transaction. Begin on a transaction opens a savepoint,
+// so repository methods that need their own transaction nest inside an outer one.
+type querier interface {
+	Begin(ctx context.Context) (pgx.Tx, error)
+	Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+}
+
+// conn returns the transaction bound to ctx by the transactor, or the pool when there is none.
+func conn(ctx context.Context, pool *pgxpool.Pool) querier {
+	if tx, ok := ctx.Value(txKey{}).(pgx.Tx); ok {
+		return tx
+	}
+	return pool
+}
+
+type transactor struct {
+	db *pgxpool.Pool
+}
+
+func NewTransactor(dbPool *pgxpool.Pool) repository.Transactor {
+	return &transactor{
+		db: dbPool,
+	}
+}
+
+func (t *transactor) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	tx, err := conn(ctx, t.db).Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(context.WithoutCancel(ctx)) //nolint:errcheck
+
+	if err := fn(context.WithValue(ctx, txKey{}, tx)); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+func (t *transactor) WithinRolledBackTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	tx, err := conn(ctx, t.db).Begin(ctx)
+	if err != nil {
+		return err
+	}
+	// The rollback must run even when the request was cancelled, otherwise the connection goes back dirty.
+	defer tx.Rollback(context.WithoutCancel(ctx)) //nolint:errcheck
+
+	return fn(context.WithValue(ctx, txKey{}, tx))
+}
@@ -0,0 +1,59 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ssokov/pr-reviewer-service/internal/model/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransactor_WithinRolledBackTx(t *testing.T) {
+	pool := setupTestDB(t)
+	transactor := NewTransactor(pool)
+	teamRepo := NewTeamRepository(pool)
+	userRepo := NewUserRepository(pool)
+	prRepo := NewPRRepository(pool)
+	cleanupPRs(t, pool)
+
+	ctx := context.Background()
+
+	team, err := teamRepo.Create(ctx, &domain.Team{TeamName: "tx-team"})
+	require.NoError(t, err)
+	_, err = userRepo.Create(ctx, &domain.User{UserID: "tx-author", Username: "Author", TeamID: team.ID, IsActive: true})
+	require.NoError(t, err)
+	_, err = userRepo.Create(ctx, &domain.User{UserID: "tx-reviewer", Username: "Reviewer", TeamID: team.ID, IsActive: true})
+	require.NoError(t, err)
+
+	err = transactor.WithinRolledBackTx(ctx, func(ctx context.Context) error {
+		// Create opens its own transaction, which becomes a savepoint here.
+		_, err := prRepo.Create(ctx, &domain.PullRequest{
+			PullRequestID:     "tx-pr",
+			PullRequestName:   "Dry run",
+			AuthorID:          "tx-author",
+			Status:            domain.PRStatusOpen,
+			AssignedReviewers: []string{"tx-reviewer"},
+		})
+		require.NoError(t, err)
+
+		deactivated, err := userRepo.DeactivateByTeamID(ctx, team.ID)
+		require.NoError(t, err)
+		assert.Len(t, deactivated, 2)
+
+		openPRs, err := prRepo.GetOpenPRsByUserIDs(ctx, []string{"tx-reviewer"})
+		require.NoError(t, err)
+		require.Len(t, openPRs, 1)
+		assert.Equal(t, []string{"tx-reviewer"}, openPRs[0].AssignedReviewers)
+		return nil
+	})
+	require.NoError(t, err)
+
+	pr, err := prRepo.GetByPRID(ctx, "tx-pr")
+	require.NoError(t, err)
+	assert.Nil(t, pr)
+
+	user, err := userRepo.GetByUserID(ctx, "tx-author")
+	require.NoError(t, err)
+	assert.True(t, user.IsActive)
+}
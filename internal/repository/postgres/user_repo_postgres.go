@@ -10,6 +10,7 @@ import (
 	"github.com/ssokov/pr-reviewer-service/internal/model/domain"
 	"github.com/ssokov/pr-reviewer-service/internal/repository"
 	"github.com/ssokov/pr-reviewer-service/internal/repository/postgres/mappers"
+	"github.com/ssokov/pr-reviewer-service/internal/tenant"
 )
 
 type userRepo struct {
@@ -24,17 +25,22 @@ func NewUserRepository(dbPool *pgxpool.Pool) repository.UserRepository {
 
 func (r *userRepo) Create(ctx context.Context, user *domain.User) (*domain.User, error) {
 	query := `
-		INSERT INTO pr_system.users (user_id, username, is_active, team_id)
-		VALUES ($1, $2, $3, $4)
-		RETURNING id, user_id, username, is_active, team_id, created_at
+		INSERT INTO pr_system.users (user_id, username, is_active, team_id, organization_id, team_role)
+		VALUES ($1, $2, $3, $4, $5, COALESCE(NULLIF($6, ''), 'member'))
+		RETURNING id, user_id, username, team_role, timezone, work_start, work_end, max_open_reviews, is_active, team_id, created_at
 	`
 
 	var dbUser db.User
 	var teamID *int64
-	err := r.db.QueryRow(ctx, query, user.UserID, user.Username, user.IsActive, nullInt64(user.TeamID)).Scan(
+	err := conn(ctx, r.db).QueryRow(ctx, query, user.UserID, user.Username, user.IsActive, nullInt64(user.TeamID), tenant.OrganizationID(ctx), string(user.TeamRole)).Scan(
 		&dbUser.ID,
 		&dbUser.UserID,
 		&dbUser.Username,
+		&dbUser.TeamRole,
+		&dbUser.Timezone,
+		&dbUser.WorkStart,
+		&dbUser.WorkEnd,
+		&dbUser.MaxOpenReviews,
 		&dbUser.IsActive,
 		&teamID,
 		&dbUser.CreatedAt,
@@ -53,17 +59,22 @@ func (r *userRepo) Create(ctx context.Context, user *domain.User) (*domain.User,
 func (r *userRepo) Update(ctx context.Context, user *domain.User) (*domain.User, error) {
 	query := `
 		UPDATE pr_system.users
-		SET username = $1, is_active = $2, team_id = $3
-		WHERE user_id = $4
-		RETURNING id, user_id, username, is_active, team_id, created_at
+		SET username = $1, is_active = $2, team_id = $3, team_role = COALESCE(NULLIF($6, ''), team_role)
+		WHERE user_id = $4 AND organization_id = $5
+		RETURNING id, user_id, username, team_role, timezone, work_start, work_end, max_open_reviews, is_active, team_id, created_at
 	`
 
 	var dbUser db.User
 	var teamID *int64
-	err := r.db.QueryRow(ctx, query, user.Username, user.IsActive, nullInt64(user.TeamID), user.UserID).Scan(
+	err := conn(ctx, r.db).QueryRow(ctx, query, user.Username, user.IsActive, nullInt64(user.TeamID), user.UserID, tenant.OrganizationID(ctx), string(user.TeamRole)).Scan(
 		&dbUser.ID,
 		&dbUser.UserID,
 		&dbUser.Username,
+		&dbUser.TeamRole,
+		&dbUser.Timezone,
+		&dbUser.WorkStart,
+		&dbUser.WorkEnd,
+		&dbUser.MaxOpenReviews,
 		&dbUser.IsActive,
 		&teamID,
 		&dbUser.CreatedAt,
@@ -84,19 +95,24 @@ func (r *userRepo) Update(ctx context.Context, user *domain.User) (*domain.User,
 
 func (r *userRepo) GetByUserID(ctx context.Context, userID string) (*domain.User, error) {
 	query := `
-		SELECT u.id, u.user_id, u.username, u.is_active, u.team_id, u.created_at, t.name as team_name
+		SELECT u.id, u.user_id, u.username, u.team_role, u.timezone, u.work_start, u.work_end, u.max_open_reviews, u.is_active, u.team_id, u.created_at, t.name as team_name
 		FROM pr_system.users u
 		LEFT JOIN pr_system.teams t ON u.team_id = t.id
-		WHERE u.user_id = $1
+		WHERE u.user_id = $1 AND u.organization_id = $2
 	`
 
 	var dbUser db.User
 	var teamID *int64
 	var teamName *string
-	err := r.db.QueryRow(ctx, query, userID).Scan(
+	err := conn(ctx, r.db).QueryRow(ctx, query, userID, tenant.OrganizationID(ctx)).Scan(
 		&dbUser.ID,
 		&dbUser.UserID,
 		&dbUser.Username,
+		&dbUser.TeamRole,
+		&dbUser.Timezone,
+		&dbUser.WorkStart,
+		&dbUser.WorkEnd,
+		&dbUser.MaxOpenReviews,
 		&dbUser.IsActive,
 		&teamID,
 		&dbUser.CreatedAt,
@@ -122,13 +138,13 @@ func (r *userRepo) GetByUserID(ctx context.Context, userID string) (*domain.User
 
 func (r *userRepo) GetByTeamID(ctx context.Context, teamID int64) ([]domain.User, error) {
 	query := `
-		SELECT u.id, u.user_id, u.username, u.is_active, u.team_id, u.created_at, t.name as team_name
+		SELECT u.id, u.user_id, u.username, u.team_role, u.timezone, u.work_start, u.work_end, u.max_open_reviews, u.is_active, u.team_id, u.created_at, t.name as team_name
 		FROM pr_system.users u
 		LEFT JOIN pr_system.teams t ON u.team_id = t.id
-		WHERE u.team_id = $1
+		WHERE u.team_id = $1 AND u.organization_id = $2
 	`
 
-	rows, err := r.db.Query(ctx, query, teamID)
+	rows, err := conn(ctx, r.db).Query(ctx, query, teamID, tenant.OrganizationID(ctx))
 	if err != nil {
 		return nil, err
 	}
@@ -143,6 +159,11 @@ func (r *userRepo) GetByTeamID(ctx context.Context, teamID int64) ([]domain.User
 			&dbUser.ID,
 			&dbUser.UserID,
 			&dbUser.Username,
+			&dbUser.TeamRole,
+			&dbUser.Timezone,
+			&dbUser.WorkStart,
+			&dbUser.WorkEnd,
+			&dbUser.MaxOpenReviews,
 			&dbUser.IsActive,
 			&teamIDPtr,
 			&dbUser.CreatedAt,
@@ -165,20 +186,174 @@ func (r *userRepo) GetByTeamID(ctx context.Context, teamID int64) ([]domain.User
 	return users, nil
 }
 
+// List returns every user of the organization, including those outside of any team, ordered by user_id.
+func (r *userRepo) List(ctx context.Context) ([]domain.User, error) {
+	query := `
+		SELECT u.id, u.user_id, u.username, u.team_role, u.timezone, u.work_start, u.work_end, u.max_open_reviews, u.is_active, u.team_id, u.created_at, t.name as team_name
+		FROM pr_system.users u
+		LEFT JOIN pr_system.teams t ON u.team_id = t.id
+		WHERE u.organization_id = $1
+		ORDER BY u.user_id
+	`
+
+	rows, err := conn(ctx, r.db).Query(ctx, query, tenant.OrganizationID(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	users := []domain.User{}
+	for rows.Next() {
+		var dbUser db.User
+		var teamID *int64
+		var teamName *string
+		if err := rows.Scan(&dbUser.ID, &dbUser.UserID, &dbUser.Username, &dbUser.TeamRole, &dbUser.Timezone, &dbUser.WorkStart, &dbUser.WorkEnd, &dbUser.MaxOpenReviews, &dbUser.IsActive, &teamID, &dbUser.CreatedAt, &teamName); err != nil {
+			return nil, err
+		}
+
+		if teamID != nil {
+			dbUser.TeamID = *teamID
+		}
+		teamNameStr := ""
+		if teamName != nil {
+			teamNameStr = *teamName
+		}
+		users = append(users, *mappers.UserDBToDomain(&dbUser, teamNameStr))
+	}
+
+	return users, rows.Err()
+}
+
 func (r *userRepo) SetIsActive(ctx context.Context, userID string, isActive bool) (*domain.User, error) {
 	query := `
 		UPDATE pr_system.users
 		SET is_active = $1
-		WHERE user_id = $2
-		RETURNING id, user_id, username, is_active, team_id, created_at
+		WHERE user_id = $2 AND organization_id = $3
+		RETURNING id, user_id, username, team_role, timezone, work_start, work_end, max_open_reviews, is_active, team_id, created_at
+	`
+
+	var dbUser db.User
+	var teamID *int64
+	err := conn(ctx, r.db).QueryRow(ctx, query, isActive, userID, tenant.OrganizationID(ctx)).Scan(
+		&dbUser.ID,
+		&dbUser.UserID,
+		&dbUser.Username,
+		&dbUser.TeamRole,
+		&dbUser.Timezone,
+		&dbUser.WorkStart,
+		&dbUser.WorkEnd,
+		&dbUser.MaxOpenReviews,
+		&dbUser.IsActive,
+		&teamID,
+		&dbUser.CreatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	if teamID != nil {
+		dbUser.TeamID = *teamID
+	}
+
+	return mappers.UserDBToDomain(&dbUser, ""), nil
+}
+
+func (r *userRepo) SetTeamRole(ctx context.Context, userID string, role domain.TeamRole) (*domain.User, error) {
+	query := `
+		UPDATE pr_system.users
+		SET team_role = $1
+		WHERE user_id = $2 AND organization_id = $3
+		RETURNING id, user_id, username, team_role, timezone, work_start, work_end, max_open_reviews, is_active, team_id, created_at
+	`
+
+	var dbUser db.User
+	var teamID *int64
+	err := conn(ctx, r.db).QueryRow(ctx, query, string(role), userID, tenant.OrganizationID(ctx)).Scan(
+		&dbUser.ID,
+		&dbUser.UserID,
+		&dbUser.Username,
+		&dbUser.TeamRole,
+		&dbUser.Timezone,
+		&dbUser.WorkStart,
+		&dbUser.WorkEnd,
+		&dbUser.MaxOpenReviews,
+		&dbUser.IsActive,
+		&teamID,
+		&dbUser.CreatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	if teamID != nil {
+		dbUser.TeamID = *teamID
+	}
+
+	return mappers.UserDBToDomain(&dbUser, ""), nil
+}
+
+func (r *userRepo) SetWorkingHours(ctx context.Context, userID, timezone string, workStart, workEnd int) (*domain.User, error) {
+	query := `
+		UPDATE pr_system.users
+		SET timezone = $1, work_start = $2, work_end = $3
+		WHERE user_id = $4 AND organization_id = $5
+		RETURNING id, user_id, username, team_role, timezone, work_start, work_end, max_open_reviews, is_active, team_id, created_at
+	`
+
+	var dbUser db.User
+	var teamID *int64
+	err := conn(ctx, r.db).QueryRow(ctx, query, timezone, workStart, workEnd, userID, tenant.OrganizationID(ctx)).Scan(
+		&dbUser.ID,
+		&dbUser.UserID,
+		&dbUser.Username,
+		&dbUser.TeamRole,
+		&dbUser.Timezone,
+		&dbUser.WorkStart,
+		&dbUser.WorkEnd,
+		&dbUser.MaxOpenReviews,
+		&dbUser.IsActive,
+		&teamID,
+		&dbUser.CreatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	if teamID != nil {
+		dbUser.TeamID = *teamID
+	}
+
+	return mappers.UserDBToDomain(&dbUser, ""), nil
+}
+
+func (r *userRepo) SetMaxOpenReviews(ctx context.Context, userID string, limit *int) (*domain.User, error) {
+	query := `
+		UPDATE pr_system.users
+		SET max_open_reviews = $1
+		WHERE user_id = $2 AND organization_id = $3
+		RETURNING id, user_id, username, team_role, timezone, work_start, work_end, max_open_reviews, is_active, team_id, created_at
 	`
 
 	var dbUser db.User
 	var teamID *int64
-	err := r.db.QueryRow(ctx, query, isActive, userID).Scan(
+	err := conn(ctx, r.db).QueryRow(ctx, query, limit, userID, tenant.OrganizationID(ctx)).Scan(
 		&dbUser.ID,
 		&dbUser.UserID,
 		&dbUser.Username,
+		&dbUser.TeamRole,
+		&dbUser.Timezone,
+		&dbUser.WorkStart,
+		&dbUser.WorkEnd,
+		&dbUser.MaxOpenReviews,
 		&dbUser.IsActive,
 		&teamID,
 		&dbUser.CreatedAt,
@@ -212,11 +387,11 @@ func (r *userRepo) GetByReviewerID(ctx context.Context, userID string) ([]domain
 		INNER JOIN pr_system.users u ON pr.author_id = u.id
 		INNER JOIN pr_system.users reviewer ON rev.reviewer_id = reviewer.id
 		INNER JOIN pr_system.statuses s ON pr.status_id = s.id
-		WHERE reviewer.user_id = $1
+		WHERE reviewer.user_id = $1 AND reviewer.organization_id = $2
 		ORDER BY pr.created_at DESC
 	`
 
-	rows, err := r.db.Query(ctx, query, userID)
+	rows, err := conn(ctx, r.db).Query(ctx, query, userID, tenant.OrganizationID(ctx))
 	if err != nil {
 		return nil, err
 	}
@@ -250,11 +425,11 @@ func (r *userRepo) DeactivateByTeamID(ctx context.Context, teamID int64) ([]doma
 		UPDATE pr_system.users u
 		SET is_active = false
 		FROM pr_system.teams t
-		WHERE u.team_id = $1 AND u.is_active = true AND u.team_id = t.id
-		RETURNING u.id, u.user_id, u.username, u.is_active, u.team_id, u.created_at, t.team_name
+		WHERE u.team_id = $1 AND u.organization_id = $2 AND u.is_active = true AND u.team_id = t.id
+		RETURNING u.id, u.user_id, u.username, u.team_role, u.timezone, u.work_start, u.work_end, u.max_open_reviews, u.is_active, u.team_id, u.created_at, t.name
 	`
 
-	rows, err := r.db.Query(ctx, query, teamID)
+	rows, err := conn(ctx, r.db).Query(ctx, query, teamID, tenant.OrganizationID(ctx))
 	if err != nil {
 		return nil, err
 	}
@@ -264,7 +439,7 @@ func (r *userRepo) DeactivateByTeamID(ctx context.Context, teamID int64) ([]doma
 	for rows.Next() {
 		var dbUser db.User
 		var teamName string
-		if err := rows.Scan(&dbUser.ID, &dbUser.UserID, &dbUser.Username, &dbUser.IsActive, &dbUser.TeamID, &dbUser.CreatedAt, &teamName); err != nil {
+		if err := rows.Scan(&dbUser.ID, &dbUser.UserID, &dbUser.Username, &dbUser.TeamRole, &dbUser.Timezone, &dbUser.WorkStart, &dbUser.WorkEnd, &dbUser.MaxOpenReviews, &dbUser.IsActive, &dbUser.TeamID, &dbUser.CreatedAt, &teamName); err != nil {
 			return nil, err
 		}
 		users = append(users, *mappers.UserDBToDomain(&dbUser, teamName))
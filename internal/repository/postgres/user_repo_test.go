@@ -108,3 +108,84 @@ func TestUserRepo_SetIsActive(t *testing.T) {
 		assert.Nil(t, updatedUser)
 	})
 }
+
+func TestUserRepo_SetWorkingHours(t *testing.T) {
+	pool := setupTestDB(t)
+	repo := NewUserRepository(pool)
+	cleanupUsers(t, pool)
+
+	ctx := context.Background()
+
+	created, err := repo.Create(ctx, &domain.User{UserID: "user789", Username: "Bob", IsActive: true})
+	require.NoError(t, err)
+	assert.Empty(t, created.Timezone)
+	assert.Equal(t, domain.DefaultWorkStart, created.WorkStart)
+
+	updated, err := repo.SetWorkingHours(ctx, "user789", "Asia/Almaty", 600, 1140)
+	require.NoError(t, err)
+	assert.Equal(t, "Asia/Almaty", updated.Timezone)
+
+	found, err := repo.GetByUserID(ctx, "user789")
+	require.NoError(t, err)
+	assert.Equal(t, 600, found.WorkStart)
+	assert.Equal(t, 1140, found.WorkEnd)
+
+	_, err = repo.SetWorkingHours(ctx, "user789", "Asia/Almaty", 1140, 600)
+	assert.Error(t, err, "working hours must start before they end")
+
+	missing, err := repo.SetWorkingHours(ctx, "ghost", "Asia/Almaty", 600, 1140)
+	require.NoError(t, err)
+	assert.Nil(t, missing)
+}
+
+func TestUserRepo_SetMaxOpenReviews(t *testing.T) {
+	pool := setupTestDB(t)
+	repo := NewUserRepository(pool)
+	cleanupUsers(t, pool)
+
+	ctx := context.Background()
+
+	created, err := repo.Create(ctx, &domain.User{UserID: "user789", Username: "Bob", IsActive: true})
+	require.NoError(t, err)
+	assert.Nil(t, created.MaxOpenReviews)
+
+	limit := 3
+	updated, err := repo.SetMaxOpenReviews(ctx, "user789", &limit)
+	require.NoError(t, err)
+	require.NotNil(t, updated.MaxOpenReviews)
+	assert.Equal(t, 3, *updated.MaxOpenReviews)
+
+	cleared, err := repo.SetMaxOpenReviews(ctx, "user789", nil)
+	require.NoError(t, err)
+	assert.Nil(t, cleared.MaxOpenReviews)
+
+	missing, err := repo.SetMaxOpenReviews(ctx, "ghost", &limit)
+	require.NoError(t, err)
+	assert.Nil(t, missing)
+}
+
+func TestUserRepo_List(t *testing.T) {
+	pool := setupTestDB(t)
+	userRepo := NewUserRepository(pool)
+	teamRepo := NewTeamRepository(pool)
+	cleanupUsers(t, pool)
+
+	ctx := context.Background()
+
+	_, err := pool.Exec(ctx, "TRUNCATE TABLE pr_system.teams CASCADE")
+	require.NoError(t, err)
+	backend, err := teamRepo.Create(ctx, &domain.Team{TeamName: "backend"})
+	require.NoError(t, err)
+	_, err = userRepo.Create(ctx, &domain.User{UserID: "u2", Username: "Bob", IsActive: true})
+	require.NoError(t, err)
+	_, err = userRepo.Create(ctx, &domain.User{UserID: "u1", Username: "Alice", TeamID: backend.ID, IsActive: true})
+	require.NoError(t, err)
+
+	users, err := userRepo.List(ctx)
+	require.NoError(t, err)
+	require.Len(t, users, 2)
+	assert.Equal(t, "u1", users[0].UserID)
+	assert.Equal(t, "backend", users[0].TeamName)
+	assert.Equal(t, "u2", users[1].UserID)
+	assert.Zero(t, users[1].TeamID)
+}
@@ -0,0 +1,103 @@
+package roster
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/ssokov/pr-reviewer-service/internal/model/domain"
+)
+
+// Spreadsheets often save CSV with a byte order mark, which would otherwise stick to the first column name.
+const utf8BOM = "\ufeff"
+
+var csvColumns = []string{columnTeamName, columnUserID, columnUsername, columnIsActive}
+
+// parseCSV expects a header row; columns are matched by name, so their order and extra columns do not matter.
+func parseCSV(r io.Reader) ([]domain.RosterEntry, []domain.RosterLineError, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, nil, errors.New("roster is empty")
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read csv header: %w", err)
+	}
+
+	index := make(map[string]int, len(header))
+	for i, name := range header {
+		index[strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, utf8BOM)))] = i
+	}
+	for _, name := range csvColumns[:3] {
+		if _, ok := index[name]; !ok {
+			return nil, nil, fmt.Errorf("csv header has no %s column", name)
+		}
+	}
+
+	var (
+		entries    []domain.RosterEntry
+		lineErrors []domain.RosterLineError
+	)
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			// The reader cannot resync after a broken quote, so report it and stop.
+			lineErrors = append(lineErrors, domain.RosterLineError{Line: parseErr.Line, Message: parseErr.Err.Error()})
+			break
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+
+		line, _ := reader.FieldPos(0)
+		field := func(name string) string {
+			i, ok := index[name]
+			if !ok || i >= len(record) {
+				return ""
+			}
+			return record[i]
+		}
+
+		isActive := true
+		if raw := strings.TrimSpace(field(columnIsActive)); raw != "" {
+			isActive, err = strconv.ParseBool(raw)
+			if err != nil {
+				lineErrors = append(lineErrors, domain.RosterLineError{Line: line, Message: fmt.Sprintf("is_active must be true or false, got %q", raw)})
+				continue
+			}
+		}
+
+		entries = append(entries, row{
+			TeamName: field(columnTeamName),
+			UserID:   field(columnUserID),
+			Username: field(columnUsername),
+			IsActive: &isActive,
+		}.entry(line))
+	}
+
+	return entries, lineErrors, nil
+}
+
+func writeCSV(w io.Writer, entries []domain.RosterEntry) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(csvColumns); err != nil {
+		return err
+	}
+	for _, e := range entries {
+		if err := writer.Write([]string{e.TeamName, e.UserID, e.Username, strconv.FormatBool(e.IsActive)}); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
@@ -0,0 +1,86 @@
+package roster
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/ssokov/pr-reviewer-service/internal/model/domain"
+)
+
+// parseJSON decodes an array of entries one element at a time, so a bad element is reported with its line
+// and the rest are still checked.
+func parseJSON(r io.Reader) ([]domain.RosterEntry, []domain.RosterLineError, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, nil, jsonSyntaxError(data, err)
+	}
+	if delim, ok := tok.(json.Delim); !ok || delim != '[' {
+		return nil, nil, errors.New("json roster must be an array of entries")
+	}
+
+	var (
+		entries    []domain.RosterEntry
+		lineErrors []domain.RosterLineError
+	)
+	for dec.More() {
+		line := lineAt(data, dec.InputOffset())
+
+		var r row
+		err := dec.Decode(&r)
+		var syntaxErr *json.SyntaxError
+		if errors.As(err, &syntaxErr) || errors.Is(err, io.ErrUnexpectedEOF) {
+			return nil, nil, jsonSyntaxError(data, err)
+		}
+		if err != nil {
+			lineErrors = append(lineErrors, domain.RosterLineError{Line: line, Message: err.Error()})
+			continue
+		}
+		entries = append(entries, r.entry(line))
+	}
+
+	if _, err := dec.Token(); err != nil {
+		return nil, nil, jsonSyntaxError(data, err)
+	}
+	return entries, lineErrors, nil
+}
+
+func jsonSyntaxError(data []byte, err error) error {
+	var syntaxErr *json.SyntaxError
+	if errors.As(err, &syntaxErr) {
+		// Offset points just past the offending byte.
+		end := min(max(syntaxErr.Offset-1, 0), int64(len(data)))
+		return fmt.Errorf("invalid json on line %d: %w", bytes.Count(data[:end], []byte("\n"))+1, err)
+	}
+	return fmt.Errorf("invalid json: %w", err)
+}
+
+// lineAt returns the line of the first value at or after offset, skipping the separators between array elements.
+func lineAt(data []byte, offset int64) int {
+	i := int(min(offset, int64(len(data))))
+	for i < len(data) && bytes.IndexByte([]byte(" \t\r\n,"), data[i]) >= 0 {
+		i++
+	}
+	return bytes.Count(data[:i], []byte("\n")) + 1
+}
+
+func writeJSON(w io.Writer, entries []domain.RosterEntry) error {
+	rows := make([]row, len(entries))
+	for i, e := range entries {
+		rows[i] = rowOf(e)
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(rows)
+}
@@ -0,0 +1,131 @@
+// Package roster reads and writes team rosters: flat lists of users with their team, kept by teams in
+// spreadsheets. CSV, JSON and YAML share the same columns.
+package roster
+
+import (
+	"fmt"
+	"io"
+	"mime"
+	"strings"
+
+	"github.com/ssokov/pr-reviewer-service/internal/model/domain"
+)
+
+type Format string
+
+const (
+	FormatCSV  Format = "csv"
+	FormatJSON Format = "json"
+	FormatYAML Format = "yaml"
+)
+
+const (
+	columnTeamName = "team_name"
+	columnUserID   = "user_id"
+	columnUsername = "username"
+	columnIsActive = "is_active"
+)
+
+// row is the JSON and YAML shape of an entry. IsActive is optional and defaults to true.
+type row struct {
+	TeamName string `json:"team_name" yaml:"team_name"`
+	UserID   string `json:"user_id" yaml:"user_id"`
+	Username string `json:"username" yaml:"username"`
+	IsActive *bool  `json:"is_active,omitempty" yaml:"is_active,omitempty"`
+}
+
+func (r row) entry(line int) domain.RosterEntry {
+	return domain.RosterEntry{
+		Line:     line,
+		TeamName: strings.TrimSpace(r.TeamName),
+		UserID:   strings.TrimSpace(r.UserID),
+		Username: strings.TrimSpace(r.Username),
+		IsActive: r.IsActive == nil || *r.IsActive,
+	}
+}
+
+func rowOf(e domain.RosterEntry) row {
+	isActive := e.IsActive
+	return row{TeamName: e.TeamName, UserID: e.UserID, Username: e.Username, IsActive: &isActive}
+}
+
+// ParseFormat accepts a format name as used in ?format= and CLI flags.
+func ParseFormat(s string) (Format, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "csv":
+		return FormatCSV, nil
+	case "json":
+		return FormatJSON, nil
+	case "yaml", "yml":
+		return FormatYAML, nil
+	default:
+		return "", fmt.Errorf("unknown roster format %q, expected csv, json or yaml", s)
+	}
+}
+
+// FormatFromContentType maps a Content-Type header to a format.
+func FormatFromContentType(contentType string) (Format, bool) {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return "", false
+	}
+
+	switch mediaType {
+	case "text/csv":
+		return FormatCSV, true
+	case "application/json":
+		return FormatJSON, true
+	case "application/yaml", "application/x-yaml", "text/yaml":
+		return FormatYAML, true
+	default:
+		return "", false
+	}
+}
+
+func (f Format) ContentType() string {
+	switch f {
+	case FormatCSV:
+		return "text/csv; charset=utf-8"
+	case FormatYAML:
+		return "application/yaml"
+	default:
+		return "application/json"
+	}
+}
+
+// Parse reads a roster. Problems confined to one entry, like a malformed is_active, end up in Roster.Errors
+// so the whole file can be reported at once; an error is returned only when the file cannot be read at all.
+func Parse(r io.Reader, format Format) (*domain.Roster, error) {
+	var (
+		entries    []domain.RosterEntry
+		lineErrors []domain.RosterLineError
+		err        error
+	)
+	switch format {
+	case FormatCSV:
+		entries, lineErrors, err = parseCSV(r)
+	case FormatJSON:
+		entries, lineErrors, err = parseJSON(r)
+	case FormatYAML:
+		entries, lineErrors, err = parseYAML(r)
+	default:
+		err = fmt.Errorf("unknown roster format %q", format)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &domain.Roster{Entries: entries, Errors: lineErrors}, nil
+}
+
+func Write(w io.Writer, format Format, entries []domain.RosterEntry) error {
+	switch format {
+	case FormatCSV:
+		return writeCSV(w, entries)
+	case FormatJSON:
+		return writeJSON(w, entries)
+	case FormatYAML:
+		return writeYAML(w, entries)
+	default:
+		return fmt.Errorf("unknown roster format %q", format)
+	}
+}
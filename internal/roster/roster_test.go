@@ -0,0 +1,139 @@
+package roster
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/ssokov/pr-reviewer-service/internal/model/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	want := []domain.RosterEntry{
+		{Line: 2, TeamName: "backend", UserID: "u1", Username: "Alice", IsActive: true},
+		{Line: 3, TeamName: "backend", UserID: "u2", Username: "Bob", IsActive: false},
+	}
+
+	t.Run("csv with reordered columns and bom", func(t *testing.T) {
+		input := utf8BOM + "user_id,team_name,username,is_active\nu1,backend,Alice,\nu2,backend,Bob,false\n"
+
+		roster, err := Parse(strings.NewReader(input), FormatCSV)
+
+		require.NoError(t, err)
+		assert.Empty(t, roster.Errors)
+		assert.Equal(t, want, roster.Entries)
+	})
+
+	t.Run("json", func(t *testing.T) {
+		input := "[\n" +
+			`{"team_name": "backend", "user_id": "u1", "username": "Alice"},` + "\n" +
+			`{"team_name": "backend", "user_id": "u2", "username": "Bob", "is_active": false}` + "\n]"
+
+		roster, err := Parse(strings.NewReader(input), FormatJSON)
+
+		require.NoError(t, err)
+		assert.Empty(t, roster.Errors)
+		assert.Equal(t, want, roster.Entries)
+	})
+
+	t.Run("yaml", func(t *testing.T) {
+		input := "# roster\n" +
+			"- {team_name: backend, user_id: u1, username: Alice}\n" +
+			"- {team_name: backend, user_id: u2, username: Bob, is_active: false}\n"
+
+		roster, err := Parse(strings.NewReader(input), FormatYAML)
+
+		require.NoError(t, err)
+		assert.Empty(t, roster.Errors)
+		assert.Equal(t, want, roster.Entries)
+	})
+}
+
+func TestParse_LineErrors(t *testing.T) {
+	t.Run("csv", func(t *testing.T) {
+		input := "team_name,user_id,username,is_active\nbackend,u1,Alice,yes\nbackend,u2,Bob,true\n"
+
+		roster, err := Parse(strings.NewReader(input), FormatCSV)
+
+		require.NoError(t, err)
+		assert.Len(t, roster.Entries, 1)
+		require.Len(t, roster.Errors, 1)
+		assert.Equal(t, 2, roster.Errors[0].Line)
+		assert.Contains(t, roster.Errors[0].Message, "is_active")
+	})
+
+	t.Run("csv without required column", func(t *testing.T) {
+		_, err := Parse(strings.NewReader("team_name,username\nbackend,Alice\n"), FormatCSV)
+		assert.ErrorContains(t, err, "user_id")
+	})
+
+	t.Run("json", func(t *testing.T) {
+		input := "[\n" +
+			`  {"team_name": "backend", "user_id": "u1", "username": "Alice", "is_active": "yes"},` + "\n" +
+			`  {"team_name": "backend", "user_id": "u2", "username": "Bob", "team": "x"}` + "\n]"
+
+		roster, err := Parse(strings.NewReader(input), FormatJSON)
+
+		require.NoError(t, err)
+		assert.Empty(t, roster.Entries)
+		require.Len(t, roster.Errors, 2)
+		assert.Equal(t, 2, roster.Errors[0].Line)
+		assert.Equal(t, 3, roster.Errors[1].Line)
+	})
+
+	t.Run("json syntax error", func(t *testing.T) {
+		_, err := Parse(strings.NewReader("[\n{\"team_name\": }\n]"), FormatJSON)
+		assert.ErrorContains(t, err, "line 2")
+	})
+
+	t.Run("yaml", func(t *testing.T) {
+		input := "- team_name: backend\n  user_id: u1\n  username: Alice\n  is_active: maybe\n"
+
+		roster, err := Parse(strings.NewReader(input), FormatYAML)
+
+		require.NoError(t, err)
+		require.Len(t, roster.Errors, 1)
+		assert.Equal(t, 1, roster.Errors[0].Line)
+	})
+}
+
+func TestWriteRoundTrip(t *testing.T) {
+	entries := []domain.RosterEntry{
+		{TeamName: "backend", UserID: "u1", Username: "Alice, Jr.", IsActive: true},
+		{TeamName: "frontend", UserID: "u2", Username: "Bob", IsActive: false},
+	}
+
+	for _, format := range []Format{FormatCSV, FormatJSON, FormatYAML} {
+		t.Run(string(format), func(t *testing.T) {
+			var buf bytes.Buffer
+			require.NoError(t, Write(&buf, format, entries))
+
+			roster, err := Parse(&buf, format)
+			require.NoError(t, err)
+			assert.Empty(t, roster.Errors)
+			require.Len(t, roster.Entries, len(entries))
+			for i := range roster.Entries {
+				roster.Entries[i].Line = 0
+			}
+			assert.Equal(t, entries, roster.Entries)
+		})
+	}
+}
+
+func TestFormat(t *testing.T) {
+	f, err := ParseFormat("YML")
+	require.NoError(t, err)
+	assert.Equal(t, FormatYAML, f)
+
+	_, err = ParseFormat("xlsx")
+	assert.Error(t, err)
+
+	f, ok := FormatFromContentType("text/csv; charset=utf-8")
+	assert.True(t, ok)
+	assert.Equal(t, FormatCSV, f)
+
+	_, ok = FormatFromContentType("text/plain")
+	assert.False(t, ok)
+}
@@ -0,0 +1,53 @@
+package roster
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/ssokov/pr-reviewer-service/internal/model/domain"
+	"gopkg.in/yaml.v3"
+)
+
+func parseYAML(r io.Reader) ([]domain.RosterEntry, []domain.RosterLineError, error) {
+	var doc yaml.Node
+	if err := yaml.NewDecoder(r).Decode(&doc); err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, nil, errors.New("roster is empty")
+		}
+		return nil, nil, fmt.Errorf("invalid yaml: %w", err)
+	}
+
+	if len(doc.Content) == 0 || doc.Content[0].Kind != yaml.SequenceNode {
+		return nil, nil, errors.New("yaml roster must be a list of entries")
+	}
+
+	var (
+		entries    []domain.RosterEntry
+		lineErrors []domain.RosterLineError
+	)
+	for _, item := range doc.Content[0].Content {
+		var r row
+		if err := item.Decode(&r); err != nil {
+			lineErrors = append(lineErrors, domain.RosterLineError{Line: item.Line, Message: err.Error()})
+			continue
+		}
+		entries = append(entries, r.entry(item.Line))
+	}
+
+	return entries, lineErrors, nil
+}
+
+func writeYAML(w io.Writer, entries []domain.RosterEntry) error {
+	rows := make([]row, len(entries))
+	for i, e := range entries {
+		rows[i] = rowOf(e)
+	}
+
+	enc := yaml.NewEncoder(w)
+	enc.SetIndent(2)
+	if err := enc.Encode(rows); err != nil {
+		return err
+	}
+	return enc.Close()
+}
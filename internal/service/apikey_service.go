@@ -0,0 +1,148 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/ssokov/pr-reviewer-service/internal/apperror"
+	"github.com/ssokov/pr-reviewer-service/internal/model/domain"
+	"github.com/ssokov/pr-reviewer-service/internal/repository"
+	"github.com/ssokov/pr-reviewer-service/internal/tracing"
+	"github.com/vmkteam/embedlog"
+)
+
+const (
+	apiKeyTag          = "prr"
+	apiKeyPrefixBytes  = 4
+	apiKeySecretBytes  = 24
+	apiKeySegmentCount = 3
+)
+
+type apiKeyService struct {
+	apiKeyRepo repository.APIKeyRepository
+	logger     embedlog.Logger
+}
+
+func NewAPIKeyService(apiKeyRepo repository.APIKeyRepository, logger embedlog.Logger) APIKeyService {
+	return &apiKeyService{
+		apiKeyRepo: apiKeyRepo,
+		logger:     logger,
+	}
+}
+
+// CreateKey stores a new key and returns it along with the plaintext value, which is never persisted.
+func (s *apiKeyService) CreateKey(ctx context.Context, name string, scopes []domain.Scope) (*domain.APIKey, string, error) {
+	if name == "" {
+		return nil, "", apperror.NewInvalidInputError("name is required")
+	}
+	if len(scopes) == 0 {
+		return nil, "", apperror.NewInvalidInputError("at least one scope is required")
+	}
+	for _, scope := range scopes {
+		if !domain.IsKnownScope(scope) {
+			return nil, "", apperror.NewInvalidInputError(fmt.Sprintf("unknown scope '%s'", scope))
+		}
+	}
+
+	prefix, err := randomHex(apiKeyPrefixBytes)
+	if err != nil {
+		return nil, "", apperror.NewInternalError("failed to generate key", err)
+	}
+	secret, err := randomHex(apiKeySecretBytes)
+	if err != nil {
+		return nil, "", apperror.NewInternalError("failed to generate key", err)
+	}
+	rawKey := fmt.Sprintf("%s_%s_%s", apiKeyTag, prefix, secret)
+
+	s.logger.Print(ctx, "creating api key", "name", name, "prefix", prefix, "scopes", scopes)
+
+	key, err := s.apiKeyRepo.Create(ctx, &domain.APIKey{
+		Name:   name,
+		Prefix: prefix,
+		Scopes: scopes,
+	}, hashAPIKey(rawKey))
+	if err != nil {
+		tracing.Logger(ctx, s.logger).Errorf("failed to create api key: %v", err)
+		return nil, "", apperror.NewInternalError("failed to create api key", err)
+	}
+
+	return key, rawKey, nil
+}
+
+func (s *apiKeyService) Authenticate(ctx context.Context, rawKey string) (*domain.APIKey, error) {
+	parts := strings.Split(rawKey, "_")
+	if len(parts) != apiKeySegmentCount || parts[0] != apiKeyTag {
+		return nil, apperror.NewUnauthorizedError("malformed api key")
+	}
+
+	key, err := s.apiKeyRepo.GetByHash(ctx, hashAPIKey(rawKey))
+	if err != nil {
+		tracing.Logger(ctx, s.logger).Errorf("failed to get api key: %v", err)
+		return nil, apperror.NewInternalError("failed to get api key", err)
+	}
+	if key == nil {
+		s.logger.Print(ctx, "unknown api key", "prefix", parts[1])
+		return nil, apperror.NewUnauthorizedError("invalid api key")
+	}
+	if key.IsRevoked() {
+		s.logger.Print(ctx, "revoked api key used", "prefix", key.Prefix)
+		return nil, apperror.NewUnauthorizedError("api key has been revoked")
+	}
+
+	if err := s.apiKeyRepo.TouchLastUsed(ctx, key.ID); err != nil {
+		tracing.Logger(ctx, s.logger).Errorf("failed to update api key usage: %v", err)
+	}
+
+	return key, nil
+}
+
+func (s *apiKeyService) RevokeKey(ctx context.Context, prefix string) (*domain.APIKey, error) {
+	if prefix == "" {
+		return nil, apperror.NewInvalidInputError("prefix is required")
+	}
+
+	s.logger.Print(ctx, "revoking api key", "prefix", prefix)
+
+	key, err := s.apiKeyRepo.Revoke(ctx, prefix)
+	if err != nil {
+		tracing.Logger(ctx, s.logger).Errorf("failed to revoke api key: %v", err)
+		return nil, apperror.NewInternalError("failed to revoke api key", err)
+	}
+	if key == nil {
+		return nil, apperror.NewNotFoundError(fmt.Sprintf("api key '%s'", prefix))
+	}
+
+	return key, nil
+}
+
+func (s *apiKeyService) ListKeys(ctx context.Context) ([]domain.APIKey, error) {
+	keys, err := s.apiKeyRepo.List(ctx)
+	if err != nil {
+		tracing.Logger(ctx, s.logger).Errorf("failed to list api keys: %v", err)
+		return nil, apperror.NewInternalError("failed to list api keys", err)
+	}
+	return keys, nil
+}
+
+// IsAPIKey reports whether a raw credential has the shape of a key issued by CreateKey. Used to accept API keys
+// sent as bearer tokens by clients that cannot set custom headers, such as SCIM provisioning in identity providers.
+func IsAPIKey(raw string) bool {
+	return strings.HasPrefix(raw, apiKeyTag+"_") && strings.Count(raw, "_") == apiKeySegmentCount-1
+}
+
+func hashAPIKey(rawKey string) string {
+	sum := sha256.Sum256([]byte(rawKey))
+	return hex.EncodeToString(sum[:])
+}
+
+func randomHex(n int) (string, error) {
+	buf := make([]byte, n)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
@@ -0,0 +1,127 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ssokov/pr-reviewer-service/internal/apperror"
+	"github.com/ssokov/pr-reviewer-service/internal/model/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/vmkteam/embedlog"
+)
+
+func TestAPIKeyService_CreateKey(t *testing.T) {
+	ctx := context.Background()
+	logger := embedlog.NewLogger(false, false)
+
+	t.Run("success", func(t *testing.T) {
+		mockRepo := new(MockAPIKeyRepository)
+		service := NewAPIKeyService(mockRepo, logger)
+
+		var storedHash string
+		mockRepo.On("Create", ctx, mock.Anything, mock.Anything).
+			Run(func(args mock.Arguments) { storedHash = args.String(2) }).
+			Return(&domain.APIKey{ID: 1, Name: "ci", Prefix: "ab12cd34"}, nil)
+
+		key, rawKey, err := service.CreateKey(ctx, "ci", []domain.Scope{domain.ScopePRWrite})
+		assert.NoError(t, err)
+		assert.NotNil(t, key)
+		assert.True(t, strings.HasPrefix(rawKey, "prr_"))
+		assert.Equal(t, hashAPIKey(rawKey), storedHash)
+		assert.NotContains(t, storedHash, rawKey)
+	})
+
+	t.Run("error - unknown scope", func(t *testing.T) {
+		mockRepo := new(MockAPIKeyRepository)
+		service := NewAPIKeyService(mockRepo, logger)
+
+		key, _, err := service.CreateKey(ctx, "ci", []domain.Scope{"root"})
+		assert.Error(t, err)
+		assert.Nil(t, key)
+		assert.True(t, apperror.Is(err, apperror.ErrCodeInvalidInput))
+	})
+
+	t.Run("error - no scopes", func(t *testing.T) {
+		mockRepo := new(MockAPIKeyRepository)
+		service := NewAPIKeyService(mockRepo, logger)
+
+		key, _, err := service.CreateKey(ctx, "ci", nil)
+		assert.Error(t, err)
+		assert.Nil(t, key)
+		assert.True(t, apperror.Is(err, apperror.ErrCodeInvalidInput))
+	})
+}
+
+func TestAPIKeyService_Authenticate(t *testing.T) {
+	ctx := context.Background()
+	logger := embedlog.NewLogger(false, false)
+	rawKey := "prr_ab12cd34_secret"
+
+	t.Run("success", func(t *testing.T) {
+		mockRepo := new(MockAPIKeyRepository)
+		service := NewAPIKeyService(mockRepo, logger)
+
+		mockRepo.On("GetByHash", ctx, hashAPIKey(rawKey)).Return(&domain.APIKey{ID: 7, Prefix: "ab12cd34"}, nil)
+		mockRepo.On("TouchLastUsed", ctx, int64(7)).Return(nil)
+
+		key, err := service.Authenticate(ctx, rawKey)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(7), key.ID)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("error - malformed", func(t *testing.T) {
+		mockRepo := new(MockAPIKeyRepository)
+		service := NewAPIKeyService(mockRepo, logger)
+
+		key, err := service.Authenticate(ctx, "garbage")
+		assert.Error(t, err)
+		assert.Nil(t, key)
+		assert.True(t, apperror.Is(err, apperror.ErrCodeUnauthorized))
+	})
+
+	t.Run("error - unknown key", func(t *testing.T) {
+		mockRepo := new(MockAPIKeyRepository)
+		service := NewAPIKeyService(mockRepo, logger)
+
+		mockRepo.On("GetByHash", ctx, hashAPIKey(rawKey)).Return(nil, nil)
+
+		key, err := service.Authenticate(ctx, rawKey)
+		assert.Error(t, err)
+		assert.Nil(t, key)
+		assert.True(t, apperror.Is(err, apperror.ErrCodeUnauthorized))
+	})
+
+	t.Run("error - revoked key", func(t *testing.T) {
+		mockRepo := new(MockAPIKeyRepository)
+		service := NewAPIKeyService(mockRepo, logger)
+
+		revokedAt := time.Now()
+		mockRepo.On("GetByHash", ctx, hashAPIKey(rawKey)).Return(&domain.APIKey{ID: 7, RevokedAt: &revokedAt}, nil)
+
+		key, err := service.Authenticate(ctx, rawKey)
+		assert.Error(t, err)
+		assert.Nil(t, key)
+		assert.True(t, apperror.Is(err, apperror.ErrCodeUnauthorized))
+	})
+}
+
+func TestAPIKeyService_RevokeKey(t *testing.T) {
+	ctx := context.Background()
+	logger := embedlog.NewLogger(false, false)
+
+	t.Run("not found", func(t *testing.T) {
+		mockRepo := new(MockAPIKeyRepository)
+		service := NewAPIKeyService(mockRepo, logger)
+
+		mockRepo.On("Revoke", ctx, "ab12cd34").Return(nil, nil)
+
+		key, err := service.RevokeKey(ctx, "ab12cd34")
+		assert.Error(t, err)
+		assert.Nil(t, key)
+		assert.True(t, apperror.Is(err, apperror.ErrCodeNotFound))
+	})
+}
@@ -0,0 +1,435 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/ssokov/pr-reviewer-service/internal/model/domain"
+	"github.com/ssokov/pr-reviewer-service/internal/repository"
+	"github.com/vmkteam/embedlog"
+)
+
+// The audited services wrap the real ones and record every successful mutation in the audit log.
+// The "before" state is read through the repositories right before the call.
+
+type auditedTeamService struct {
+	TeamService
+	teamRepo repository.TeamRepository
+	recorder *auditRecorder
+}
+
+func NewAuditedTeamService(next TeamService, teamRepo repository.TeamRepository, auditRepo repository.AuditRepository, logger embedlog.Logger) TeamService {
+	return &auditedTeamService{
+		TeamService: next,
+		teamRepo:    teamRepo,
+		recorder:    &auditRecorder{auditRepo: auditRepo, logger: logger},
+	}
+}
+
+func (s *auditedTeamService) AddTeam(ctx context.Context, team *domain.Team) (*domain.Team, error) {
+	created, err := s.TeamService.AddTeam(ctx, team)
+	if err != nil {
+		return nil, err
+	}
+
+	s.recorder.record(ctx, domain.AuditActionTeamAdd, created.TeamName, nil, created)
+	return created, nil
+}
+
+func (s *auditedTeamService) DeactivateTeam(ctx context.Context, teamName string) ([]domain.User, []domain.PullRequest, error) {
+	var before json.RawMessage
+	if team, err := s.teamRepo.GetByName(ctx, teamName); err == nil && team != nil {
+		before = s.recorder.snapshot(team)
+	}
+
+	users, prs, err := s.TeamService.DeactivateTeam(ctx, teamName)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	s.recorder.record(ctx, domain.AuditActionTeamDeactivate, teamName, before, map[string]any{
+		"deactivated_users": userIDs(users),
+		"open_prs":          prIDs(prs),
+	})
+	return users, prs, nil
+}
+
+type auditedRosterService struct {
+	RosterService
+	recorder *auditRecorder
+}
+
+func NewAuditedRosterService(next RosterService, auditRepo repository.AuditRepository, logger embedlog.Logger) RosterService {
+	return &auditedRosterService{
+		RosterService: next,
+		recorder:      &auditRecorder{auditRepo: auditRepo, logger: logger},
+	}
+}
+
+// ImportRoster records only applied imports; the changes are the "after" state.
+func (s *auditedRosterService) ImportRoster(ctx context.Context, roster *domain.Roster) (*domain.RosterImportResult, error) {
+	result, err := s.RosterService.ImportRoster(ctx, roster)
+	if err != nil {
+		return nil, err
+	}
+
+	if result.Applied {
+		s.recorder.record(ctx, domain.AuditActionTeamImport, "roster", nil, result.Changes)
+	}
+	return result, nil
+}
+
+type auditedUserService struct {
+	UserService
+	userRepo repository.UserRepository
+	recorder *auditRecorder
+}
+
+func NewAuditedUserService(next UserService, userRepo repository.UserRepository, auditRepo repository.AuditRepository, logger embedlog.Logger) UserService {
+	return &auditedUserService{
+		UserService: next,
+		userRepo:    userRepo,
+		recorder:    &auditRecorder{auditRepo: auditRepo, logger: logger},
+	}
+}
+
+func (s *auditedUserService) SetIsActive(ctx context.Context, userID string, isActive bool) (*domain.User, error) {
+	var before json.RawMessage
+	if user, err := s.userRepo.GetByUserID(ctx, userID); err == nil && user != nil {
+		before = s.recorder.snapshot(user)
+	}
+
+	user, err := s.UserService.SetIsActive(ctx, userID, isActive)
+	if err != nil {
+		return nil, err
+	}
+
+	s.recorder.record(ctx, domain.AuditActionUserSetActive, userID, before, user)
+	return user, nil
+}
+
+func (s *auditedUserService) SetTeamRole(ctx context.Context, userID string, role domain.TeamRole) (*domain.User, error) {
+	var before json.RawMessage
+	if user, err := s.userRepo.GetByUserID(ctx, userID); err == nil && user != nil {
+		before = s.recorder.snapshot(user)
+	}
+
+	user, err := s.UserService.SetTeamRole(ctx, userID, role)
+	if err != nil {
+		return nil, err
+	}
+
+	s.recorder.record(ctx, domain.AuditActionUserSetRole, userID, before, user)
+	return user, nil
+}
+
+func (s *auditedUserService) SetWorkingHours(ctx context.Context, userID, timezone string, workStart, workEnd int) (*domain.User, error) {
+	var before json.RawMessage
+	if user, err := s.userRepo.GetByUserID(ctx, userID); err == nil && user != nil {
+		before = s.recorder.snapshot(user)
+	}
+
+	user, err := s.UserService.SetWorkingHours(ctx, userID, timezone, workStart, workEnd)
+	if err != nil {
+		return nil, err
+	}
+
+	s.recorder.record(ctx, domain.AuditActionUserSetHours, userID, before, user)
+	return user, nil
+}
+
+func (s *auditedUserService) SetMaxOpenReviews(ctx context.Context, userID string, limit *int) (*domain.User, error) {
+	var before json.RawMessage
+	if user, err := s.userRepo.GetByUserID(ctx, userID); err == nil && user != nil {
+		before = s.recorder.snapshot(user)
+	}
+
+	user, err := s.UserService.SetMaxOpenReviews(ctx, userID, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	s.recorder.record(ctx, domain.AuditActionUserSetLimit, userID, before, user)
+	return user, nil
+}
+
+type auditedPRService struct {
+	PRService
+	prRepo   repository.PRRepository
+	recorder *auditRecorder
+}
+
+func NewAuditedPRService(next PRService, prRepo repository.PRRepository, auditRepo repository.AuditRepository, logger embedlog.Logger) PRService {
+	return &auditedPRService{
+		PRService: next,
+		prRepo:    prRepo,
+		recorder:  &auditRecorder{auditRepo: auditRepo, logger: logger},
+	}
+}
+
+func (s *auditedPRService) CreatePR(ctx context.Context, authorID string, pr *domain.PullRequest) (*domain.PullRequest, error) {
+	created, err := s.PRService.CreatePR(ctx, authorID, pr)
+	if err != nil {
+		return nil, err
+	}
+
+	s.recorder.record(ctx, domain.AuditActionPRCreate, created.PullRequestID, nil, created)
+	return created, nil
+}
+
+func (s *auditedPRService) MergePR(ctx context.Context, prID string) (*domain.PullRequest, error) {
+	before := s.currentPR(ctx, prID)
+
+	merged, err := s.PRService.MergePR(ctx, prID)
+	if err != nil {
+		return nil, err
+	}
+
+	s.recorder.record(ctx, domain.AuditActionPRMerge, prID, before, merged)
+	return merged, nil
+}
+
+func (s *auditedPRService) ReassignReviewer(ctx context.Context, prID string, oldUserID string) (*domain.PullRequest, string, error) {
+	before := s.currentPR(ctx, prID)
+
+	updated, newReviewerID, err := s.PRService.ReassignReviewer(ctx, prID, oldUserID)
+	if err != nil {
+		return nil, "", err
+	}
+
+	s.recorder.record(ctx, domain.AuditActionPRReassign, prID, before, updated)
+	return updated, newReviewerID, nil
+}
+
+func (s *auditedPRService) AddReviewer(ctx context.Context, prID, userID string, pin bool) (*domain.PullRequest, error) {
+	before := s.currentPR(ctx, prID)
+
+	updated, err := s.PRService.AddReviewer(ctx, prID, userID, pin)
+	if err != nil {
+		return nil, err
+	}
+
+	s.recorder.record(ctx, domain.AuditActionPRAddReviewer, prID, before, updated)
+	return updated, nil
+}
+
+func (s *auditedPRService) RemoveReviewer(ctx context.Context, prID, userID string) (*domain.PullRequest, error) {
+	before := s.currentPR(ctx, prID)
+
+	updated, err := s.PRService.RemoveReviewer(ctx, prID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	s.recorder.record(ctx, domain.AuditActionPRRemoveReviewer, prID, before, updated)
+	return updated, nil
+}
+
+// AssignPendingReviewers is only recorded when a pending PR got reviewers; retries that find nobody change nothing.
+func (s *auditedPRService) AssignPendingReviewers(ctx context.Context, prID string) (*domain.PullRequest, error) {
+	pending, err := s.prRepo.GetByPRID(ctx, prID)
+	if err != nil || pending == nil || !pending.PendingReviewers {
+		return s.PRService.AssignPendingReviewers(ctx, prID)
+	}
+
+	updated, err := s.PRService.AssignPendingReviewers(ctx, prID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !updated.PendingReviewers {
+		s.recorder.record(ctx, domain.AuditActionPRAssign, prID, s.recorder.snapshot(pending), updated)
+	}
+	return updated, nil
+}
+
+func (s *auditedPRService) currentPR(ctx context.Context, prID string) json.RawMessage {
+	pr, err := s.prRepo.GetByPRID(ctx, prID)
+	if err != nil || pr == nil {
+		return nil
+	}
+	return s.recorder.snapshot(pr)
+}
+
+type auditedCodeOwnersService struct {
+	CodeOwnersService
+	codeOwnersRepo repository.CodeOwnersRepository
+	recorder       *auditRecorder
+}
+
+func NewAuditedCodeOwnersService(next CodeOwnersService, codeOwnersRepo repository.CodeOwnersRepository, auditRepo repository.AuditRepository, logger embedlog.Logger) CodeOwnersService {
+	return &auditedCodeOwnersService{
+		CodeOwnersService: next,
+		codeOwnersRepo:    codeOwnersRepo,
+		recorder:          &auditRecorder{auditRepo: auditRepo, logger: logger},
+	}
+}
+
+func (s *auditedCodeOwnersService) Upload(ctx context.Context, file *domain.CodeOwners) (*domain.CodeOwners, []string, error) {
+	var before json.RawMessage
+	if existing, err := s.codeOwnersRepo.GetByRepository(ctx, file.Repository); err == nil && existing != nil {
+		before = s.recorder.snapshot(existing)
+	}
+
+	saved, unknown, err := s.CodeOwnersService.Upload(ctx, file)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	s.recorder.record(ctx, domain.AuditActionCodeOwnersUpload, saved.Repository, before, saved)
+	return saved, unknown, nil
+}
+
+type auditedSkillService struct {
+	SkillService
+	skillRepo repository.SkillRepository
+	prRepo    repository.PRRepository
+	recorder  *auditRecorder
+}
+
+func NewAuditedSkillService(next SkillService, skillRepo repository.SkillRepository, prRepo repository.PRRepository, auditRepo repository.AuditRepository, logger embedlog.Logger) SkillService {
+	return &auditedSkillService{
+		SkillService: next,
+		skillRepo:    skillRepo,
+		prRepo:       prRepo,
+		recorder:     &auditRecorder{auditRepo: auditRepo, logger: logger},
+	}
+}
+
+func (s *auditedSkillService) SetUserSkills(ctx context.Context, userID string, skills []string) ([]string, error) {
+	var before json.RawMessage
+	if current, err := s.skillRepo.GetSkillsByUserIDs(ctx, []string{userID}); err == nil {
+		before = s.recorder.snapshot(map[string]any{"skills": current[userID]})
+	}
+
+	updated, err := s.SkillService.SetUserSkills(ctx, userID, skills)
+	if err != nil {
+		return nil, err
+	}
+
+	s.recorder.record(ctx, domain.AuditActionUserSetSkills, userID, before, map[string]any{"skills": updated})
+	return updated, nil
+}
+
+func (s *auditedSkillService) SetPRLabels(ctx context.Context, prID string, labels []string) (*domain.PullRequest, error) {
+	var before json.RawMessage
+	if pr, err := s.prRepo.GetByPRID(ctx, prID); err == nil && pr != nil {
+		before = s.recorder.snapshot(map[string]any{"labels": pr.Labels})
+	}
+
+	pr, err := s.SkillService.SetPRLabels(ctx, prID, labels)
+	if err != nil {
+		return nil, err
+	}
+
+	s.recorder.record(ctx, domain.AuditActionPRSetLabels, prID, before, map[string]any{"labels": pr.Labels})
+	return pr, nil
+}
+
+type auditedPoolService struct {
+	PoolService
+	poolRepo repository.PoolRepository
+	teamRepo repository.TeamRepository
+	recorder *auditRecorder
+}
+
+func NewAuditedPoolService(next PoolService, poolRepo repository.PoolRepository, teamRepo repository.TeamRepository, auditRepo repository.AuditRepository, logger embedlog.Logger) PoolService {
+	return &auditedPoolService{
+		PoolService: next,
+		poolRepo:    poolRepo,
+		teamRepo:    teamRepo,
+		recorder:    &auditRecorder{auditRepo: auditRepo, logger: logger},
+	}
+}
+
+func (s *auditedPoolService) SetPool(ctx context.Context, pool *domain.ReviewerPool) (*domain.ReviewerPool, error) {
+	var before json.RawMessage
+	if existing, err := s.poolRepo.GetByName(ctx, pool.Name); err == nil && existing != nil {
+		before = s.recorder.snapshot(map[string]any{"members": userIDs(existing.Members)})
+	}
+
+	saved, err := s.PoolService.SetPool(ctx, pool)
+	if err != nil {
+		return nil, err
+	}
+
+	s.recorder.record(ctx, domain.AuditActionPoolSet, saved.Name, before, map[string]any{"members": userIDs(saved.Members)})
+	return saved, nil
+}
+
+func (s *auditedPoolService) SetFallback(ctx context.Context, chain *domain.FallbackChain) (*domain.FallbackChain, error) {
+	var before json.RawMessage
+	if team, err := s.teamRepo.GetByName(ctx, chain.TeamName); err == nil && team != nil {
+		if steps, err := s.poolRepo.GetFallback(ctx, team.ID); err == nil {
+			before = s.recorder.snapshot(steps)
+		}
+	}
+
+	saved, err := s.PoolService.SetFallback(ctx, chain)
+	if err != nil {
+		return nil, err
+	}
+
+	s.recorder.record(ctx, domain.AuditActionTeamSetFallback, saved.TeamName, before, saved.Steps)
+	return saved, nil
+}
+
+type auditedHierarchyService struct {
+	HierarchyService
+	teamRepo repository.TeamRepository
+	recorder *auditRecorder
+}
+
+func NewAuditedHierarchyService(next HierarchyService, teamRepo repository.TeamRepository, auditRepo repository.AuditRepository, logger embedlog.Logger) HierarchyService {
+	return &auditedHierarchyService{
+		HierarchyService: next,
+		teamRepo:         teamRepo,
+		recorder:         &auditRecorder{auditRepo: auditRepo, logger: logger},
+	}
+}
+
+func (s *auditedHierarchyService) SetParent(ctx context.Context, teamName, parentName string) (*domain.Team, error) {
+	var before json.RawMessage
+	if team, err := s.teamRepo.GetByName(ctx, teamName); err == nil && team != nil {
+		before = s.recorder.snapshot(map[string]any{"parent_team_name": team.ParentName})
+	}
+
+	team, err := s.HierarchyService.SetParent(ctx, teamName, parentName)
+	if err != nil {
+		return nil, err
+	}
+
+	s.recorder.record(ctx, domain.AuditActionTeamSetParent, team.TeamName, before, map[string]any{"parent_team_name": team.ParentName})
+	return team, nil
+}
+
+func (s *auditedHierarchyService) SetSettings(ctx context.Context, teamName string, settings domain.TeamSettings) (*domain.EffectiveTeamSettings, error) {
+	var before json.RawMessage
+	if team, err := s.teamRepo.GetByName(ctx, teamName); err == nil && team != nil {
+		before = s.recorder.snapshot(team.Settings)
+	}
+
+	effective, err := s.HierarchyService.SetSettings(ctx, teamName, settings)
+	if err != nil {
+		return nil, err
+	}
+
+	s.recorder.record(ctx, domain.AuditActionTeamSetSettings, effective.TeamName, before, effective.Own)
+	return effective, nil
+}
+
+func userIDs(users []domain.User) []string {
+	ids := make([]string, len(users))
+	for i, u := range users {
+		ids[i] = u.UserID
+	}
+	return ids
+}
+
+func prIDs(prs []domain.PullRequest) []string {
+	ids := make([]string, len(prs))
+	for i, pr := range prs {
+		ids[i] = pr.PullRequestID
+	}
+	return ids
+}
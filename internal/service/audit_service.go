@@ -0,0 +1,95 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/ssokov/pr-reviewer-service/internal/apperror"
+	"github.com/ssokov/pr-reviewer-service/internal/auth"
+	"github.com/ssokov/pr-reviewer-service/internal/dryrun"
+	"github.com/ssokov/pr-reviewer-service/internal/model/domain"
+	"github.com/ssokov/pr-reviewer-service/internal/repository"
+	"github.com/ssokov/pr-reviewer-service/internal/requestid"
+	"github.com/ssokov/pr-reviewer-service/internal/tracing"
+	"github.com/vmkteam/embedlog"
+)
+
+const (
+	defaultAuditLimit = 100
+	maxAuditLimit     = 10000
+)
+
+type auditService struct {
+	auditRepo repository.AuditRepository
+	logger    embedlog.Logger
+}
+
+func NewAuditService(auditRepo repository.AuditRepository, logger embedlog.Logger) AuditService {
+	return &auditService{
+		auditRepo: auditRepo,
+		logger:    logger,
+	}
+}
+
+func (s *auditService) List(ctx context.Context, filter domain.AuditFilter) ([]domain.AuditEntry, error) {
+	if filter.Limit <= 0 {
+		filter.Limit = defaultAuditLimit
+	}
+	if filter.Limit > maxAuditLimit {
+		return nil, apperror.NewInvalidInputError("limit is too large")
+	}
+	if filter.Offset < 0 {
+		return nil, apperror.NewInvalidInputError("offset must not be negative")
+	}
+	if filter.From != nil && filter.To != nil && filter.To.Before(*filter.From) {
+		return nil, apperror.NewInvalidInputError("'to' must be after 'from'")
+	}
+
+	entries, err := s.auditRepo.List(ctx, filter)
+	if err != nil {
+		tracing.Logger(ctx, s.logger).Errorf("failed to list audit entries: %v", err)
+		return nil, apperror.NewInternalError("failed to list audit entries", err)
+	}
+
+	return entries, nil
+}
+
+// auditRecorder writes audit entries for the audited service decorators.
+// A failed write is logged and does not fail the already committed operation.
+type auditRecorder struct {
+	auditRepo repository.AuditRepository
+	logger    embedlog.Logger
+}
+
+// record stores an entry; before is taken with snapshot prior to the mutation so later changes to the object don't leak into it.
+func (r *auditRecorder) record(ctx context.Context, action domain.AuditAction, target string, before json.RawMessage, after any) {
+	// A dry run changes nothing, so there is nothing to record.
+	if dryrun.Enabled(ctx) {
+		return
+	}
+
+	entry := &domain.AuditEntry{
+		Actor:     auth.Actor(ctx),
+		Action:    action,
+		Target:    target,
+		Before:    before,
+		After:     r.snapshot(after),
+		RequestID: requestid.FromContext(ctx),
+	}
+
+	if err := r.auditRepo.Create(context.WithoutCancel(ctx), entry); err != nil {
+		r.logger.Error(ctx, "failed to write audit entry", "action", action, "target", target, "error", err)
+	}
+}
+
+func (r *auditRecorder) snapshot(v any) json.RawMessage {
+	if v == nil {
+		return nil
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		r.logger.Errorf("failed to marshal audit snapshot: %v", err)
+		return nil
+	}
+	return data
+}
@@ -0,0 +1,224 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ssokov/pr-reviewer-service/internal/apperror"
+	"github.com/ssokov/pr-reviewer-service/internal/auth"
+	"github.com/ssokov/pr-reviewer-service/internal/dryrun"
+	"github.com/ssokov/pr-reviewer-service/internal/model/domain"
+	"github.com/ssokov/pr-reviewer-service/internal/requestid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/vmkteam/embedlog"
+)
+
+func TestAuditService_List(t *testing.T) {
+	ctx := context.Background()
+	logger := embedlog.NewLogger(false, false)
+
+	t.Run("success - default limit", func(t *testing.T) {
+		mockAuditRepo := new(MockAuditRepository)
+		service := NewAuditService(mockAuditRepo, logger)
+
+		mockAuditRepo.On("List", ctx, domain.AuditFilter{Limit: defaultAuditLimit}).Return([]domain.AuditEntry{{ID: 1}}, nil)
+
+		entries, err := service.List(ctx, domain.AuditFilter{})
+		assert.NoError(t, err)
+		assert.Len(t, entries, 1)
+		mockAuditRepo.AssertExpectations(t)
+	})
+
+	t.Run("error - inverted time range", func(t *testing.T) {
+		service := NewAuditService(new(MockAuditRepository), logger)
+
+		from := time.Now()
+		to := from.Add(-time.Hour)
+		entries, err := service.List(ctx, domain.AuditFilter{From: &from, To: &to})
+		assert.Nil(t, entries)
+		assert.True(t, apperror.Is(err, apperror.ErrCodeInvalidInput))
+	})
+}
+
+func TestAuditedUserService_SetIsActive(t *testing.T) {
+	logger := embedlog.NewLogger(false, false)
+	ctx := requestid.WithID(auth.WithPrincipal(context.Background(), &auth.Principal{Subject: "apikey:ab12cd34"}), "req-42")
+
+	t.Run("records before and after", func(t *testing.T) {
+		mockUserRepo := new(MockUserRepository)
+		mockAuditRepo := new(MockAuditRepository)
+		service := NewAuditedUserService(NewUserService(mockUserRepo, new(MockTeamRepository), logger), mockUserRepo, mockAuditRepo, logger)
+
+		mockUserRepo.On("GetByUserID", ctx, "u1").Return(&domain.User{UserID: "u1", IsActive: true}, nil)
+		mockUserRepo.On("SetIsActive", ctx, "u1", false).Return(&domain.User{UserID: "u1", IsActive: false}, nil)
+
+		var entry *domain.AuditEntry
+		mockAuditRepo.On("Create", mock.Anything, mock.Anything).
+			Run(func(args mock.Arguments) { entry = args.Get(1).(*domain.AuditEntry) }).
+			Return(nil)
+
+		_, err := service.SetIsActive(ctx, "u1", false)
+		assert.NoError(t, err)
+		if assert.NotNil(t, entry) {
+			assert.Equal(t, "apikey:ab12cd34", entry.Actor)
+			assert.Equal(t, domain.AuditActionUserSetActive, entry.Action)
+			assert.Equal(t, "u1", entry.Target)
+			assert.Equal(t, "req-42", entry.RequestID)
+			assert.Contains(t, string(entry.Before), `"IsActive":true`)
+			assert.Contains(t, string(entry.After), `"IsActive":false`)
+		}
+	})
+
+	t.Run("failed mutation is not recorded", func(t *testing.T) {
+		mockUserRepo := new(MockUserRepository)
+		mockAuditRepo := new(MockAuditRepository)
+		service := NewAuditedUserService(NewUserService(mockUserRepo, new(MockTeamRepository), logger), mockUserRepo, mockAuditRepo, logger)
+
+		mockUserRepo.On("GetByUserID", ctx, "u1").Return(nil, nil)
+		mockUserRepo.On("SetIsActive", ctx, "u1", false).Return(nil, nil)
+
+		_, err := service.SetIsActive(ctx, "u1", false)
+		assert.Error(t, err)
+		mockAuditRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("dry run is not recorded", func(t *testing.T) {
+		dryCtx := dryrun.With(ctx)
+		mockUserRepo := new(MockUserRepository)
+		mockAuditRepo := new(MockAuditRepository)
+		service := NewAuditedUserService(NewUserService(mockUserRepo, new(MockTeamRepository), logger), mockUserRepo, mockAuditRepo, logger)
+
+		mockUserRepo.On("GetByUserID", dryCtx, "u1").Return(&domain.User{UserID: "u1", IsActive: true}, nil)
+		mockUserRepo.On("SetIsActive", dryCtx, "u1", false).Return(&domain.User{UserID: "u1"}, nil)
+
+		_, err := service.SetIsActive(dryCtx, "u1", false)
+		assert.NoError(t, err)
+		mockAuditRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("audit write failure does not fail the call", func(t *testing.T) {
+		mockUserRepo := new(MockUserRepository)
+		mockAuditRepo := new(MockAuditRepository)
+		service := NewAuditedUserService(NewUserService(mockUserRepo, new(MockTeamRepository), logger), mockUserRepo, mockAuditRepo, logger)
+
+		mockUserRepo.On("GetByUserID", ctx, "u1").Return(&domain.User{UserID: "u1", IsActive: true}, nil)
+		mockUserRepo.On("SetIsActive", ctx, "u1", false).Return(&domain.User{UserID: "u1"}, nil)
+		mockAuditRepo.On("Create", mock.Anything, mock.Anything).Return(errors.New("db error"))
+
+		result, err := service.SetIsActive(ctx, "u1", false)
+		assert.NoError(t, err)
+		assert.NotNil(t, result)
+	})
+}
+
+func TestAuditedTeamService_DeactivateTeam(t *testing.T) {
+	ctx := context.Background()
+	logger := embedlog.NewLogger(false, false)
+
+	mockTeamRepo := new(MockTeamRepository)
+	mockUserRepo := new(MockUserRepository)
+	mockPRRepo := new(MockPRRepository)
+	mockAuditRepo := new(MockAuditRepository)
+	service := NewAuditedTeamService(NewTeamService(mockTeamRepo, mockUserRepo, mockPRRepo, logger), mockTeamRepo, mockAuditRepo, logger)
+
+	team := &domain.Team{ID: 1, TeamName: "backend"}
+	mockTeamRepo.On("GetByName", ctx, "backend").Return(team, nil)
+	mockUserRepo.On("DeactivateByTeamID", ctx, int64(1)).Return([]domain.User{{UserID: "u1"}}, nil)
+	mockPRRepo.On("GetOpenPRsByUserIDs", ctx, []string{"u1"}).Return([]domain.PullRequest{{PullRequestID: "pr1"}}, nil)
+
+	var entry *domain.AuditEntry
+	mockAuditRepo.On("Create", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) { entry = args.Get(1).(*domain.AuditEntry) }).
+		Return(nil)
+
+	_, _, err := service.DeactivateTeam(ctx, "backend")
+	assert.NoError(t, err)
+	if assert.NotNil(t, entry) {
+		assert.Equal(t, "system", entry.Actor)
+		assert.Equal(t, domain.AuditActionTeamDeactivate, entry.Action)
+		assert.JSONEq(t, `{"deactivated_users":["u1"],"open_prs":["pr1"]}`, string(entry.After))
+	}
+}
+
+func TestAuditedPRService_ReassignReviewer(t *testing.T) {
+	ctx := context.Background()
+	logger := embedlog.NewLogger(false, false)
+
+	mockPRRepo := new(MockPRRepository)
+	mockUserRepo := new(MockUserRepository)
+	mockAuditRepo := new(MockAuditRepository)
+	service := NewAuditedPRService(NewPRService(mockPRRepo, mockUserRepo, new(MockTeamRepository), new(MockCodeOwnersRepository), new(MockSkillRepository), new(MockPoolRepository), logger), mockPRRepo, mockAuditRepo, logger)
+
+	pr := &domain.PullRequest{PullRequestID: "pr1", AuthorID: "u1", Status: domain.PRStatusOpen, AssignedReviewers: []string{"u2"}}
+	mockPRRepo.On("GetByPRID", ctx, "pr1").Return(pr, nil)
+	mockUserRepo.On("GetByUserID", ctx, "u2").Return(&domain.User{UserID: "u2", TeamID: 1}, nil)
+	mockUserRepo.On("GetByTeamID", ctx, int64(1)).Return([]domain.User{{UserID: "u2", IsActive: true}, {UserID: "u3", IsActive: true}}, nil)
+	mockPRRepo.On("Update", ctx, mock.Anything).Return(&domain.PullRequest{PullRequestID: "pr1", AssignedReviewers: []string{"u3"}}, nil)
+	mockAuditRepo.On("Create", mock.Anything, mock.MatchedBy(func(e *domain.AuditEntry) bool {
+		return e.Action == domain.AuditActionPRReassign && e.Target == "pr1" && e.Before != nil && e.After != nil
+	})).Return(nil)
+
+	_, newReviewer, err := service.ReassignReviewer(ctx, "pr1", "u2")
+	assert.NoError(t, err)
+	assert.Equal(t, "u3", newReviewer)
+	mockAuditRepo.AssertExpectations(t)
+}
+
+func TestAuditedPRService_AssignPendingReviewers(t *testing.T) {
+	ctx := context.Background()
+	logger := embedlog.NewLogger(false, false)
+
+	mockPRService := new(MockPRService)
+	mockPRRepo := new(MockPRRepository)
+	mockAuditRepo := new(MockAuditRepository)
+	service := NewAuditedPRService(mockPRService, mockPRRepo, mockAuditRepo, logger)
+
+	mockPRRepo.On("GetByPRID", ctx, "pr1").Return(&domain.PullRequest{PullRequestID: "pr1", PendingReviewers: true}, nil)
+	mockPRService.On("AssignPendingReviewers", ctx, "pr1").Return(&domain.PullRequest{PullRequestID: "pr1", PendingReviewers: true}, nil).Once()
+	mockPRService.On("AssignPendingReviewers", ctx, "pr1").Return(&domain.PullRequest{PullRequestID: "pr1", AssignedReviewers: []string{"u2"}}, nil).Once()
+	mockAuditRepo.On("Create", mock.Anything, mock.MatchedBy(func(e *domain.AuditEntry) bool {
+		return e.Action == domain.AuditActionPRAssign && e.Target == "pr1" && e.Actor == "system"
+	})).Return(nil).Once()
+
+	// The first retry finds nobody and is not recorded.
+	_, err := service.AssignPendingReviewers(ctx, "pr1")
+	assert.NoError(t, err)
+	_, err = service.AssignPendingReviewers(ctx, "pr1")
+	assert.NoError(t, err)
+	mockAuditRepo.AssertExpectations(t)
+}
+
+func TestAuditedPRService_AddRemoveReviewer(t *testing.T) {
+	ctx := context.Background()
+	logger := embedlog.NewLogger(false, false)
+
+	mockPRService := new(MockPRService)
+	mockPRRepo := new(MockPRRepository)
+	mockAuditRepo := new(MockAuditRepository)
+	service := NewAuditedPRService(mockPRService, mockPRRepo, mockAuditRepo, logger)
+
+	mockPRRepo.On("GetByPRID", ctx, "pr1").Return(&domain.PullRequest{PullRequestID: "pr1", AssignedReviewers: []string{"u2"}}, nil)
+	mockPRService.On("AddReviewer", ctx, "pr1", "u3", true).Return(&domain.PullRequest{
+		PullRequestID: "pr1", AssignedReviewers: []string{"u2", "u3"}, PinnedReviewers: []string{"u3"},
+	}, nil)
+	mockPRService.On("RemoveReviewer", ctx, "pr1", "u2").Return(&domain.PullRequest{PullRequestID: "pr1"}, nil)
+	mockPRService.On("RemoveReviewer", ctx, "pr1", "u9").Return(nil, apperror.NewNotAssignedError("u9", "pr1"))
+	mockAuditRepo.On("Create", mock.Anything, mock.MatchedBy(func(e *domain.AuditEntry) bool {
+		return e.Action == domain.AuditActionPRAddReviewer && e.Target == "pr1" && e.Before != nil
+	})).Return(nil).Once()
+	mockAuditRepo.On("Create", mock.Anything, mock.MatchedBy(func(e *domain.AuditEntry) bool {
+		return e.Action == domain.AuditActionPRRemoveReviewer && e.Target == "pr1"
+	})).Return(nil).Once()
+
+	_, err := service.AddReviewer(ctx, "pr1", "u3", true)
+	assert.NoError(t, err)
+	_, err = service.RemoveReviewer(ctx, "pr1", "u2")
+	assert.NoError(t, err)
+	// Failed calls are not recorded.
+	_, err = service.RemoveReviewer(ctx, "pr1", "u9")
+	assert.Error(t, err)
+	mockAuditRepo.AssertExpectations(t)
+}
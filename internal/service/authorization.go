@@ -0,0 +1,47 @@
+package service
+
+import (
+	"context"
+
+	"github.com/ssokov/pr-reviewer-service/internal/apperror"
+	"github.com/ssokov/pr-reviewer-service/internal/auth"
+	"github.com/ssokov/pr-reviewer-service/internal/model/domain"
+	"github.com/ssokov/pr-reviewer-service/internal/repository"
+)
+
+// Role checks only apply to users authenticated by a bearer token.
+// API keys are limited by their scopes at the HTTP layer, and calls without a principal come from internal tooling.
+
+func authorizeAdmin(ctx context.Context) error {
+	p := auth.FromContext(ctx)
+	if p == nil || !p.IsUser() || p.HasRole(domain.RoleAdmin) {
+		return nil
+	}
+	return apperror.NewForbiddenError("only admins can perform this action")
+}
+
+// authorizeTeamLead lets team leads manage their own team only. The lead is looked up in the request's organization,
+// which ResolveTenant binds to the principal's, so a lead cannot borrow a same-named user of another organization.
+func authorizeTeamLead(ctx context.Context, userRepo repository.UserRepository, teamID int64) error {
+	p := auth.FromContext(ctx)
+	if p == nil || !p.IsUser() || p.HasRole(domain.RoleAdmin) {
+		return nil
+	}
+	if !p.HasRole(domain.RoleTeamLead) {
+		return apperror.NewForbiddenError("only team leads or admins can perform this action")
+	}
+
+	lead, err := userRepo.GetByUserID(ctx, p.UserID)
+	if err != nil {
+		return apperror.NewInternalError("failed to get team lead", err)
+	}
+	if lead == nil || lead.TeamID == 0 || lead.TeamID != teamID {
+		return apperror.NewForbiddenError("team leads can only manage members of their own team")
+	}
+	return nil
+}
+
+func requiresUserAuthorization(ctx context.Context) bool {
+	p := auth.FromContext(ctx)
+	return p != nil && p.IsUser() && !p.HasRole(domain.RoleAdmin)
+}
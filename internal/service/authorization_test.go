@@ -0,0 +1,108 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ssokov/pr-reviewer-service/internal/apperror"
+	"github.com/ssokov/pr-reviewer-service/internal/auth"
+	"github.com/ssokov/pr-reviewer-service/internal/model/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/vmkteam/embedlog"
+)
+
+func userContext(userID string, roles ...domain.Role) context.Context {
+	return auth.WithPrincipal(context.Background(), &auth.Principal{
+		Subject: "user:" + userID,
+		UserID:  userID,
+		Roles:   roles,
+		Scopes:  auth.ScopesForRoles(roles),
+	})
+}
+
+func TestTeamService_DeactivateTeam_Authorization(t *testing.T) {
+	logger := embedlog.NewLogger(false, false)
+
+	t.Run("error - team lead is not admin", func(t *testing.T) {
+		ctx := userContext("lead", domain.RoleTeamLead)
+		service := NewTeamService(new(MockTeamRepository), new(MockUserRepository), new(MockPRRepository), logger)
+
+		users, prs, err := service.DeactivateTeam(ctx, "Backend Team")
+		assert.Error(t, err)
+		assert.Nil(t, users)
+		assert.Nil(t, prs)
+		assert.True(t, apperror.Is(err, apperror.ErrCodeForbidden))
+	})
+
+	t.Run("success - admin", func(t *testing.T) {
+		ctx := userContext("root", domain.RoleAdmin)
+		mockTeamRepo := new(MockTeamRepository)
+		mockUserRepo := new(MockUserRepository)
+		service := NewTeamService(mockTeamRepo, mockUserRepo, new(MockPRRepository), logger)
+
+		mockTeamRepo.On("GetByName", ctx, "Backend Team").Return(&domain.Team{ID: 1, TeamName: "Backend Team"}, nil)
+		mockUserRepo.On("DeactivateByTeamID", ctx, int64(1)).Return([]domain.User{}, nil)
+
+		_, _, err := service.DeactivateTeam(ctx, "Backend Team")
+		assert.NoError(t, err)
+	})
+}
+
+func TestUserService_SetIsActive_Authorization(t *testing.T) {
+	logger := embedlog.NewLogger(false, false)
+	target := &domain.User{UserID: "u2", TeamID: 1}
+
+	t.Run("success - team lead of the same team", func(t *testing.T) {
+		ctx := userContext("lead", domain.RoleTeamLead)
+		mockUserRepo := new(MockUserRepository)
+		service := NewUserService(mockUserRepo, new(MockTeamRepository), logger)
+
+		mockUserRepo.On("GetByUserID", ctx, "u2").Return(target, nil)
+		mockUserRepo.On("GetByUserID", ctx, "lead").Return(&domain.User{UserID: "lead", TeamID: 1}, nil)
+		mockUserRepo.On("SetIsActive", ctx, "u2", false).Return(&domain.User{UserID: "u2"}, nil)
+
+		result, err := service.SetIsActive(ctx, "u2", false)
+		assert.NoError(t, err)
+		assert.NotNil(t, result)
+		mockUserRepo.AssertExpectations(t)
+	})
+
+	t.Run("error - team lead of another team", func(t *testing.T) {
+		ctx := userContext("lead", domain.RoleTeamLead)
+		mockUserRepo := new(MockUserRepository)
+		service := NewUserService(mockUserRepo, new(MockTeamRepository), logger)
+
+		mockUserRepo.On("GetByUserID", ctx, "u2").Return(target, nil)
+		mockUserRepo.On("GetByUserID", ctx, "lead").Return(&domain.User{UserID: "lead", TeamID: 2}, nil)
+
+		result, err := service.SetIsActive(ctx, "u2", false)
+		assert.Nil(t, result)
+		assert.True(t, apperror.Is(err, apperror.ErrCodeForbidden))
+		mockUserRepo.AssertNotCalled(t, "SetIsActive", ctx, "u2", false)
+	})
+
+	t.Run("error - member", func(t *testing.T) {
+		ctx := userContext("dev", domain.RoleMember)
+		mockUserRepo := new(MockUserRepository)
+		service := NewUserService(mockUserRepo, new(MockTeamRepository), logger)
+
+		mockUserRepo.On("GetByUserID", ctx, "u2").Return(target, nil)
+
+		result, err := service.SetIsActive(ctx, "u2", false)
+		assert.Nil(t, result)
+		assert.True(t, apperror.Is(err, apperror.ErrCodeForbidden))
+	})
+
+	t.Run("success - admin skips team check", func(t *testing.T) {
+		ctx := userContext("root", domain.RoleAdmin)
+		mockUserRepo := new(MockUserRepository)
+		service := NewUserService(mockUserRepo, new(MockTeamRepository), logger)
+
+		mockUserRepo.On("SetIsActive", ctx, "u2", true).Return(&domain.User{UserID: "u2", IsActive: true}, nil)
+
+		result, err := service.SetIsActive(ctx, "u2", true)
+		assert.NoError(t, err)
+		assert.True(t, result.IsActive)
+		mockUserRepo.AssertExpectations(t)
+	})
+}
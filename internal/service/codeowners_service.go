@@ -0,0 +1,113 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/ssokov/pr-reviewer-service/internal/apperror"
+	"github.com/ssokov/pr-reviewer-service/internal/codeowners"
+	"github.com/ssokov/pr-reviewer-service/internal/model/domain"
+	"github.com/ssokov/pr-reviewer-service/internal/repository"
+	"github.com/ssokov/pr-reviewer-service/internal/tracing"
+	"github.com/vmkteam/embedlog"
+)
+
+type codeOwnersService struct {
+	codeOwnersRepo repository.CodeOwnersRepository
+	userRepo       repository.UserRepository
+	teamRepo       repository.TeamRepository
+	logger         embedlog.Logger
+}
+
+func NewCodeOwnersService(codeOwnersRepo repository.CodeOwnersRepository, userRepo repository.UserRepository, teamRepo repository.TeamRepository, logger embedlog.Logger) CodeOwnersService {
+	return &codeOwnersService{
+		codeOwnersRepo: codeOwnersRepo,
+		userRepo:       userRepo,
+		teamRepo:       teamRepo,
+		logger:         logger,
+	}
+}
+
+func (s *codeOwnersService) Upload(ctx context.Context, file *domain.CodeOwners) (*domain.CodeOwners, []string, error) {
+	if err := authorizeAdmin(ctx); err != nil {
+		return nil, nil, err
+	}
+	if file.Repository == "" {
+		return nil, nil, apperror.NewInvalidInputError("repository is required")
+	}
+	if file.Mode == "" {
+		file.Mode = domain.CodeOwnersPreferred
+	}
+	if !file.Mode.IsValid() {
+		return nil, nil, apperror.NewInvalidInputError("mode must be preferred or required")
+	}
+
+	rules, err := codeowners.Parse(strings.NewReader(file.Content))
+	if err != nil {
+		var parseErr *codeowners.ParseError
+		if errors.As(err, &parseErr) {
+			return nil, nil, apperror.NewInvalidInputError(fmt.Sprintf("invalid CODEOWNERS: %v", parseErr))
+		}
+		return nil, nil, apperror.NewInvalidInputError(fmt.Sprintf("failed to read CODEOWNERS: %v", err))
+	}
+
+	unknown, err := s.unknownOwners(ctx, rules.Owners())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	s.logger.Print(ctx, "uploading CODEOWNERS", "repository", file.Repository, "rules", len(rules.Rules), "unknown_owners", len(unknown))
+
+	saved, err := s.codeOwnersRepo.Upsert(ctx, file)
+	if err != nil {
+		tracing.Logger(ctx, s.logger).Errorf("failed to save CODEOWNERS: %v", err)
+		return nil, nil, apperror.NewInternalError("failed to save CODEOWNERS", err)
+	}
+
+	return saved, unknown, nil
+}
+
+func (s *codeOwnersService) Get(ctx context.Context, repository string) (*domain.CodeOwners, error) {
+	if repository == "" {
+		return nil, apperror.NewInvalidInputError("repository is required")
+	}
+
+	file, err := s.codeOwnersRepo.GetByRepository(ctx, repository)
+	if err != nil {
+		tracing.Logger(ctx, s.logger).Errorf("failed to get CODEOWNERS: %v", err)
+		return nil, apperror.NewInternalError("failed to get CODEOWNERS", err)
+	}
+	if file == nil {
+		return nil, apperror.NewNotFoundError(fmt.Sprintf("CODEOWNERS for '%s'", repository))
+	}
+
+	return file, nil
+}
+
+// unknownOwners lists the owners matching no user or team. They are reported, not rejected: the file usually
+// comes from the repository as is, and its owners may be provisioned later.
+func (s *codeOwnersService) unknownOwners(ctx context.Context, owners []codeowners.Owner) ([]string, error) {
+	var unknown []string
+	for _, owner := range owners {
+		var (
+			exists bool
+			err    error
+		)
+		if owner.Kind == codeowners.OwnerTeam {
+			exists, err = s.teamRepo.ExistsByName(ctx, owner.Name)
+		} else {
+			var user *domain.User
+			user, err = s.userRepo.GetByUserID(ctx, owner.Name)
+			exists = user != nil
+		}
+		if err != nil {
+			return nil, apperror.NewInternalError("failed to resolve owner", err)
+		}
+		if !exists {
+			unknown = append(unknown, owner.Raw)
+		}
+	}
+	return unknown, nil
+}
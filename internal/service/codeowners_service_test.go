@@ -0,0 +1,82 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ssokov/pr-reviewer-service/internal/apperror"
+	"github.com/ssokov/pr-reviewer-service/internal/model/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/vmkteam/embedlog"
+)
+
+func TestCodeOwnersService_Upload(t *testing.T) {
+	ctx := context.Background()
+	logger := embedlog.NewLogger(false, false)
+
+	t.Run("success - reports unknown owners", func(t *testing.T) {
+		mockRepo := new(MockCodeOwnersRepository)
+		mockUserRepo := new(MockUserRepository)
+		mockTeamRepo := new(MockTeamRepository)
+		service := NewCodeOwnersService(mockRepo, mockUserRepo, mockTeamRepo, logger)
+
+		mockUserRepo.On("GetByUserID", ctx, "u1").Return(&domain.User{UserID: "u1"}, nil)
+		mockUserRepo.On("GetByUserID", ctx, "ghost").Return(nil, nil)
+		mockTeamRepo.On("ExistsByName", ctx, "backend").Return(true, nil)
+		mockRepo.On("Upsert", ctx, mock.MatchedBy(func(file *domain.CodeOwners) bool {
+			return file.Repository == "acme/api" && file.Mode == domain.CodeOwnersPreferred
+		})).Return(&domain.CodeOwners{Repository: "acme/api", Mode: domain.CodeOwnersPreferred}, nil)
+
+		saved, unknown, err := service.Upload(ctx, &domain.CodeOwners{
+			Repository: "acme/api",
+			Content:    "* @u1\n/internal/ @acme/backend @ghost\n",
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, "acme/api", saved.Repository)
+		assert.Equal(t, []string{"@ghost"}, unknown)
+
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("error - invalid lines", func(t *testing.T) {
+		mockRepo := new(MockCodeOwnersRepository)
+		service := NewCodeOwnersService(mockRepo, new(MockUserRepository), new(MockTeamRepository), logger)
+
+		_, _, err := service.Upload(ctx, &domain.CodeOwners{
+			Repository: "acme/api",
+			Content:    "* @u1\n!*.md @u2\n",
+		})
+		assert.True(t, apperror.Is(err, apperror.ErrCodeInvalidInput))
+		assert.Contains(t, err.Error(), "line 2")
+		mockRepo.AssertNotCalled(t, "Upsert", mock.Anything, mock.Anything)
+	})
+
+	t.Run("error - unknown mode", func(t *testing.T) {
+		service := NewCodeOwnersService(new(MockCodeOwnersRepository), new(MockUserRepository), new(MockTeamRepository), logger)
+
+		_, _, err := service.Upload(ctx, &domain.CodeOwners{Repository: "acme/api", Mode: "strict"})
+		assert.True(t, apperror.Is(err, apperror.ErrCodeInvalidInput))
+	})
+
+	t.Run("error - not an admin", func(t *testing.T) {
+		service := NewCodeOwnersService(new(MockCodeOwnersRepository), new(MockUserRepository), new(MockTeamRepository), logger)
+
+		_, _, err := service.Upload(userContext("lead", domain.RoleTeamLead), &domain.CodeOwners{Repository: "acme/api"})
+		assert.True(t, apperror.Is(err, apperror.ErrCodeForbidden))
+	})
+}
+
+func TestCodeOwnersService_Get(t *testing.T) {
+	ctx := context.Background()
+	logger := embedlog.NewLogger(false, false)
+
+	mockRepo := new(MockCodeOwnersRepository)
+	service := NewCodeOwnersService(mockRepo, new(MockUserRepository), new(MockTeamRepository), logger)
+
+	mockRepo.On("GetByRepository", ctx, "acme/ghost").Return(nil, nil)
+
+	file, err := service.Get(ctx, "acme/ghost")
+	assert.Nil(t, file)
+	assert.True(t, apperror.Is(err, apperror.ErrCodeNotFound))
+}
@@ -0,0 +1,366 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/ssokov/pr-reviewer-service/internal/apperror"
+	"github.com/ssokov/pr-reviewer-service/internal/auth"
+	"github.com/ssokov/pr-reviewer-service/internal/model/domain"
+	"github.com/ssokov/pr-reviewer-service/internal/repository"
+	"github.com/ssokov/pr-reviewer-service/internal/tracing"
+	"github.com/vmkteam/embedlog"
+)
+
+// reassignSkipCodes are reassignment failures that leave the review with the deprovisioned user instead of
+// failing the whole deprovisioning, e.g. when nobody else in the team is active or the reviewer is pinned.
+var reassignSkipCodes = []apperror.ErrorCode{
+	apperror.ErrCodeInvalidInput,
+	apperror.ErrCodeNoCandidate,
+	apperror.ErrCodeNotAssigned,
+	apperror.ErrCodePRMerged,
+	apperror.ErrCodePinned,
+}
+
+type directoryService struct {
+	userRepo    repository.UserRepository
+	teamRepo    repository.TeamRepository
+	userService UserService
+	prService   PRService
+	transactor  repository.Transactor
+	logger      embedlog.Logger
+}
+
+// NewDirectoryService maps directory provisioning onto users and teams. Deactivation and reassignment go through
+// userService and prService, so they are audited and counted like the same calls made over the API.
+func NewDirectoryService(
+	userRepo repository.UserRepository,
+	teamRepo repository.TeamRepository,
+	userService UserService,
+	prService PRService,
+	transactor repository.Transactor,
+	logger embedlog.Logger,
+) DirectoryService {
+	return &directoryService{
+		userRepo:    userRepo,
+		teamRepo:    teamRepo,
+		userService: userService,
+		prService:   prService,
+		transactor:  transactor,
+		logger:      logger,
+	}
+}
+
+func (s *directoryService) ListUsers(ctx context.Context) ([]domain.User, error) {
+	if err := authorizeAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	users, err := s.userRepo.List(ctx)
+	if err != nil {
+		tracing.Logger(ctx, s.logger).Errorf("failed to list users: %v", err)
+		return nil, apperror.NewInternalError("failed to list users", err)
+	}
+	return users, nil
+}
+
+func (s *directoryService) GetUser(ctx context.Context, userID string) (*domain.User, error) {
+	if err := authorizeAdmin(ctx); err != nil {
+		return nil, err
+	}
+	return s.getUser(ctx, userID)
+}
+
+func (s *directoryService) CreateUser(ctx context.Context, user *domain.User) (*domain.User, error) {
+	if err := authorizeAdmin(ctx); err != nil {
+		s.logger.Print(ctx, "directory user create denied", "actor", auth.Actor(ctx))
+		return nil, err
+	}
+	if user.UserID == "" {
+		return nil, apperror.NewInvalidInputError("userName is required")
+	}
+	if user.Username == "" {
+		user.Username = user.UserID
+	}
+
+	s.logger.Print(ctx, "provisioning user", "user_id", user.UserID)
+
+	existing, err := s.userRepo.GetByUserID(ctx, user.UserID)
+	if err != nil {
+		tracing.Logger(ctx, s.logger).Errorf("failed to get user: %v", err)
+		return nil, apperror.NewInternalError("failed to get user", err)
+	}
+	if existing != nil {
+		return nil, apperror.NewUserExistsError(user.UserID)
+	}
+
+	created, err := s.userRepo.Create(ctx, &domain.User{UserID: user.UserID, Username: user.Username, IsActive: user.IsActive})
+	if err != nil {
+		tracing.Logger(ctx, s.logger).Errorf("failed to create user: %v", err)
+		return nil, apperror.NewInternalError("failed to create user", err)
+	}
+
+	s.logger.Print(ctx, "user provisioned", "user_id", created.UserID)
+	return created, nil
+}
+
+// UpdateUser renames the user and applies its active flag. Going from active to inactive deprovisions the user;
+// the team is managed through group membership and is left as it is.
+func (s *directoryService) UpdateUser(ctx context.Context, user *domain.User) (*domain.User, error) {
+	if err := authorizeAdmin(ctx); err != nil {
+		s.logger.Print(ctx, "directory user update denied", "user_id", user.UserID, "actor", auth.Actor(ctx))
+		return nil, err
+	}
+
+	existing, err := s.getUser(ctx, user.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.Username != "" && user.Username != existing.Username {
+		updated := *existing
+		updated.Username = user.Username
+		if _, err := s.userRepo.Update(ctx, &updated); err != nil {
+			tracing.Logger(ctx, s.logger).Errorf("failed to update user: %v", err)
+			return nil, apperror.NewInternalError("failed to update user", err)
+		}
+		s.logger.Print(ctx, "user renamed", "user_id", user.UserID, "username", user.Username)
+	}
+
+	switch {
+	case existing.IsActive && !user.IsActive:
+		return s.DeprovisionUser(ctx, user.UserID)
+	case !existing.IsActive && user.IsActive:
+		if _, err := s.userService.SetIsActive(ctx, user.UserID, true); err != nil {
+			return nil, err
+		}
+	}
+
+	return s.getUser(ctx, user.UserID)
+}
+
+// DeprovisionUser deactivates the user and hands its open reviews to other members of its team. Reviews nobody
+// can take over stay assigned and are logged. Running it again retries the reviews that are still assigned.
+func (s *directoryService) DeprovisionUser(ctx context.Context, userID string) (*domain.User, error) {
+	if err := authorizeAdmin(ctx); err != nil {
+		s.logger.Print(ctx, "directory user deprovision denied", "user_id", userID, "actor", auth.Actor(ctx))
+		return nil, err
+	}
+
+	s.logger.Print(ctx, "deprovisioning user", "user_id", userID)
+
+	// Deactivation and reassignment commit together, so a failed reassignment leaves the user active and no review
+	// half moved.
+	reassigned, kept := 0, 0
+	err := s.transactor.WithinTx(ctx, func(ctx context.Context) error {
+		if _, err := s.userService.SetIsActive(ctx, userID, false); err != nil {
+			return err
+		}
+
+		reviews, err := s.userRepo.GetByReviewerID(ctx, userID)
+		if err != nil {
+			tracing.Logger(ctx, s.logger).Errorf("failed to get reviews: %v", err)
+			return apperror.NewInternalError("failed to get reviews", err)
+		}
+
+		for _, pr := range reviews {
+			if pr.Status != domain.PRStatusOpen {
+				continue
+			}
+
+			_, newReviewerID, err := s.prService.ReassignReviewer(ctx, pr.PullRequestID, userID)
+			if err != nil {
+				if !slices.Contains(reassignSkipCodes, apperror.CodeOf(err)) {
+					tracing.Logger(ctx, s.logger).Errorf("failed to reassign review of %s: %v", pr.PullRequestID, err)
+					return err
+				}
+				s.logger.Print(ctx, "review left with deprovisioned user", "pr_id", pr.PullRequestID, "user_id", userID, "error", err)
+				kept++
+				continue
+			}
+			s.logger.Print(ctx, "review reassigned", "pr_id", pr.PullRequestID, "old_user_id", userID, "new_user_id", newReviewerID)
+			reassigned++
+		}
+		return nil
+	})
+	if err != nil {
+		if errors.As(err, new(*apperror.AppError)) {
+			return nil, err
+		}
+		tracing.Logger(ctx, s.logger).Errorf("failed to deprovision user: %v", err)
+		return nil, apperror.NewInternalError("failed to deprovision user", err)
+	}
+
+	s.logger.Print(ctx, "user deprovisioned", "user_id", userID, "reassigned", reassigned, "kept", kept)
+	return s.getUser(ctx, userID)
+}
+
+func (s *directoryService) ListGroups(ctx context.Context) ([]domain.Team, error) {
+	if err := authorizeAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	teams, err := s.teamRepo.List(ctx)
+	if err != nil {
+		tracing.Logger(ctx, s.logger).Errorf("failed to list teams: %v", err)
+		return nil, apperror.NewInternalError("failed to list teams", err)
+	}
+	return teams, nil
+}
+
+func (s *directoryService) GetGroup(ctx context.Context, teamName string) (*domain.Team, error) {
+	if err := authorizeAdmin(ctx); err != nil {
+		return nil, err
+	}
+	return s.getTeam(ctx, teamName)
+}
+
+// CreateGroup creates the team and moves the listed users into it.
+func (s *directoryService) CreateGroup(ctx context.Context, teamName string, memberIDs []string) (*domain.Team, error) {
+	if err := authorizeAdmin(ctx); err != nil {
+		s.logger.Print(ctx, "directory group create denied", "team_name", teamName, "actor", auth.Actor(ctx))
+		return nil, err
+	}
+	if teamName == "" {
+		return nil, apperror.NewInvalidInputError("displayName is required")
+	}
+
+	s.logger.Print(ctx, "provisioning team", "team_name", teamName, "members", len(memberIDs))
+
+	exists, err := s.teamRepo.ExistsByName(ctx, teamName)
+	if err != nil {
+		tracing.Logger(ctx, s.logger).Errorf("failed to check team existence: %v", err)
+		return nil, apperror.NewInternalError("failed to check team existence", err)
+	}
+	if exists {
+		return nil, apperror.NewTeamExistsError(teamName)
+	}
+
+	members, err := s.getMembers(ctx, memberIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.teamRepo.Create(ctx, &domain.Team{TeamName: teamName, Members: members}); err != nil {
+		tracing.Logger(ctx, s.logger).Errorf("failed to create team: %v", err)
+		return nil, apperror.NewInternalError("failed to create team", err)
+	}
+
+	s.logger.Print(ctx, "team provisioned", "team_name", teamName)
+	return s.getTeam(ctx, teamName)
+}
+
+// UpdateGroupMembers moves the added users into the team and takes the removed ones out of it. Removing a user
+// that is not a member is a no-op.
+func (s *directoryService) UpdateGroupMembers(ctx context.Context, teamName string, add, remove []string) (*domain.Team, error) {
+	if err := authorizeAdmin(ctx); err != nil {
+		s.logger.Print(ctx, "directory group update denied", "team_name", teamName, "actor", auth.Actor(ctx))
+		return nil, err
+	}
+
+	team, err := s.getTeam(ctx, teamName)
+	if err != nil {
+		return nil, err
+	}
+	return s.applyMembers(ctx, team, add, remove)
+}
+
+// ReplaceGroupMembers makes memberIDs the exact member list of the team.
+func (s *directoryService) ReplaceGroupMembers(ctx context.Context, teamName string, memberIDs []string) (*domain.Team, error) {
+	if err := authorizeAdmin(ctx); err != nil {
+		s.logger.Print(ctx, "directory group update denied", "team_name", teamName, "actor", auth.Actor(ctx))
+		return nil, err
+	}
+
+	team, err := s.getTeam(ctx, teamName)
+	if err != nil {
+		return nil, err
+	}
+
+	var remove []string
+	for _, m := range team.Members {
+		if !slices.Contains(memberIDs, m.UserID) {
+			remove = append(remove, m.UserID)
+		}
+	}
+	return s.applyMembers(ctx, team, memberIDs, remove)
+}
+
+func (s *directoryService) applyMembers(ctx context.Context, team *domain.Team, add, remove []string) (*domain.Team, error) {
+	added, err := s.getMembers(ctx, add)
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Print(ctx, "updating team members", "team_name", team.TeamName, "add", len(add), "remove", len(remove))
+
+	err = s.transactor.WithinTx(ctx, func(ctx context.Context) error {
+		for _, u := range added {
+			if u.TeamID == team.ID {
+				continue
+			}
+			u.TeamID = team.ID
+			if _, err := s.userRepo.Update(ctx, &u); err != nil {
+				return fmt.Errorf("add %s: %w", u.UserID, err)
+			}
+		}
+		for _, m := range team.Members {
+			if !slices.Contains(remove, m.UserID) || slices.Contains(add, m.UserID) {
+				continue
+			}
+			m.TeamID = 0
+			if _, err := s.userRepo.Update(ctx, &m); err != nil {
+				return fmt.Errorf("remove %s: %w", m.UserID, err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		tracing.Logger(ctx, s.logger).Errorf("failed to update team members: %v", err)
+		return nil, apperror.NewInternalError("failed to update team members", err)
+	}
+
+	return s.getTeam(ctx, team.TeamName)
+}
+
+func (s *directoryService) getMembers(ctx context.Context, userIDs []string) ([]domain.User, error) {
+	members := make([]domain.User, 0, len(userIDs))
+	for _, id := range userIDs {
+		u, err := s.userRepo.GetByUserID(ctx, id)
+		if err != nil {
+			tracing.Logger(ctx, s.logger).Errorf("failed to get user: %v", err)
+			return nil, apperror.NewInternalError("failed to get user", err)
+		}
+		if u == nil {
+			return nil, apperror.NewInvalidInputError(fmt.Sprintf("member '%s' does not exist", id))
+		}
+		members = append(members, *u)
+	}
+	return members, nil
+}
+
+func (s *directoryService) getUser(ctx context.Context, userID string) (*domain.User, error) {
+	user, err := s.userRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		tracing.Logger(ctx, s.logger).Errorf("failed to get user: %v", err)
+		return nil, apperror.NewInternalError("failed to get user", err)
+	}
+	if user == nil {
+		return nil, apperror.NewUserNotFoundError(userID)
+	}
+	return user, nil
+}
+
+func (s *directoryService) getTeam(ctx context.Context, teamName string) (*domain.Team, error) {
+	team, err := s.teamRepo.GetByName(ctx, teamName)
+	if err != nil {
+		tracing.Logger(ctx, s.logger).Errorf("failed to get team: %v", err)
+		return nil, apperror.NewInternalError("failed to get team", err)
+	}
+	if team == nil {
+		return nil, apperror.NewTeamNotFoundError(teamName)
+	}
+	return team, nil
+}
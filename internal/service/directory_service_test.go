@@ -0,0 +1,233 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ssokov/pr-reviewer-service/internal/apperror"
+	"github.com/ssokov/pr-reviewer-service/internal/model/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/vmkteam/embedlog"
+)
+
+type directoryMocks struct {
+	userRepo    *MockUserRepository
+	teamRepo    *MockTeamRepository
+	userService *MockUserService
+	prService   *MockPRService
+	transactor  *MockTransactor
+}
+
+func newDirectoryService() (DirectoryService, directoryMocks) {
+	m := directoryMocks{
+		userRepo:    new(MockUserRepository),
+		teamRepo:    new(MockTeamRepository),
+		userService: new(MockUserService),
+		prService:   new(MockPRService),
+		transactor:  new(MockTransactor),
+	}
+	return NewDirectoryService(m.userRepo, m.teamRepo, m.userService, m.prService, m.transactor, embedlog.NewLogger(false, false)), m
+}
+
+func TestDirectoryService_DeprovisionUser(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("deactivates and reassigns open reviews", func(t *testing.T) {
+		service, m := newDirectoryService()
+
+		m.transactor.On("WithinTx", ctx).Return(nil)
+		m.userService.On("SetIsActive", inTx, "u2", false).Return(&domain.User{UserID: "u2"}, nil).Once()
+		m.userRepo.On("GetByReviewerID", inTx, "u2").Return([]domain.PullRequest{
+			{PullRequestID: "pr-1", Status: domain.PRStatusOpen},
+			{PullRequestID: "pr-2", Status: domain.PRStatusMerged},
+			{PullRequestID: "pr-3", Status: domain.PRStatusOpen},
+		}, nil)
+		m.prService.On("ReassignReviewer", inTx, "pr-1", "u2").Return(&domain.PullRequest{}, "u3", nil).Once()
+		m.prService.On("ReassignReviewer", inTx, "pr-3", "u2").Return(nil, "", apperror.NewInvalidInputError("no active reviewers in team")).Once()
+		m.userRepo.On("GetByUserID", ctx, "u2").Return(&domain.User{UserID: "u2", TeamName: "backend"}, nil)
+
+		user, err := service.DeprovisionUser(ctx, "u2")
+
+		require.NoError(t, err)
+		assert.Equal(t, "backend", user.TeamName)
+		m.userService.AssertExpectations(t)
+		m.prService.AssertExpectations(t)
+		m.prService.AssertNotCalled(t, "ReassignReviewer", ctx, "pr-2", "u2")
+	})
+
+	t.Run("keeps pinned reviews", func(t *testing.T) {
+		service, m := newDirectoryService()
+
+		m.transactor.On("WithinTx", ctx).Return(nil)
+		m.userService.On("SetIsActive", inTx, "u2", false).Return(&domain.User{UserID: "u2"}, nil)
+		m.userRepo.On("GetByReviewerID", inTx, "u2").Return([]domain.PullRequest{{PullRequestID: "pr-1", Status: domain.PRStatusOpen}}, nil)
+		m.prService.On("ReassignReviewer", inTx, "pr-1", "u2").Return(nil, "", apperror.NewPinnedError("u2", "pr-1")).Once()
+		m.userRepo.On("GetByUserID", ctx, "u2").Return(&domain.User{UserID: "u2"}, nil)
+
+		_, err := service.DeprovisionUser(ctx, "u2")
+
+		require.NoError(t, err)
+		m.prService.AssertExpectations(t)
+	})
+
+	t.Run("stops on unexpected reassignment failure", func(t *testing.T) {
+		service, m := newDirectoryService()
+
+		m.transactor.On("WithinTx", ctx).Return(nil)
+		m.userService.On("SetIsActive", inTx, "u2", false).Return(&domain.User{UserID: "u2"}, nil)
+		m.userRepo.On("GetByReviewerID", inTx, "u2").Return([]domain.PullRequest{{PullRequestID: "pr-1", Status: domain.PRStatusOpen}}, nil)
+		m.prService.On("ReassignReviewer", inTx, "pr-1", "u2").Return(nil, "", apperror.NewInternalError("failed to update PR", errors.New("db down")))
+
+		_, err := service.DeprovisionUser(ctx, "u2")
+
+		assert.True(t, apperror.Is(err, apperror.ErrCodeInternalError))
+	})
+
+	t.Run("unknown user", func(t *testing.T) {
+		service, m := newDirectoryService()
+
+		m.transactor.On("WithinTx", ctx).Return(nil)
+		m.userService.On("SetIsActive", inTx, "ghost", false).Return(nil, apperror.NewUserNotFoundError("ghost"))
+
+		_, err := service.DeprovisionUser(ctx, "ghost")
+
+		assert.True(t, apperror.Is(err, apperror.ErrCodeUserNotFound))
+	})
+
+	t.Run("team lead is not allowed", func(t *testing.T) {
+		service, m := newDirectoryService()
+
+		_, err := service.DeprovisionUser(userContext("lead", domain.RoleTeamLead), "u2")
+
+		assert.True(t, apperror.Is(err, apperror.ErrCodeForbidden))
+		m.userService.AssertNotCalled(t, "SetIsActive", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestDirectoryService_UpdateUser(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("rename and deactivate", func(t *testing.T) {
+		service, m := newDirectoryService()
+
+		m.userRepo.On("GetByUserID", ctx, "u2").Return(&domain.User{UserID: "u2", Username: "Bob", TeamID: 1, IsActive: true}, nil)
+		m.userRepo.On("Update", ctx, &domain.User{UserID: "u2", Username: "Robert", TeamID: 1, IsActive: true}).Return(&domain.User{}, nil).Once()
+		m.transactor.On("WithinTx", ctx).Return(nil)
+		m.userService.On("SetIsActive", inTx, "u2", false).Return(&domain.User{UserID: "u2"}, nil).Once()
+		m.userRepo.On("GetByReviewerID", inTx, "u2").Return([]domain.PullRequest{}, nil)
+
+		_, err := service.UpdateUser(ctx, &domain.User{UserID: "u2", Username: "Robert", IsActive: false})
+
+		require.NoError(t, err)
+		m.userRepo.AssertExpectations(t)
+		m.userService.AssertExpectations(t)
+	})
+
+	t.Run("unchanged inactive user is not deprovisioned again", func(t *testing.T) {
+		service, m := newDirectoryService()
+
+		m.userRepo.On("GetByUserID", ctx, "u2").Return(&domain.User{UserID: "u2", Username: "Bob", IsActive: false}, nil)
+
+		_, err := service.UpdateUser(ctx, &domain.User{UserID: "u2", Username: "Bob", IsActive: false})
+
+		require.NoError(t, err)
+		m.userService.AssertNotCalled(t, "SetIsActive", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("reactivation", func(t *testing.T) {
+		service, m := newDirectoryService()
+
+		m.userRepo.On("GetByUserID", ctx, "u2").Return(&domain.User{UserID: "u2", Username: "Bob", IsActive: false}, nil)
+		m.userService.On("SetIsActive", ctx, "u2", true).Return(&domain.User{UserID: "u2", IsActive: true}, nil).Once()
+
+		_, err := service.UpdateUser(ctx, &domain.User{UserID: "u2", IsActive: true})
+
+		require.NoError(t, err)
+		m.userService.AssertExpectations(t)
+		m.userRepo.AssertNotCalled(t, "GetByReviewerID", mock.Anything, mock.Anything)
+	})
+}
+
+func TestDirectoryService_CreateUser(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("creates user without team", func(t *testing.T) {
+		service, m := newDirectoryService()
+
+		m.userRepo.On("GetByUserID", ctx, "u9").Return(nil, nil)
+		m.userRepo.On("Create", ctx, &domain.User{UserID: "u9", Username: "u9", IsActive: true}).
+			Return(&domain.User{UserID: "u9", Username: "u9", IsActive: true}, nil).Once()
+
+		user, err := service.CreateUser(ctx, &domain.User{UserID: "u9", IsActive: true})
+
+		require.NoError(t, err)
+		assert.Equal(t, "u9", user.UserID)
+		m.userRepo.AssertExpectations(t)
+	})
+
+	t.Run("existing user", func(t *testing.T) {
+		service, m := newDirectoryService()
+
+		m.userRepo.On("GetByUserID", ctx, "u1").Return(&domain.User{UserID: "u1"}, nil)
+
+		_, err := service.CreateUser(ctx, &domain.User{UserID: "u1", Username: "Alice"})
+
+		assert.True(t, apperror.Is(err, apperror.ErrCodeUserExists))
+	})
+}
+
+func TestDirectoryService_GroupMembers(t *testing.T) {
+	ctx := context.Background()
+	backend := &domain.Team{ID: 1, TeamName: "backend", Members: []domain.User{
+		{UserID: "u1", Username: "Alice", TeamID: 1, IsActive: true},
+		{UserID: "u4", Username: "Dan", TeamID: 1, IsActive: true},
+	}}
+
+	t.Run("replace moves users in and out of the team", func(t *testing.T) {
+		service, m := newDirectoryService()
+
+		m.teamRepo.On("GetByName", ctx, "backend").Return(backend, nil)
+		m.userRepo.On("GetByUserID", ctx, "u1").Return(&domain.User{UserID: "u1", Username: "Alice", TeamID: 1, IsActive: true}, nil)
+		m.userRepo.On("GetByUserID", ctx, "u2").Return(&domain.User{UserID: "u2", Username: "Bob", TeamID: 2, IsActive: true}, nil)
+		m.transactor.On("WithinTx", ctx).Return(nil).Once()
+		m.userRepo.On("Update", mock.Anything, &domain.User{UserID: "u2", Username: "Bob", TeamID: 1, IsActive: true}).Return(&domain.User{}, nil).Once()
+		m.userRepo.On("Update", mock.Anything, &domain.User{UserID: "u4", Username: "Dan", TeamID: 0, IsActive: true}).Return(&domain.User{}, nil).Once()
+
+		_, err := service.ReplaceGroupMembers(ctx, "backend", []string{"u1", "u2"})
+
+		require.NoError(t, err)
+		m.userRepo.AssertExpectations(t)
+		m.transactor.AssertExpectations(t)
+	})
+
+	t.Run("unknown member", func(t *testing.T) {
+		service, m := newDirectoryService()
+
+		m.teamRepo.On("GetByName", ctx, "backend").Return(backend, nil)
+		m.userRepo.On("GetByUserID", ctx, "ghost").Return(nil, nil)
+
+		_, err := service.UpdateGroupMembers(ctx, "backend", []string{"ghost"}, nil)
+
+		assert.True(t, apperror.Is(err, apperror.ErrCodeInvalidInput))
+		m.transactor.AssertNotCalled(t, "WithinTx", mock.Anything)
+	})
+
+	t.Run("create group with members", func(t *testing.T) {
+		service, m := newDirectoryService()
+
+		m.teamRepo.On("ExistsByName", ctx, "mobile").Return(false, nil)
+		m.userRepo.On("GetByUserID", ctx, "u5").Return(&domain.User{UserID: "u5", Username: "Eve"}, nil)
+		m.teamRepo.On("Create", ctx, &domain.Team{TeamName: "mobile", Members: []domain.User{{UserID: "u5", Username: "Eve"}}}).
+			Return(&domain.Team{ID: 3, TeamName: "mobile"}, nil).Once()
+		m.teamRepo.On("GetByName", ctx, "mobile").Return(&domain.Team{ID: 3, TeamName: "mobile", Members: []domain.User{{UserID: "u5"}}}, nil)
+
+		team, err := service.CreateGroup(ctx, "mobile", []string{"u5"})
+
+		require.NoError(t, err)
+		assert.Len(t, team.Members, 1)
+		m.teamRepo.AssertExpectations(t)
+	})
+}
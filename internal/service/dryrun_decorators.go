@@ -0,0 +1,195 @@
+package service
+
+import (
+	"context"
+
+	"github.com/ssokov/pr-reviewer-service/internal/dryrun"
+	"github.com/ssokov/pr-reviewer-service/internal/model/domain"
+	"github.com/ssokov/pr-reviewer-service/internal/repository"
+)
+
+// The dry-run services are the outermost layer. For a context marked with dryrun.With they run the wrapped
+// call, with all its checks and reviewer selection, inside a transaction that is rolled back afterwards,
+// so the result shows exactly what the call would change.
+
+type dryRunPRService struct {
+	PRService
+	transactor repository.Transactor
+}
+
+func NewDryRunPRService(next PRService, transactor repository.Transactor) PRService {
+	return &dryRunPRService{
+		PRService:  next,
+		transactor: transactor,
+	}
+}
+
+func (s *dryRunPRService) CreatePR(ctx context.Context, authorID string, pr *domain.PullRequest) (*domain.PullRequest, error) {
+	if !dryrun.Enabled(ctx) {
+		return s.PRService.CreatePR(ctx, authorID, pr)
+	}
+
+	var created *domain.PullRequest
+	err := s.transactor.WithinRolledBackTx(ctx, func(ctx context.Context) (err error) {
+		created, err = s.PRService.CreatePR(ctx, authorID, pr)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return created, nil
+}
+
+func (s *dryRunPRService) ReassignReviewer(ctx context.Context, prID string, oldUserID string) (*domain.PullRequest, string, error) {
+	if !dryrun.Enabled(ctx) {
+		return s.PRService.ReassignReviewer(ctx, prID, oldUserID)
+	}
+
+	var updated *domain.PullRequest
+	var newReviewerID string
+	err := s.transactor.WithinRolledBackTx(ctx, func(ctx context.Context) (err error) {
+		updated, newReviewerID, err = s.PRService.ReassignReviewer(ctx, prID, oldUserID)
+		return err
+	})
+	if err != nil {
+		return nil, "", err
+	}
+	return updated, newReviewerID, nil
+}
+
+func (s *dryRunPRService) AddReviewer(ctx context.Context, prID, userID string, pin bool) (*domain.PullRequest, error) {
+	if !dryrun.Enabled(ctx) {
+		return s.PRService.AddReviewer(ctx, prID, userID, pin)
+	}
+
+	var updated *domain.PullRequest
+	err := s.transactor.WithinRolledBackTx(ctx, func(ctx context.Context) (err error) {
+		updated, err = s.PRService.AddReviewer(ctx, prID, userID, pin)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
+
+func (s *dryRunPRService) RemoveReviewer(ctx context.Context, prID, userID string) (*domain.PullRequest, error) {
+	if !dryrun.Enabled(ctx) {
+		return s.PRService.RemoveReviewer(ctx, prID, userID)
+	}
+
+	var updated *domain.PullRequest
+	err := s.transactor.WithinRolledBackTx(ctx, func(ctx context.Context) (err error) {
+		updated, err = s.PRService.RemoveReviewer(ctx, prID, userID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
+
+type dryRunTeamService struct {
+	TeamService
+	transactor repository.Transactor
+}
+
+func NewDryRunTeamService(next TeamService, transactor repository.Transactor) TeamService {
+	return &dryRunTeamService{
+		TeamService: next,
+		transactor:  transactor,
+	}
+}
+
+func (s *dryRunTeamService) DeactivateTeam(ctx context.Context, teamName string) ([]domain.User, []domain.PullRequest, error) {
+	if !dryrun.Enabled(ctx) {
+		return s.TeamService.DeactivateTeam(ctx, teamName)
+	}
+
+	var users []domain.User
+	var prs []domain.PullRequest
+	err := s.transactor.WithinRolledBackTx(ctx, func(ctx context.Context) (err error) {
+		users, prs, err = s.TeamService.DeactivateTeam(ctx, teamName)
+		return err
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return users, prs, nil
+}
+
+type dryRunUserService struct {
+	UserService
+	transactor repository.Transactor
+}
+
+func NewDryRunUserService(next UserService, transactor repository.Transactor) UserService {
+	return &dryRunUserService{
+		UserService: next,
+		transactor:  transactor,
+	}
+}
+
+func (s *dryRunUserService) SetIsActive(ctx context.Context, userID string, isActive bool) (*domain.User, error) {
+	if !dryrun.Enabled(ctx) {
+		return s.UserService.SetIsActive(ctx, userID, isActive)
+	}
+
+	var user *domain.User
+	err := s.transactor.WithinRolledBackTx(ctx, func(ctx context.Context) (err error) {
+		user, err = s.UserService.SetIsActive(ctx, userID, isActive)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+func (s *dryRunUserService) SetTeamRole(ctx context.Context, userID string, role domain.TeamRole) (*domain.User, error) {
+	if !dryrun.Enabled(ctx) {
+		return s.UserService.SetTeamRole(ctx, userID, role)
+	}
+
+	var user *domain.User
+	err := s.transactor.WithinRolledBackTx(ctx, func(ctx context.Context) (err error) {
+		user, err = s.UserService.SetTeamRole(ctx, userID, role)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+func (s *dryRunUserService) SetWorkingHours(ctx context.Context, userID, timezone string, workStart, workEnd int) (*domain.User, error) {
+	if !dryrun.Enabled(ctx) {
+		return s.UserService.SetWorkingHours(ctx, userID, timezone, workStart, workEnd)
+	}
+
+	var user *domain.User
+	err := s.transactor.WithinRolledBackTx(ctx, func(ctx context.Context) (err error) {
+		user, err = s.UserService.SetWorkingHours(ctx, userID, timezone, workStart, workEnd)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+func (s *dryRunUserService) SetMaxOpenReviews(ctx context.Context, userID string, limit *int) (*domain.User, error) {
+	if !dryrun.Enabled(ctx) {
+		return s.UserService.SetMaxOpenReviews(ctx, userID, limit)
+	}
+
+	var user *domain.User
+	err := s.transactor.WithinRolledBackTx(ctx, func(ctx context.Context) (err error) {
+		user, err = s.UserService.SetMaxOpenReviews(ctx, userID, limit)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return user, nil
+}
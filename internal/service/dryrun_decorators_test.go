@@ -0,0 +1,75 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ssokov/pr-reviewer-service/internal/dryrun"
+	"github.com/ssokov/pr-reviewer-service/internal/model/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// txRecordingTeamService records whether DeactivateTeam ran inside the mock transaction.
+type txRecordingTeamService struct {
+	TeamService
+	inTx bool
+}
+
+func (s *txRecordingTeamService) DeactivateTeam(ctx context.Context, _ string) ([]domain.User, []domain.PullRequest, error) {
+	s.inTx, _ = ctx.Value(mockTxKey{}).(bool)
+	return []domain.User{{UserID: "u1"}}, []domain.PullRequest{{PullRequestID: "pr-1"}}, nil
+}
+
+func TestDryRunTeamService_DeactivateTeam(t *testing.T) {
+	t.Run("dry run goes through the rolled back transaction", func(t *testing.T) {
+		transactor := new(MockTransactor)
+		transactor.On("WithinRolledBackTx", mock.Anything).Return(nil).Once()
+		next := &txRecordingTeamService{}
+
+		users, prs, err := NewDryRunTeamService(next, transactor).DeactivateTeam(dryrun.With(context.Background()), "backend")
+
+		require.NoError(t, err)
+		assert.True(t, next.inTx)
+		assert.Len(t, users, 1)
+		assert.Len(t, prs, 1)
+		transactor.AssertExpectations(t)
+	})
+
+	t.Run("regular call skips the transaction", func(t *testing.T) {
+		transactor := new(MockTransactor)
+		next := &txRecordingTeamService{}
+
+		_, _, err := NewDryRunTeamService(next, transactor).DeactivateTeam(context.Background(), "backend")
+
+		require.NoError(t, err)
+		assert.False(t, next.inTx)
+		transactor.AssertNotCalled(t, "WithinRolledBackTx", mock.Anything)
+	})
+
+	t.Run("transaction error", func(t *testing.T) {
+		transactor := new(MockTransactor)
+		transactor.On("WithinRolledBackTx", mock.Anything).Return(errors.New("connection refused")).Once()
+
+		users, prs, err := NewDryRunTeamService(&txRecordingTeamService{}, transactor).DeactivateTeam(dryrun.With(context.Background()), "backend")
+
+		assert.Error(t, err)
+		assert.Nil(t, users)
+		assert.Nil(t, prs)
+	})
+}
+
+func TestDryRunPRService_CreatePR(t *testing.T) {
+	ctx := dryrun.With(context.Background())
+	transactor := new(MockTransactor)
+	transactor.On("WithinRolledBackTx", mock.Anything).Return(nil).Once()
+	next := &stubPRService{created: &domain.PullRequest{PullRequestID: "pr-1", AssignedReviewers: []string{"u2", "u3"}}}
+
+	created, err := NewDryRunPRService(next, transactor).CreatePR(ctx, "u1", &domain.PullRequest{PullRequestID: "pr-1"})
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"u2", "u3"}, created.AssignedReviewers)
+	transactor.AssertExpectations(t)
+}
@@ -0,0 +1,87 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ssokov/pr-reviewer-service/internal/model/domain"
+	"github.com/ssokov/pr-reviewer-service/internal/repository"
+	"github.com/vmkteam/embedlog"
+)
+
+const healthCheckTimeout = 2 * time.Second
+
+// Worker is a background loop whose state is part of readiness.
+type Worker interface {
+	Name() string
+	Healthy() error
+}
+
+type HealthService interface {
+	Ready(ctx context.Context) domain.Readiness
+}
+
+type healthService struct {
+	healthRepo       repository.HealthRepository
+	migrationVersion uint
+	workers          []Worker
+	logger           embedlog.Logger
+}
+
+// NewHealthService checks the database, that it is migrated to migrationVersion and that all workers are healthy.
+func NewHealthService(healthRepo repository.HealthRepository, migrationVersion uint, workers []Worker, logger embedlog.Logger) HealthService {
+	return &healthService{
+		healthRepo:       healthRepo,
+		migrationVersion: migrationVersion,
+		workers:          workers,
+		logger:           logger,
+	}
+}
+
+func (s *healthService) Ready(ctx context.Context) domain.Readiness {
+	deps := []domain.DependencyHealth{
+		s.check(ctx, "database", s.healthRepo.Ping),
+		s.check(ctx, "migrations", s.checkMigrations),
+	}
+	for _, w := range s.workers {
+		deps = append(deps, s.check(ctx, w.Name(), func(context.Context) error { return w.Healthy() }))
+	}
+
+	readiness := domain.Readiness{Status: domain.HealthStatusOK, Dependencies: deps}
+	for _, d := range deps {
+		if d.Status != domain.HealthStatusOK {
+			readiness.Status = domain.HealthStatusFail
+		}
+	}
+	return readiness
+}
+
+func (s *healthService) checkMigrations(ctx context.Context) error {
+	version, dirty, err := s.healthRepo.MigrationVersion(ctx)
+	if err != nil {
+		return err
+	}
+	if dirty {
+		return fmt.Errorf("migration %d is dirty", version)
+	}
+	if version != s.migrationVersion {
+		return fmt.Errorf("database is at migration %d, expected %d", version, s.migrationVersion)
+	}
+	return nil
+}
+
+func (s *healthService) check(ctx context.Context, name string, fn func(context.Context) error) domain.DependencyHealth {
+	ctx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+	defer cancel()
+
+	start := time.Now()
+	err := fn(ctx)
+	dep := domain.DependencyHealth{Name: name, Status: domain.HealthStatusOK, Duration: time.Since(start)}
+	if err != nil {
+		s.logger.Print(ctx, "readiness check failed", "dependency", name, "error", err)
+		dep.Status = domain.HealthStatusFail
+		dep.Error = err.Error()
+	}
+	return dep
+}
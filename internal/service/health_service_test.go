@@ -0,0 +1,84 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ssokov/pr-reviewer-service/internal/model/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/vmkteam/embedlog"
+)
+
+type stubWorker struct {
+	err error
+}
+
+func (w stubWorker) Name() string   { return "stub" }
+func (w stubWorker) Healthy() error { return w.err }
+
+func TestHealthService_Ready(t *testing.T) {
+	ctx := context.Background()
+	logger := embedlog.NewLogger(false, false)
+
+	statuses := func(r domain.Readiness) map[string]domain.HealthStatus {
+		m := make(map[string]domain.HealthStatus, len(r.Dependencies))
+		for _, d := range r.Dependencies {
+			m[d.Name] = d.Status
+		}
+		return m
+	}
+
+	t.Run("all dependencies ok", func(t *testing.T) {
+		repo := new(MockHealthRepository)
+		repo.On("Ping", mock.Anything).Return(nil)
+		repo.On("MigrationVersion", mock.Anything).Return(uint(5), false, nil)
+
+		readiness := NewHealthService(repo, 5, []Worker{stubWorker{}}, logger).Ready(ctx)
+
+		assert.Equal(t, domain.HealthStatusOK, readiness.Status)
+		assert.Equal(t, map[string]domain.HealthStatus{
+			"database":   domain.HealthStatusOK,
+			"migrations": domain.HealthStatusOK,
+			"stub":       domain.HealthStatusOK,
+		}, statuses(readiness))
+	})
+
+	t.Run("outdated migrations", func(t *testing.T) {
+		repo := new(MockHealthRepository)
+		repo.On("Ping", mock.Anything).Return(nil)
+		repo.On("MigrationVersion", mock.Anything).Return(uint(4), false, nil)
+
+		readiness := NewHealthService(repo, 5, nil, logger).Ready(ctx)
+
+		assert.Equal(t, domain.HealthStatusFail, readiness.Status)
+		assert.Equal(t, domain.HealthStatusFail, statuses(readiness)["migrations"])
+		assert.Equal(t, "database is at migration 4, expected 5", readiness.Dependencies[1].Error)
+	})
+
+	t.Run("dirty migration", func(t *testing.T) {
+		repo := new(MockHealthRepository)
+		repo.On("Ping", mock.Anything).Return(nil)
+		repo.On("MigrationVersion", mock.Anything).Return(uint(5), true, nil)
+
+		readiness := NewHealthService(repo, 5, nil, logger).Ready(ctx)
+
+		assert.Equal(t, domain.HealthStatusFail, statuses(readiness)["migrations"])
+	})
+
+	t.Run("database and worker down", func(t *testing.T) {
+		repo := new(MockHealthRepository)
+		repo.On("Ping", mock.Anything).Return(errors.New("connection refused"))
+		repo.On("MigrationVersion", mock.Anything).Return(uint(0), false, errors.New("connection refused"))
+
+		readiness := NewHealthService(repo, 5, []Worker{stubWorker{err: errors.New("stalled")}}, logger).Ready(ctx)
+
+		assert.Equal(t, domain.HealthStatusFail, readiness.Status)
+		assert.Equal(t, map[string]domain.HealthStatus{
+			"database":   domain.HealthStatusFail,
+			"migrations": domain.HealthStatusFail,
+			"stub":       domain.HealthStatusFail,
+		}, statuses(readiness))
+	})
+}
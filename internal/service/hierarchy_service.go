@@ -0,0 +1,322 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ssokov/pr-reviewer-service/internal/apperror"
+	"github.com/ssokov/pr-reviewer-service/internal/model/domain"
+	"github.com/ssokov/pr-reviewer-service/internal/repository"
+	"github.com/ssokov/pr-reviewer-service/internal/tenant"
+	"github.com/ssokov/pr-reviewer-service/internal/tracing"
+	"github.com/vmkteam/embedlog"
+)
+
+type hierarchyService struct {
+	teamRepo   repository.TeamRepository
+	poolRepo   repository.PoolRepository
+	transactor repository.Transactor
+	logger     embedlog.Logger
+}
+
+func NewHierarchyService(
+	teamRepo repository.TeamRepository,
+	poolRepo repository.PoolRepository,
+	transactor repository.Transactor,
+	logger embedlog.Logger,
+) HierarchyService {
+	return &hierarchyService{
+		teamRepo:   teamRepo,
+		poolRepo:   poolRepo,
+		transactor: transactor,
+		logger:     logger,
+	}
+}
+
+// SetParent moves a team under parentName, or makes it a root team when parentName is empty. Moves that would
+// create a cycle or nest deeper than domain.MaxTeamDepth are rejected. The check and the move run in one
+// transaction holding the organization's teams locked, so two concurrent moves cannot build a cycle together.
+func (s *hierarchyService) SetParent(ctx context.Context, teamName, parentName string) (*domain.Team, error) {
+	if err := authorizeAdmin(ctx); err != nil {
+		return nil, err
+	}
+	if teamName == "" {
+		return nil, apperror.NewInvalidInputError("team_name is required")
+	}
+
+	var team *domain.Team
+	err := s.transactor.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		team, err = s.moveTeam(ctx, teamName, parentName)
+		return err
+	})
+	if err != nil {
+		if errors.As(err, new(*apperror.AppError)) {
+			return nil, err
+		}
+		tracing.Logger(ctx, s.logger).Errorf("failed to move team: %v", err)
+		return nil, apperror.NewInternalError("failed to move team", err)
+	}
+
+	s.logger.Print(ctx, "team moved", "team_name", teamName, "parent_team_name", parentName)
+	return team, nil
+}
+
+func (s *hierarchyService) moveTeam(ctx context.Context, teamName, parentName string) (*domain.Team, error) {
+	if err := s.teamRepo.LockHierarchy(ctx); err != nil {
+		tracing.Logger(ctx, s.logger).Errorf("failed to lock teams: %v", err)
+		return nil, apperror.NewInternalError("failed to lock teams", err)
+	}
+
+	teams, err := s.teamRepo.List(ctx)
+	if err != nil {
+		tracing.Logger(ctx, s.logger).Errorf("failed to list teams: %v", err)
+		return nil, apperror.NewInternalError("failed to list teams", err)
+	}
+
+	byName := make(map[string]*domain.Team, len(teams))
+	for i := range teams {
+		byName[teams[i].TeamName] = &teams[i]
+	}
+
+	team, ok := byName[teamName]
+	if !ok {
+		return nil, apperror.NewTeamNotFoundError(teamName)
+	}
+
+	var parent *domain.Team
+	if parentName != "" {
+		if parent, ok = byName[parentName]; !ok {
+			return nil, apperror.NewTeamNotFoundError(parentName)
+		}
+		if err := checkPlacement(teams, team, parent); err != nil {
+			return nil, err
+		}
+	}
+
+	parentID := int64(0)
+	if parent != nil {
+		parentID = parent.ID
+	}
+	if err := s.teamRepo.SetParent(ctx, team.ID, parentID); err != nil {
+		tracing.Logger(ctx, s.logger).Errorf("failed to set parent team: %v", err)
+		return nil, apperror.NewInternalError("failed to set parent team", err)
+	}
+
+	team.ParentID = parentID
+	team.ParentName = parentName
+	return team, nil
+}
+
+// checkPlacement rejects putting team under parent when parent is the team itself or one of its descendants, or
+// when the team's subtree would end up deeper than domain.MaxTeamDepth.
+func checkPlacement(teams []domain.Team, team, parent *domain.Team) error {
+	byID := make(map[int64]*domain.Team, len(teams))
+	children := make(map[int64][]int64)
+	for i := range teams {
+		byID[teams[i].ID] = &teams[i]
+		if teams[i].ParentID != 0 {
+			children[teams[i].ParentID] = append(children[teams[i].ParentID], teams[i].ID)
+		}
+	}
+
+	depth := 1
+	for current := parent; current != nil; current = byID[current.ParentID] {
+		if current.ID == team.ID {
+			return apperror.NewInvalidInputError(fmt.Sprintf("moving '%s' under '%s' would create a cycle", team.TeamName, parent.TeamName))
+		}
+		depth++
+		if depth > domain.MaxTeamDepth+1 {
+			break
+		}
+	}
+
+	var height func(id int64, level int) int
+	height = func(id int64, level int) int {
+		h := 1
+		if level > domain.MaxTeamDepth {
+			return h
+		}
+		for _, child := range children[id] {
+			h = max(h, 1+height(child, level+1))
+		}
+		return h
+	}
+
+	if depth-1+height(team.ID, 0) > domain.MaxTeamDepth {
+		return apperror.NewInvalidInputError(fmt.Sprintf("team hierarchy can be at most %d levels deep", domain.MaxTeamDepth))
+	}
+	return nil
+}
+
+// GetTree returns the subtree rooted at rootName, or every root team with its subtree when rootName is empty.
+func (s *hierarchyService) GetTree(ctx context.Context, rootName string) ([]domain.TeamNode, error) {
+	teams, err := s.teamRepo.List(ctx)
+	if err != nil {
+		tracing.Logger(ctx, s.logger).Errorf("failed to list teams: %v", err)
+		return nil, apperror.NewInternalError("failed to list teams", err)
+	}
+
+	children := make(map[int64][]domain.Team)
+	var roots []domain.Team
+	for _, team := range teams {
+		if team.ParentID != 0 {
+			children[team.ParentID] = append(children[team.ParentID], team)
+		}
+		if (rootName == "" && team.ParentID == 0) || team.TeamName == rootName {
+			roots = append(roots, team)
+		}
+	}
+	if rootName != "" && len(roots) == 0 {
+		return nil, apperror.NewTeamNotFoundError(rootName)
+	}
+
+	var build func(team domain.Team, level int) domain.TeamNode
+	build = func(team domain.Team, level int) domain.TeamNode {
+		node := domain.TeamNode{TeamName: team.TeamName, MembersCount: len(team.Members), Children: []domain.TeamNode{}}
+		for _, member := range team.Members {
+			if member.IsActive {
+				node.ActiveMembers++
+			}
+		}
+		if level < domain.MaxTeamDepth {
+			for _, child := range children[team.ID] {
+				node.Children = append(node.Children, build(child, level+1))
+			}
+		}
+		return node
+	}
+
+	nodes := make([]domain.TeamNode, 0, len(roots))
+	for _, root := range roots {
+		nodes = append(nodes, build(root, 1))
+	}
+	return nodes, nil
+}
+
+// SetSettings replaces the team's own settings; nil fields fall back to the parent teams again.
+func (s *hierarchyService) SetSettings(ctx context.Context, teamName string, settings domain.TeamSettings) (*domain.EffectiveTeamSettings, error) {
+	if err := authorizeAdmin(ctx); err != nil {
+		return nil, err
+	}
+	if settings.ReviewerCount != nil && *settings.ReviewerCount < 0 {
+		return nil, apperror.NewInvalidInputError("reviewer_count must not be negative")
+	}
+	if settings.MaxOpenReviews != nil && *settings.MaxOpenReviews < 0 {
+		return nil, apperror.NewInvalidInputError("max_open_reviews must not be negative")
+	}
+	if settings.ReviewSLAHours != nil && *settings.ReviewSLAHours <= 0 {
+		return nil, apperror.NewInvalidInputError("review_sla_hours must be positive")
+	}
+
+	team, err := s.getTeam(ctx, teamName)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.teamRepo.UpdateSettings(ctx, team.ID, settings); err != nil {
+		tracing.Logger(ctx, s.logger).Errorf("failed to update team settings: %v", err)
+		return nil, apperror.NewInternalError("failed to update team settings", err)
+	}
+
+	s.logger.Print(ctx, "team settings updated", "team_name", teamName)
+	return s.GetSettings(ctx, teamName)
+}
+
+func (s *hierarchyService) GetSettings(ctx context.Context, teamName string) (*domain.EffectiveTeamSettings, error) {
+	team, err := s.getTeam(ctx, teamName)
+	if err != nil {
+		return nil, err
+	}
+
+	ancestors, err := s.teamRepo.GetAncestors(ctx, team.ID)
+	if err != nil {
+		tracing.Logger(ctx, s.logger).Errorf("failed to get parent teams: %v", err)
+		return nil, apperror.NewInternalError("failed to get parent teams", err)
+	}
+
+	effective := &domain.EffectiveTeamSettings{TeamName: team.TeamName, Own: team.Settings}
+	effective.ReviewerCount, effective.ReviewerCountFrom = resolveReviewerCount(ctx, ancestors)
+	effective.RequireSenior, effective.RequireSeniorFrom = resolveRequireSenior(ctx, ancestors)
+	effective.MaxOpenReviews, effective.MaxOpenReviewsFrom = resolveMaxOpenReviews(ctx, ancestors)
+	sla, slaFrom := resolveReviewSLA(ctx, ancestors)
+	effective.ReviewSLAHours, effective.ReviewSLAHoursFrom = int(sla.Hours()), slaFrom
+	effective.Fallback, effective.FallbackFrom, err = resolveFallback(ctx, s.poolRepo, ancestors)
+	if err != nil {
+		return nil, err
+	}
+	return effective, nil
+}
+
+func (s *hierarchyService) getTeam(ctx context.Context, teamName string) (*domain.Team, error) {
+	if teamName == "" {
+		return nil, apperror.NewInvalidInputError("team_name is required")
+	}
+
+	team, err := s.teamRepo.GetByName(ctx, teamName)
+	if err != nil {
+		tracing.Logger(ctx, s.logger).Errorf("failed to get team: %v", err)
+		return nil, apperror.NewInternalError("failed to get team", err)
+	}
+	if team == nil {
+		return nil, apperror.NewTeamNotFoundError(teamName)
+	}
+	return team, nil
+}
+
+// resolveReviewerCount returns the reviewer count of the first team in ancestors that sets one, with its name.
+// Without one the organization setting applies and the name is empty.
+func resolveReviewerCount(ctx context.Context, ancestors []domain.Team) (int, string) {
+	for _, team := range ancestors {
+		if team.Settings.ReviewerCount != nil {
+			return *team.Settings.ReviewerCount, team.TeamName
+		}
+	}
+	return tenant.Settings(ctx).ReviewerCount, ""
+}
+
+// resolveRequireSenior works like resolveReviewerCount for the require_senior policy.
+func resolveRequireSenior(ctx context.Context, ancestors []domain.Team) (bool, string) {
+	for _, team := range ancestors {
+		if team.Settings.RequireSenior != nil {
+			return *team.Settings.RequireSenior, team.TeamName
+		}
+	}
+	return tenant.Settings(ctx).RequireSenior, ""
+}
+
+// resolveMaxOpenReviews works like resolveReviewerCount for the default capacity of the team's members.
+func resolveMaxOpenReviews(ctx context.Context, ancestors []domain.Team) (int, string) {
+	for _, team := range ancestors {
+		if team.Settings.MaxOpenReviews != nil {
+			return *team.Settings.MaxOpenReviews, team.TeamName
+		}
+	}
+	return tenant.Settings(ctx).MaxOpenReviews, ""
+}
+
+// resolveReviewSLA works like resolveReviewerCount for the review deadline of the team's PRs.
+func resolveReviewSLA(ctx context.Context, ancestors []domain.Team) (time.Duration, string) {
+	for _, team := range ancestors {
+		if team.Settings.ReviewSLAHours != nil {
+			return time.Duration(*team.Settings.ReviewSLAHours) * time.Hour, team.TeamName
+		}
+	}
+	return tenant.Settings(ctx).ReviewSLA(), ""
+}
+
+// resolveFallback returns the fallback chain of the first team in ancestors that has one, with its name.
+func resolveFallback(ctx context.Context, poolRepo repository.PoolRepository, ancestors []domain.Team) ([]domain.FallbackStep, string, error) {
+	for _, team := range ancestors {
+		steps, err := poolRepo.GetFallback(ctx, team.ID)
+		if err != nil {
+			return nil, "", apperror.NewInternalError("failed to get fallback chain", err)
+		}
+		if len(steps) > 0 {
+			return steps, team.TeamName, nil
+		}
+	}
+	return nil, "", nil
+}
@@ -0,0 +1,228 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/ssokov/pr-reviewer-service/internal/apperror"
+	"github.com/ssokov/pr-reviewer-service/internal/model/domain"
+	"github.com/ssokov/pr-reviewer-service/internal/tenant"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/vmkteam/embedlog"
+)
+
+// engineering -> backend -> payments, plus a separate root team design.
+func hierarchyTeams() []domain.Team {
+	return []domain.Team{
+		{ID: 1, TeamName: "backend", ParentID: 3, ParentName: "engineering", Members: []domain.User{
+			{UserID: "u1", IsActive: true},
+			{UserID: "u2", IsActive: false},
+		}},
+		{ID: 2, TeamName: "design"},
+		{ID: 3, TeamName: "engineering"},
+		{ID: 4, TeamName: "payments", ParentID: 1, ParentName: "backend", Members: []domain.User{
+			{UserID: "u3", IsActive: true},
+		}},
+	}
+}
+
+func TestHierarchyService_SetParent(t *testing.T) {
+	ctx := context.Background()
+	logger := embedlog.NewLogger(false, false)
+
+	setup := func(teams []domain.Team) (HierarchyService, *MockTeamRepository) {
+		mockTeamRepo := new(MockTeamRepository)
+		transactor := new(MockTransactor)
+		transactor.On("WithinTx", ctx).Return(nil)
+		mockTeamRepo.On("LockHierarchy", inTx).Return(nil)
+		mockTeamRepo.On("List", inTx).Return(teams, nil)
+		return NewHierarchyService(mockTeamRepo, new(MockPoolRepository), transactor, logger), mockTeamRepo
+	}
+
+	t.Run("success", func(t *testing.T) {
+		service, mockTeamRepo := setup(hierarchyTeams())
+		mockTeamRepo.On("SetParent", inTx, int64(2), int64(3)).Return(nil)
+
+		team, err := service.SetParent(ctx, "design", "engineering")
+		require.NoError(t, err)
+		assert.Equal(t, int64(3), team.ParentID)
+		assert.Equal(t, "engineering", team.ParentName)
+		mockTeamRepo.AssertExpectations(t)
+	})
+
+	t.Run("success - detach", func(t *testing.T) {
+		service, mockTeamRepo := setup(hierarchyTeams())
+		mockTeamRepo.On("SetParent", inTx, int64(4), int64(0)).Return(nil)
+
+		team, err := service.SetParent(ctx, "payments", "")
+		require.NoError(t, err)
+		assert.Zero(t, team.ParentID)
+		assert.Empty(t, team.ParentName)
+	})
+
+	tests := []struct {
+		name   string
+		team   string
+		parent string
+		code   apperror.ErrorCode
+	}{
+		{name: "team under itself", team: "backend", parent: "backend", code: apperror.ErrCodeInvalidInput},
+		{name: "team under its descendant", team: "engineering", parent: "payments", code: apperror.ErrCodeInvalidInput},
+		{name: "unknown team", team: "ghost", parent: "engineering", code: apperror.ErrCodeTeamNotFound},
+		{name: "unknown parent", team: "design", parent: "ghost", code: apperror.ErrCodeTeamNotFound},
+	}
+	for _, tt := range tests {
+		t.Run("error - "+tt.name, func(t *testing.T) {
+			service, mockTeamRepo := setup(hierarchyTeams())
+
+			_, err := service.SetParent(ctx, tt.team, tt.parent)
+			assert.True(t, apperror.Is(err, tt.code), "got %v", err)
+			mockTeamRepo.AssertNotCalled(t, "SetParent", mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+
+	t.Run("error - too deep", func(t *testing.T) {
+		// A chain t1 <- t2 <- ... <- tN that is already as deep as allowed.
+		chain := make([]domain.Team, domain.MaxTeamDepth)
+		for i := range chain {
+			chain[i] = domain.Team{ID: int64(i + 1), TeamName: fmt.Sprintf("t%d", i+1), ParentID: int64(i)}
+		}
+		chain = append(chain, domain.Team{ID: 100, TeamName: "new"})
+		service, _ := setup(chain)
+
+		_, err := service.SetParent(ctx, "new", fmt.Sprintf("t%d", domain.MaxTeamDepth))
+		assert.True(t, apperror.Is(err, apperror.ErrCodeInvalidInput), "got %v", err)
+
+		_, err = service.SetParent(ctx, "t1", "new")
+		assert.True(t, apperror.Is(err, apperror.ErrCodeInvalidInput), "got %v", err)
+	})
+
+	t.Run("error - lock fails", func(t *testing.T) {
+		mockTeamRepo := new(MockTeamRepository)
+		transactor := new(MockTransactor)
+		transactor.On("WithinTx", ctx).Return(nil)
+		mockTeamRepo.On("LockHierarchy", inTx).Return(errors.New("db error"))
+		service := NewHierarchyService(mockTeamRepo, new(MockPoolRepository), transactor, logger)
+
+		_, err := service.SetParent(ctx, "design", "engineering")
+		assert.True(t, apperror.Is(err, apperror.ErrCodeInternalError), "got %v", err)
+		mockTeamRepo.AssertNotCalled(t, "List", mock.Anything)
+	})
+
+	t.Run("error - transaction fails", func(t *testing.T) {
+		transactor := new(MockTransactor)
+		transactor.On("WithinTx", ctx).Return(errors.New("db down"))
+		service := NewHierarchyService(new(MockTeamRepository), new(MockPoolRepository), transactor, logger)
+
+		_, err := service.SetParent(ctx, "design", "engineering")
+		assert.True(t, apperror.Is(err, apperror.ErrCodeInternalError), "got %v", err)
+	})
+
+	t.Run("error - forbidden for members", func(t *testing.T) {
+		service := NewHierarchyService(new(MockTeamRepository), new(MockPoolRepository), new(MockTransactor), logger)
+
+		_, err := service.SetParent(userContext("u1", domain.RoleMember), "design", "engineering")
+		assert.True(t, apperror.Is(err, apperror.ErrCodeForbidden))
+	})
+}
+
+func TestHierarchyService_GetTree(t *testing.T) {
+	ctx := context.Background()
+	logger := embedlog.NewLogger(false, false)
+
+	mockTeamRepo := new(MockTeamRepository)
+	service := NewHierarchyService(mockTeamRepo, new(MockPoolRepository), new(MockTransactor), logger)
+	mockTeamRepo.On("List", ctx).Return(hierarchyTeams(), nil)
+
+	payments := domain.TeamNode{TeamName: "payments", MembersCount: 1, ActiveMembers: 1, Children: []domain.TeamNode{}}
+	backend := domain.TeamNode{TeamName: "backend", MembersCount: 2, ActiveMembers: 1, Children: []domain.TeamNode{payments}}
+
+	t.Run("whole forest", func(t *testing.T) {
+		nodes, err := service.GetTree(ctx, "")
+		require.NoError(t, err)
+		assert.Equal(t, []domain.TeamNode{
+			{TeamName: "design", Children: []domain.TeamNode{}},
+			{TeamName: "engineering", Children: []domain.TeamNode{backend}},
+		}, nodes)
+	})
+
+	t.Run("subtree", func(t *testing.T) {
+		nodes, err := service.GetTree(ctx, "backend")
+		require.NoError(t, err)
+		assert.Equal(t, []domain.TeamNode{backend}, nodes)
+	})
+
+	t.Run("error - unknown root", func(t *testing.T) {
+		_, err := service.GetTree(ctx, "ghost")
+		assert.True(t, apperror.Is(err, apperror.ErrCodeTeamNotFound))
+	})
+}
+
+func TestHierarchyService_Settings(t *testing.T) {
+	logger := embedlog.NewLogger(false, false)
+	ctx := tenant.WithOrganization(context.Background(), &domain.Organization{
+		ID:       1,
+		Settings: domain.OrganizationSettings{ReviewerCount: 2},
+	})
+	three := 3
+	chain := []domain.FallbackStep{{PoolName: "oncall"}}
+
+	setup := func(engineering domain.TeamSettings) (HierarchyService, *MockTeamRepository) {
+		mockTeamRepo := new(MockTeamRepository)
+		mockPoolRepo := new(MockPoolRepository)
+
+		payments := &domain.Team{ID: 4, TeamName: "payments"}
+		mockTeamRepo.On("GetByName", ctx, "payments").Return(payments, nil)
+		mockTeamRepo.On("GetAncestors", ctx, int64(4)).Return([]domain.Team{
+			*payments,
+			{ID: 1, TeamName: "backend"},
+			{ID: 3, TeamName: "engineering", Settings: engineering},
+		}, nil)
+		mockPoolRepo.On("GetFallback", ctx, int64(4)).Return(nil, nil)
+		mockPoolRepo.On("GetFallback", ctx, int64(1)).Return(chain, nil)
+		return NewHierarchyService(mockTeamRepo, mockPoolRepo, new(MockTransactor), logger), mockTeamRepo
+	}
+
+	t.Run("inherited from the nearest parent", func(t *testing.T) {
+		service, _ := setup(domain.TeamSettings{ReviewerCount: &three})
+
+		effective, err := service.GetSettings(ctx, "payments")
+		require.NoError(t, err)
+		assert.Equal(t, 3, effective.ReviewerCount)
+		assert.Equal(t, "engineering", effective.ReviewerCountFrom)
+		assert.Equal(t, chain, effective.Fallback)
+		assert.Equal(t, "backend", effective.FallbackFrom)
+	})
+
+	t.Run("organization default", func(t *testing.T) {
+		service, _ := setup(domain.TeamSettings{})
+
+		effective, err := service.GetSettings(ctx, "payments")
+		require.NoError(t, err)
+		assert.Equal(t, 2, effective.ReviewerCount)
+		assert.Empty(t, effective.ReviewerCountFrom)
+	})
+
+	t.Run("set", func(t *testing.T) {
+		service, mockTeamRepo := setup(domain.TeamSettings{})
+		settings := domain.TeamSettings{ReviewerCount: &three}
+		mockTeamRepo.On("UpdateSettings", ctx, int64(4), settings).Return(nil)
+
+		_, err := service.SetSettings(ctx, "payments", settings)
+		require.NoError(t, err)
+		mockTeamRepo.AssertExpectations(t)
+	})
+
+	t.Run("error - negative reviewer count", func(t *testing.T) {
+		service, mockTeamRepo := setup(domain.TeamSettings{})
+		negative := -1
+
+		_, err := service.SetSettings(ctx, "payments", domain.TeamSettings{ReviewerCount: &negative})
+		assert.True(t, apperror.Is(err, apperror.ErrCodeInvalidInput))
+		mockTeamRepo.AssertNotCalled(t, "UpdateSettings", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
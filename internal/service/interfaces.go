@@ -8,6 +8,9 @@ import (
 
 type UserService interface {
 	SetIsActive(ctx context.Context, userID string, isActive bool) (*domain.User, error)
+	SetTeamRole(ctx context.Context, userID string, role domain.TeamRole) (*domain.User, error)
+	SetWorkingHours(ctx context.Context, userID, timezone string, workStart, workEnd int) (*domain.User, error)
+	SetMaxOpenReviews(ctx context.Context, userID string, limit *int) (*domain.User, error)
 	GetReview(ctx context.Context, userID string) ([]domain.PullRequest, error)
 }
 
@@ -15,6 +18,11 @@ type PRService interface {
 	CreatePR(ctx context.Context, authorID string, pr *domain.PullRequest) (*domain.PullRequest, error)
 	MergePR(ctx context.Context, prID string) (*domain.PullRequest, error)
 	ReassignReviewer(ctx context.Context, prID string, oldUserID string) (*domain.PullRequest, string, error)
+	AddReviewer(ctx context.Context, prID, userID string, pin bool) (*domain.PullRequest, error)
+	RemoveReviewer(ctx context.Context, prID, userID string) (*domain.PullRequest, error)
+	ListPRs(ctx context.Context, filter domain.PRFilter) ([]domain.PullRequest, error)
+	GetSLA(ctx context.Context, prID string) (*domain.ReviewSLA, error)
+	AssignPendingReviewers(ctx context.Context, prID string) (*domain.PullRequest, error)
 }
 
 type TeamService interface {
@@ -22,3 +30,73 @@ type TeamService interface {
 	GetTeam(ctx context.Context, teamName string) (*domain.Team, error)
 	DeactivateTeam(ctx context.Context, teamName string) ([]domain.User, []domain.PullRequest, error)
 }
+
+type RosterService interface {
+	ImportRoster(ctx context.Context, roster *domain.Roster) (*domain.RosterImportResult, error)
+	ExportRoster(ctx context.Context) ([]domain.RosterEntry, error)
+}
+
+type APIKeyService interface {
+	CreateKey(ctx context.Context, name string, scopes []domain.Scope) (*domain.APIKey, string, error)
+	Authenticate(ctx context.Context, rawKey string) (*domain.APIKey, error)
+	RevokeKey(ctx context.Context, prefix string) (*domain.APIKey, error)
+	ListKeys(ctx context.Context) ([]domain.APIKey, error)
+}
+
+type AuditService interface {
+	List(ctx context.Context, filter domain.AuditFilter) ([]domain.AuditEntry, error)
+}
+
+type OrganizationService interface {
+	CreateOrganization(ctx context.Context, slug, name string, settings domain.OrganizationSettings) (*domain.Organization, error)
+	GetBySlug(ctx context.Context, slug string) (*domain.Organization, error)
+	GetByID(ctx context.Context, id int64) (*domain.Organization, error)
+	ListOrganizations(ctx context.Context) ([]domain.Organization, error)
+	UpdateSettings(ctx context.Context, slug string, settings domain.OrganizationSettings) (*domain.Organization, error)
+}
+
+// DirectoryService backs directory provisioning (SCIM): users map to domain.User by user_id and groups to
+// domain.Team by name.
+type DirectoryService interface {
+	ListUsers(ctx context.Context) ([]domain.User, error)
+	GetUser(ctx context.Context, userID string) (*domain.User, error)
+	CreateUser(ctx context.Context, user *domain.User) (*domain.User, error)
+	UpdateUser(ctx context.Context, user *domain.User) (*domain.User, error)
+	DeprovisionUser(ctx context.Context, userID string) (*domain.User, error)
+	ListGroups(ctx context.Context) ([]domain.Team, error)
+	GetGroup(ctx context.Context, teamName string) (*domain.Team, error)
+	CreateGroup(ctx context.Context, teamName string, memberIDs []string) (*domain.Team, error)
+	UpdateGroupMembers(ctx context.Context, teamName string, add, remove []string) (*domain.Team, error)
+	ReplaceGroupMembers(ctx context.Context, teamName string, memberIDs []string) (*domain.Team, error)
+}
+
+// CodeOwnersService stores the CODEOWNERS file of each repository, used by CreatePR to pick reviewers.
+type CodeOwnersService interface {
+	// Upload validates and stores the file. It also returns the owners that match no user or team.
+	Upload(ctx context.Context, file *domain.CodeOwners) (*domain.CodeOwners, []string, error)
+	Get(ctx context.Context, repository string) (*domain.CodeOwners, error)
+}
+
+// SkillService manages user skills and PR labels. Labels naming a skill ask for a reviewer with that skill.
+type SkillService interface {
+	SetUserSkills(ctx context.Context, userID string, skills []string) ([]string, error)
+	GetUserSkills(ctx context.Context, userID string) ([]string, error)
+	SetPRLabels(ctx context.Context, prID string, labels []string) (*domain.PullRequest, error)
+}
+
+// PoolService manages reviewer pools and the fallback chains teams use when they run out of reviewers.
+type PoolService interface {
+	SetPool(ctx context.Context, pool *domain.ReviewerPool) (*domain.ReviewerPool, error)
+	GetPool(ctx context.Context, name string) (*domain.ReviewerPool, error)
+	ListPools(ctx context.Context) ([]domain.ReviewerPool, error)
+	SetFallback(ctx context.Context, chain *domain.FallbackChain) (*domain.FallbackChain, error)
+	GetFallback(ctx context.Context, teamName string) (*domain.FallbackChain, error)
+}
+
+// HierarchyService manages parent/child teams and the settings children inherit from their parents.
+type HierarchyService interface {
+	SetParent(ctx context.Context, teamName, parentName string) (*domain.Team, error)
+	GetTree(ctx context.Context, rootName string) ([]domain.TeamNode, error)
+	SetSettings(ctx context.Context, teamName string, settings domain.TeamSettings) (*domain.EffectiveTeamSettings, error)
+	GetSettings(ctx context.Context, teamName string) (*domain.EffectiveTeamSettings, error)
+}
@@ -0,0 +1,72 @@
+package service
+
+import (
+	"context"
+
+	"github.com/ssokov/pr-reviewer-service/internal/apperror"
+	"github.com/ssokov/pr-reviewer-service/internal/dryrun"
+	"github.com/ssokov/pr-reviewer-service/internal/metrics"
+	"github.com/ssokov/pr-reviewer-service/internal/model/domain"
+	"github.com/ssokov/pr-reviewer-service/internal/repository"
+	"github.com/ssokov/pr-reviewer-service/internal/tenant"
+)
+
+// instrumentedPRService counts PR lifecycle events and assignment failures for Prometheus. Dry runs are not counted.
+type instrumentedPRService struct {
+	PRService
+	prRepo repository.PRRepository
+}
+
+func NewInstrumentedPRService(next PRService, prRepo repository.PRRepository) PRService {
+	return &instrumentedPRService{
+		PRService: next,
+		prRepo:    prRepo,
+	}
+}
+
+func (s *instrumentedPRService) CreatePR(ctx context.Context, authorID string, pr *domain.PullRequest) (*domain.PullRequest, error) {
+	created, err := s.PRService.CreatePR(ctx, authorID, pr)
+	if dryrun.Enabled(ctx) {
+		return created, err
+	}
+	if err != nil {
+		metrics.AssignmentFailures.WithLabelValues("create", string(apperror.CodeOf(err))).Inc()
+		return nil, err
+	}
+
+	metrics.PRsCreated.WithLabelValues(tenant.Slug(ctx)).Inc()
+	metrics.ReviewersPerPR.Observe(float64(len(created.AssignedReviewers)))
+	return created, nil
+}
+
+// MergePR only counts PRs that were open before the call, since merging is idempotent.
+func (s *instrumentedPRService) MergePR(ctx context.Context, prID string) (*domain.PullRequest, error) {
+	wasOpen := false
+	if pr, err := s.prRepo.GetByPRID(ctx, prID); err == nil && pr != nil {
+		wasOpen = pr.Status == domain.PRStatusOpen
+	}
+
+	merged, err := s.PRService.MergePR(ctx, prID)
+	if err != nil {
+		return nil, err
+	}
+
+	if wasOpen {
+		metrics.PRsMerged.WithLabelValues(tenant.Slug(ctx)).Inc()
+	}
+	return merged, nil
+}
+
+func (s *instrumentedPRService) ReassignReviewer(ctx context.Context, prID string, oldUserID string) (*domain.PullRequest, string, error) {
+	updated, newReviewerID, err := s.PRService.ReassignReviewer(ctx, prID, oldUserID)
+	if dryrun.Enabled(ctx) {
+		return updated, newReviewerID, err
+	}
+	if err != nil {
+		metrics.AssignmentFailures.WithLabelValues("reassign", string(apperror.CodeOf(err))).Inc()
+		return nil, "", err
+	}
+
+	metrics.ReviewersReassigned.WithLabelValues(tenant.Slug(ctx)).Inc()
+	return updated, newReviewerID, nil
+}
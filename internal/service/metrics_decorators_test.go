@@ -0,0 +1,100 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/ssokov/pr-reviewer-service/internal/apperror"
+	"github.com/ssokov/pr-reviewer-service/internal/dryrun"
+	"github.com/ssokov/pr-reviewer-service/internal/metrics"
+	"github.com/ssokov/pr-reviewer-service/internal/model/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+type stubPRService struct {
+	PRService
+	created *domain.PullRequest
+	merged  *domain.PullRequest
+	err     error
+}
+
+func (s *stubPRService) CreatePR(_ context.Context, _ string, _ *domain.PullRequest) (*domain.PullRequest, error) {
+	return s.created, s.err
+}
+
+func (s *stubPRService) MergePR(_ context.Context, _ string) (*domain.PullRequest, error) {
+	return s.merged, s.err
+}
+
+func (s *stubPRService) ReassignReviewer(_ context.Context, _ string, _ string) (*domain.PullRequest, string, error) {
+	return nil, "", s.err
+}
+
+func TestInstrumentedPRService(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("create counts PR and reviewers", func(t *testing.T) {
+		before := testutil.ToFloat64(metrics.PRsCreated.WithLabelValues("default"))
+		service := NewInstrumentedPRService(&stubPRService{
+			created: &domain.PullRequest{PullRequestID: "pr1", AssignedReviewers: []string{"u2", "u3"}},
+		}, new(MockPRRepository))
+
+		_, err := service.CreatePR(ctx, "u1", &domain.PullRequest{})
+		assert.NoError(t, err)
+		assert.Equal(t, before+1, testutil.ToFloat64(metrics.PRsCreated.WithLabelValues("default")))
+	})
+
+	t.Run("create failure counted by error code", func(t *testing.T) {
+		counter := metrics.AssignmentFailures.WithLabelValues("create", string(apperror.ErrCodeNoCandidate))
+		before := testutil.ToFloat64(counter)
+		service := NewInstrumentedPRService(&stubPRService{
+			err: apperror.NewNoCandidateError("backend"),
+		}, new(MockPRRepository))
+
+		_, err := service.CreatePR(ctx, "u1", &domain.PullRequest{})
+		assert.Error(t, err)
+		assert.Equal(t, before+1, testutil.ToFloat64(counter))
+	})
+
+	t.Run("merge of already merged PR is not counted", func(t *testing.T) {
+		mockPRRepo := new(MockPRRepository)
+		mockPRRepo.On("GetByPRID", ctx, "pr1").Return(&domain.PullRequest{Status: domain.PRStatusMerged}, nil).Once()
+		mockPRRepo.On("GetByPRID", ctx, "pr2").Return(&domain.PullRequest{Status: domain.PRStatusOpen}, nil).Once()
+		before := testutil.ToFloat64(metrics.PRsMerged.WithLabelValues("default"))
+		service := NewInstrumentedPRService(&stubPRService{
+			merged: &domain.PullRequest{Status: domain.PRStatusMerged},
+		}, mockPRRepo)
+
+		_, err := service.MergePR(ctx, "pr1")
+		assert.NoError(t, err)
+		_, err = service.MergePR(ctx, "pr2")
+		assert.NoError(t, err)
+
+		assert.Equal(t, before+1, testutil.ToFloat64(metrics.PRsMerged.WithLabelValues("default")))
+		mockPRRepo.AssertExpectations(t)
+	})
+
+	t.Run("dry run is not counted", func(t *testing.T) {
+		before := testutil.ToFloat64(metrics.PRsCreated.WithLabelValues("default"))
+		service := NewInstrumentedPRService(&stubPRService{
+			created: &domain.PullRequest{PullRequestID: "pr1"},
+		}, new(MockPRRepository))
+
+		_, err := service.CreatePR(dryrun.With(ctx), "u1", &domain.PullRequest{})
+		assert.NoError(t, err)
+		assert.Equal(t, before, testutil.ToFloat64(metrics.PRsCreated.WithLabelValues("default")))
+	})
+
+	t.Run("reassign failure counted by error code", func(t *testing.T) {
+		counter := metrics.AssignmentFailures.WithLabelValues("reassign", string(apperror.ErrCodePRMerged))
+		before := testutil.ToFloat64(counter)
+		service := NewInstrumentedPRService(&stubPRService{
+			err: apperror.NewPRMergedError("pr1"),
+		}, new(MockPRRepository))
+
+		_, _, err := service.ReassignReviewer(ctx, "pr1", "u2")
+		assert.Error(t, err)
+		assert.Equal(t, before+1, testutil.ToFloat64(counter))
+	})
+}
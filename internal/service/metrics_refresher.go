@@ -0,0 +1,96 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/ssokov/pr-reviewer-service/internal/metrics"
+	"github.com/ssokov/pr-reviewer-service/internal/repository"
+	"github.com/ssokov/pr-reviewer-service/internal/tenant"
+	"github.com/vmkteam/embedlog"
+)
+
+// MetricsRefresher periodically updates the gauges that are read from the database instead of counted in process.
+type MetricsRefresher struct {
+	statsRepo repository.StatsRepository
+	orgRepo   repository.OrganizationRepository
+	logger    embedlog.Logger
+
+	// interval and lastRun are unix nanoseconds, read by Healthy from other goroutines.
+	interval atomic.Int64
+	lastRun  atomic.Int64
+}
+
+func NewMetricsRefresher(statsRepo repository.StatsRepository, orgRepo repository.OrganizationRepository, logger embedlog.Logger) *MetricsRefresher {
+	return &MetricsRefresher{
+		statsRepo: statsRepo,
+		orgRepo:   orgRepo,
+		logger:    logger,
+	}
+}
+
+// Run refreshes the gauges every interval until ctx is done.
+func (r *MetricsRefresher) Run(ctx context.Context, interval time.Duration) {
+	r.lastRun.Store(time.Now().UnixNano())
+	r.interval.Store(int64(interval))
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := r.Refresh(ctx); err != nil {
+			r.logger.Print(ctx, "failed to refresh metrics", "error", err)
+		}
+		r.lastRun.Store(time.Now().UnixNano())
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (r *MetricsRefresher) Name() string {
+	return "metrics_refresher"
+}
+
+// Healthy reports an error when the loop has not completed a pass for three intervals. A refresher that was
+// never started is considered healthy, since it is disabled in the config.
+func (r *MetricsRefresher) Healthy() error {
+	interval := time.Duration(r.interval.Load())
+	if interval == 0 {
+		return nil
+	}
+
+	if since := time.Since(time.Unix(0, r.lastRun.Load())); since > 3*interval {
+		return fmt.Errorf("no refresh for %s", since.Round(time.Second))
+	}
+	return nil
+}
+
+// Refresh replaces the open PR gauges of every organization. On error the previous values are kept.
+func (r *MetricsRefresher) Refresh(ctx context.Context) error {
+	orgs, err := r.orgRepo.List(ctx)
+	if err != nil {
+		return err
+	}
+
+	openPRs := make(map[string]map[string]int, len(orgs))
+	for i := range orgs {
+		counts, err := r.statsRepo.GetOpenPRsByTeam(tenant.WithOrganization(ctx, &orgs[i]))
+		if err != nil {
+			return err
+		}
+		openPRs[orgs[i].Slug] = counts
+	}
+
+	metrics.OpenPRsByTeam.Reset()
+	for org, counts := range openPRs {
+		for team, count := range counts {
+			metrics.OpenPRsByTeam.WithLabelValues(org, team).Set(float64(count))
+		}
+	}
+	return nil
+}
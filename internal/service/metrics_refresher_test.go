@@ -0,0 +1,72 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/ssokov/pr-reviewer-service/internal/metrics"
+	"github.com/ssokov/pr-reviewer-service/internal/model/domain"
+	"github.com/ssokov/pr-reviewer-service/internal/tenant"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/vmkteam/embedlog"
+)
+
+func TestMetricsRefresher_Refresh(t *testing.T) {
+	ctx := context.Background()
+	logger := embedlog.NewLogger(false, false)
+
+	orgs := []domain.Organization{
+		{ID: 1, Slug: "default"},
+		{ID: 2, Slug: "acme"},
+	}
+	inOrg := func(id int64) any {
+		return mock.MatchedBy(func(ctx context.Context) bool { return tenant.OrganizationID(ctx) == id })
+	}
+
+	t.Run("success", func(t *testing.T) {
+		mockStatsRepo := new(MockStatsRepository)
+		mockOrgRepo := new(MockOrganizationRepository)
+		refresher := NewMetricsRefresher(mockStatsRepo, mockOrgRepo, logger)
+
+		mockOrgRepo.On("List", ctx).Return(orgs, nil)
+		mockStatsRepo.On("GetOpenPRsByTeam", inOrg(1)).Return(map[string]int{"backend": 3}, nil)
+		mockStatsRepo.On("GetOpenPRsByTeam", inOrg(2)).Return(map[string]int{"backend": 1, "mobile": 2}, nil)
+
+		err := refresher.Refresh(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, 3, testutil.CollectAndCount(metrics.OpenPRsByTeam))
+		assert.Equal(t, float64(3), testutil.ToFloat64(metrics.OpenPRsByTeam.WithLabelValues("default", "backend")))
+		assert.Equal(t, float64(2), testutil.ToFloat64(metrics.OpenPRsByTeam.WithLabelValues("acme", "mobile")))
+
+		mockStatsRepo.AssertExpectations(t)
+	})
+
+	t.Run("error - keeps previous values", func(t *testing.T) {
+		mockStatsRepo := new(MockStatsRepository)
+		mockOrgRepo := new(MockOrganizationRepository)
+		refresher := NewMetricsRefresher(mockStatsRepo, mockOrgRepo, logger)
+
+		mockOrgRepo.On("List", ctx).Return(orgs, nil)
+		mockStatsRepo.On("GetOpenPRsByTeam", inOrg(1)).Return(nil, errors.New("db error"))
+
+		err := refresher.Refresh(ctx)
+		assert.Error(t, err)
+		assert.Equal(t, 3, testutil.CollectAndCount(metrics.OpenPRsByTeam))
+	})
+}
+
+func TestMetricsRefresher_Healthy(t *testing.T) {
+	refresher := NewMetricsRefresher(new(MockStatsRepository), new(MockOrganizationRepository), embedlog.NewLogger(false, false))
+	assert.NoError(t, refresher.Healthy(), "not started refresher is healthy")
+
+	refresher.interval.Store(int64(time.Second))
+	refresher.lastRun.Store(time.Now().UnixNano())
+	assert.NoError(t, refresher.Healthy())
+
+	refresher.lastRun.Store(time.Now().Add(-time.Minute).UnixNano())
+	assert.Error(t, refresher.Healthy())
+}
@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"time"
 
 	"github.com/ssokov/pr-reviewer-service/internal/model/domain"
 	"github.com/stretchr/testify/mock"
@@ -43,6 +44,14 @@ func (m *MockUserRepository) GetByTeamID(ctx context.Context, teamID int64) ([]d
 	return args.Get(0).([]domain.User), args.Error(1)
 }
 
+func (m *MockUserRepository) List(ctx context.Context) ([]domain.User, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.User), args.Error(1)
+}
+
 func (m *MockUserRepository) SetIsActive(ctx context.Context, userID string, isActive bool) (*domain.User, error) {
 	args := m.Called(ctx, userID, isActive)
 	if args.Get(0) == nil {
@@ -51,6 +60,30 @@ func (m *MockUserRepository) SetIsActive(ctx context.Context, userID string, isA
 	return args.Get(0).(*domain.User), args.Error(1)
 }
 
+func (m *MockUserRepository) SetTeamRole(ctx context.Context, userID string, role domain.TeamRole) (*domain.User, error) {
+	args := m.Called(ctx, userID, role)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.User), args.Error(1)
+}
+
+func (m *MockUserRepository) SetWorkingHours(ctx context.Context, userID, timezone string, workStart, workEnd int) (*domain.User, error) {
+	args := m.Called(ctx, userID, timezone, workStart, workEnd)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.User), args.Error(1)
+}
+
+func (m *MockUserRepository) SetMaxOpenReviews(ctx context.Context, userID string, limit *int) (*domain.User, error) {
+	args := m.Called(ctx, userID, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.User), args.Error(1)
+}
+
 func (m *MockUserRepository) GetByReviewerID(ctx context.Context, userID string) ([]domain.PullRequest, error) {
 	args := m.Called(ctx, userID)
 	if args.Get(0) == nil {
@@ -111,6 +144,46 @@ func (m *MockPRRepository) GetOpenPRsByUserIDs(ctx context.Context, userIDs []st
 	return args.Get(0).([]domain.PullRequest), args.Error(1)
 }
 
+func (m *MockPRRepository) List(ctx context.Context, filter domain.PRFilter) ([]domain.PullRequest, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.PullRequest), args.Error(1)
+}
+
+func (m *MockPRRepository) GetPairCounts(ctx context.Context, authorID string, since time.Time) (map[string]int, error) {
+	args := m.Called(ctx, authorID, since)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[string]int), args.Error(1)
+}
+
+func (m *MockPRRepository) GetOpenReviewCounts(ctx context.Context, userIDs []string) (map[string]int, error) {
+	args := m.Called(ctx, userIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[string]int), args.Error(1)
+}
+
+func (m *MockPRRepository) GetOpenShadowCounts(ctx context.Context, userIDs []string) (map[string]int, error) {
+	args := m.Called(ctx, userIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[string]int), args.Error(1)
+}
+
+func (m *MockPRRepository) GetReviewerAssignedAt(ctx context.Context, prID string) (map[string]time.Time, error) {
+	args := m.Called(ctx, prID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[string]time.Time), args.Error(1)
+}
+
 type MockTeamRepository struct {
 	mock.Mock
 }
@@ -136,6 +209,37 @@ func (m *MockTeamRepository) ExistsByName(ctx context.Context, teamName string)
 	return args.Bool(0), args.Error(1)
 }
 
+func (m *MockTeamRepository) List(ctx context.Context) ([]domain.Team, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.Team), args.Error(1)
+}
+
+func (m *MockTeamRepository) LockHierarchy(ctx context.Context) error {
+	args := m.Called(ctx)
+	return args.Error(0)
+}
+
+func (m *MockTeamRepository) SetParent(ctx context.Context, teamID int64, parentID int64) error {
+	args := m.Called(ctx, teamID, parentID)
+	return args.Error(0)
+}
+
+func (m *MockTeamRepository) UpdateSettings(ctx context.Context, teamID int64, settings domain.TeamSettings) error {
+	args := m.Called(ctx, teamID, settings)
+	return args.Error(0)
+}
+
+func (m *MockTeamRepository) GetAncestors(ctx context.Context, teamID int64) ([]domain.Team, error) {
+	args := m.Called(ctx, teamID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.Team), args.Error(1)
+}
+
 type MockStatsRepository struct {
 	mock.Mock
 }
@@ -170,3 +274,384 @@ func (m *MockStatsRepository) GetTopReviewers(ctx context.Context, limit int) ([
 	}
 	return args.Get(0).([]domain.ReviewerStats), args.Error(1)
 }
+
+func (m *MockStatsRepository) GetOpenPRsByTeam(ctx context.Context) (map[string]int, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[string]int), args.Error(1)
+}
+
+func (m *MockStatsRepository) GetTeamPairings(ctx context.Context, teamName string, since time.Time) (*domain.TeamPairings, error) {
+	args := m.Called(ctx, teamName, since)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.TeamPairings), args.Error(1)
+}
+
+func (m *MockStatsRepository) GetTeamStats(ctx context.Context) ([]domain.TeamStats, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.TeamStats), args.Error(1)
+}
+
+type MockAPIKeyRepository struct {
+	mock.Mock
+}
+
+func (m *MockAPIKeyRepository) Create(ctx context.Context, key *domain.APIKey, keyHash string) (*domain.APIKey, error) {
+	args := m.Called(ctx, key, keyHash)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.APIKey), args.Error(1)
+}
+
+func (m *MockAPIKeyRepository) GetByHash(ctx context.Context, keyHash string) (*domain.APIKey, error) {
+	args := m.Called(ctx, keyHash)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.APIKey), args.Error(1)
+}
+
+func (m *MockAPIKeyRepository) List(ctx context.Context) ([]domain.APIKey, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.APIKey), args.Error(1)
+}
+
+func (m *MockAPIKeyRepository) Revoke(ctx context.Context, prefix string) (*domain.APIKey, error) {
+	args := m.Called(ctx, prefix)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.APIKey), args.Error(1)
+}
+
+func (m *MockAPIKeyRepository) TouchLastUsed(ctx context.Context, id int64) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+type MockAuditRepository struct {
+	mock.Mock
+}
+
+func (m *MockAuditRepository) Create(ctx context.Context, entry *domain.AuditEntry) error {
+	args := m.Called(ctx, entry)
+	return args.Error(0)
+}
+
+func (m *MockAuditRepository) List(ctx context.Context, filter domain.AuditFilter) ([]domain.AuditEntry, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.AuditEntry), args.Error(1)
+}
+
+type MockNotifier struct {
+	mock.Mock
+}
+
+func (m *MockNotifier) ReviewersAssigned(ctx context.Context, pr *domain.PullRequest) error {
+	args := m.Called(ctx, pr)
+	return args.Error(0)
+}
+
+type MockOrganizationRepository struct {
+	mock.Mock
+}
+
+func (m *MockOrganizationRepository) Create(ctx context.Context, org *domain.Organization) (*domain.Organization, error) {
+	args := m.Called(ctx, org)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Organization), args.Error(1)
+}
+
+func (m *MockOrganizationRepository) GetByID(ctx context.Context, id int64) (*domain.Organization, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Organization), args.Error(1)
+}
+
+func (m *MockOrganizationRepository) GetBySlug(ctx context.Context, slug string) (*domain.Organization, error) {
+	args := m.Called(ctx, slug)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Organization), args.Error(1)
+}
+
+func (m *MockOrganizationRepository) List(ctx context.Context) ([]domain.Organization, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.Organization), args.Error(1)
+}
+
+func (m *MockOrganizationRepository) UpdateSettings(ctx context.Context, slug string, settings domain.OrganizationSettings) (*domain.Organization, error) {
+	args := m.Called(ctx, slug, settings)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Organization), args.Error(1)
+}
+
+type MockCodeOwnersRepository struct {
+	mock.Mock
+}
+
+func (m *MockCodeOwnersRepository) Upsert(ctx context.Context, file *domain.CodeOwners) (*domain.CodeOwners, error) {
+	args := m.Called(ctx, file)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.CodeOwners), args.Error(1)
+}
+
+func (m *MockCodeOwnersRepository) GetByRepository(ctx context.Context, repository string) (*domain.CodeOwners, error) {
+	args := m.Called(ctx, repository)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.CodeOwners), args.Error(1)
+}
+
+type MockSkillRepository struct {
+	mock.Mock
+}
+
+func (m *MockSkillRepository) SetUserSkills(ctx context.Context, userID string, skills []string) error {
+	args := m.Called(ctx, userID, skills)
+	return args.Error(0)
+}
+
+func (m *MockSkillRepository) GetSkillsByUserIDs(ctx context.Context, userIDs []string) (map[string][]string, error) {
+	args := m.Called(ctx, userIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[string][]string), args.Error(1)
+}
+
+func (m *MockSkillRepository) ListSkills(ctx context.Context) ([]string, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *MockSkillRepository) SetPRLabels(ctx context.Context, prID string, labels []string) error {
+	args := m.Called(ctx, prID, labels)
+	return args.Error(0)
+}
+
+type MockPoolRepository struct {
+	mock.Mock
+}
+
+func (m *MockPoolRepository) Upsert(ctx context.Context, pool *domain.ReviewerPool) (*domain.ReviewerPool, error) {
+	args := m.Called(ctx, pool)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.ReviewerPool), args.Error(1)
+}
+
+func (m *MockPoolRepository) GetByName(ctx context.Context, name string) (*domain.ReviewerPool, error) {
+	args := m.Called(ctx, name)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.ReviewerPool), args.Error(1)
+}
+
+func (m *MockPoolRepository) List(ctx context.Context) ([]domain.ReviewerPool, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.ReviewerPool), args.Error(1)
+}
+
+func (m *MockPoolRepository) SetFallback(ctx context.Context, teamID int64, steps []domain.FallbackStep) error {
+	args := m.Called(ctx, teamID, steps)
+	return args.Error(0)
+}
+
+func (m *MockPoolRepository) GetFallback(ctx context.Context, teamID int64) ([]domain.FallbackStep, error) {
+	args := m.Called(ctx, teamID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.FallbackStep), args.Error(1)
+}
+
+type MockHealthRepository struct {
+	mock.Mock
+}
+
+func (m *MockHealthRepository) Ping(ctx context.Context) error {
+	args := m.Called(ctx)
+	return args.Error(0)
+}
+
+func (m *MockHealthRepository) MigrationVersion(ctx context.Context) (uint, bool, error) {
+	args := m.Called(ctx)
+	return args.Get(0).(uint), args.Bool(1), args.Error(2)
+}
+
+// MockTransactor runs fn directly, marking the context the way the postgres transactor binds its transaction.
+type MockTransactor struct {
+	mock.Mock
+}
+
+type mockTxKey struct{}
+
+// inTx matches a context inside a MockTransactor transaction.
+var inTx = mock.MatchedBy(func(ctx context.Context) bool {
+	inTx, _ := ctx.Value(mockTxKey{}).(bool)
+	return inTx
+})
+
+func (m *MockTransactor) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	args := m.Called(ctx)
+	if err := args.Error(0); err != nil {
+		return err
+	}
+	return fn(context.WithValue(ctx, mockTxKey{}, true))
+}
+
+func (m *MockTransactor) WithinRolledBackTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	args := m.Called(ctx)
+	if err := args.Error(0); err != nil {
+		return err
+	}
+	return fn(context.WithValue(ctx, mockTxKey{}, true))
+}
+
+type MockUserService struct {
+	mock.Mock
+}
+
+func (m *MockUserService) SetIsActive(ctx context.Context, userID string, isActive bool) (*domain.User, error) {
+	args := m.Called(ctx, userID, isActive)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.User), args.Error(1)
+}
+
+func (m *MockUserService) SetTeamRole(ctx context.Context, userID string, role domain.TeamRole) (*domain.User, error) {
+	args := m.Called(ctx, userID, role)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.User), args.Error(1)
+}
+
+func (m *MockUserService) SetWorkingHours(ctx context.Context, userID, timezone string, workStart, workEnd int) (*domain.User, error) {
+	args := m.Called(ctx, userID, timezone, workStart, workEnd)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.User), args.Error(1)
+}
+
+func (m *MockUserService) SetMaxOpenReviews(ctx context.Context, userID string, limit *int) (*domain.User, error) {
+	args := m.Called(ctx, userID, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.User), args.Error(1)
+}
+
+func (m *MockUserService) GetReview(ctx context.Context, userID string) ([]domain.PullRequest, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.PullRequest), args.Error(1)
+}
+
+type MockPRService struct {
+	mock.Mock
+}
+
+func (m *MockPRService) CreatePR(ctx context.Context, authorID string, pr *domain.PullRequest) (*domain.PullRequest, error) {
+	args := m.Called(ctx, authorID, pr)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.PullRequest), args.Error(1)
+}
+
+func (m *MockPRService) MergePR(ctx context.Context, prID string) (*domain.PullRequest, error) {
+	args := m.Called(ctx, prID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.PullRequest), args.Error(1)
+}
+
+func (m *MockPRService) ReassignReviewer(ctx context.Context, prID, oldUserID string) (*domain.PullRequest, string, error) {
+	args := m.Called(ctx, prID, oldUserID)
+	if args.Get(0) == nil {
+		return nil, "", args.Error(2)
+	}
+	return args.Get(0).(*domain.PullRequest), args.String(1), args.Error(2)
+}
+
+func (m *MockPRService) ListPRs(ctx context.Context, filter domain.PRFilter) ([]domain.PullRequest, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.PullRequest), args.Error(1)
+}
+
+func (m *MockPRService) GetSLA(ctx context.Context, prID string) (*domain.ReviewSLA, error) {
+	args := m.Called(ctx, prID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.ReviewSLA), args.Error(1)
+}
+
+func (m *MockPRService) AssignPendingReviewers(ctx context.Context, prID string) (*domain.PullRequest, error) {
+	args := m.Called(ctx, prID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.PullRequest), args.Error(1)
+}
+
+func (m *MockPRService) AddReviewer(ctx context.Context, prID, userID string, pin bool) (*domain.PullRequest, error) {
+	args := m.Called(ctx, prID, userID, pin)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.PullRequest), args.Error(1)
+}
+
+func (m *MockPRService) RemoveReviewer(ctx context.Context, prID, userID string) (*domain.PullRequest, error) {
+	args := m.Called(ctx, prID, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.PullRequest), args.Error(1)
+}
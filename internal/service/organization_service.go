@@ -0,0 +1,147 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"github.com/ssokov/pr-reviewer-service/internal/apperror"
+	"github.com/ssokov/pr-reviewer-service/internal/model/domain"
+	"github.com/ssokov/pr-reviewer-service/internal/repository"
+	"github.com/ssokov/pr-reviewer-service/internal/tracing"
+	"github.com/vmkteam/embedlog"
+)
+
+var organizationSlugRe = regexp.MustCompile(`^[a-z0-9][a-z0-9-]{0,99}$`)
+
+type organizationService struct {
+	orgRepo repository.OrganizationRepository
+	logger  embedlog.Logger
+}
+
+func NewOrganizationService(orgRepo repository.OrganizationRepository, logger embedlog.Logger) OrganizationService {
+	return &organizationService{
+		orgRepo: orgRepo,
+		logger:  logger,
+	}
+}
+
+func (s *organizationService) CreateOrganization(ctx context.Context, slug, name string, settings domain.OrganizationSettings) (*domain.Organization, error) {
+	if !organizationSlugRe.MatchString(slug) {
+		return nil, apperror.NewInvalidInputError("slug must consist of lowercase letters, digits and dashes")
+	}
+	if name == "" {
+		return nil, apperror.NewInvalidInputError("name is required")
+	}
+	if err := validateOrganizationSettings(settings); err != nil {
+		return nil, err
+	}
+
+	existing, err := s.orgRepo.GetBySlug(ctx, slug)
+	if err != nil {
+		tracing.Logger(ctx, s.logger).Errorf("failed to get organization: %v", err)
+		return nil, apperror.NewInternalError("failed to get organization", err)
+	}
+	if existing != nil {
+		return nil, apperror.NewInvalidInputError(fmt.Sprintf("organization '%s' already exists", slug))
+	}
+
+	s.logger.Print(ctx, "creating organization", "slug", slug)
+
+	org, err := s.orgRepo.Create(ctx, &domain.Organization{
+		Slug:     slug,
+		Name:     name,
+		Settings: settings,
+	})
+	if err != nil {
+		tracing.Logger(ctx, s.logger).Errorf("failed to create organization: %v", err)
+		return nil, apperror.NewInternalError("failed to create organization", err)
+	}
+
+	return org, nil
+}
+
+func (s *organizationService) GetBySlug(ctx context.Context, slug string) (*domain.Organization, error) {
+	if slug == "" {
+		return nil, apperror.NewInvalidInputError("organization is required")
+	}
+
+	org, err := s.orgRepo.GetBySlug(ctx, slug)
+	if err != nil {
+		tracing.Logger(ctx, s.logger).Errorf("failed to get organization: %v", err)
+		return nil, apperror.NewInternalError("failed to get organization", err)
+	}
+	if org == nil {
+		return nil, apperror.NewNotFoundError(fmt.Sprintf("organization '%s'", slug))
+	}
+
+	return org, nil
+}
+
+func (s *organizationService) GetByID(ctx context.Context, id int64) (*domain.Organization, error) {
+	org, err := s.orgRepo.GetByID(ctx, id)
+	if err != nil {
+		tracing.Logger(ctx, s.logger).Errorf("failed to get organization: %v", err)
+		return nil, apperror.NewInternalError("failed to get organization", err)
+	}
+	if org == nil {
+		return nil, apperror.NewNotFoundError(fmt.Sprintf("organization %d", id))
+	}
+
+	return org, nil
+}
+
+func (s *organizationService) ListOrganizations(ctx context.Context) ([]domain.Organization, error) {
+	orgs, err := s.orgRepo.List(ctx)
+	if err != nil {
+		tracing.Logger(ctx, s.logger).Errorf("failed to list organizations: %v", err)
+		return nil, apperror.NewInternalError("failed to list organizations", err)
+	}
+	return orgs, nil
+}
+
+func (s *organizationService) UpdateSettings(ctx context.Context, slug string, settings domain.OrganizationSettings) (*domain.Organization, error) {
+	if err := validateOrganizationSettings(settings); err != nil {
+		return nil, err
+	}
+
+	s.logger.Print(ctx, "updating organization settings", "slug", slug, "settings", settings)
+
+	org, err := s.orgRepo.UpdateSettings(ctx, slug, settings)
+	if err != nil {
+		tracing.Logger(ctx, s.logger).Errorf("failed to update organization settings: %v", err)
+		return nil, apperror.NewInternalError("failed to update organization settings", err)
+	}
+	if org == nil {
+		return nil, apperror.NewNotFoundError(fmt.Sprintf("organization '%s'", slug))
+	}
+
+	return org, nil
+}
+
+func validateOrganizationSettings(settings domain.OrganizationSettings) error {
+	if settings.ReviewerCount < 0 {
+		return apperror.NewInvalidInputError("reviewer_count must not be negative")
+	}
+	if settings.TopReviewersLimit < 0 {
+		return apperror.NewInvalidInputError("top_reviewers_limit must not be negative")
+	}
+	if settings.PairingWindowDays < 0 {
+		return apperror.NewInvalidInputError("pairing_window_days must not be negative")
+	}
+	if settings.AvailabilityWindowHours < 0 {
+		return apperror.NewInvalidInputError("availability_window_hours must not be negative")
+	}
+	if settings.ReviewSLAHours < 0 {
+		return apperror.NewInvalidInputError("review_sla_hours must not be negative")
+	}
+	if settings.MaxOpenReviews < 0 {
+		return apperror.NewInvalidInputError("max_open_reviews must not be negative")
+	}
+	switch settings.AtCapacity {
+	case "", domain.AtCapacityReject, domain.AtCapacityQueue:
+	default:
+		return apperror.NewInvalidInputError("at_capacity must be reject or queue")
+	}
+	return nil
+}
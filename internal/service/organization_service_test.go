@@ -0,0 +1,132 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ssokov/pr-reviewer-service/internal/apperror"
+	"github.com/ssokov/pr-reviewer-service/internal/model/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/vmkteam/embedlog"
+)
+
+func TestOrganizationService_CreateOrganization(t *testing.T) {
+	ctx := context.Background()
+	logger := embedlog.NewLogger(false, false)
+
+	t.Run("success", func(t *testing.T) {
+		mockRepo := new(MockOrganizationRepository)
+		service := NewOrganizationService(mockRepo, logger)
+
+		settings := domain.OrganizationSettings{ReviewerCount: 2}
+		mockRepo.On("GetBySlug", ctx, "acme").Return(nil, nil)
+		mockRepo.On("Create", ctx, mock.MatchedBy(func(org *domain.Organization) bool {
+			return org.Slug == "acme" && org.Name == "Acme" && org.Settings == settings
+		})).Return(&domain.Organization{ID: 2, Slug: "acme", Name: "Acme", Settings: settings}, nil)
+
+		org, err := service.CreateOrganization(ctx, "acme", "Acme", settings)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(2), org.ID)
+
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("error - invalid slug", func(t *testing.T) {
+		mockRepo := new(MockOrganizationRepository)
+		service := NewOrganizationService(mockRepo, logger)
+
+		org, err := service.CreateOrganization(ctx, "Acme Corp", "Acme", domain.OrganizationSettings{})
+		assert.Nil(t, org)
+		assert.True(t, apperror.Is(err, apperror.ErrCodeInvalidInput))
+	})
+
+	t.Run("error - negative reviewer count", func(t *testing.T) {
+		mockRepo := new(MockOrganizationRepository)
+		service := NewOrganizationService(mockRepo, logger)
+
+		org, err := service.CreateOrganization(ctx, "acme", "Acme", domain.OrganizationSettings{ReviewerCount: -1})
+		assert.Nil(t, org)
+		assert.True(t, apperror.Is(err, apperror.ErrCodeInvalidInput))
+	})
+
+	t.Run("error - already exists", func(t *testing.T) {
+		mockRepo := new(MockOrganizationRepository)
+		service := NewOrganizationService(mockRepo, logger)
+
+		mockRepo.On("GetBySlug", ctx, "acme").Return(&domain.Organization{ID: 2, Slug: "acme"}, nil)
+
+		org, err := service.CreateOrganization(ctx, "acme", "Acme", domain.OrganizationSettings{})
+		assert.Nil(t, org)
+		assert.True(t, apperror.Is(err, apperror.ErrCodeInvalidInput))
+
+		mockRepo.AssertExpectations(t)
+	})
+}
+
+func TestOrganizationService_GetBySlug(t *testing.T) {
+	ctx := context.Background()
+	logger := embedlog.NewLogger(false, false)
+
+	t.Run("success", func(t *testing.T) {
+		mockRepo := new(MockOrganizationRepository)
+		service := NewOrganizationService(mockRepo, logger)
+
+		mockRepo.On("GetBySlug", ctx, "acme").Return(&domain.Organization{ID: 2, Slug: "acme"}, nil)
+
+		org, err := service.GetBySlug(ctx, "acme")
+		assert.NoError(t, err)
+		assert.Equal(t, int64(2), org.ID)
+	})
+
+	t.Run("error - not found", func(t *testing.T) {
+		mockRepo := new(MockOrganizationRepository)
+		service := NewOrganizationService(mockRepo, logger)
+
+		mockRepo.On("GetBySlug", ctx, "ghost").Return(nil, nil)
+
+		org, err := service.GetBySlug(ctx, "ghost")
+		assert.Nil(t, org)
+		assert.True(t, apperror.Is(err, apperror.ErrCodeNotFound))
+	})
+
+	t.Run("error - repository failure", func(t *testing.T) {
+		mockRepo := new(MockOrganizationRepository)
+		service := NewOrganizationService(mockRepo, logger)
+
+		mockRepo.On("GetBySlug", ctx, "acme").Return(nil, errors.New("db error"))
+
+		org, err := service.GetBySlug(ctx, "acme")
+		assert.Nil(t, org)
+		assert.True(t, apperror.Is(err, apperror.ErrCodeInternalError))
+	})
+}
+
+func TestOrganizationService_UpdateSettings(t *testing.T) {
+	ctx := context.Background()
+	logger := embedlog.NewLogger(false, false)
+
+	t.Run("success", func(t *testing.T) {
+		mockRepo := new(MockOrganizationRepository)
+		service := NewOrganizationService(mockRepo, logger)
+
+		settings := domain.OrganizationSettings{TopReviewersLimit: 3}
+		mockRepo.On("UpdateSettings", ctx, "acme", settings).Return(&domain.Organization{ID: 2, Slug: "acme", Settings: settings}, nil)
+
+		org, err := service.UpdateSettings(ctx, "acme", settings)
+		assert.NoError(t, err)
+		assert.Equal(t, 3, org.Settings.TopReviewersLimit)
+	})
+
+	t.Run("error - not found", func(t *testing.T) {
+		mockRepo := new(MockOrganizationRepository)
+		service := NewOrganizationService(mockRepo, logger)
+
+		mockRepo.On("UpdateSettings", ctx, "ghost", domain.OrganizationSettings{}).Return(nil, nil)
+
+		org, err := service.UpdateSettings(ctx, "ghost", domain.OrganizationSettings{})
+		assert.Nil(t, org)
+		assert.True(t, apperror.Is(err, apperror.ErrCodeNotFound))
+	})
+}
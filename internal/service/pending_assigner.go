@@ -0,0 +1,119 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/ssokov/pr-reviewer-service/internal/model/domain"
+	"github.com/ssokov/pr-reviewer-service/internal/repository"
+	"github.com/ssokov/pr-reviewer-service/internal/tenant"
+	"github.com/vmkteam/embedlog"
+)
+
+// Notifier tells users about changes the service made on its own.
+type Notifier interface {
+	// ReviewersAssigned tells the author of pr that reviewers were assigned to it.
+	ReviewersAssigned(ctx context.Context, pr *domain.PullRequest) error
+}
+
+// PendingAssigner periodically retries reviewer assignment for the PRs created while nobody could review them.
+// Polling picks up every way a reviewer becomes available: users activated or back from absence, PRs merged or
+// reassigned, limits raised. The author is notified once reviewers are assigned.
+type PendingAssigner struct {
+	prService PRService
+	orgRepo   repository.OrganizationRepository
+	notifier  Notifier
+	logger    embedlog.Logger
+
+	// interval and lastRun are unix nanoseconds, read by Healthy from other goroutines.
+	interval atomic.Int64
+	lastRun  atomic.Int64
+}
+
+// NewPendingAssigner takes the decorated prService, so that assignments made by the worker are audited and traced
+// like the ones made over the API.
+func NewPendingAssigner(prService PRService, orgRepo repository.OrganizationRepository, notifier Notifier, logger embedlog.Logger) *PendingAssigner {
+	return &PendingAssigner{
+		prService: prService,
+		orgRepo:   orgRepo,
+		notifier:  notifier,
+		logger:    logger,
+	}
+}
+
+// Run retries the pending PRs every interval until ctx is done.
+func (a *PendingAssigner) Run(ctx context.Context, interval time.Duration) {
+	a.lastRun.Store(time.Now().UnixNano())
+	a.interval.Store(int64(interval))
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := a.Retry(ctx); err != nil {
+			a.logger.Print(ctx, "failed to retry pending PRs", "error", err)
+		}
+		a.lastRun.Store(time.Now().UnixNano())
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (a *PendingAssigner) Name() string {
+	return "pending_assigner"
+}
+
+// Healthy reports an error when the loop has not completed a pass for three intervals. An assigner that was never
+// started is considered healthy, since it is disabled in the config.
+func (a *PendingAssigner) Healthy() error {
+	interval := time.Duration(a.interval.Load())
+	if interval == 0 {
+		return nil
+	}
+
+	if since := time.Since(time.Unix(0, a.lastRun.Load())); since > 3*interval {
+		return fmt.Errorf("no retry for %s", since.Round(time.Second))
+	}
+	return nil
+}
+
+// Retry tries to assign reviewers to the pending PRs of every organization, oldest first, and returns how many got
+// reviewers. A PR that fails is logged and skipped so that it does not hold up the others, and so is a failed
+// notification: the assignment stands either way.
+func (a *PendingAssigner) Retry(ctx context.Context) (int, error) {
+	orgs, err := a.orgRepo.List(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	assigned := 0
+	for i := range orgs {
+		orgCtx := tenant.WithOrganization(ctx, &orgs[i])
+		prs, err := a.prService.ListPRs(orgCtx, domain.PRFilter{Status: domain.PRStatusOpen, Pending: true, Limit: maxPRListLimit})
+		if err != nil {
+			return assigned, err
+		}
+
+		for _, pending := range prs {
+			pr, err := a.prService.AssignPendingReviewers(orgCtx, pending.PullRequestID)
+			if err != nil {
+				a.logger.Print(orgCtx, "failed to assign pending PR", "pr_id", pending.PullRequestID, "error", err)
+				continue
+			}
+			if pr.PendingReviewers {
+				continue
+			}
+
+			assigned++
+			if err := a.notifier.ReviewersAssigned(orgCtx, pr); err != nil {
+				a.logger.Print(orgCtx, "failed to notify author", "pr_id", pr.PullRequestID, "author_id", pr.AuthorID, "error", err)
+			}
+		}
+	}
+	return assigned, nil
+}
@@ -0,0 +1,64 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ssokov/pr-reviewer-service/internal/model/domain"
+	"github.com/ssokov/pr-reviewer-service/internal/tenant"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/vmkteam/embedlog"
+)
+
+func TestPendingAssigner_Retry(t *testing.T) {
+	ctx := context.Background()
+	logger := embedlog.NewLogger(false, false)
+	inOrg := func(id int64) any {
+		return mock.MatchedBy(func(ctx context.Context) bool { return tenant.OrganizationID(ctx) == id })
+	}
+	pendingFilter := domain.PRFilter{Status: domain.PRStatusOpen, Pending: true, Limit: maxPRListLimit}
+
+	mockPRService := new(MockPRService)
+	mockOrgRepo := new(MockOrganizationRepository)
+	mockNotifier := new(MockNotifier)
+	assigner := NewPendingAssigner(mockPRService, mockOrgRepo, mockNotifier, logger)
+
+	mockOrgRepo.On("List", ctx).Return([]domain.Organization{{ID: 1, Slug: "default"}, {ID: 2, Slug: "acme"}}, nil)
+	mockPRService.On("ListPRs", inOrg(1), pendingFilter).Return([]domain.PullRequest{
+		{PullRequestID: "pr-1"}, {PullRequestID: "pr-2"}, {PullRequestID: "pr-3"},
+	}, nil)
+	mockPRService.On("ListPRs", inOrg(2), pendingFilter).Return([]domain.PullRequest{{PullRequestID: "pr-4"}}, nil)
+
+	assigned := &domain.PullRequest{PullRequestID: "pr-1", AuthorID: "u1", AssignedReviewers: []string{"u2"}}
+	mockPRService.On("AssignPendingReviewers", inOrg(1), "pr-1").Return(assigned, nil)
+	mockPRService.On("AssignPendingReviewers", inOrg(1), "pr-2").Return(&domain.PullRequest{PullRequestID: "pr-2", PendingReviewers: true}, nil)
+	mockPRService.On("AssignPendingReviewers", inOrg(1), "pr-3").Return(nil, errors.New("db error"))
+	acmePR := &domain.PullRequest{PullRequestID: "pr-4", AuthorID: "u7", AssignedReviewers: []string{"u8"}}
+	mockPRService.On("AssignPendingReviewers", inOrg(2), "pr-4").Return(acmePR, nil)
+
+	mockNotifier.On("ReviewersAssigned", inOrg(1), assigned).Return(nil)
+	mockNotifier.On("ReviewersAssigned", inOrg(2), acmePR).Return(errors.New("webhook down"))
+
+	count, err := assigner.Retry(ctx)
+	require.NoError(t, err)
+	// A failed PR or notification does not stop the others.
+	assert.Equal(t, 2, count)
+	mockNotifier.AssertNumberOfCalls(t, "ReviewersAssigned", 2)
+	mockPRService.AssertExpectations(t)
+}
+
+func TestPendingAssigner_Healthy(t *testing.T) {
+	assigner := NewPendingAssigner(new(MockPRService), new(MockOrganizationRepository), new(MockNotifier), embedlog.NewLogger(false, false))
+	assert.NoError(t, assigner.Healthy(), "not started assigner is healthy")
+
+	assigner.interval.Store(int64(time.Second))
+	assigner.lastRun.Store(time.Now().UnixNano())
+	assert.NoError(t, assigner.Healthy())
+
+	assigner.lastRun.Store(time.Now().Add(-time.Minute).UnixNano())
+	assert.Error(t, assigner.Healthy())
+}
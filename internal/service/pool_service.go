@@ -0,0 +1,183 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ssokov/pr-reviewer-service/internal/apperror"
+	"github.com/ssokov/pr-reviewer-service/internal/model/domain"
+	"github.com/ssokov/pr-reviewer-service/internal/repository"
+	"github.com/ssokov/pr-reviewer-service/internal/tracing"
+	"github.com/vmkteam/embedlog"
+)
+
+const maxFallbackSteps = 10
+
+type poolService struct {
+	poolRepo repository.PoolRepository
+	userRepo repository.UserRepository
+	teamRepo repository.TeamRepository
+	logger   embedlog.Logger
+}
+
+func NewPoolService(poolRepo repository.PoolRepository, userRepo repository.UserRepository, teamRepo repository.TeamRepository, logger embedlog.Logger) PoolService {
+	return &poolService{
+		poolRepo: poolRepo,
+		userRepo: userRepo,
+		teamRepo: teamRepo,
+		logger:   logger,
+	}
+}
+
+// SetPool creates a pool or replaces its members. Members must exist; they may belong to any team.
+func (s *poolService) SetPool(ctx context.Context, pool *domain.ReviewerPool) (*domain.ReviewerPool, error) {
+	if err := authorizeAdmin(ctx); err != nil {
+		return nil, err
+	}
+	if pool.Name == "" {
+		return nil, apperror.NewInvalidInputError("pool_name is required")
+	}
+	if len(pool.Members) == 0 {
+		return nil, apperror.NewInvalidInputError("pool must have at least one member")
+	}
+
+	seen := make(map[string]bool, len(pool.Members))
+	for _, member := range pool.Members {
+		if seen[member.UserID] {
+			return nil, apperror.NewInvalidInputError(fmt.Sprintf("user '%s' is listed twice", member.UserID))
+		}
+		seen[member.UserID] = true
+
+		user, err := s.userRepo.GetByUserID(ctx, member.UserID)
+		if err != nil {
+			tracing.Logger(ctx, s.logger).Errorf("failed to get user: %v", err)
+			return nil, apperror.NewInternalError("failed to get user", err)
+		}
+		if user == nil {
+			return nil, apperror.NewUserNotFoundError(member.UserID)
+		}
+	}
+
+	saved, err := s.poolRepo.Upsert(ctx, pool)
+	if err != nil {
+		tracing.Logger(ctx, s.logger).Errorf("failed to save pool: %v", err)
+		return nil, apperror.NewInternalError("failed to save pool", err)
+	}
+
+	s.logger.Print(ctx, "reviewer pool saved", "pool_name", saved.Name, "members_count", len(saved.Members))
+	return saved, nil
+}
+
+func (s *poolService) GetPool(ctx context.Context, name string) (*domain.ReviewerPool, error) {
+	if name == "" {
+		return nil, apperror.NewInvalidInputError("pool_name is required")
+	}
+
+	pool, err := s.poolRepo.GetByName(ctx, name)
+	if err != nil {
+		tracing.Logger(ctx, s.logger).Errorf("failed to get pool: %v", err)
+		return nil, apperror.NewInternalError("failed to get pool", err)
+	}
+	if pool == nil {
+		return nil, apperror.NewNotFoundError(fmt.Sprintf("pool '%s'", name))
+	}
+	return pool, nil
+}
+
+func (s *poolService) ListPools(ctx context.Context) ([]domain.ReviewerPool, error) {
+	pools, err := s.poolRepo.List(ctx)
+	if err != nil {
+		tracing.Logger(ctx, s.logger).Errorf("failed to list pools: %v", err)
+		return nil, apperror.NewInternalError("failed to list pools", err)
+	}
+	return pools, nil
+}
+
+// SetFallback replaces the fallback chain of a team. An empty chain removes it.
+func (s *poolService) SetFallback(ctx context.Context, chain *domain.FallbackChain) (*domain.FallbackChain, error) {
+	if err := authorizeAdmin(ctx); err != nil {
+		return nil, err
+	}
+	if chain.TeamName == "" {
+		return nil, apperror.NewInvalidInputError("team_name is required")
+	}
+	if len(chain.Steps) > maxFallbackSteps {
+		return nil, apperror.NewInvalidInputError(fmt.Sprintf("at most %d fallback steps are allowed", maxFallbackSteps))
+	}
+
+	team, err := s.getTeam(ctx, chain.TeamName)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[domain.FallbackStep]bool, len(chain.Steps))
+	for i, step := range chain.Steps {
+		if err := s.validateStep(ctx, chain.TeamName, step); err != nil {
+			return nil, err
+		}
+		if seen[step] {
+			return nil, apperror.NewInvalidInputError(fmt.Sprintf("fallback step %d repeats an earlier step", i+1))
+		}
+		seen[step] = true
+	}
+
+	if err := s.poolRepo.SetFallback(ctx, team.ID, chain.Steps); err != nil {
+		tracing.Logger(ctx, s.logger).Errorf("failed to save fallback chain: %v", err)
+		return nil, apperror.NewInternalError("failed to save fallback chain", err)
+	}
+
+	s.logger.Print(ctx, "fallback chain saved", "team_name", chain.TeamName, "steps", len(chain.Steps))
+	return chain, nil
+}
+
+func (s *poolService) GetFallback(ctx context.Context, teamName string) (*domain.FallbackChain, error) {
+	if teamName == "" {
+		return nil, apperror.NewInvalidInputError("team_name is required")
+	}
+
+	team, err := s.getTeam(ctx, teamName)
+	if err != nil {
+		return nil, err
+	}
+
+	steps, err := s.poolRepo.GetFallback(ctx, team.ID)
+	if err != nil {
+		tracing.Logger(ctx, s.logger).Errorf("failed to get fallback chain: %v", err)
+		return nil, apperror.NewInternalError("failed to get fallback chain", err)
+	}
+	return &domain.FallbackChain{TeamName: teamName, Steps: steps}, nil
+}
+
+func (s *poolService) validateStep(ctx context.Context, teamName string, step domain.FallbackStep) error {
+	switch {
+	case (step.TeamName == "") == (step.PoolName == ""):
+		return apperror.NewInvalidInputError("each fallback step needs exactly one of team_name and pool_name")
+	case step.TeamName == teamName:
+		return apperror.NewInvalidInputError("a team cannot fall back to itself")
+	case step.TeamName != "":
+		_, err := s.getTeam(ctx, step.TeamName)
+		return err
+	}
+
+	pool, err := s.poolRepo.GetByName(ctx, step.PoolName)
+	if err != nil {
+		tracing.Logger(ctx, s.logger).Errorf("failed to get pool: %v", err)
+		return apperror.NewInternalError("failed to get pool", err)
+	}
+	if pool == nil {
+		return apperror.NewNotFoundError(fmt.Sprintf("pool '%s'", step.PoolName))
+	}
+	return nil
+}
+
+func (s *poolService) getTeam(ctx context.Context, teamName string) (*domain.Team, error) {
+	team, err := s.teamRepo.GetByName(ctx, teamName)
+	if err != nil {
+		tracing.Logger(ctx, s.logger).Errorf("failed to get team: %v", err)
+		return nil, apperror.NewInternalError("failed to get team", err)
+	}
+	if team == nil {
+		return nil, apperror.NewTeamNotFoundError(teamName)
+	}
+	return team, nil
+}
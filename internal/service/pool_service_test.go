@@ -0,0 +1,99 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ssokov/pr-reviewer-service/internal/apperror"
+	"github.com/ssokov/pr-reviewer-service/internal/model/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/vmkteam/embedlog"
+)
+
+func TestPoolService_SetPool(t *testing.T) {
+	ctx := context.Background()
+	logger := embedlog.NewLogger(false, false)
+
+	t.Run("success", func(t *testing.T) {
+		mockPoolRepo := new(MockPoolRepository)
+		mockUserRepo := new(MockUserRepository)
+		service := NewPoolService(mockPoolRepo, mockUserRepo, new(MockTeamRepository), logger)
+
+		pool := &domain.ReviewerPool{Name: "oncall", Members: []domain.User{{UserID: "u1"}, {UserID: "u5"}}}
+		mockUserRepo.On("GetByUserID", ctx, "u1").Return(&domain.User{UserID: "u1", TeamID: 1}, nil)
+		mockUserRepo.On("GetByUserID", ctx, "u5").Return(&domain.User{UserID: "u5", TeamID: 2}, nil)
+		mockPoolRepo.On("Upsert", ctx, pool).Return(pool, nil)
+
+		saved, err := service.SetPool(ctx, pool)
+		assert.NoError(t, err)
+		assert.Equal(t, "oncall", saved.Name)
+		mockPoolRepo.AssertExpectations(t)
+	})
+
+	t.Run("error - unknown member", func(t *testing.T) {
+		mockPoolRepo := new(MockPoolRepository)
+		mockUserRepo := new(MockUserRepository)
+		service := NewPoolService(mockPoolRepo, mockUserRepo, new(MockTeamRepository), logger)
+
+		mockUserRepo.On("GetByUserID", ctx, "ghost").Return(nil, nil)
+
+		_, err := service.SetPool(ctx, &domain.ReviewerPool{Name: "oncall", Members: []domain.User{{UserID: "ghost"}}})
+		assert.True(t, apperror.Is(err, apperror.ErrCodeUserNotFound))
+		mockPoolRepo.AssertNotCalled(t, "Upsert", mock.Anything, mock.Anything)
+	})
+
+	t.Run("error - forbidden for members", func(t *testing.T) {
+		service := NewPoolService(new(MockPoolRepository), new(MockUserRepository), new(MockTeamRepository), logger)
+
+		_, err := service.SetPool(userContext("u1", domain.RoleMember), &domain.ReviewerPool{Name: "oncall"})
+		assert.True(t, apperror.Is(err, apperror.ErrCodeForbidden))
+	})
+}
+
+func TestPoolService_SetFallback(t *testing.T) {
+	ctx := context.Background()
+	logger := embedlog.NewLogger(false, false)
+
+	setup := func() (PoolService, *MockPoolRepository) {
+		mockPoolRepo := new(MockPoolRepository)
+		mockTeamRepo := new(MockTeamRepository)
+		mockTeamRepo.On("GetByName", ctx, "mobile").Return(&domain.Team{ID: 1, TeamName: "mobile"}, nil)
+		mockTeamRepo.On("GetByName", ctx, "platform").Return(&domain.Team{ID: 2, TeamName: "platform"}, nil)
+		mockTeamRepo.On("GetByName", ctx, "ghost").Return(nil, nil)
+		mockPoolRepo.On("GetByName", ctx, "oncall").Return(&domain.ReviewerPool{Name: "oncall"}, nil)
+		return NewPoolService(mockPoolRepo, new(MockUserRepository), mockTeamRepo, logger), mockPoolRepo
+	}
+
+	t.Run("success", func(t *testing.T) {
+		service, mockPoolRepo := setup()
+		steps := []domain.FallbackStep{{TeamName: "platform"}, {PoolName: "oncall"}}
+		mockPoolRepo.On("SetFallback", ctx, int64(1), steps).Return(nil)
+
+		chain, err := service.SetFallback(ctx, &domain.FallbackChain{TeamName: "mobile", Steps: steps})
+		assert.NoError(t, err)
+		assert.Equal(t, steps, chain.Steps)
+		mockPoolRepo.AssertExpectations(t)
+	})
+
+	tests := []struct {
+		name  string
+		steps []domain.FallbackStep
+		code  apperror.ErrorCode
+	}{
+		{name: "step names both team and pool", steps: []domain.FallbackStep{{TeamName: "platform", PoolName: "oncall"}}, code: apperror.ErrCodeInvalidInput},
+		{name: "empty step", steps: []domain.FallbackStep{{}}, code: apperror.ErrCodeInvalidInput},
+		{name: "team falls back to itself", steps: []domain.FallbackStep{{TeamName: "mobile"}}, code: apperror.ErrCodeInvalidInput},
+		{name: "repeated step", steps: []domain.FallbackStep{{PoolName: "oncall"}, {PoolName: "oncall"}}, code: apperror.ErrCodeInvalidInput},
+		{name: "unknown team", steps: []domain.FallbackStep{{TeamName: "ghost"}}, code: apperror.ErrCodeTeamNotFound},
+	}
+	for _, tt := range tests {
+		t.Run("error - "+tt.name, func(t *testing.T) {
+			service, mockPoolRepo := setup()
+
+			_, err := service.SetFallback(ctx, &domain.FallbackChain{TeamName: "mobile", Steps: tt.steps})
+			assert.True(t, apperror.Is(err, tt.code), "got %v", err)
+			mockPoolRepo.AssertNotCalled(t, "SetFallback", mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
@@ -0,0 +1,69 @@
+package service
+
+import (
+	"context"
+
+	"github.com/ssokov/pr-reviewer-service/internal/apperror"
+	"github.com/ssokov/pr-reviewer-service/internal/model/domain"
+)
+
+// atCapacity returns the users among users who already review as many open PRs as they may. A user's limit is their
+// own max_open_reviews, else the one of their team, inherited like reviewer_count, else the organization's; 0 means
+// no limit. Open reviews are only counted for users with a limit.
+func (s *prService) atCapacity(ctx context.Context, users []domain.User) (map[string]bool, error) {
+	limits := make(map[string]int)
+	teamLimits := make(map[int64]int)
+	var limited []string
+	for _, user := range users {
+		var limit int
+		if user.MaxOpenReviews != nil {
+			limit = *user.MaxOpenReviews
+		} else if teamLimit, ok := teamLimits[user.TeamID]; ok {
+			limit = teamLimit
+		} else {
+			ancestors, err := s.teamAncestors(ctx, user.TeamID)
+			if err != nil {
+				return nil, err
+			}
+			limit, _ = resolveMaxOpenReviews(ctx, ancestors)
+			teamLimits[user.TeamID] = limit
+		}
+
+		if limit > 0 {
+			limits[user.UserID] = limit
+			limited = append(limited, user.UserID)
+		}
+	}
+
+	full := make(map[string]bool)
+	if len(limited) == 0 {
+		return full, nil
+	}
+
+	counts, err := s.prRepo.GetOpenReviewCounts(ctx, limited)
+	if err != nil {
+		return nil, apperror.NewInternalError("failed to count open reviews", err)
+	}
+	for _, userID := range limited {
+		if counts[userID] >= limits[userID] {
+			full[userID] = true
+		}
+	}
+	return full, nil
+}
+
+// withCapacity drops the users at capacity from users.
+func (s *prService) withCapacity(ctx context.Context, users []domain.User) ([]domain.User, error) {
+	full, err := s.atCapacity(ctx, users)
+	if err != nil || len(full) == 0 {
+		return users, err
+	}
+
+	available := make([]domain.User, 0, len(users))
+	for _, user := range users {
+		if !full[user.UserID] {
+			available = append(available, user)
+		}
+	}
+	return available, nil
+}
@@ -0,0 +1,172 @@
+package service
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"github.com/ssokov/pr-reviewer-service/internal/apperror"
+	"github.com/ssokov/pr-reviewer-service/internal/codeowners"
+	"github.com/ssokov/pr-reviewer-service/internal/model/domain"
+)
+
+// ownedFile is a changed file matched by a CODEOWNERS rule with owners, together with the owners able to review it.
+// hasOwners reports whether the owners resolve to any reviewer besides the author, available or not.
+type ownedFile struct {
+	path       string
+	rule       *codeowners.Rule
+	candidates []string
+	hasOwners  bool
+}
+
+// resolvedOwner is a CODEOWNERS owner resolved to the users able to review now and whether it names any reviewer
+// besides the author at all.
+type resolvedOwner struct {
+	candidates []string
+	known      bool
+}
+
+// codeOwnedFiles matches the changed files against the CODEOWNERS file of the PR's repository. Files without a
+// rule, or whose last matching rule has no owners, are unowned and left out. It returns no files when the
+// repository has no CODEOWNERS file.
+func (s *prService) codeOwnedFiles(ctx context.Context, author *domain.User, pr *domain.PullRequest) ([]ownedFile, domain.CodeOwnersMode, error) {
+	if pr.Repository == "" || len(pr.ChangedFiles) == 0 {
+		return nil, "", nil
+	}
+
+	file, err := s.codeOwnersRepo.GetByRepository(ctx, pr.Repository)
+	if err != nil {
+		return nil, "", apperror.NewInternalError("failed to get CODEOWNERS", err)
+	}
+	if file == nil {
+		return nil, "", nil
+	}
+
+	rules, err := codeowners.Parse(strings.NewReader(file.Content))
+	if err != nil {
+		return nil, "", apperror.NewInternalError("failed to parse CODEOWNERS", err)
+	}
+
+	resolved := make(map[string]resolvedOwner)
+	var files []ownedFile
+	for _, path := range pr.ChangedFiles {
+		rule := rules.Match(path)
+		if rule == nil || len(rule.Owners) == 0 {
+			continue
+		}
+
+		f := ownedFile{path: path, rule: rule}
+		for _, owner := range rule.Owners {
+			r, ok := resolved[owner.Raw]
+			if !ok {
+				if r, err = s.resolveOwner(ctx, owner, author.UserID); err != nil {
+					return nil, "", err
+				}
+				resolved[owner.Raw] = r
+			}
+			f.hasOwners = f.hasOwners || r.known
+			for _, userID := range r.candidates {
+				if !slices.Contains(f.candidates, userID) {
+					f.candidates = append(f.candidates, userID)
+				}
+			}
+		}
+		files = append(files, f)
+	}
+
+	return files, file.Mode, nil
+}
+
+// resolveOwner returns the users behind an owner that can review and have capacity left, without the author. @user and email owners are matched
+// against user_id, @org/team owners against team names; unknown owners resolve to nobody.
+func (s *prService) resolveOwner(ctx context.Context, owner codeowners.Owner, authorID string) (resolvedOwner, error) {
+	var users []domain.User
+	if owner.Kind == codeowners.OwnerTeam {
+		team, err := s.teamRepo.GetByName(ctx, owner.Name)
+		if err != nil {
+			return resolvedOwner{}, apperror.NewInternalError("failed to get owner team", err)
+		}
+		if team != nil {
+			users = team.Members
+		}
+	} else {
+		user, err := s.userRepo.GetByUserID(ctx, owner.Name)
+		if err != nil {
+			return resolvedOwner{}, apperror.NewInternalError("failed to get owner", err)
+		}
+		if user != nil {
+			users = []domain.User{*user}
+		}
+	}
+
+	var r resolvedOwner
+	var eligible []domain.User
+	for _, user := range users {
+		if user.UserID == authorID || user.TeamRole == domain.TeamRoleTrainee {
+			continue
+		}
+		r.known = true
+		if user.IsActive {
+			eligible = append(eligible, user)
+		}
+	}
+	eligible, err := s.withCapacity(ctx, eligible)
+	if err != nil {
+		return resolvedOwner{}, err
+	}
+	r.candidates = userIDs(eligible)
+	return r, nil
+}
+
+// pickCodeOwners covers the owned files greedily: each round takes the candidate owning the most uncovered files,
+// the earliest listed one on ties. It stops at limit unless coverAll is set, and a zero limit covers every file.
+// Each selection points at the rule of the first file the reviewer covers.
+func pickCodeOwners(files []ownedFile, limit int, coverAll bool) []domain.ReviewerSelection {
+	covered := make([]bool, len(files))
+	var selections []domain.ReviewerSelection
+
+	for coverAll || limit <= 0 || len(selections) < limit {
+		var (
+			order     []string
+			counts    = make(map[string]int)
+			firstFile = make(map[string]int)
+		)
+		for i, f := range files {
+			if covered[i] {
+				continue
+			}
+			for _, userID := range f.candidates {
+				if _, ok := counts[userID]; !ok {
+					order = append(order, userID)
+					firstFile[userID] = i
+				}
+				counts[userID]++
+			}
+		}
+
+		best := ""
+		for _, userID := range order {
+			if best == "" || counts[userID] > counts[best] {
+				best = userID
+			}
+		}
+		if best == "" {
+			break
+		}
+
+		rule := files[firstFile[best]].rule
+		selections = append(selections, domain.ReviewerSelection{
+			UserID:  best,
+			Rule:    domain.SelectionRuleCodeOwners,
+			Pattern: rule.Pattern,
+			Line:    rule.Line,
+		})
+		for i, f := range files {
+			if slices.Contains(f.candidates, best) {
+				covered[i] = true
+			}
+		}
+	}
+
+	return selections
+}
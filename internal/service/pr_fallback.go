@@ -0,0 +1,169 @@
+package service
+
+import (
+	"context"
+	"maps"
+	"slices"
+
+	"github.com/ssokov/pr-reviewer-service/internal/apperror"
+	"github.com/ssokov/pr-reviewer-service/internal/model/domain"
+)
+
+// teamAncestors returns the team followed by its parent teams up to the root, or nil for users without a team.
+func (s *prService) teamAncestors(ctx context.Context, teamID int64) ([]domain.Team, error) {
+	if teamID == 0 {
+		return nil, nil
+	}
+
+	ancestors, err := s.teamRepo.GetAncestors(ctx, teamID)
+	if err != nil {
+		return nil, apperror.NewInternalError("failed to get parent teams", err)
+	}
+	return ancestors, nil
+}
+
+// fallbackReviewers walks the fallback chain of a team, inherited from the nearest parent team that has one, and
+// picks up to want reviewers that can review, have capacity left and are not in exclude. ancestors is the team followed by its parent teams.
+// Steps are used in order, so a later step only contributes when the earlier ones are exhausted; within a step,
+// candidates within their working hours come first. Chains are not transitive: the fallback chains of the teams in
+// a chain are not followed.
+func (s *prService) fallbackReviewers(ctx context.Context, ancestors []domain.Team, exclude []string, want int) ([]domain.ReviewerSelection, error) {
+	if len(ancestors) == 0 || want <= 0 {
+		return nil, nil
+	}
+
+	steps, from, err := resolveFallback(ctx, s.poolRepo, ancestors)
+	if err != nil {
+		return nil, err
+	}
+
+	var selections []domain.ReviewerSelection
+	for _, step := range steps {
+		candidates, err := s.fallbackCandidates(ctx, step)
+		if err != nil {
+			return nil, err
+		}
+		for _, candidate := range candidates {
+			if len(selections) >= want {
+				return selections, nil
+			}
+			if !candidate.CanReview() || slices.Contains(exclude, candidate.UserID) || isSelected(selections, candidate.UserID) {
+				continue
+			}
+			selections = append(selections, domain.ReviewerSelection{
+				UserID: candidate.UserID,
+				Rule:   domain.SelectionRuleFallback,
+				Team:   step.TeamName,
+				Pool:   step.PoolName,
+			})
+		}
+	}
+
+	if len(selections) > 0 {
+		s.logger.Print(ctx, "reviewers taken from fallback chain", "team_name", ancestors[0].TeamName, "chain_of", from, "count", len(selections))
+	}
+	return selections, nil
+}
+
+// fallbackSkills covers labels the author's team has nobody for from the fallback chain: each label gets the first
+// candidate in chain order who has the skill, can review and is not in exclude. A label nobody in the chain covers
+// fails with NO_CANDIDATE, so the PR can wait in the queue for such a reviewer.
+func (s *prService) fallbackSkills(
+	ctx context.Context,
+	ancestors []domain.Team,
+	match *skillMatch,
+	labels []string,
+	selections []domain.ReviewerSelection,
+	exclude []string,
+) ([]domain.ReviewerSelection, error) {
+	var steps []domain.FallbackStep
+	if len(ancestors) > 0 {
+		var err error
+		if steps, _, err = resolveFallback(ctx, s.poolRepo, ancestors); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, step := range steps {
+		if len(labels) == 0 {
+			break
+		}
+		candidates, err := s.fallbackCandidates(ctx, step)
+		if err != nil {
+			return nil, err
+		}
+		candidates = slices.DeleteFunc(slices.Clone(candidates), func(u domain.User) bool {
+			return !u.CanReview() || slices.Contains(exclude, u.UserID) || isSelected(selections, u.UserID)
+		})
+		if len(candidates) == 0 {
+			continue
+		}
+		skills, err := s.skillRepo.GetSkillsByUserIDs(ctx, userIDs(candidates))
+		if err != nil {
+			return nil, apperror.NewInternalError("failed to get reviewer skills", err)
+		}
+		if match.skills == nil {
+			match.skills = make(map[string][]string, len(skills))
+		}
+		maps.Copy(match.skills, skills)
+
+		labels = slices.DeleteFunc(labels, func(label string) bool {
+			idx := slices.IndexFunc(candidates, func(u domain.User) bool {
+				return match.has(u.UserID, label) && !isSelected(selections, u.UserID)
+			})
+			if idx < 0 {
+				return false
+			}
+			selections = append(selections, domain.ReviewerSelection{
+				UserID: candidates[idx].UserID,
+				Rule:   domain.SelectionRuleSkill,
+				Skill:  label,
+				Team:   step.TeamName,
+				Pool:   step.PoolName,
+			})
+			return true
+		})
+	}
+
+	if len(labels) > 0 {
+		return nil, apperror.NewNoSkilledReviewerError(labels[0])
+	}
+	return selections, nil
+}
+
+// fallbackCandidates returns the members of a fallback step that have capacity left, those within their working
+// hours first.
+func (s *prService) fallbackCandidates(ctx context.Context, step domain.FallbackStep) ([]domain.User, error) {
+	members, err := s.fallbackMembers(ctx, step)
+	if err != nil {
+		return nil, err
+	}
+	if members, err = s.withCapacity(ctx, members); err != nil {
+		return nil, err
+	}
+	tiers := s.availability(ctx, members)
+	slices.SortStableFunc(members, func(a, b domain.User) int { return tiers[a.UserID] - tiers[b.UserID] })
+	return members, nil
+}
+
+func (s *prService) fallbackMembers(ctx context.Context, step domain.FallbackStep) ([]domain.User, error) {
+	if step.PoolName != "" {
+		pool, err := s.poolRepo.GetByName(ctx, step.PoolName)
+		if err != nil {
+			return nil, apperror.NewInternalError("failed to get reviewer pool", err)
+		}
+		if pool == nil {
+			return nil, nil
+		}
+		return pool.Members, nil
+	}
+
+	team, err := s.teamRepo.GetByName(ctx, step.TeamName)
+	if err != nil {
+		return nil, apperror.NewInternalError("failed to get fallback team", err)
+	}
+	if team == nil {
+		return nil, nil
+	}
+	return team.Members, nil
+}
@@ -0,0 +1,135 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/ssokov/pr-reviewer-service/internal/apperror"
+	"github.com/ssokov/pr-reviewer-service/internal/model/domain"
+	"github.com/ssokov/pr-reviewer-service/internal/tracing"
+)
+
+// openPR loads a PR whose reviewers may still change.
+func (s *prService) openPR(ctx context.Context, prID string) (*domain.PullRequest, error) {
+	pr, err := s.prRepo.GetByPRID(ctx, prID)
+	if err != nil {
+		tracing.Logger(ctx, s.logger).Errorf("failed to get PR: %v", err)
+		return nil, apperror.NewInternalError("failed to get PR", err)
+	}
+	if pr == nil {
+		s.logger.Print(ctx, "PR not found", "pr_id", prID)
+		return nil, apperror.NewPRNotFoundError(prID)
+	}
+	if pr.Status == domain.PRStatusMerged {
+		s.logger.Print(ctx, "cannot change reviewers of merged PR", "pr_id", prID)
+		return nil, apperror.NewPRMergedError(prID)
+	}
+	return pr, nil
+}
+
+// checkReviewer reports why user cannot review pr: authors never review their own PRs, and inactive users and
+// trainees cannot be assigned.
+func checkReviewer(pr *domain.PullRequest, user *domain.User) error {
+	switch {
+	case user.UserID == pr.AuthorID:
+		return apperror.NewInvalidInputError("the author cannot review their own pull request")
+	case !user.IsActive:
+		return apperror.NewInvalidInputError(fmt.Sprintf("user '%s' is inactive", user.UserID))
+	case !user.CanReview():
+		return apperror.NewInvalidInputError(fmt.Sprintf("user '%s' is a trainee and can only shadow reviews", user.UserID))
+	}
+	return nil
+}
+
+// AddReviewer assigns a reviewer picked by hand, on top of the automatic selection and regardless of capacity
+// limits. With pin the reviewer is kept through reassignment and deprovisioning; pinning an assigned reviewer only
+// sets the pin. A pending PR stops waiting once it has a reviewer.
+func (s *prService) AddReviewer(ctx context.Context, prID, userID string, pin bool) (*domain.PullRequest, error) {
+	if prID == "" {
+		return nil, apperror.NewInvalidInputError("pull_request_id is required")
+	}
+	if userID == "" {
+		return nil, apperror.NewInvalidInputError("user_id is required")
+	}
+
+	s.logger.Print(ctx, "adding reviewer", "pr_id", prID, "user_id", userID, "pin", pin)
+
+	pr, err := s.openPR(ctx, prID)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.userRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		tracing.Logger(ctx, s.logger).Errorf("failed to get user: %v", err)
+		return nil, apperror.NewInternalError("failed to get user", err)
+	}
+	if user == nil {
+		s.logger.Print(ctx, "user not found", "user_id", userID)
+		return nil, apperror.NewUserNotFoundError(userID)
+	}
+	if err := checkReviewer(pr, user); err != nil {
+		s.logger.Print(ctx, "user cannot review PR", "pr_id", prID, "user_id", userID, "error", err)
+		return nil, err
+	}
+
+	assigned := slices.Contains(pr.AssignedReviewers, userID)
+	pinned := slices.Contains(pr.PinnedReviewers, userID)
+	if assigned && (pinned || !pin) {
+		s.logger.Print(ctx, "reviewer already assigned", "pr_id", prID, "user_id", userID)
+		return pr, nil
+	}
+
+	if !assigned {
+		pr.AssignedReviewers = append(pr.AssignedReviewers, userID)
+	}
+	if pin {
+		pr.PinnedReviewers = append(pr.PinnedReviewers, userID)
+	}
+	pr.PendingReviewers = false
+
+	updatedPR, err := s.prRepo.Update(ctx, pr)
+	if err != nil {
+		tracing.Logger(ctx, s.logger).Errorf("failed to update PR: %v", err)
+		return nil, apperror.NewInternalError("failed to update PR", err)
+	}
+
+	s.logger.Print(ctx, "reviewer added", "pr_id", prID, "user_id", userID, "pin", pin)
+	return updatedPR, nil
+}
+
+// RemoveReviewer unassigns a reviewer, pinned or not, without picking a replacement.
+func (s *prService) RemoveReviewer(ctx context.Context, prID, userID string) (*domain.PullRequest, error) {
+	if prID == "" {
+		return nil, apperror.NewInvalidInputError("pull_request_id is required")
+	}
+	if userID == "" {
+		return nil, apperror.NewInvalidInputError("user_id is required")
+	}
+
+	s.logger.Print(ctx, "removing reviewer", "pr_id", prID, "user_id", userID)
+
+	pr, err := s.openPR(ctx, prID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !slices.Contains(pr.AssignedReviewers, userID) {
+		s.logger.Print(ctx, "user not assigned to PR", "pr_id", prID, "user_id", userID)
+		return nil, apperror.NewNotAssignedError(userID, prID)
+	}
+
+	isUser := func(reviewerID string) bool { return reviewerID == userID }
+	pr.AssignedReviewers = slices.DeleteFunc(pr.AssignedReviewers, isUser)
+	pr.PinnedReviewers = slices.DeleteFunc(pr.PinnedReviewers, isUser)
+
+	updatedPR, err := s.prRepo.Update(ctx, pr)
+	if err != nil {
+		tracing.Logger(ctx, s.logger).Errorf("failed to update PR: %v", err)
+		return nil, apperror.NewInternalError("failed to update PR", err)
+	}
+
+	s.logger.Print(ctx, "reviewer removed", "pr_id", prID, "user_id", userID)
+	return updatedPR, nil
+}
@@ -0,0 +1,22 @@
+package service
+
+import (
+	"context"
+
+	"github.com/ssokov/pr-reviewer-service/internal/apperror"
+	"github.com/ssokov/pr-reviewer-service/internal/tenant"
+)
+
+// pairCounts returns how many of the author's PRs each reviewer was assigned to within the organization's pairing
+// window. Candidates are sorted by it, fewest first, before skill ranking, which sorts stably on top; among equally
+// skilled candidates the ones who paired with the author least often come first. The order only decides who is
+// picked when there are more candidates than slots: with a reviewer count of 0, the default, every teammate is
+// assigned, and it only steers reassignment.
+func (s *prService) pairCounts(ctx context.Context, authorID string) (map[string]int, error) {
+	since := s.now().Add(-tenant.Settings(ctx).PairingWindow())
+	counts, err := s.prRepo.GetPairCounts(ctx, authorID, since)
+	if err != nil {
+		return nil, apperror.NewInternalError("failed to get reviewer pairings", err)
+	}
+	return counts, nil
+}
@@ -0,0 +1,65 @@
+package service
+
+import (
+	"context"
+
+	"github.com/ssokov/pr-reviewer-service/internal/apperror"
+	"github.com/ssokov/pr-reviewer-service/internal/model/domain"
+	"github.com/ssokov/pr-reviewer-service/internal/tracing"
+)
+
+// AssignPendingReviewers retries reviewer selection for an open PR that was created while nobody could review it.
+// Selection works as in CreatePR except for CODEOWNERS, since the changed files are not stored. While selection
+// finds nobody, for whatever reason, the PR is returned unchanged and still pending; merged PRs and PRs that have
+// reviewers are left alone.
+func (s *prService) AssignPendingReviewers(ctx context.Context, prID string) (*domain.PullRequest, error) {
+	if prID == "" {
+		return nil, apperror.NewInvalidInputError("pull_request_id is required")
+	}
+
+	pr, err := s.prRepo.GetByPRID(ctx, prID)
+	if err != nil {
+		tracing.Logger(ctx, s.logger).Errorf("failed to get PR: %v", err)
+		return nil, apperror.NewInternalError("failed to get PR", err)
+	}
+	if pr == nil {
+		return nil, apperror.NewPRNotFoundError(prID)
+	}
+	if pr.Status != domain.PRStatusOpen || !pr.PendingReviewers {
+		return pr, nil
+	}
+
+	author, err := s.userRepo.GetByUserID(ctx, pr.AuthorID)
+	if err != nil {
+		tracing.Logger(ctx, s.logger).Errorf("failed to get author: %v", err)
+		return nil, apperror.NewInternalError("failed to get author", err)
+	}
+	if author == nil {
+		return nil, apperror.NewUserNotFoundError(pr.AuthorID)
+	}
+
+	// Any reason selection rejects the PR, like a missing senior, may go away as the team changes; only internal
+	// errors fail the retry.
+	selections, err := s.selectReviewers(ctx, author, pr)
+	if err != nil && apperror.CodeOf(err) != apperror.ErrCodeInternalError {
+		s.logger.Print(ctx, "PR still waiting for reviewers", "pr_id", prID, "reason", err.Error())
+		return pr, nil
+	}
+	if err != nil {
+		tracing.Logger(ctx, s.logger).Errorf("failed to assign reviewers: %v", err)
+		return nil, err
+	}
+
+	pr.AssignedReviewers = selectedUserIDs(selections)
+	pr.PendingReviewers = false
+
+	updated, err := s.prRepo.Update(ctx, pr)
+	if err != nil {
+		tracing.Logger(ctx, s.logger).Errorf("failed to update PR: %v", err)
+		return nil, apperror.NewInternalError("failed to update PR", err)
+	}
+	updated.Selections = selections
+
+	s.logger.Print(ctx, "pending PR assigned", "pr_id", prID, "reviewers_count", len(updated.AssignedReviewers))
+	return updated, nil
+}
@@ -0,0 +1,119 @@
+package service
+
+import (
+	"context"
+	"slices"
+
+	"github.com/ssokov/pr-reviewer-service/internal/apperror"
+	"github.com/ssokov/pr-reviewer-service/internal/model/domain"
+)
+
+// teamCandidates returns the active teammates of user, split into those who can review and trainees. Teammates at
+// capacity are left out. When no teammate is active or all of them are at capacity, the error is NO_CANDIDATE;
+// a user without a team is INVALID_INPUT.
+func (s *prService) teamCandidates(ctx context.Context, user *domain.User) (reviewers, trainees []domain.User, err error) {
+	if user.TeamID == 0 {
+		return nil, nil, apperror.NewInvalidInputError("user has no team")
+	}
+
+	teamMembers, err := s.userRepo.GetByTeamID(ctx, user.TeamID)
+	if err != nil {
+		return nil, nil, apperror.NewInternalError("failed to get team members", err)
+	}
+
+	for _, member := range teamMembers {
+		if member.UserID == user.UserID || !member.IsActive {
+			continue
+		}
+		if member.TeamRole == domain.TeamRoleTrainee {
+			trainees = append(trainees, member)
+		} else {
+			reviewers = append(reviewers, member)
+		}
+	}
+
+	if len(reviewers) == 0 {
+		return nil, trainees, apperror.NewNoCandidateError(user.TeamName)
+	}
+
+	active := len(reviewers)
+	if reviewers, err = s.withCapacity(ctx, reviewers); err != nil {
+		return nil, nil, err
+	}
+	if len(reviewers) == 0 {
+		s.logger.Print(ctx, "all reviewers at capacity", "team_id", user.TeamID, "reviewers", active)
+		return nil, trainees, apperror.NewAtCapacityError(user.TeamName, active)
+	}
+	return reviewers, trainees, nil
+}
+
+// seniorIDs returns the user IDs of the leads and seniors among users.
+func seniorIDs(users []domain.User) []string {
+	var ids []string
+	for _, user := range users {
+		if user.TeamRole.IsSenior() {
+			ids = append(ids, user.UserID)
+		}
+	}
+	return ids
+}
+
+// coverSenior makes sure a lead or senior from seniors is among selections, adding the best ranked one when
+// none is. Seniority is only known for the author's teammates, so reviewers from elsewhere do not count.
+func coverSenior(selections []domain.ReviewerSelection, seniors []string, match *skillMatch) ([]domain.ReviewerSelection, error) {
+	if slices.ContainsFunc(selections, func(sel domain.ReviewerSelection) bool { return slices.Contains(seniors, sel.UserID) }) {
+		return selections, nil
+	}
+	if len(seniors) == 0 {
+		return nil, apperror.NewInvalidInputError("no active senior or lead reviewer in team")
+	}
+	return append(selections, domain.ReviewerSelection{UserID: match.rank(seniors)[0], Rule: domain.SelectionRuleSenior}), nil
+}
+
+// seniorReplacements narrows the candidates replacing a lead or senior reviewer to leads and seniors when the
+// require_senior policy applies to the reviewer's team and nobody else on the PR is a lead or senior.
+func (s *prService) seniorReplacements(ctx context.Context, pr *domain.PullRequest, oldUser *domain.User, members []domain.User, candidates []string) ([]string, error) {
+	ancestors, err := s.teamAncestors(ctx, oldUser.TeamID)
+	if err != nil {
+		return nil, err
+	}
+	if required, _ := resolveRequireSenior(ctx, ancestors); !required {
+		return candidates, nil
+	}
+
+	seniors := seniorIDs(members)
+	for _, reviewerID := range pr.AssignedReviewers {
+		if reviewerID != oldUser.UserID && slices.Contains(seniors, reviewerID) {
+			return candidates, nil
+		}
+	}
+
+	var replacements []string
+	for _, candidate := range candidates {
+		if slices.Contains(seniors, candidate) {
+			replacements = append(replacements, candidate)
+		}
+	}
+	if len(replacements) == 0 {
+		s.logger.Print(ctx, "no senior replacement", "pr_id", pr.PullRequestID, "old_user_id", oldUser.UserID)
+		return nil, apperror.NewInvalidInputError("no active senior or lead reviewer in team")
+	}
+	return replacements, nil
+}
+
+// shadowReviewers picks the trainee that shadows a new PR: the one shadowing the fewest open PRs, so trainees take
+// turns. Shadow reviewers follow the review but do not count toward approvals.
+func (s *prService) shadowReviewers(ctx context.Context, trainees []domain.User) ([]string, error) {
+	if len(trainees) == 0 {
+		return nil, nil
+	}
+	if len(trainees) > 1 {
+		counts, err := s.prRepo.GetOpenShadowCounts(ctx, userIDs(trainees))
+		if err != nil {
+			return nil, apperror.NewInternalError("failed to get open shadow reviews", err)
+		}
+		trainees = slices.Clone(trainees)
+		slices.SortStableFunc(trainees, func(a, b domain.User) int { return counts[a.UserID] - counts[b.UserID] })
+	}
+	return []string{trainees[0].UserID}, nil
+}
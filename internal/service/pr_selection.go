@@ -0,0 +1,116 @@
+package service
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/ssokov/pr-reviewer-service/internal/apperror"
+	"github.com/ssokov/pr-reviewer-service/internal/model/domain"
+)
+
+// selectReviewers picks the reviewers of a new PR in this order:
+//  1. code owners of the changed files;
+//  2. teammates covering the skills the PR labels ask for, then the fallback chain for skills nobody in the team has;
+//  3. a lead or senior teammate when require_senior applies;
+//  4. the rest of the author's team, best skill match first, up to the reviewer count;
+//  5. the team's fallback chain for the slots still open.
+//
+// Required code owners, skill and senior picks may exceed the reviewer count. Teammates are ordered by working hours,
+// then by how rarely they reviewed the author. The reviewer count, require_senior and the fallback chain are inherited
+// from the nearest team that sets them. Trainees are never selected; one of them shadows the PR instead.
+func (s *prService) selectReviewers(ctx context.Context, author *domain.User, pr *domain.PullRequest) ([]domain.ReviewerSelection, error) {
+	ancestors, err := s.teamAncestors(ctx, author.TeamID)
+	if err != nil {
+		return nil, err
+	}
+	limit, _ := resolveReviewerCount(ctx, ancestors)
+	requireSenior, _ := resolveRequireSenior(ctx, ancestors)
+
+	files, mode, err := s.codeOwnedFiles(ctx, author, pr)
+	if err != nil {
+		return nil, err
+	}
+
+	required := mode == domain.CodeOwnersRequired
+	if required {
+		for _, f := range files {
+			if len(f.candidates) > 0 {
+				continue
+			}
+			// Owners who are inactive or at capacity may free up later; a rule naming nobody will not.
+			if f.hasOwners {
+				return nil, apperror.New(apperror.ErrCodeNoCandidate, fmt.Sprintf("no code owner of %s can review right now (CODEOWNERS line %d)", f.path, f.rule.Line))
+			}
+			return nil, apperror.NewInvalidInputError(fmt.Sprintf("no code owner configured for %s (CODEOWNERS line %d)", f.path, f.rule.Line))
+		}
+	}
+
+	selections := pickCodeOwners(files, limit, required)
+
+	// Owners are enough when the author's team has nobody to add.
+	members, trainees, teamErr := s.teamCandidates(ctx, author)
+	if teamErr != nil && !apperror.Is(teamErr, apperror.ErrCodeInvalidInput) && !apperror.Is(teamErr, apperror.ErrCodeNoCandidate) {
+		return nil, teamErr
+	}
+	if len(members) > 1 {
+		counts, err := s.pairCounts(ctx, author.UserID)
+		if err != nil {
+			return nil, err
+		}
+		tiers := s.availability(ctx, members)
+		slices.SortStableFunc(members, func(a, b domain.User) int {
+			return cmp.Or(tiers[a.UserID]-tiers[b.UserID], counts[a.UserID]-counts[b.UserID])
+		})
+	}
+	teammates := userIDs(members)
+
+	match, err := s.loadSkillMatch(ctx, pr.Labels, append(selectedUserIDs(selections), teammates...))
+	if err != nil {
+		return nil, err
+	}
+	selections, missing := match.coverSkills(selections, teammates)
+	if len(missing) > 0 {
+		if selections, err = s.fallbackSkills(ctx, ancestors, match, missing, selections, []string{author.UserID}); err != nil {
+			return nil, err
+		}
+	}
+	if requireSenior {
+		// Nobody in the team can review right now, so no senior can either.
+		if len(members) == 0 {
+			return nil, teamErr
+		}
+		if selections, err = coverSenior(selections, seniorIDs(members), match); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, userID := range match.rank(teammates) {
+		if limit > 0 && len(selections) >= limit {
+			break
+		}
+		if !isSelected(selections, userID) {
+			selections = append(selections, domain.ReviewerSelection{UserID: userID, Rule: domain.SelectionRuleTeam})
+		}
+	}
+
+	// Without a reviewer count the chain only steps in when nobody was found.
+	want := limit - len(selections)
+	if limit == 0 && len(selections) == 0 {
+		want = 1
+	}
+	fallback, err := s.fallbackReviewers(ctx, ancestors, append(selectedUserIDs(selections), author.UserID), want)
+	if err != nil {
+		return nil, err
+	}
+	selections = append(selections, fallback...)
+
+	if len(selections) == 0 {
+		return nil, teamErr
+	}
+	if pr.ShadowReviewers, err = s.shadowReviewers(ctx, trainees); err != nil {
+		return nil, err
+	}
+	return selections, nil
+}
@@ -1,28 +1,40 @@
 package service
 
 import (
+	"cmp"
 	"context"
+	"slices"
 	"time"
 
 	"github.com/ssokov/pr-reviewer-service/internal/apperror"
 	"github.com/ssokov/pr-reviewer-service/internal/model/domain"
 	"github.com/ssokov/pr-reviewer-service/internal/repository"
+	"github.com/ssokov/pr-reviewer-service/internal/tenant"
+	"github.com/ssokov/pr-reviewer-service/internal/tracing"
 	"github.com/vmkteam/embedlog"
 )
 
 type prService struct {
-	prRepo   repository.PRRepository
-	userRepo repository.UserRepository
-	teamRepo repository.TeamRepository
-	logger   embedlog.Logger
+	prRepo         repository.PRRepository
+	userRepo       repository.UserRepository
+	teamRepo       repository.TeamRepository
+	codeOwnersRepo repository.CodeOwnersRepository
+	skillRepo      repository.SkillRepository
+	poolRepo       repository.PoolRepository
+	logger         embedlog.Logger
+	now            func() time.Time
 }
 
-func NewPRService(prRepo repository.PRRepository, userRepo repository.UserRepository, teamRepo repository.TeamRepository, logger embedlog.Logger) PRService {
+func NewPRService(prRepo repository.PRRepository, userRepo repository.UserRepository, teamRepo repository.TeamRepository, codeOwnersRepo repository.CodeOwnersRepository, skillRepo repository.SkillRepository, poolRepo repository.PoolRepository, logger embedlog.Logger) PRService {
 	return &prService{
-		prRepo:   prRepo,
-		userRepo: userRepo,
-		teamRepo: teamRepo,
-		logger:   logger,
+		prRepo:         prRepo,
+		userRepo:       userRepo,
+		teamRepo:       teamRepo,
+		codeOwnersRepo: codeOwnersRepo,
+		skillRepo:      skillRepo,
+		poolRepo:       poolRepo,
+		logger:         logger,
+		now:            time.Now,
 	}
 }
 
@@ -36,12 +48,20 @@ func (s *prService) CreatePR(ctx context.Context, authorID string, pr *domain.Pu
 	if authorID == "" {
 		return nil, apperror.NewInvalidInputError("author_id is required")
 	}
+	if len(pr.ChangedFiles) > 0 && pr.Repository == "" {
+		return nil, apperror.NewInvalidInputError("repository is required with changed_files")
+	}
+	labels, err := normalizeTags("labels", pr.Labels)
+	if err != nil {
+		return nil, err
+	}
+	pr.Labels = labels
 
 	s.logger.Print(ctx, "creating PR", "pr_id", pr.PullRequestID, "author_id", authorID)
 
 	author, err := s.userRepo.GetByUserID(ctx, authorID)
 	if err != nil {
-		s.logger.Errorf("failed to get author: %v", err)
+		tracing.Logger(ctx, s.logger).Errorf("failed to get author: %v", err)
 		return nil, apperror.NewInternalError("failed to get author", err)
 	}
 	if author == nil {
@@ -54,20 +74,32 @@ func (s *prService) CreatePR(ctx context.Context, authorID string, pr *domain.Pu
 		return nil, apperror.NewInvalidInputError("author is not active")
 	}
 
-	reviewers, err := s.autoAssignReviewers(ctx, author)
-	if err != nil {
-		s.logger.Errorf("failed to assign reviewers: %v", err)
+	selections, err := s.selectReviewers(ctx, author, pr)
+	switch {
+	case apperror.Is(err, apperror.ErrCodeNoCandidate) && tenant.Settings(ctx).AtCapacity == domain.AtCapacityQueue:
+		// Nobody is active or everyone is at capacity; the organization opted to let the PR wait for the assignment
+		// worker instead of failing.
+		s.logger.Print(ctx, "PR queued for reviewers", "pr_id", pr.PullRequestID, "reason", err.Error())
+		pr.PendingReviewers = true
+	case err != nil:
+		tracing.Logger(ctx, s.logger).Errorf("failed to assign reviewers: %v", err)
 		return nil, err
 	}
 
+	reviewers := make([]string, 0, len(selections))
+	for _, selection := range selections {
+		reviewers = append(reviewers, selection.UserID)
+	}
+
 	pr.AssignedReviewers = reviewers
 	pr.Status = domain.PRStatusOpen
 
 	createdPR, err := s.prRepo.Create(ctx, pr)
 	if err != nil {
-		s.logger.Errorf("failed to create PR: %v", err)
+		tracing.Logger(ctx, s.logger).Errorf("failed to create PR: %v", err)
 		return nil, apperror.NewInternalError("failed to create PR", err)
 	}
+	createdPR.Selections = selections
 
 	s.logger.Print(ctx, "PR created successfully", "pr_id", createdPR.PullRequestID, "reviewers_count", len(reviewers))
 	return createdPR, nil
@@ -82,7 +114,7 @@ func (s *prService) MergePR(ctx context.Context, prID string) (*domain.PullReque
 
 	pr, err := s.prRepo.GetByPRID(ctx, prID)
 	if err != nil {
-		s.logger.Errorf("failed to get PR: %v", err)
+		tracing.Logger(ctx, s.logger).Errorf("failed to get PR: %v", err)
 		return nil, apperror.NewInternalError("failed to get PR", err)
 	}
 	if pr == nil {
@@ -95,13 +127,13 @@ func (s *prService) MergePR(ctx context.Context, prID string) (*domain.PullReque
 		return pr, nil
 	}
 
-	now := time.Now()
+	now := s.now()
 	pr.Status = domain.PRStatusMerged
 	pr.MergedAt = &now
 
 	updatedPR, err := s.prRepo.Update(ctx, pr)
 	if err != nil {
-		s.logger.Errorf("failed to merge PR: %v", err)
+		tracing.Logger(ctx, s.logger).Errorf("failed to merge PR: %v", err)
 		return nil, apperror.NewInternalError("failed to merge PR", err)
 	}
 
@@ -119,36 +151,23 @@ func (s *prService) ReassignReviewer(ctx context.Context, prID string, oldUserID
 
 	s.logger.Print(ctx, "reassigning reviewer", "pr_id", prID, "old_user_id", oldUserID)
 
-	pr, err := s.prRepo.GetByPRID(ctx, prID)
+	pr, err := s.openPR(ctx, prID)
 	if err != nil {
-		s.logger.Errorf("failed to get PR: %v", err)
-		return nil, "", apperror.NewInternalError("failed to get PR", err)
-	}
-	if pr == nil {
-		s.logger.Print(ctx, "PR not found", "pr_id", prID)
-		return nil, "", apperror.NewPRNotFoundError(prID)
-	}
-
-	if pr.Status == domain.PRStatusMerged {
-		s.logger.Print(ctx, "cannot reassign on merged PR", "pr_id", prID)
-		return nil, "", apperror.NewPRMergedError(prID)
+		return nil, "", err
 	}
 
-	isAssigned := false
-	for _, reviewerID := range pr.AssignedReviewers {
-		if reviewerID == oldUserID {
-			isAssigned = true
-			break
-		}
-	}
-	if !isAssigned {
+	if !slices.Contains(pr.AssignedReviewers, oldUserID) {
 		s.logger.Print(ctx, "user not assigned to PR", "pr_id", prID, "user_id", oldUserID)
-		return nil, "", apperror.NewNotAssignedError(prID, oldUserID)
+		return nil, "", apperror.NewNotAssignedError(oldUserID, prID)
+	}
+	if slices.Contains(pr.PinnedReviewers, oldUserID) {
+		s.logger.Print(ctx, "cannot reassign pinned reviewer", "pr_id", prID, "user_id", oldUserID)
+		return nil, "", apperror.NewPinnedError(oldUserID, prID)
 	}
 
 	oldUser, err := s.userRepo.GetByUserID(ctx, oldUserID)
 	if err != nil {
-		s.logger.Errorf("failed to get old user: %v", err)
+		tracing.Logger(ctx, s.logger).Errorf("failed to get old user: %v", err)
 		return nil, "", apperror.NewInternalError("failed to get old user", err)
 	}
 	if oldUser == nil {
@@ -156,18 +175,57 @@ func (s *prService) ReassignReviewer(ctx context.Context, prID string, oldUserID
 		return nil, "", apperror.NewUserNotFoundError(oldUserID)
 	}
 
-	newReviewers, err := s.autoAssignReviewers(ctx, oldUser)
+	members, _, err := s.teamCandidates(ctx, oldUser)
+	candidates := slices.DeleteFunc(slices.Clone(members), func(member domain.User) bool {
+		return checkReviewer(pr, &member) != nil || slices.Contains(pr.AssignedReviewers, member.UserID)
+	})
+	if err == nil && len(candidates) == 0 {
+		err = apperror.NewNoCandidateError(oldUser.TeamName)
+	}
+	newReviewers := userIDs(candidates)
+	if err != nil && (apperror.Is(err, apperror.ErrCodeInvalidInput) || apperror.Is(err, apperror.ErrCodeNoCandidate)) {
+		// Nobody is left in the old reviewer's team; its fallback chain may still have someone.
+		ancestors, fallbackErr := s.teamAncestors(ctx, oldUser.TeamID)
+		if fallbackErr != nil {
+			return nil, "", fallbackErr
+		}
+		fallback, fallbackErr := s.fallbackReviewers(ctx, ancestors, append(slices.Clone(pr.AssignedReviewers), pr.AuthorID), 1)
+		if fallbackErr != nil {
+			return nil, "", fallbackErr
+		}
+		if len(fallback) > 0 {
+			newReviewers, err = selectedUserIDs(fallback), nil
+		}
+	}
 	if err != nil {
-		s.logger.Errorf("failed to assign new reviewer: %v", err)
+		tracing.Logger(ctx, s.logger).Errorf("failed to assign new reviewer: %v", err)
 		return nil, "", err
 	}
 
+	if oldUser.TeamRole.IsSenior() {
+		if newReviewers, err = s.seniorReplacements(ctx, pr, oldUser, members, newReviewers); err != nil {
+			return nil, "", err
+		}
+	}
+
 	if len(newReviewers) == 0 {
 		s.logger.Print(ctx, "no available reviewers", "team_id", oldUser.TeamID)
 		return nil, "", apperror.NewInvalidInputError("no available reviewers in team")
 	}
 
-	newReviewerID := newReviewers[0]
+	if len(newReviewers) > 1 {
+		counts, err := s.pairCounts(ctx, pr.AuthorID)
+		if err != nil {
+			return nil, "", err
+		}
+		tiers := s.availability(ctx, members)
+		slices.SortStableFunc(newReviewers, func(a, b string) int { return cmp.Or(tiers[a]-tiers[b], counts[a]-counts[b]) })
+	}
+
+	newReviewerID, err := s.pickReplacement(ctx, pr, oldUserID, newReviewers)
+	if err != nil {
+		return nil, "", err
+	}
 
 	updatedReviewers := make([]string, 0, len(pr.AssignedReviewers))
 	for _, reviewer := range pr.AssignedReviewers {
@@ -180,7 +238,7 @@ func (s *prService) ReassignReviewer(ctx context.Context, prID string, oldUserID
 
 	updatedPR, err := s.prRepo.Update(ctx, pr)
 	if err != nil {
-		s.logger.Errorf("failed to update PR: %v", err)
+		tracing.Logger(ctx, s.logger).Errorf("failed to update PR: %v", err)
 		return nil, "", apperror.NewInternalError("failed to update PR", err)
 	}
 
@@ -188,26 +246,55 @@ func (s *prService) ReassignReviewer(ctx context.Context, prID string, oldUserID
 	return updatedPR, newReviewerID, nil
 }
 
-func (s *prService) autoAssignReviewers(ctx context.Context, user *domain.User) ([]string, error) {
-	if user.TeamID == 0 {
-		return nil, apperror.NewInvalidInputError("user has no team")
+// pickReplacement prefers a candidate with the skills the PR labels ask for and the leaving reviewer covered,
+// then the best overall skill match. Without labels it is the first candidate.
+func (s *prService) pickReplacement(ctx context.Context, pr *domain.PullRequest, oldUserID string, candidates []string) (string, error) {
+	remaining := make([]string, 0, len(pr.AssignedReviewers))
+	for _, reviewerID := range pr.AssignedReviewers {
+		if reviewerID != oldUserID {
+			remaining = append(remaining, reviewerID)
+		}
 	}
 
-	teamMembers, err := s.userRepo.GetByTeamID(ctx, user.TeamID)
+	match, err := s.loadSkillMatch(ctx, pr.Labels, append(remaining, candidates...))
 	if err != nil {
-		return nil, apperror.NewInternalError("failed to get team members", err)
+		return "", err
 	}
 
-	var activeReviewers []string
-	for _, member := range teamMembers {
-		if member.UserID != user.UserID && member.IsActive {
-			activeReviewers = append(activeReviewers, member.UserID)
+	ranked := match.rank(candidates)
+	for _, label := range match.uncovered(remaining) {
+		if idx := slices.IndexFunc(ranked, func(userID string) bool { return match.has(userID, label) }); idx >= 0 {
+			return ranked[idx], nil
 		}
+		s.logger.Print(ctx, "no replacement with required skill", "pr_id", pr.PullRequestID, "skill", label)
+	}
+	return ranked[0], nil
+}
+
+const (
+	defaultPRListLimit = 100
+	maxPRListLimit     = 1000
+)
+
+func (s *prService) ListPRs(ctx context.Context, filter domain.PRFilter) ([]domain.PullRequest, error) {
+	switch filter.Status {
+	case "", domain.PRStatusOpen, domain.PRStatusMerged:
+	default:
+		return nil, apperror.NewInvalidInputError("status must be OPEN or MERGED")
 	}
 
-	if len(activeReviewers) == 0 {
-		return nil, apperror.NewInvalidInputError("no active reviewers in team")
+	switch {
+	case filter.Limit < 0:
+		return nil, apperror.NewInvalidInputError("limit must not be negative")
+	case filter.Limit == 0:
+		filter.Limit = defaultPRListLimit
+	case filter.Limit > maxPRListLimit:
+		filter.Limit = maxPRListLimit
 	}
 
-	return activeReviewers, nil
+	prs, err := s.prRepo.List(ctx, filter)
+	if err != nil {
+		return nil, apperror.NewInternalError("failed to list PRs", err)
+	}
+	return prs, nil
 }
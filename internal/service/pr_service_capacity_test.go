@@ -0,0 +1,199 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ssokov/pr-reviewer-service/internal/apperror"
+	"github.com/ssokov/pr-reviewer-service/internal/model/domain"
+	"github.com/ssokov/pr-reviewer-service/internal/tenant"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/vmkteam/embedlog"
+)
+
+func TestPRService_CreatePR_Capacity(t *testing.T) {
+	logger := embedlog.NewLogger(false, false)
+	author := &domain.User{UserID: "author", TeamID: 1, TeamName: "backend", IsActive: true}
+	unlimited := 0
+
+	setup := func(settings domain.OrganizationSettings, team domain.Team, counts map[string]int) (context.Context, PRService, *MockPRRepository) {
+		ctx := tenant.WithOrganization(context.Background(), &domain.Organization{ID: 1, Settings: settings})
+		mockPRRepo := new(MockPRRepository)
+		mockUserRepo := new(MockUserRepository)
+		mockTeamRepo := new(MockTeamRepository)
+		mockPoolRepo := new(MockPoolRepository)
+
+		mockUserRepo.On("GetByUserID", ctx, "author").Return(author, nil)
+		mockUserRepo.On("GetByTeamID", ctx, int64(1)).Return([]domain.User{
+			*author,
+			{UserID: "busy", TeamID: 1, IsActive: true},
+			{UserID: "free", TeamID: 1, IsActive: true},
+			{UserID: "no-limit", TeamID: 1, IsActive: true, MaxOpenReviews: &unlimited},
+		}, nil)
+		mockTeamRepo.On("GetAncestors", ctx, int64(1)).Return([]domain.Team{team}, nil)
+		mockPoolRepo.On("GetFallback", ctx, int64(1)).Return(nil, nil)
+		mockPRRepo.On("GetOpenReviewCounts", ctx, []string{"busy", "free"}).Return(counts, nil)
+		mockPRRepo.On("GetPairCounts", ctx, "author", mock.Anything).Return(map[string]int{}, nil)
+		stored := &domain.PullRequest{}
+		mockPRRepo.On("Create", ctx, mock.Anything).Run(func(args mock.Arguments) {
+			*stored = *args.Get(1).(*domain.PullRequest)
+		}).Return(stored, nil)
+
+		return ctx, NewPRService(mockPRRepo, mockUserRepo, mockTeamRepo, new(MockCodeOwnersRepository), new(MockSkillRepository), mockPoolRepo, logger), mockPRRepo
+	}
+
+	t.Run("skips reviewers at capacity", func(t *testing.T) {
+		ctx, service, _ := setup(domain.OrganizationSettings{MaxOpenReviews: 2}, domain.Team{ID: 1}, map[string]int{"busy": 2, "free": 1})
+
+		pr, err := service.CreatePR(ctx, "author", &domain.PullRequest{PullRequestID: "pr1", PullRequestName: "Change"})
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []string{"free", "no-limit"}, pr.AssignedReviewers)
+		assert.False(t, pr.PendingReviewers)
+	})
+
+	t.Run("team default overrides the organization", func(t *testing.T) {
+		teamLimit := 5
+		ctx, service, _ := setup(domain.OrganizationSettings{MaxOpenReviews: 2}, domain.Team{ID: 1, Settings: domain.TeamSettings{MaxOpenReviews: &teamLimit}},
+			map[string]int{"busy": 2, "free": 1})
+
+		pr, err := service.CreatePR(ctx, "author", &domain.PullRequest{PullRequestID: "pr1", PullRequestName: "Change"})
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []string{"busy", "free", "no-limit"}, pr.AssignedReviewers)
+	})
+
+	t.Run("error - everyone at capacity", func(t *testing.T) {
+		ctx := tenant.WithOrganization(context.Background(), &domain.Organization{ID: 1, Settings: domain.OrganizationSettings{MaxOpenReviews: 1}})
+		mockPRRepo := new(MockPRRepository)
+		mockUserRepo := new(MockUserRepository)
+		mockTeamRepo := new(MockTeamRepository)
+		mockPoolRepo := new(MockPoolRepository)
+		service := NewPRService(mockPRRepo, mockUserRepo, mockTeamRepo, new(MockCodeOwnersRepository), new(MockSkillRepository), mockPoolRepo, logger)
+
+		mockUserRepo.On("GetByUserID", ctx, "author").Return(author, nil)
+		mockUserRepo.On("GetByTeamID", ctx, int64(1)).Return([]domain.User{*author, {UserID: "busy", TeamID: 1, IsActive: true}}, nil)
+		mockTeamRepo.On("GetAncestors", ctx, int64(1)).Return([]domain.Team{{ID: 1}}, nil)
+		mockPoolRepo.On("GetFallback", ctx, int64(1)).Return(nil, nil)
+		mockPRRepo.On("GetOpenReviewCounts", ctx, []string{"busy"}).Return(map[string]int{"busy": 1}, nil)
+
+		_, err := service.CreatePR(ctx, "author", &domain.PullRequest{PullRequestID: "pr1", PullRequestName: "Change"})
+		require.Error(t, err)
+		assert.True(t, apperror.Is(err, apperror.ErrCodeNoCandidate))
+		assert.Contains(t, err.Error(), "max_open_reviews")
+		mockPRRepo.AssertNotCalled(t, "Create", ctx, mock.Anything)
+	})
+
+	t.Run("queues the PR when the organization opts in", func(t *testing.T) {
+		ctx := tenant.WithOrganization(context.Background(), &domain.Organization{ID: 1, Settings: domain.OrganizationSettings{
+			MaxOpenReviews: 1,
+			AtCapacity:     domain.AtCapacityQueue,
+		}})
+		mockPRRepo := new(MockPRRepository)
+		mockUserRepo := new(MockUserRepository)
+		mockTeamRepo := new(MockTeamRepository)
+		mockPoolRepo := new(MockPoolRepository)
+		service := NewPRService(mockPRRepo, mockUserRepo, mockTeamRepo, new(MockCodeOwnersRepository), new(MockSkillRepository), mockPoolRepo, logger)
+
+		mockUserRepo.On("GetByUserID", ctx, "author").Return(author, nil)
+		mockUserRepo.On("GetByTeamID", ctx, int64(1)).Return([]domain.User{*author, {UserID: "busy", TeamID: 1, IsActive: true}}, nil)
+		mockTeamRepo.On("GetAncestors", ctx, int64(1)).Return([]domain.Team{{ID: 1}}, nil)
+		mockPoolRepo.On("GetFallback", ctx, int64(1)).Return(nil, nil)
+		mockPRRepo.On("GetOpenReviewCounts", ctx, []string{"busy"}).Return(map[string]int{"busy": 1}, nil)
+		stored := &domain.PullRequest{}
+		mockPRRepo.On("Create", ctx, mock.Anything).Run(func(args mock.Arguments) {
+			*stored = *args.Get(1).(*domain.PullRequest)
+		}).Return(stored, nil)
+
+		pr, err := service.CreatePR(ctx, "author", &domain.PullRequest{PullRequestID: "pr1", PullRequestName: "Change"})
+		require.NoError(t, err)
+		assert.True(t, pr.PendingReviewers)
+		assert.Empty(t, pr.AssignedReviewers)
+		assert.Equal(t, domain.PRStatusOpen, pr.Status)
+	})
+
+	t.Run("queues the PR when require_senior applies and the team is at capacity", func(t *testing.T) {
+		ctx := tenant.WithOrganization(context.Background(), &domain.Organization{ID: 1, Settings: domain.OrganizationSettings{
+			MaxOpenReviews: 1,
+			RequireSenior:  true,
+			AtCapacity:     domain.AtCapacityQueue,
+		}})
+		mockPRRepo := new(MockPRRepository)
+		mockUserRepo := new(MockUserRepository)
+		mockTeamRepo := new(MockTeamRepository)
+		mockPoolRepo := new(MockPoolRepository)
+		service := NewPRService(mockPRRepo, mockUserRepo, mockTeamRepo, new(MockCodeOwnersRepository), new(MockSkillRepository), mockPoolRepo, logger)
+
+		mockUserRepo.On("GetByUserID", ctx, "author").Return(author, nil)
+		mockUserRepo.On("GetByTeamID", ctx, int64(1)).Return([]domain.User{
+			*author,
+			{UserID: "senior", TeamID: 1, TeamRole: domain.TeamRoleSenior, IsActive: true},
+			{UserID: "member", TeamID: 1, IsActive: true},
+		}, nil)
+		mockTeamRepo.On("GetAncestors", ctx, int64(1)).Return([]domain.Team{{ID: 1}}, nil)
+		mockPoolRepo.On("GetFallback", ctx, int64(1)).Return(nil, nil)
+		mockPRRepo.On("GetOpenReviewCounts", ctx, []string{"senior", "member"}).Return(map[string]int{"senior": 1, "member": 1}, nil)
+		stored := &domain.PullRequest{}
+		mockPRRepo.On("Create", ctx, mock.Anything).Run(func(args mock.Arguments) {
+			*stored = *args.Get(1).(*domain.PullRequest)
+		}).Return(stored, nil)
+
+		pr, err := service.CreatePR(ctx, "author", &domain.PullRequest{PullRequestID: "pr1", PullRequestName: "Change"})
+		require.NoError(t, err)
+		assert.True(t, pr.PendingReviewers)
+		assert.Empty(t, pr.AssignedReviewers)
+	})
+}
+
+func TestPRService_ReassignReviewer_Capacity(t *testing.T) {
+	logger := embedlog.NewLogger(false, false)
+	ctx := tenant.WithOrganization(context.Background(), &domain.Organization{ID: 1, Settings: domain.OrganizationSettings{MaxOpenReviews: 3}})
+
+	setup := func(counts map[string]int) (PRService, *MockPRRepository) {
+		mockPRRepo := new(MockPRRepository)
+		mockUserRepo := new(MockUserRepository)
+		mockTeamRepo := new(MockTeamRepository)
+		mockPoolRepo := new(MockPoolRepository)
+		service := NewPRService(mockPRRepo, mockUserRepo, mockTeamRepo, new(MockCodeOwnersRepository), new(MockSkillRepository), mockPoolRepo, logger)
+
+		mockPRRepo.On("GetByPRID", ctx, "pr1").Return(&domain.PullRequest{
+			PullRequestID:     "pr1",
+			AuthorID:          "author",
+			Status:            domain.PRStatusOpen,
+			AssignedReviewers: []string{"old"},
+		}, nil)
+		old := &domain.User{UserID: "old", TeamID: 1, IsActive: true}
+		mockUserRepo.On("GetByUserID", ctx, "old").Return(old, nil)
+		mockUserRepo.On("GetByTeamID", ctx, int64(1)).Return([]domain.User{
+			*old,
+			{UserID: "busy", TeamID: 1, IsActive: true},
+			{UserID: "free", TeamID: 1, IsActive: true},
+		}, nil)
+		mockTeamRepo.On("GetAncestors", ctx, int64(1)).Return([]domain.Team{{ID: 1}}, nil)
+		mockPoolRepo.On("GetFallback", ctx, int64(1)).Return(nil, nil)
+		mockPRRepo.On("GetOpenReviewCounts", ctx, []string{"busy", "free"}).Return(counts, nil)
+		mockPRRepo.On("GetPairCounts", ctx, "author", mock.Anything).Return(map[string]int{}, nil)
+		stored := &domain.PullRequest{}
+		mockPRRepo.On("Update", ctx, mock.Anything).Run(func(args mock.Arguments) {
+			*stored = *args.Get(1).(*domain.PullRequest)
+		}).Return(stored, nil)
+		return service, mockPRRepo
+	}
+
+	t.Run("skips reviewers at capacity", func(t *testing.T) {
+		service, _ := setup(map[string]int{"busy": 3})
+
+		_, newReviewer, err := service.ReassignReviewer(ctx, "pr1", "old")
+		require.NoError(t, err)
+		assert.Equal(t, "free", newReviewer)
+	})
+
+	t.Run("error - everyone at capacity", func(t *testing.T) {
+		service, mockPRRepo := setup(map[string]int{"busy": 3, "free": 4})
+
+		_, _, err := service.ReassignReviewer(ctx, "pr1", "old")
+		require.Error(t, err)
+		assert.True(t, apperror.Is(err, apperror.ErrCodeNoCandidate))
+		mockPRRepo.AssertNotCalled(t, "Update", ctx, mock.Anything)
+	})
+}
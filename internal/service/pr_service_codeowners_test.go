@@ -0,0 +1,190 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ssokov/pr-reviewer-service/internal/apperror"
+	"github.com/ssokov/pr-reviewer-service/internal/codeowners"
+	"github.com/ssokov/pr-reviewer-service/internal/model/domain"
+	"github.com/ssokov/pr-reviewer-service/internal/tenant"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/vmkteam/embedlog"
+)
+
+const testCodeOwners = `*.md @docs-writer
+/internal/ @acme/platform
+/internal/billing/ @billing-owner
+/vendor/
+`
+
+func TestPRService_CreatePR_CodeOwners(t *testing.T) {
+	logger := embedlog.NewLogger(false, false)
+	author := &domain.User{UserID: "author", IsActive: true, TeamID: 1}
+	teammates := []domain.User{
+		{UserID: "author", IsActive: true},
+		{UserID: "mate1", IsActive: true},
+		{UserID: "mate2", IsActive: true},
+	}
+	platform := &domain.Team{TeamName: "platform", Members: []domain.User{
+		{UserID: "author", IsActive: true},
+		{UserID: "plat1", IsActive: true},
+		{UserID: "plat2", IsActive: false},
+	}}
+
+	setup := func(ctx context.Context, mode domain.CodeOwnersMode) (PRService, *MockPRRepository, *MockUserRepository) {
+		mockPRRepo := new(MockPRRepository)
+		mockUserRepo := new(MockUserRepository)
+		mockTeamRepo := new(MockTeamRepository)
+		mockCodeOwnersRepo := new(MockCodeOwnersRepository)
+
+		mockUserRepo.On("GetByUserID", ctx, "author").Return(author, nil)
+		mockUserRepo.On("GetByUserID", ctx, "billing-owner").Return(&domain.User{UserID: "billing-owner", IsActive: true, TeamID: 3}, nil)
+		mockUserRepo.On("GetByUserID", ctx, "docs-writer").Return(nil, nil)
+		mockUserRepo.On("GetByTeamID", ctx, int64(1)).Return(teammates, nil)
+		mockTeamRepo.On("GetByName", ctx, "platform").Return(platform, nil)
+		mockTeamRepo.On("GetAncestors", ctx, int64(1)).Return([]domain.Team{{ID: 1}}, nil)
+		mockTeamRepo.On("GetAncestors", ctx, int64(3)).Return([]domain.Team{{ID: 3}}, nil)
+		mockCodeOwnersRepo.On("GetByRepository", ctx, "acme/api").Return(&domain.CodeOwners{
+			Repository: "acme/api",
+			Mode:       mode,
+			Content:    testCodeOwners,
+		}, nil)
+		created := &domain.PullRequest{}
+		mockPRRepo.On("GetPairCounts", ctx, mock.Anything, mock.Anything).Return(map[string]int{}, nil)
+		mockPRRepo.On("Create", ctx, mock.Anything).Run(func(args mock.Arguments) {
+			created.AssignedReviewers = args.Get(1).(*domain.PullRequest).AssignedReviewers
+		}).Return(created, nil)
+
+		return NewPRService(mockPRRepo, mockUserRepo, mockTeamRepo, mockCodeOwnersRepo, new(MockSkillRepository), new(MockPoolRepository), logger), mockPRRepo, mockUserRepo
+	}
+
+	withReviewerCount := func(n int) context.Context {
+		return tenant.WithOrganization(context.Background(), &domain.Organization{
+			ID:       1,
+			Settings: domain.OrganizationSettings{ReviewerCount: n},
+		})
+	}
+
+	newPR := func(files ...string) *domain.PullRequest {
+		return &domain.PullRequest{
+			PullRequestID:   "pr1",
+			PullRequestName: "Billing fix",
+			Repository:      "acme/api",
+			ChangedFiles:    files,
+		}
+	}
+
+	t.Run("preferred - owners first, team fills the rest", func(t *testing.T) {
+		ctx := withReviewerCount(2)
+		service, _, _ := setup(ctx, domain.CodeOwnersPreferred)
+
+		result, err := service.CreatePR(ctx, "author", newPR("internal/billing/invoice.go", "vendor/lib.go", "README.md"))
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"billing-owner", "mate1"}, result.AssignedReviewers)
+		assert.Equal(t, []domain.ReviewerSelection{
+			{UserID: "billing-owner", Rule: domain.SelectionRuleCodeOwners, Pattern: "/internal/billing/", Line: 3},
+			{UserID: "mate1", Rule: domain.SelectionRuleTeam},
+		}, result.Selections)
+	})
+
+	t.Run("preferred - owners are capped by the reviewer count", func(t *testing.T) {
+		ctx := withReviewerCount(1)
+		service, _, _ := setup(ctx, domain.CodeOwnersPreferred)
+
+		result, err := service.CreatePR(ctx, "author", newPR("internal/service/pr.go", "internal/billing/invoice.go"))
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"plat1"}, result.AssignedReviewers)
+		assert.Equal(t, "/internal/", result.Selections[0].Pattern)
+	})
+
+	t.Run("required - every owned file gets an owner beyond the reviewer count", func(t *testing.T) {
+		ctx := withReviewerCount(1)
+		service, _, _ := setup(ctx, domain.CodeOwnersRequired)
+
+		result, err := service.CreatePR(ctx, "author", newPR("internal/service/pr.go", "internal/billing/invoice.go"))
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"plat1", "billing-owner"}, result.AssignedReviewers)
+	})
+
+	t.Run("required - owned file without a known owner", func(t *testing.T) {
+		ctx := withReviewerCount(1)
+		service, mockPRRepo, _ := setup(ctx, domain.CodeOwnersRequired)
+
+		_, err := service.CreatePR(ctx, "author", newPR("docs/guide.md"))
+		assert.True(t, apperror.Is(err, apperror.ErrCodeInvalidInput))
+		assert.Contains(t, err.Error(), "docs/guide.md (CODEOWNERS line 1)")
+		mockPRRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("required - owners inactive or at capacity", func(t *testing.T) {
+		ctx := tenant.WithOrganization(context.Background(), &domain.Organization{
+			ID:       1,
+			Settings: domain.OrganizationSettings{ReviewerCount: 1, MaxOpenReviews: 1},
+		})
+		service, mockPRRepo, _ := setup(ctx, domain.CodeOwnersRequired)
+		mockPRRepo.On("GetOpenReviewCounts", ctx, []string{"plat1"}).Return(map[string]int{"plat1": 1}, nil)
+
+		_, err := service.CreatePR(ctx, "author", newPR("internal/service/pr.go"))
+		assert.True(t, apperror.Is(err, apperror.ErrCodeNoCandidate), "got %v", err)
+		assert.Contains(t, err.Error(), "no code owner of internal/service/pr.go can review right now (CODEOWNERS line 2)")
+		mockPRRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("no CODEOWNERS file falls back to the team", func(t *testing.T) {
+		ctx := withReviewerCount(1)
+		mockPRRepo := new(MockPRRepository)
+		mockUserRepo := new(MockUserRepository)
+		mockTeamRepo := new(MockTeamRepository)
+		mockCodeOwnersRepo := new(MockCodeOwnersRepository)
+		service := NewPRService(mockPRRepo, mockUserRepo, mockTeamRepo, mockCodeOwnersRepo, new(MockSkillRepository), new(MockPoolRepository), logger)
+
+		mockUserRepo.On("GetByUserID", ctx, "author").Return(author, nil)
+		mockUserRepo.On("GetByTeamID", ctx, int64(1)).Return(teammates, nil)
+		mockTeamRepo.On("GetAncestors", ctx, int64(1)).Return([]domain.Team{{ID: 1}}, nil)
+		mockCodeOwnersRepo.On("GetByRepository", ctx, "acme/web").Return(nil, nil)
+		mockPRRepo.On("GetPairCounts", ctx, mock.Anything, mock.Anything).Return(map[string]int{}, nil)
+		mockPRRepo.On("Create", ctx, mock.Anything).Return(&domain.PullRequest{PullRequestID: "pr1", AssignedReviewers: []string{"mate1"}}, nil)
+
+		pr := newPR("main.go")
+		pr.Repository = "acme/web"
+		result, err := service.CreatePR(ctx, "author", pr)
+		require.NoError(t, err)
+		assert.Equal(t, []domain.ReviewerSelection{{UserID: "mate1", Rule: domain.SelectionRuleTeam}}, result.Selections)
+	})
+
+	t.Run("changed files without repository", func(t *testing.T) {
+		service := NewPRService(new(MockPRRepository), new(MockUserRepository), new(MockTeamRepository), new(MockCodeOwnersRepository), new(MockSkillRepository), new(MockPoolRepository), logger)
+
+		pr := newPR("main.go")
+		pr.Repository = ""
+		_, err := service.CreatePR(context.Background(), "author", pr)
+		assert.True(t, apperror.Is(err, apperror.ErrCodeInvalidInput))
+	})
+}
+
+func TestPickCodeOwners(t *testing.T) {
+	files := []ownedFile{
+		{path: "a.go", rule: &codeowners.Rule{Line: 1, Pattern: "a.go"}, candidates: []string{"u1", "u2"}},
+		{path: "b.go", rule: &codeowners.Rule{Line: 2, Pattern: "b.go"}, candidates: []string{"u2"}},
+		{path: "c.go", rule: &codeowners.Rule{Line: 3, Pattern: "c.go"}, candidates: []string{"u3"}},
+	}
+
+	ids := func(selections []domain.ReviewerSelection) []string {
+		var result []string
+		for _, s := range selections {
+			result = append(result, s.UserID)
+		}
+		return result
+	}
+
+	assert.Equal(t, []string{"u2", "u3"}, ids(pickCodeOwners(files, 0, false)))
+	assert.Equal(t, []string{"u2"}, ids(pickCodeOwners(files, 1, false)))
+	assert.Equal(t, []string{"u2", "u3"}, ids(pickCodeOwners(files, 1, true)))
+	assert.Empty(t, pickCodeOwners(nil, 2, true))
+}
@@ -0,0 +1,155 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ssokov/pr-reviewer-service/internal/model/domain"
+	"github.com/ssokov/pr-reviewer-service/internal/tenant"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/vmkteam/embedlog"
+)
+
+func TestPRService_CreatePR_Fallback(t *testing.T) {
+	logger := embedlog.NewLogger(false, false)
+	author := &domain.User{UserID: "author", TeamID: 1, TeamName: "mobile", IsActive: true}
+	oncall := &domain.ReviewerPool{Name: "oncall", Members: []domain.User{
+		{UserID: "author", IsActive: true},
+		{UserID: "o1", IsActive: false},
+		{UserID: "o2", IsActive: true},
+		{UserID: "o3", IsActive: true},
+	}}
+	platform := &domain.Team{TeamName: "platform", Members: []domain.User{
+		{UserID: "p1", IsActive: true},
+		{UserID: "p2", IsActive: true},
+	}}
+	chain := []domain.FallbackStep{{TeamName: "platform"}, {PoolName: "oncall"}}
+
+	setup := func(ctx context.Context, teammates []domain.User) (PRService, *MockPoolRepository) {
+		mockPRRepo := new(MockPRRepository)
+		mockUserRepo := new(MockUserRepository)
+		mockTeamRepo := new(MockTeamRepository)
+		mockPoolRepo := new(MockPoolRepository)
+
+		mockUserRepo.On("GetByUserID", ctx, "author").Return(author, nil)
+		mockUserRepo.On("GetByTeamID", ctx, int64(1)).Return(teammates, nil)
+		mockPoolRepo.On("GetFallback", ctx, int64(1)).Return(chain, nil)
+		mockPoolRepo.On("GetByName", ctx, "oncall").Return(oncall, nil)
+		mockTeamRepo.On("GetByName", ctx, "platform").Return(platform, nil)
+		mockTeamRepo.On("GetAncestors", ctx, int64(1)).Return([]domain.Team{{ID: 1, TeamName: "mobile"}}, nil)
+		created := &domain.PullRequest{}
+		mockPRRepo.On("Create", ctx, mock.Anything).Run(func(args mock.Arguments) {
+			created.AssignedReviewers = args.Get(1).(*domain.PullRequest).AssignedReviewers
+		}).Return(created, nil)
+
+		return NewPRService(mockPRRepo, mockUserRepo, mockTeamRepo, new(MockCodeOwnersRepository), new(MockSkillRepository), mockPoolRepo, logger), mockPoolRepo
+	}
+
+	newPR := func() *domain.PullRequest {
+		return &domain.PullRequest{PullRequestID: "pr1", PullRequestName: "Change"}
+	}
+
+	t.Run("chain is walked until the reviewer count is met", func(t *testing.T) {
+		ctx := tenant.WithOrganization(context.Background(), &domain.Organization{
+			ID:       1,
+			Settings: domain.OrganizationSettings{ReviewerCount: 4},
+		})
+		service, _ := setup(ctx, []domain.User{*author, {UserID: "m1", IsActive: true}})
+
+		result, err := service.CreatePR(ctx, "author", newPR())
+		require.NoError(t, err)
+		assert.Equal(t, []string{"m1", "p1", "p2", "o2"}, result.AssignedReviewers)
+		assert.Equal(t, []domain.ReviewerSelection{
+			{UserID: "m1", Rule: domain.SelectionRuleTeam},
+			{UserID: "p1", Rule: domain.SelectionRuleFallback, Team: "platform"},
+			{UserID: "p2", Rule: domain.SelectionRuleFallback, Team: "platform"},
+			{UserID: "o2", Rule: domain.SelectionRuleFallback, Pool: "oncall"},
+		}, result.Selections)
+	})
+
+	t.Run("full team does not use the chain", func(t *testing.T) {
+		ctx := tenant.WithOrganization(context.Background(), &domain.Organization{
+			ID:       1,
+			Settings: domain.OrganizationSettings{ReviewerCount: 1},
+		})
+		service, mockPoolRepo := setup(ctx, []domain.User{*author, {UserID: "m1", IsActive: true}})
+
+		result, err := service.CreatePR(ctx, "author", newPR())
+		require.NoError(t, err)
+		assert.Equal(t, []string{"m1"}, result.AssignedReviewers)
+		mockPoolRepo.AssertNotCalled(t, "GetFallback", mock.Anything, mock.Anything)
+	})
+
+	t.Run("empty team without reviewer count takes one fallback reviewer", func(t *testing.T) {
+		ctx := context.Background()
+		service, _ := setup(ctx, []domain.User{*author, {UserID: "m1", IsActive: false}})
+
+		result, err := service.CreatePR(ctx, "author", newPR())
+		require.NoError(t, err)
+		assert.Equal(t, []string{"p1"}, result.AssignedReviewers)
+	})
+}
+
+func TestPRService_ReassignReviewer_Fallback(t *testing.T) {
+	ctx := context.Background()
+	logger := embedlog.NewLogger(false, false)
+
+	mockPRRepo := new(MockPRRepository)
+	mockUserRepo := new(MockUserRepository)
+	mockTeamRepo := new(MockTeamRepository)
+	mockPoolRepo := new(MockPoolRepository)
+	service := NewPRService(mockPRRepo, mockUserRepo, mockTeamRepo, new(MockCodeOwnersRepository), new(MockSkillRepository), mockPoolRepo, logger)
+
+	pr := &domain.PullRequest{PullRequestID: "pr1", AuthorID: "author", Status: domain.PRStatusOpen, AssignedReviewers: []string{"m1", "o2"}}
+	mockPRRepo.On("GetByPRID", ctx, "pr1").Return(pr, nil)
+	mockUserRepo.On("GetByUserID", ctx, "m1").Return(&domain.User{UserID: "m1", TeamID: 1, IsActive: true}, nil)
+	mockUserRepo.On("GetByTeamID", ctx, int64(1)).Return([]domain.User{{UserID: "m1", IsActive: true}}, nil)
+	mockTeamRepo.On("GetAncestors", ctx, int64(1)).Return([]domain.Team{{ID: 1}}, nil)
+	mockPoolRepo.On("GetFallback", ctx, int64(1)).Return([]domain.FallbackStep{{PoolName: "oncall"}}, nil)
+	mockPoolRepo.On("GetByName", ctx, "oncall").Return(&domain.ReviewerPool{Name: "oncall", Members: []domain.User{
+		{UserID: "author", IsActive: true},
+		{UserID: "o2", IsActive: true},
+		{UserID: "o3", IsActive: true},
+	}}, nil)
+	mockPRRepo.On("Update", ctx, mock.Anything).Return(pr, nil)
+
+	_, newReviewer, err := service.ReassignReviewer(ctx, "pr1", "m1")
+	require.NoError(t, err)
+	assert.Equal(t, "o3", newReviewer)
+	assert.Equal(t, []string{"o2", "o3"}, pr.AssignedReviewers)
+}
+
+func TestPRService_CreatePR_InheritedSettings(t *testing.T) {
+	ctx := context.Background()
+	logger := embedlog.NewLogger(false, false)
+
+	mockPRRepo := new(MockPRRepository)
+	mockUserRepo := new(MockUserRepository)
+	mockTeamRepo := new(MockTeamRepository)
+	mockPoolRepo := new(MockPoolRepository)
+	service := NewPRService(mockPRRepo, mockUserRepo, mockTeamRepo, new(MockCodeOwnersRepository), new(MockSkillRepository), mockPoolRepo, logger)
+
+	two := 2
+	author := &domain.User{UserID: "author", TeamID: 4, TeamName: "payments", IsActive: true}
+	mockUserRepo.On("GetByUserID", ctx, "author").Return(author, nil)
+	mockUserRepo.On("GetByTeamID", ctx, int64(4)).Return([]domain.User{*author, {UserID: "m1", IsActive: true}}, nil)
+	mockTeamRepo.On("GetAncestors", ctx, int64(4)).Return([]domain.Team{
+		{ID: 4, TeamName: "payments"},
+		{ID: 3, TeamName: "engineering", Settings: domain.TeamSettings{ReviewerCount: &two}},
+	}, nil)
+	mockPoolRepo.On("GetFallback", ctx, int64(4)).Return(nil, nil)
+	mockPoolRepo.On("GetFallback", ctx, int64(3)).Return([]domain.FallbackStep{{PoolName: "oncall"}}, nil)
+	mockPoolRepo.On("GetByName", ctx, "oncall").Return(&domain.ReviewerPool{Name: "oncall", Members: []domain.User{
+		{UserID: "o1", IsActive: true},
+	}}, nil)
+	mockPRRepo.On("Create", ctx, mock.Anything).Return(&domain.PullRequest{}, nil)
+
+	result, err := service.CreatePR(ctx, "author", &domain.PullRequest{PullRequestID: "pr1", PullRequestName: "Change"})
+	require.NoError(t, err)
+	assert.Equal(t, []domain.ReviewerSelection{
+		{UserID: "m1", Rule: domain.SelectionRuleTeam},
+		{UserID: "o1", Rule: domain.SelectionRuleFallback, Pool: "oncall"},
+	}, result.Selections)
+}
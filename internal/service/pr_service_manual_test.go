@@ -0,0 +1,198 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ssokov/pr-reviewer-service/internal/apperror"
+	"github.com/ssokov/pr-reviewer-service/internal/model/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/vmkteam/embedlog"
+)
+
+func TestPRService_AddReviewer(t *testing.T) {
+	ctx := context.Background()
+	logger := embedlog.NewLogger(false, false)
+
+	setup := func(pr *domain.PullRequest) (PRService, *MockPRRepository, *domain.PullRequest) {
+		mockPRRepo := new(MockPRRepository)
+		mockUserRepo := new(MockUserRepository)
+		service := NewPRService(mockPRRepo, mockUserRepo, new(MockTeamRepository), new(MockCodeOwnersRepository), new(MockSkillRepository), new(MockPoolRepository), logger)
+
+		mockPRRepo.On("GetByPRID", ctx, "pr1").Return(pr, nil)
+		mockPRRepo.On("GetByPRID", ctx, "missing").Return(nil, nil)
+		mockUserRepo.On("GetByUserID", ctx, "author").Return(&domain.User{UserID: "author", IsActive: true}, nil)
+		mockUserRepo.On("GetByUserID", ctx, "u2").Return(&domain.User{UserID: "u2", IsActive: true}, nil)
+		mockUserRepo.On("GetByUserID", ctx, "u3").Return(&domain.User{UserID: "u3", IsActive: true}, nil)
+		mockUserRepo.On("GetByUserID", ctx, "away").Return(&domain.User{UserID: "away"}, nil)
+		mockUserRepo.On("GetByUserID", ctx, "trainee").Return(&domain.User{UserID: "trainee", IsActive: true, TeamRole: domain.TeamRoleTrainee}, nil)
+		mockUserRepo.On("GetByUserID", ctx, "ghost").Return(nil, nil)
+		stored := &domain.PullRequest{}
+		mockPRRepo.On("Update", ctx, mock.Anything).Run(func(args mock.Arguments) {
+			*stored = *args.Get(1).(*domain.PullRequest)
+		}).Return(stored, nil)
+		return service, mockPRRepo, stored
+	}
+	openPR := func() *domain.PullRequest {
+		return &domain.PullRequest{PullRequestID: "pr1", AuthorID: "author", Status: domain.PRStatusOpen, AssignedReviewers: []string{"u2"}}
+	}
+
+	t.Run("adds and pins a reviewer", func(t *testing.T) {
+		service, _, _ := setup(openPR())
+
+		pr, err := service.AddReviewer(ctx, "pr1", "u3", true)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"u2", "u3"}, pr.AssignedReviewers)
+		assert.Equal(t, []string{"u3"}, pr.PinnedReviewers)
+	})
+
+	t.Run("pins an assigned reviewer", func(t *testing.T) {
+		service, _, _ := setup(openPR())
+
+		pr, err := service.AddReviewer(ctx, "pr1", "u2", true)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"u2"}, pr.AssignedReviewers)
+		assert.Equal(t, []string{"u2"}, pr.PinnedReviewers)
+	})
+
+	t.Run("assigned reviewer without pin changes nothing", func(t *testing.T) {
+		service, mockPRRepo, _ := setup(openPR())
+
+		pr, err := service.AddReviewer(ctx, "pr1", "u2", false)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"u2"}, pr.AssignedReviewers)
+		mockPRRepo.AssertNotCalled(t, "Update", ctx, mock.Anything)
+	})
+
+	t.Run("pending PR stops waiting", func(t *testing.T) {
+		pending := openPR()
+		pending.AssignedReviewers = nil
+		pending.PendingReviewers = true
+		service, _, _ := setup(pending)
+
+		pr, err := service.AddReviewer(ctx, "pr1", "u3", false)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"u3"}, pr.AssignedReviewers)
+		assert.False(t, pr.PendingReviewers)
+		assert.Empty(t, pr.PinnedReviewers)
+	})
+
+	errorCases := []struct {
+		name   string
+		pr     *domain.PullRequest
+		prID   string
+		userID string
+		code   apperror.ErrorCode
+	}{
+		{name: "author cannot review", pr: openPR(), prID: "pr1", userID: "author", code: apperror.ErrCodeInvalidInput},
+		{name: "inactive user", pr: openPR(), prID: "pr1", userID: "away", code: apperror.ErrCodeInvalidInput},
+		{name: "trainee", pr: openPR(), prID: "pr1", userID: "trainee", code: apperror.ErrCodeInvalidInput},
+		{name: "unknown user", pr: openPR(), prID: "pr1", userID: "ghost", code: apperror.ErrCodeUserNotFound},
+		{name: "unknown PR", pr: openPR(), prID: "missing", userID: "u3", code: apperror.ErrCodePRNotFound},
+		{name: "merged PR", pr: &domain.PullRequest{PullRequestID: "pr1", AuthorID: "author", Status: domain.PRStatusMerged}, prID: "pr1", userID: "u3", code: apperror.ErrCodePRMerged},
+		{name: "missing user id", pr: openPR(), prID: "pr1", code: apperror.ErrCodeInvalidInput},
+	}
+	for _, tc := range errorCases {
+		t.Run("error - "+tc.name, func(t *testing.T) {
+			service, mockPRRepo, _ := setup(tc.pr)
+
+			_, err := service.AddReviewer(ctx, tc.prID, tc.userID, true)
+			require.Error(t, err)
+			assert.True(t, apperror.Is(err, tc.code), err.Error())
+			mockPRRepo.AssertNotCalled(t, "Update", ctx, mock.Anything)
+		})
+	}
+}
+
+func TestPRService_RemoveReviewer(t *testing.T) {
+	ctx := context.Background()
+	logger := embedlog.NewLogger(false, false)
+
+	setup := func(pr *domain.PullRequest) (PRService, *MockPRRepository) {
+		mockPRRepo := new(MockPRRepository)
+		service := NewPRService(mockPRRepo, new(MockUserRepository), new(MockTeamRepository), new(MockCodeOwnersRepository), new(MockSkillRepository), new(MockPoolRepository), logger)
+
+		mockPRRepo.On("GetByPRID", ctx, "pr1").Return(pr, nil)
+		stored := &domain.PullRequest{}
+		mockPRRepo.On("Update", ctx, mock.Anything).Run(func(args mock.Arguments) {
+			*stored = *args.Get(1).(*domain.PullRequest)
+		}).Return(stored, nil)
+		return service, mockPRRepo
+	}
+
+	t.Run("removes a pinned reviewer", func(t *testing.T) {
+		service, _ := setup(&domain.PullRequest{
+			PullRequestID: "pr1", Status: domain.PRStatusOpen, AssignedReviewers: []string{"u2", "u3"}, PinnedReviewers: []string{"u3"},
+		})
+
+		pr, err := service.RemoveReviewer(ctx, "pr1", "u3")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"u2"}, pr.AssignedReviewers)
+		assert.Empty(t, pr.PinnedReviewers)
+	})
+
+	t.Run("error - not assigned", func(t *testing.T) {
+		service, mockPRRepo := setup(&domain.PullRequest{PullRequestID: "pr1", Status: domain.PRStatusOpen, AssignedReviewers: []string{"u2"}})
+
+		_, err := service.RemoveReviewer(ctx, "pr1", "u3")
+		require.Error(t, err)
+		assert.True(t, apperror.Is(err, apperror.ErrCodeNotAssigned))
+		mockPRRepo.AssertNotCalled(t, "Update", ctx, mock.Anything)
+	})
+
+	t.Run("error - merged PR", func(t *testing.T) {
+		service, _ := setup(&domain.PullRequest{PullRequestID: "pr1", Status: domain.PRStatusMerged, AssignedReviewers: []string{"u2"}})
+
+		_, err := service.RemoveReviewer(ctx, "pr1", "u2")
+		require.Error(t, err)
+		assert.True(t, apperror.Is(err, apperror.ErrCodePRMerged))
+	})
+}
+
+func TestPRService_ReassignReviewer_Manual(t *testing.T) {
+	ctx := context.Background()
+	logger := embedlog.NewLogger(false, false)
+
+	t.Run("error - pinned reviewer", func(t *testing.T) {
+		mockPRRepo := new(MockPRRepository)
+		mockUserRepo := new(MockUserRepository)
+		service := NewPRService(mockPRRepo, mockUserRepo, new(MockTeamRepository), new(MockCodeOwnersRepository), new(MockSkillRepository), new(MockPoolRepository), logger)
+
+		mockPRRepo.On("GetByPRID", ctx, "pr1").Return(&domain.PullRequest{
+			PullRequestID: "pr1", Status: domain.PRStatusOpen, AssignedReviewers: []string{"u2"}, PinnedReviewers: []string{"u2"},
+		}, nil)
+
+		_, _, err := service.ReassignReviewer(ctx, "pr1", "u2")
+		require.Error(t, err)
+		assert.True(t, apperror.Is(err, apperror.ErrCodePinned))
+		mockUserRepo.AssertNotCalled(t, "GetByUserID", ctx, "u2")
+	})
+
+	t.Run("never picks the author or an assigned reviewer", func(t *testing.T) {
+		mockPRRepo := new(MockPRRepository)
+		mockUserRepo := new(MockUserRepository)
+		mockTeamRepo := new(MockTeamRepository)
+		mockPoolRepo := new(MockPoolRepository)
+		service := NewPRService(mockPRRepo, mockUserRepo, mockTeamRepo, new(MockCodeOwnersRepository), new(MockSkillRepository), mockPoolRepo, logger)
+
+		mockPRRepo.On("GetByPRID", ctx, "pr1").Return(&domain.PullRequest{
+			PullRequestID: "pr1", AuthorID: "author", Status: domain.PRStatusOpen, AssignedReviewers: []string{"u2", "u3"}, PinnedReviewers: []string{"u3"},
+		}, nil)
+		old := &domain.User{UserID: "u2", TeamID: 1, IsActive: true}
+		mockUserRepo.On("GetByUserID", ctx, "u2").Return(old, nil)
+		mockUserRepo.On("GetByTeamID", ctx, int64(1)).Return([]domain.User{
+			*old,
+			{UserID: "author", TeamID: 1, IsActive: true},
+			{UserID: "u3", TeamID: 1, IsActive: true},
+		}, nil)
+		mockTeamRepo.On("GetAncestors", ctx, int64(1)).Return([]domain.Team{{ID: 1}}, nil)
+		mockPoolRepo.On("GetFallback", ctx, int64(1)).Return(nil, nil)
+
+		_, _, err := service.ReassignReviewer(ctx, "pr1", "u2")
+		require.Error(t, err)
+		assert.True(t, apperror.Is(err, apperror.ErrCodeNoCandidate))
+		mockPRRepo.AssertNotCalled(t, "Update", ctx, mock.Anything)
+	})
+}
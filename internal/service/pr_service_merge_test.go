@@ -20,7 +20,9 @@ func TestPRService_MergePR(t *testing.T) {
 		mockPRRepo := new(MockPRRepository)
 		mockUserRepo := new(MockUserRepository)
 		mockTeamRepo := new(MockTeamRepository)
-		service := NewPRService(mockPRRepo, mockUserRepo, mockTeamRepo, logger)
+		service := NewPRService(mockPRRepo, mockUserRepo, mockTeamRepo, new(MockCodeOwnersRepository), new(MockSkillRepository), new(MockPoolRepository), logger).(*prService)
+		mergedAt := time.Date(2025, time.March, 7, 16, 0, 0, 0, time.UTC)
+		service.now = func() time.Time { return mergedAt }
 
 		existingPR := &domain.PullRequest{
 			ID:            1,
@@ -28,8 +30,11 @@ func TestPRService_MergePR(t *testing.T) {
 			Status:        domain.PRStatusOpen,
 		}
 
+		var saved *domain.PullRequest
 		mockPRRepo.On("GetByPRID", ctx, "pr-1").Return(existingPR, nil)
-		mockPRRepo.On("Update", ctx, mock.Anything).Return(&domain.PullRequest{
+		mockPRRepo.On("Update", ctx, mock.Anything).Run(func(args mock.Arguments) {
+			saved = args.Get(1).(*domain.PullRequest)
+		}).Return(&domain.PullRequest{
 			ID:            1,
 			PullRequestID: "pr-1",
 			Status:        domain.PRStatusMerged,
@@ -40,13 +45,14 @@ func TestPRService_MergePR(t *testing.T) {
 		assert.NoError(t, err)
 		assert.NotNil(t, result)
 		assert.Equal(t, domain.PRStatusMerged, result.Status)
+		assert.Equal(t, &mergedAt, saved.MergedAt)
 	})
 
 	t.Run("success - already merged (idempotent)", func(t *testing.T) {
 		mockPRRepo := new(MockPRRepository)
 		mockUserRepo := new(MockUserRepository)
 		mockTeamRepo := new(MockTeamRepository)
-		service := NewPRService(mockPRRepo, mockUserRepo, mockTeamRepo, logger)
+		service := NewPRService(mockPRRepo, mockUserRepo, mockTeamRepo, new(MockCodeOwnersRepository), new(MockSkillRepository), new(MockPoolRepository), logger)
 
 		now := time.Now()
 		existingPR := &domain.PullRequest{
@@ -69,7 +75,7 @@ func TestPRService_MergePR(t *testing.T) {
 		mockPRRepo := new(MockPRRepository)
 		mockUserRepo := new(MockUserRepository)
 		mockTeamRepo := new(MockTeamRepository)
-		service := NewPRService(mockPRRepo, mockUserRepo, mockTeamRepo, logger)
+		service := NewPRService(mockPRRepo, mockUserRepo, mockTeamRepo, new(MockCodeOwnersRepository), new(MockSkillRepository), new(MockPoolRepository), logger)
 
 		mockPRRepo.On("GetByPRID", ctx, "pr-unknown").Return((*domain.PullRequest)(nil), nil)
 
@@ -83,7 +89,7 @@ func TestPRService_MergePR(t *testing.T) {
 		mockPRRepo := new(MockPRRepository)
 		mockUserRepo := new(MockUserRepository)
 		mockTeamRepo := new(MockTeamRepository)
-		service := NewPRService(mockPRRepo, mockUserRepo, mockTeamRepo, logger)
+		service := NewPRService(mockPRRepo, mockUserRepo, mockTeamRepo, new(MockCodeOwnersRepository), new(MockSkillRepository), new(MockPoolRepository), logger)
 
 		result, err := service.MergePR(ctx, "")
 		assert.Error(t, err)
@@ -100,7 +106,7 @@ func TestPRService_ReassignReviewer(t *testing.T) {
 		mockPRRepo := new(MockPRRepository)
 		mockUserRepo := new(MockUserRepository)
 		mockTeamRepo := new(MockTeamRepository)
-		service := NewPRService(mockPRRepo, mockUserRepo, mockTeamRepo, logger)
+		service := NewPRService(mockPRRepo, mockUserRepo, mockTeamRepo, new(MockCodeOwnersRepository), new(MockSkillRepository), new(MockPoolRepository), logger)
 
 		existingPR := &domain.PullRequest{
 			ID:                1,
@@ -119,6 +125,7 @@ func TestPRService_ReassignReviewer(t *testing.T) {
 		mockPRRepo.On("GetByPRID", ctx, "pr-1").Return(existingPR, nil)
 		mockUserRepo.On("GetByUserID", ctx, "u2").Return(oldUser, nil)
 		mockUserRepo.On("GetByTeamID", ctx, int64(1)).Return(teamMembers, nil)
+		mockPRRepo.On("GetPairCounts", ctx, mock.Anything, mock.Anything).Return(map[string]int{}, nil)
 		mockPRRepo.On("Update", ctx, mock.Anything).Return(&domain.PullRequest{
 			ID:                1,
 			PullRequestID:     "pr-1",
@@ -136,7 +143,7 @@ func TestPRService_ReassignReviewer(t *testing.T) {
 		mockPRRepo := new(MockPRRepository)
 		mockUserRepo := new(MockUserRepository)
 		mockTeamRepo := new(MockTeamRepository)
-		service := NewPRService(mockPRRepo, mockUserRepo, mockTeamRepo, logger)
+		service := NewPRService(mockPRRepo, mockUserRepo, mockTeamRepo, new(MockCodeOwnersRepository), new(MockSkillRepository), new(MockPoolRepository), logger)
 
 		now := time.Now()
 		existingPR := &domain.PullRequest{
@@ -158,7 +165,7 @@ func TestPRService_ReassignReviewer(t *testing.T) {
 		mockPRRepo := new(MockPRRepository)
 		mockUserRepo := new(MockUserRepository)
 		mockTeamRepo := new(MockTeamRepository)
-		service := NewPRService(mockPRRepo, mockUserRepo, mockTeamRepo, logger)
+		service := NewPRService(mockPRRepo, mockUserRepo, mockTeamRepo, new(MockCodeOwnersRepository), new(MockSkillRepository), new(MockPoolRepository), logger)
 
 		existingPR := &domain.PullRequest{
 			ID:                1,
@@ -180,7 +187,7 @@ func TestPRService_ReassignReviewer(t *testing.T) {
 		mockPRRepo := new(MockPRRepository)
 		mockUserRepo := new(MockUserRepository)
 		mockTeamRepo := new(MockTeamRepository)
-		service := NewPRService(mockPRRepo, mockUserRepo, mockTeamRepo, logger)
+		service := NewPRService(mockPRRepo, mockUserRepo, mockTeamRepo, new(MockCodeOwnersRepository), new(MockSkillRepository), new(MockPoolRepository), logger)
 
 		result, newReviewer, err := service.ReassignReviewer(ctx, "", "u2")
 		assert.Error(t, err)
@@ -193,7 +200,7 @@ func TestPRService_ReassignReviewer(t *testing.T) {
 		mockPRRepo := new(MockPRRepository)
 		mockUserRepo := new(MockUserRepository)
 		mockTeamRepo := new(MockTeamRepository)
-		service := NewPRService(mockPRRepo, mockUserRepo, mockTeamRepo, logger)
+		service := NewPRService(mockPRRepo, mockUserRepo, mockTeamRepo, new(MockCodeOwnersRepository), new(MockSkillRepository), new(MockPoolRepository), logger)
 
 		result, newReviewer, err := service.ReassignReviewer(ctx, "pr-1", "")
 		assert.Error(t, err)
@@ -206,7 +213,7 @@ func TestPRService_ReassignReviewer(t *testing.T) {
 		mockPRRepo := new(MockPRRepository)
 		mockUserRepo := new(MockUserRepository)
 		mockTeamRepo := new(MockTeamRepository)
-		service := NewPRService(mockPRRepo, mockUserRepo, mockTeamRepo, logger)
+		service := NewPRService(mockPRRepo, mockUserRepo, mockTeamRepo, new(MockCodeOwnersRepository), new(MockSkillRepository), new(MockPoolRepository), logger)
 
 		mockPRRepo.On("GetByPRID", ctx, "pr-unknown").Return(nil, nil)
 
@@ -221,7 +228,7 @@ func TestPRService_ReassignReviewer(t *testing.T) {
 		mockPRRepo := new(MockPRRepository)
 		mockUserRepo := new(MockUserRepository)
 		mockTeamRepo := new(MockTeamRepository)
-		service := NewPRService(mockPRRepo, mockUserRepo, mockTeamRepo, logger)
+		service := NewPRService(mockPRRepo, mockUserRepo, mockTeamRepo, new(MockCodeOwnersRepository), new(MockSkillRepository), new(MockPoolRepository), logger)
 
 		existingPR := &domain.PullRequest{
 			ID:                1,
@@ -244,7 +251,8 @@ func TestPRService_ReassignReviewer(t *testing.T) {
 		mockPRRepo := new(MockPRRepository)
 		mockUserRepo := new(MockUserRepository)
 		mockTeamRepo := new(MockTeamRepository)
-		service := NewPRService(mockPRRepo, mockUserRepo, mockTeamRepo, logger)
+		mockPoolRepo := new(MockPoolRepository)
+		service := NewPRService(mockPRRepo, mockUserRepo, mockTeamRepo, new(MockCodeOwnersRepository), new(MockSkillRepository), mockPoolRepo, logger)
 
 		existingPR := &domain.PullRequest{
 			ID:                1,
@@ -261,12 +269,14 @@ func TestPRService_ReassignReviewer(t *testing.T) {
 		mockPRRepo.On("GetByPRID", ctx, "pr-1").Return(existingPR, nil)
 		mockUserRepo.On("GetByUserID", ctx, "u2").Return(oldUser, nil)
 		mockUserRepo.On("GetByTeamID", ctx, int64(1)).Return(teamMembers, nil)
+		mockTeamRepo.On("GetAncestors", ctx, int64(1)).Return([]domain.Team{{ID: 1}}, nil)
+		mockPoolRepo.On("GetFallback", ctx, int64(1)).Return(nil, nil)
 
 		result, newReviewer, err := service.ReassignReviewer(ctx, "pr-1", "u2")
 		assert.Error(t, err)
 		assert.Nil(t, result)
 		assert.Empty(t, newReviewer)
-		assert.True(t, apperror.Is(err, apperror.ErrCodeInvalidInput))
+		assert.True(t, apperror.Is(err, apperror.ErrCodeNoCandidate))
 	})
 
 }
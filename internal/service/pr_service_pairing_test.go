@@ -0,0 +1,79 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ssokov/pr-reviewer-service/internal/model/domain"
+	"github.com/ssokov/pr-reviewer-service/internal/tenant"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/vmkteam/embedlog"
+)
+
+func TestPRService_CreatePR_Pairing(t *testing.T) {
+	logger := embedlog.NewLogger(false, false)
+	ctx := tenant.WithOrganization(context.Background(), &domain.Organization{
+		ID:       1,
+		Settings: domain.OrganizationSettings{ReviewerCount: 2, PairingWindowDays: 14},
+	})
+	author := &domain.User{UserID: "author", TeamID: 1, IsActive: true}
+
+	mockPRRepo := new(MockPRRepository)
+	mockUserRepo := new(MockUserRepository)
+	mockTeamRepo := new(MockTeamRepository)
+	mockPoolRepo := new(MockPoolRepository)
+	service := NewPRService(mockPRRepo, mockUserRepo, mockTeamRepo, new(MockCodeOwnersRepository), new(MockSkillRepository), mockPoolRepo, logger).(*prService)
+	now := time.Date(2025, time.March, 3, 9, 0, 0, 0, time.UTC)
+	service.now = func() time.Time { return now }
+
+	mockUserRepo.On("GetByUserID", ctx, "author").Return(author, nil)
+	mockUserRepo.On("GetByTeamID", ctx, int64(1)).Return([]domain.User{
+		*author,
+		{UserID: "m1", IsActive: true},
+		{UserID: "m2", IsActive: true},
+		{UserID: "m3", IsActive: true},
+	}, nil)
+	mockTeamRepo.On("GetAncestors", ctx, int64(1)).Return([]domain.Team{{ID: 1, TeamName: "backend"}}, nil)
+	mockPoolRepo.On("GetFallback", ctx, int64(1)).Return(nil, nil)
+	mockPRRepo.On("GetPairCounts", ctx, "author", now.Add(-14*24*time.Hour)).Return(map[string]int{"m1": 5, "m2": 1}, nil)
+	created := &domain.PullRequest{}
+	mockPRRepo.On("Create", ctx, mock.Anything).Run(func(args mock.Arguments) {
+		created.AssignedReviewers = args.Get(1).(*domain.PullRequest).AssignedReviewers
+	}).Return(created, nil)
+
+	pr, err := service.CreatePR(ctx, "author", &domain.PullRequest{PullRequestID: "pr1", PullRequestName: "Change"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"m3", "m2"}, pr.AssignedReviewers)
+	mockPRRepo.AssertExpectations(t)
+}
+
+func TestPRService_ReassignReviewer_Pairing(t *testing.T) {
+	ctx := context.Background()
+	logger := embedlog.NewLogger(false, false)
+
+	mockPRRepo := new(MockPRRepository)
+	mockUserRepo := new(MockUserRepository)
+	service := NewPRService(mockPRRepo, mockUserRepo, new(MockTeamRepository), new(MockCodeOwnersRepository), new(MockSkillRepository), new(MockPoolRepository), logger)
+
+	mockPRRepo.On("GetByPRID", ctx, "pr1").Return(&domain.PullRequest{
+		PullRequestID:     "pr1",
+		AuthorID:          "author",
+		Status:            domain.PRStatusOpen,
+		AssignedReviewers: []string{"m1"},
+	}, nil)
+	mockUserRepo.On("GetByUserID", ctx, "m1").Return(&domain.User{UserID: "m1", TeamID: 1}, nil)
+	mockUserRepo.On("GetByTeamID", ctx, int64(1)).Return([]domain.User{
+		{UserID: "m1", IsActive: true},
+		{UserID: "m2", IsActive: true},
+		{UserID: "m3", IsActive: true},
+	}, nil)
+	mockPRRepo.On("GetPairCounts", ctx, "author", mock.Anything).Return(map[string]int{"m2": 3}, nil)
+	mockPRRepo.On("Update", ctx, mock.Anything).Return(&domain.PullRequest{PullRequestID: "pr1"}, nil)
+
+	_, newReviewerID, err := service.ReassignReviewer(ctx, "pr1", "m1")
+	require.NoError(t, err)
+	assert.Equal(t, "m3", newReviewerID)
+}
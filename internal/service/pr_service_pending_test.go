@@ -0,0 +1,113 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ssokov/pr-reviewer-service/internal/apperror"
+	"github.com/ssokov/pr-reviewer-service/internal/model/domain"
+	"github.com/ssokov/pr-reviewer-service/internal/tenant"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/vmkteam/embedlog"
+)
+
+func TestPRService_AssignPendingReviewers(t *testing.T) {
+	logger := embedlog.NewLogger(false, false)
+	ctx := tenant.WithOrganization(context.Background(), &domain.Organization{ID: 1, Settings: domain.OrganizationSettings{ReviewerCount: 2}})
+	author := &domain.User{UserID: "author", TeamID: 1, TeamName: "backend", IsActive: true}
+	pending := func() *domain.PullRequest {
+		return &domain.PullRequest{PullRequestID: "pr1", AuthorID: "author", Status: domain.PRStatusOpen, PendingReviewers: true}
+	}
+
+	setup := func(pr *domain.PullRequest, members []domain.User) (PRService, *MockPRRepository) {
+		mockPRRepo := new(MockPRRepository)
+		mockUserRepo := new(MockUserRepository)
+		mockTeamRepo := new(MockTeamRepository)
+		mockPoolRepo := new(MockPoolRepository)
+
+		mockPRRepo.On("GetByPRID", ctx, "pr1").Return(pr, nil)
+		mockUserRepo.On("GetByUserID", ctx, "author").Return(author, nil)
+		mockUserRepo.On("GetByTeamID", ctx, int64(1)).Return(append([]domain.User{*author}, members...), nil)
+		mockTeamRepo.On("GetAncestors", ctx, int64(1)).Return([]domain.Team{{ID: 1}}, nil)
+		mockPoolRepo.On("GetFallback", ctx, int64(1)).Return(nil, nil)
+		mockPRRepo.On("GetPairCounts", ctx, "author", mock.Anything).Return(map[string]int{}, nil)
+		stored := &domain.PullRequest{}
+		mockPRRepo.On("Update", ctx, mock.Anything).Run(func(args mock.Arguments) {
+			*stored = *args.Get(1).(*domain.PullRequest)
+		}).Return(stored, nil)
+
+		return NewPRService(mockPRRepo, mockUserRepo, mockTeamRepo, new(MockCodeOwnersRepository), new(MockSkillRepository), mockPoolRepo, logger), mockPRRepo
+	}
+
+	t.Run("assigns reviewers once someone is active", func(t *testing.T) {
+		service, _ := setup(pending(), []domain.User{
+			{UserID: "back", TeamID: 1, IsActive: true},
+			{UserID: "away", TeamID: 1, IsActive: false},
+			{UserID: "trainee", TeamID: 1, IsActive: true, TeamRole: domain.TeamRoleTrainee},
+		})
+
+		pr, err := service.AssignPendingReviewers(ctx, "pr1")
+		require.NoError(t, err)
+		assert.False(t, pr.PendingReviewers)
+		assert.Equal(t, []string{"back"}, pr.AssignedReviewers)
+		assert.Equal(t, []string{"trainee"}, pr.ShadowReviewers)
+		require.Len(t, pr.Selections, 1)
+		assert.Equal(t, domain.SelectionRuleTeam, pr.Selections[0].Rule)
+	})
+
+	t.Run("still nobody available", func(t *testing.T) {
+		service, mockPRRepo := setup(pending(), []domain.User{{UserID: "away", TeamID: 1, IsActive: false}})
+
+		pr, err := service.AssignPendingReviewers(ctx, "pr1")
+		require.NoError(t, err)
+		assert.True(t, pr.PendingReviewers)
+		mockPRRepo.AssertNotCalled(t, "Update", ctx, mock.Anything)
+	})
+
+	t.Run("stays pending when selection fails for another reason", func(t *testing.T) {
+		seniorCtx := tenant.WithOrganization(context.Background(), &domain.Organization{
+			ID:       1,
+			Settings: domain.OrganizationSettings{ReviewerCount: 2, RequireSenior: true},
+		})
+		mockPRRepo := new(MockPRRepository)
+		mockUserRepo := new(MockUserRepository)
+		mockTeamRepo := new(MockTeamRepository)
+		mockPoolRepo := new(MockPoolRepository)
+		service := NewPRService(mockPRRepo, mockUserRepo, mockTeamRepo, new(MockCodeOwnersRepository), new(MockSkillRepository), mockPoolRepo, logger)
+
+		mockPRRepo.On("GetByPRID", seniorCtx, "pr1").Return(pending(), nil)
+		mockUserRepo.On("GetByUserID", seniorCtx, "author").Return(author, nil)
+		mockUserRepo.On("GetByTeamID", seniorCtx, int64(1)).Return([]domain.User{*author, {UserID: "member", TeamID: 1, IsActive: true}}, nil)
+		mockTeamRepo.On("GetAncestors", seniorCtx, int64(1)).Return([]domain.Team{{ID: 1}}, nil)
+		mockPoolRepo.On("GetFallback", seniorCtx, int64(1)).Return(nil, nil)
+
+		pr, err := service.AssignPendingReviewers(seniorCtx, "pr1")
+		require.NoError(t, err)
+		assert.True(t, pr.PendingReviewers)
+		assert.Empty(t, pr.AssignedReviewers)
+		mockPRRepo.AssertNotCalled(t, "Update", seniorCtx, mock.Anything)
+	})
+
+	t.Run("merged and assigned PRs are left alone", func(t *testing.T) {
+		for _, pr := range []*domain.PullRequest{
+			{PullRequestID: "pr1", AuthorID: "author", Status: domain.PRStatusMerged, PendingReviewers: true},
+			{PullRequestID: "pr1", AuthorID: "author", Status: domain.PRStatusOpen, AssignedReviewers: []string{"u2"}},
+		} {
+			service, mockPRRepo := setup(pr, nil)
+
+			got, err := service.AssignPendingReviewers(ctx, "pr1")
+			require.NoError(t, err)
+			assert.Same(t, pr, got)
+			mockPRRepo.AssertNotCalled(t, "Update", ctx, mock.Anything)
+		}
+	})
+
+	t.Run("error - PR not found", func(t *testing.T) {
+		service, _ := setup(nil, nil)
+
+		_, err := service.AssignPendingReviewers(ctx, "pr1")
+		assert.True(t, apperror.Is(err, apperror.ErrCodePRNotFound))
+	})
+}
@@ -0,0 +1,190 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ssokov/pr-reviewer-service/internal/apperror"
+	"github.com/ssokov/pr-reviewer-service/internal/model/domain"
+	"github.com/ssokov/pr-reviewer-service/internal/tenant"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/vmkteam/embedlog"
+)
+
+func TestPRService_CreatePR_TeamRoles(t *testing.T) {
+	logger := embedlog.NewLogger(false, false)
+	author := &domain.User{UserID: "author", TeamID: 1, IsActive: true}
+	requireSenior := true
+
+	setup := func(ctx context.Context, settings domain.TeamSettings, teammates []domain.User) PRService {
+		mockPRRepo := new(MockPRRepository)
+		mockUserRepo := new(MockUserRepository)
+		mockTeamRepo := new(MockTeamRepository)
+		mockPoolRepo := new(MockPoolRepository)
+
+		mockUserRepo.On("GetByUserID", ctx, "author").Return(author, nil)
+		mockUserRepo.On("GetByTeamID", ctx, int64(1)).Return(append([]domain.User{*author}, teammates...), nil)
+		mockTeamRepo.On("GetAncestors", ctx, int64(1)).Return([]domain.Team{{ID: 1, TeamName: "backend", Settings: settings}}, nil)
+		mockPoolRepo.On("GetFallback", ctx, int64(1)).Return(nil, nil)
+		mockPRRepo.On("GetPairCounts", ctx, "author", mock.Anything).Return(map[string]int{}, nil)
+		created := &domain.PullRequest{}
+		mockPRRepo.On("Create", ctx, mock.Anything).Run(func(args mock.Arguments) {
+			pr := args.Get(1).(*domain.PullRequest)
+			created.AssignedReviewers = pr.AssignedReviewers
+			created.ShadowReviewers = pr.ShadowReviewers
+		}).Return(created, nil)
+
+		return NewPRService(mockPRRepo, mockUserRepo, mockTeamRepo, new(MockCodeOwnersRepository), new(MockSkillRepository), mockPoolRepo, logger)
+	}
+	newPR := func() *domain.PullRequest {
+		return &domain.PullRequest{PullRequestID: "pr1", PullRequestName: "Change"}
+	}
+	ctx := tenant.WithOrganization(context.Background(), &domain.Organization{
+		ID:       1,
+		Settings: domain.OrganizationSettings{ReviewerCount: 2},
+	})
+
+	t.Run("trainees shadow instead of reviewing", func(t *testing.T) {
+		service := setup(ctx, domain.TeamSettings{}, []domain.User{
+			{UserID: "t1", TeamRole: domain.TeamRoleTrainee, IsActive: true},
+			{UserID: "m1", TeamRole: domain.TeamRoleMember, IsActive: true},
+		})
+
+		pr, err := service.CreatePR(ctx, "author", newPR())
+		require.NoError(t, err)
+		assert.Equal(t, []string{"m1"}, pr.AssignedReviewers)
+		assert.Equal(t, []string{"t1"}, pr.ShadowReviewers)
+	})
+
+	t.Run("trainees take turns shadowing", func(t *testing.T) {
+		mockPRRepo := new(MockPRRepository)
+		mockUserRepo := new(MockUserRepository)
+		mockTeamRepo := new(MockTeamRepository)
+		mockPoolRepo := new(MockPoolRepository)
+		service := NewPRService(mockPRRepo, mockUserRepo, mockTeamRepo, new(MockCodeOwnersRepository), new(MockSkillRepository), mockPoolRepo, logger)
+
+		mockUserRepo.On("GetByUserID", ctx, "author").Return(author, nil)
+		mockUserRepo.On("GetByTeamID", ctx, int64(1)).Return([]domain.User{
+			*author,
+			{UserID: "t1", TeamRole: domain.TeamRoleTrainee, IsActive: true},
+			{UserID: "t2", TeamRole: domain.TeamRoleTrainee, IsActive: true},
+			{UserID: "m1", TeamRole: domain.TeamRoleMember, IsActive: true},
+		}, nil)
+		mockTeamRepo.On("GetAncestors", ctx, int64(1)).Return([]domain.Team{{ID: 1, TeamName: "backend"}}, nil)
+		mockPoolRepo.On("GetFallback", ctx, int64(1)).Return(nil, nil)
+		mockPRRepo.On("GetPairCounts", ctx, "author", mock.Anything).Return(map[string]int{}, nil)
+		mockPRRepo.On("GetOpenShadowCounts", ctx, []string{"t1", "t2"}).Return(map[string]int{"t1": 2, "t2": 1}, nil)
+		created := &domain.PullRequest{}
+		mockPRRepo.On("Create", ctx, mock.Anything).Run(func(args mock.Arguments) {
+			*created = *args.Get(1).(*domain.PullRequest)
+		}).Return(created, nil)
+
+		pr, err := service.CreatePR(ctx, "author", newPR())
+		require.NoError(t, err)
+		assert.Equal(t, []string{"t2"}, pr.ShadowReviewers)
+	})
+
+	t.Run("senior is added when none is selected", func(t *testing.T) {
+		service := setup(ctx, domain.TeamSettings{RequireSenior: &requireSenior}, []domain.User{
+			{UserID: "m1", TeamRole: domain.TeamRoleMember, IsActive: true},
+			{UserID: "m2", TeamRole: domain.TeamRoleMember, IsActive: true},
+			{UserID: "s1", TeamRole: domain.TeamRoleSenior, IsActive: true},
+		})
+
+		pr, err := service.CreatePR(ctx, "author", newPR())
+		require.NoError(t, err)
+		assert.Equal(t, []string{"s1", "m1"}, pr.AssignedReviewers)
+		assert.Equal(t, []domain.ReviewerSelection{
+			{UserID: "s1", Rule: domain.SelectionRuleSenior},
+			{UserID: "m1", Rule: domain.SelectionRuleTeam},
+		}, pr.Selections)
+	})
+
+	t.Run("lead counts as senior", func(t *testing.T) {
+		orgCtx := tenant.WithOrganization(context.Background(), &domain.Organization{
+			ID:       1,
+			Settings: domain.OrganizationSettings{ReviewerCount: 2, RequireSenior: true},
+		})
+		service := setup(orgCtx, domain.TeamSettings{}, []domain.User{
+			{UserID: "l1", TeamRole: domain.TeamRoleLead, IsActive: true},
+			{UserID: "m1", TeamRole: domain.TeamRoleMember, IsActive: true},
+		})
+
+		pr, err := service.CreatePR(orgCtx, "author", newPR())
+		require.NoError(t, err)
+		assert.Equal(t, []string{"l1", "m1"}, pr.AssignedReviewers)
+	})
+
+	t.Run("error - no senior in team", func(t *testing.T) {
+		service := setup(ctx, domain.TeamSettings{RequireSenior: &requireSenior}, []domain.User{
+			{UserID: "m1", TeamRole: domain.TeamRoleMember, IsActive: true},
+			{UserID: "s1", TeamRole: domain.TeamRoleSenior, IsActive: false},
+		})
+
+		_, err := service.CreatePR(ctx, "author", newPR())
+		assert.True(t, apperror.Is(err, apperror.ErrCodeInvalidInput), "got %v", err)
+	})
+}
+
+func TestPRService_ReassignReviewer_RequireSenior(t *testing.T) {
+	logger := embedlog.NewLogger(false, false)
+	ctx := tenant.WithOrganization(context.Background(), &domain.Organization{
+		ID:       1,
+		Settings: domain.OrganizationSettings{RequireSenior: true},
+	})
+	senior := &domain.User{UserID: "s1", TeamID: 1, TeamRole: domain.TeamRoleSenior, IsActive: true}
+
+	setup := func(assigned []string, teammates []domain.User) PRService {
+		mockPRRepo := new(MockPRRepository)
+		mockUserRepo := new(MockUserRepository)
+		mockTeamRepo := new(MockTeamRepository)
+
+		mockPRRepo.On("GetByPRID", ctx, "pr1").Return(&domain.PullRequest{
+			PullRequestID:     "pr1",
+			AuthorID:          "author",
+			Status:            domain.PRStatusOpen,
+			AssignedReviewers: assigned,
+		}, nil)
+		mockUserRepo.On("GetByUserID", ctx, "s1").Return(senior, nil)
+		mockUserRepo.On("GetByTeamID", ctx, int64(1)).Return(append([]domain.User{*senior}, teammates...), nil)
+		mockTeamRepo.On("GetAncestors", ctx, int64(1)).Return([]domain.Team{{ID: 1, TeamName: "backend"}}, nil)
+		mockPRRepo.On("GetPairCounts", ctx, "author", mock.Anything).Return(map[string]int{}, nil)
+		mockPRRepo.On("Update", ctx, mock.Anything).Return(&domain.PullRequest{PullRequestID: "pr1"}, nil)
+
+		return NewPRService(mockPRRepo, mockUserRepo, mockTeamRepo, new(MockCodeOwnersRepository), new(MockSkillRepository), new(MockPoolRepository), logger)
+	}
+
+	t.Run("only senior is replaced by a senior", func(t *testing.T) {
+		service := setup([]string{"s1", "m1"}, []domain.User{
+			{UserID: "m2", TeamRole: domain.TeamRoleMember, IsActive: true},
+			{UserID: "l1", TeamRole: domain.TeamRoleLead, IsActive: true},
+		})
+
+		_, newReviewerID, err := service.ReassignReviewer(ctx, "pr1", "s1")
+		require.NoError(t, err)
+		assert.Equal(t, "l1", newReviewerID)
+	})
+
+	t.Run("another senior stays on the PR", func(t *testing.T) {
+		service := setup([]string{"s1", "l1"}, []domain.User{
+			{UserID: "m2", TeamRole: domain.TeamRoleMember, IsActive: true},
+			{UserID: "l1", TeamRole: domain.TeamRoleLead, IsActive: true},
+		})
+
+		_, newReviewerID, err := service.ReassignReviewer(ctx, "pr1", "s1")
+		require.NoError(t, err)
+		assert.Equal(t, "m2", newReviewerID)
+	})
+
+	t.Run("error - no senior left", func(t *testing.T) {
+		service := setup([]string{"s1", "m1"}, []domain.User{
+			{UserID: "m2", TeamRole: domain.TeamRoleMember, IsActive: true},
+			{UserID: "t1", TeamRole: domain.TeamRoleTrainee, IsActive: true},
+		})
+
+		_, _, err := service.ReassignReviewer(ctx, "pr1", "s1")
+		assert.True(t, apperror.Is(err, apperror.ErrCodeInvalidInput), "got %v", err)
+	})
+}
@@ -0,0 +1,189 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ssokov/pr-reviewer-service/internal/apperror"
+	"github.com/ssokov/pr-reviewer-service/internal/model/domain"
+	"github.com/ssokov/pr-reviewer-service/internal/tenant"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/vmkteam/embedlog"
+)
+
+func TestPRService_CreatePR_Skills(t *testing.T) {
+	logger := embedlog.NewLogger(false, false)
+	ctx := tenant.WithOrganization(context.Background(), &domain.Organization{
+		ID:       1,
+		Settings: domain.OrganizationSettings{ReviewerCount: 2},
+	})
+	author := &domain.User{UserID: "author", IsActive: true, TeamID: 1}
+	teammates := []domain.User{
+		{UserID: "author", IsActive: true},
+		{UserID: "go1", IsActive: true},
+		{UserID: "sql1", IsActive: true},
+		{UserID: "sec1", IsActive: true},
+		{UserID: "away", IsActive: false},
+	}
+	skills := map[string][]string{
+		"go1":  {"go"},
+		"sql1": {"go", "sql"},
+		"sec1": {"security"},
+	}
+
+	setup := func(ctx context.Context) (PRService, *MockSkillRepository, *MockPoolRepository) {
+		mockPRRepo := new(MockPRRepository)
+		mockUserRepo := new(MockUserRepository)
+		mockTeamRepo := new(MockTeamRepository)
+		mockSkillRepo := new(MockSkillRepository)
+		mockPoolRepo := new(MockPoolRepository)
+
+		mockUserRepo.On("GetByUserID", ctx, "author").Return(author, nil)
+		mockUserRepo.On("GetByTeamID", ctx, int64(1)).Return(teammates, nil)
+		mockTeamRepo.On("GetAncestors", ctx, int64(1)).Return([]domain.Team{{ID: 1}}, nil)
+		mockSkillRepo.On("ListSkills", ctx).Return([]string{"go", "security", "sql"}, nil)
+		mockSkillRepo.On("GetSkillsByUserIDs", ctx, []string{"go1", "sql1", "sec1"}).Return(skills, nil)
+		created := &domain.PullRequest{}
+		mockPRRepo.On("GetPairCounts", ctx, mock.Anything, mock.Anything).Return(map[string]int{}, nil)
+		mockPRRepo.On("Create", ctx, mock.Anything).Run(func(args mock.Arguments) {
+			created.AssignedReviewers = args.Get(1).(*domain.PullRequest).AssignedReviewers
+			created.Labels = args.Get(1).(*domain.PullRequest).Labels
+			created.PendingReviewers = args.Get(1).(*domain.PullRequest).PendingReviewers
+		}).Return(created, nil)
+
+		return NewPRService(mockPRRepo, mockUserRepo, mockTeamRepo, new(MockCodeOwnersRepository), mockSkillRepo, mockPoolRepo, logger), mockSkillRepo, mockPoolRepo
+	}
+
+	newPR := func(labels ...string) *domain.PullRequest {
+		return &domain.PullRequest{PullRequestID: "pr1", PullRequestName: "Change", Labels: labels}
+	}
+
+	t.Run("security label requires a security reviewer", func(t *testing.T) {
+		service, _, _ := setup(ctx)
+
+		result, err := service.CreatePR(ctx, "author", newPR("Security"))
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"security"}, result.Labels)
+		assert.Equal(t, []domain.ReviewerSelection{
+			{UserID: "sec1", Rule: domain.SelectionRuleSkill, Skill: "security"},
+			{UserID: "go1", Rule: domain.SelectionRuleTeam},
+		}, result.Selections)
+	})
+
+	t.Run("teammates are ranked by skill overlap", func(t *testing.T) {
+		service, _, _ := setup(ctx)
+
+		result, err := service.CreatePR(ctx, "author", newPR("go", "sql", "bugfix"))
+		require.NoError(t, err)
+
+		// sql1 covers both go and sql, go1 has the best remaining score.
+		assert.Equal(t, []string{"sql1", "go1"}, result.AssignedReviewers)
+		assert.Equal(t, domain.SelectionRuleSkill, result.Selections[0].Rule)
+		assert.Equal(t, domain.SelectionRuleTeam, result.Selections[1].Rule)
+	})
+
+	t.Run("skill matches may exceed the reviewer count", func(t *testing.T) {
+		oneReviewerCtx := tenant.WithOrganization(context.Background(), &domain.Organization{
+			ID:       1,
+			Settings: domain.OrganizationSettings{ReviewerCount: 1},
+		})
+		service, _, _ := setup(oneReviewerCtx)
+
+		result, err := service.CreatePR(oneReviewerCtx, "author", newPR("go", "security", "sql"))
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"sql1", "sec1"}, result.AssignedReviewers)
+	})
+
+	frontendOnly := func(mockSkillRepo *MockSkillRepository) {
+		mockSkillRepo.ExpectedCalls = nil
+		mockSkillRepo.On("ListSkills", ctx).Return([]string{"frontend"}, nil)
+		mockSkillRepo.On("GetSkillsByUserIDs", ctx, []string{"go1", "sql1", "sec1"}).Return(skills, nil)
+	}
+
+	t.Run("fallback chain covers a skill nobody in the team has", func(t *testing.T) {
+		service, mockSkillRepo, mockPoolRepo := setup(ctx)
+		frontendOnly(mockSkillRepo)
+		mockPoolRepo.On("GetFallback", ctx, int64(1)).Return([]domain.FallbackStep{{PoolName: "web"}}, nil)
+		mockPoolRepo.On("GetByName", ctx, "web").Return(&domain.ReviewerPool{Name: "web", Members: []domain.User{
+			{UserID: "author", IsActive: true},
+			{UserID: "fe1", IsActive: true},
+		}}, nil)
+		mockSkillRepo.On("GetSkillsByUserIDs", ctx, []string{"fe1"}).Return(map[string][]string{"fe1": {"frontend"}}, nil)
+
+		result, err := service.CreatePR(ctx, "author", newPR("frontend"))
+		require.NoError(t, err)
+		assert.Equal(t, []domain.ReviewerSelection{
+			{UserID: "fe1", Rule: domain.SelectionRuleSkill, Skill: "frontend", Pool: "web"},
+			{UserID: "go1", Rule: domain.SelectionRuleTeam},
+		}, result.Selections)
+	})
+
+	t.Run("error - nobody in the team or the fallback chain has a known skill", func(t *testing.T) {
+		service, mockSkillRepo, mockPoolRepo := setup(ctx)
+		frontendOnly(mockSkillRepo)
+		mockPoolRepo.On("GetFallback", ctx, int64(1)).Return(nil, nil)
+
+		_, err := service.CreatePR(ctx, "author", newPR("frontend"))
+		assert.True(t, apperror.Is(err, apperror.ErrCodeNoCandidate), "got %v", err)
+		assert.Contains(t, err.Error(), "frontend")
+	})
+
+	t.Run("missing skill queues the PR when the organization opts in", func(t *testing.T) {
+		queueCtx := tenant.WithOrganization(context.Background(), &domain.Organization{
+			ID:       1,
+			Settings: domain.OrganizationSettings{ReviewerCount: 2, AtCapacity: domain.AtCapacityQueue},
+		})
+		service, mockSkillRepo, mockPoolRepo := setup(queueCtx)
+		mockSkillRepo.ExpectedCalls = nil
+		mockSkillRepo.On("ListSkills", queueCtx).Return([]string{"frontend"}, nil)
+		mockSkillRepo.On("GetSkillsByUserIDs", queueCtx, mock.Anything).Return(skills, nil)
+		mockPoolRepo.On("GetFallback", queueCtx, int64(1)).Return(nil, nil)
+
+		result, err := service.CreatePR(queueCtx, "author", newPR("frontend"))
+		require.NoError(t, err)
+		assert.True(t, result.PendingReviewers)
+		assert.Empty(t, result.AssignedReviewers)
+	})
+
+	t.Run("error - invalid label", func(t *testing.T) {
+		service, _, _ := setup(ctx)
+
+		_, err := service.CreatePR(ctx, "author", newPR("needs review"))
+		assert.True(t, apperror.Is(err, apperror.ErrCodeInvalidInput))
+	})
+}
+
+func TestPRService_ReassignReviewer_Skills(t *testing.T) {
+	ctx := context.Background()
+	logger := embedlog.NewLogger(false, false)
+
+	mockPRRepo := new(MockPRRepository)
+	mockUserRepo := new(MockUserRepository)
+	mockSkillRepo := new(MockSkillRepository)
+	service := NewPRService(mockPRRepo, mockUserRepo, new(MockTeamRepository), new(MockCodeOwnersRepository), mockSkillRepo, new(MockPoolRepository), logger)
+
+	mockPRRepo.On("GetByPRID", ctx, "pr1").Return(&domain.PullRequest{
+		PullRequestID:     "pr1",
+		Status:            domain.PRStatusOpen,
+		AssignedReviewers: []string{"sec1", "go1"},
+		Labels:            []string{"security"},
+	}, nil)
+	mockUserRepo.On("GetByUserID", ctx, "sec1").Return(&domain.User{UserID: "sec1", TeamID: 1}, nil)
+	mockUserRepo.On("GetByTeamID", ctx, int64(1)).Return([]domain.User{
+		{UserID: "sec1", IsActive: true},
+		{UserID: "go2", IsActive: true},
+		{UserID: "sec2", IsActive: true},
+	}, nil)
+	mockSkillRepo.On("ListSkills", ctx).Return([]string{"security"}, nil)
+	mockSkillRepo.On("GetSkillsByUserIDs", ctx, mock.Anything).Return(map[string][]string{"sec2": {"security"}}, nil)
+	mockPRRepo.On("GetPairCounts", ctx, mock.Anything, mock.Anything).Return(map[string]int{}, nil)
+	mockPRRepo.On("Update", ctx, mock.Anything).Return(&domain.PullRequest{PullRequestID: "pr1"}, nil)
+
+	_, newReviewerID, err := service.ReassignReviewer(ctx, "pr1", "sec1")
+	require.NoError(t, err)
+	assert.Equal(t, "sec2", newReviewerID)
+}
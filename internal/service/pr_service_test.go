@@ -6,6 +6,7 @@ import (
 
 	"github.com/ssokov/pr-reviewer-service/internal/apperror"
 	"github.com/ssokov/pr-reviewer-service/internal/model/domain"
+	"github.com/ssokov/pr-reviewer-service/internal/tenant"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/vmkteam/embedlog"
@@ -19,7 +20,7 @@ func TestPRService_CreatePR(t *testing.T) {
 		mockPRRepo := new(MockPRRepository)
 		mockUserRepo := new(MockUserRepository)
 		mockTeamRepo := new(MockTeamRepository)
-		service := NewPRService(mockPRRepo, mockUserRepo, mockTeamRepo, logger)
+		service := NewPRService(mockPRRepo, mockUserRepo, mockTeamRepo, new(MockCodeOwnersRepository), new(MockSkillRepository), new(MockPoolRepository), logger)
 
 		pr := &domain.PullRequest{
 			PullRequestID:   "pr123",
@@ -40,6 +41,8 @@ func TestPRService_CreatePR(t *testing.T) {
 
 		mockUserRepo.On("GetByUserID", ctx, "user1").Return(author, nil)
 		mockUserRepo.On("GetByTeamID", ctx, int64(1)).Return(teamMembers, nil)
+		mockTeamRepo.On("GetAncestors", ctx, int64(1)).Return([]domain.Team{{ID: 1}}, nil)
+		mockPRRepo.On("GetPairCounts", ctx, mock.Anything, mock.Anything).Return(map[string]int{}, nil)
 		mockPRRepo.On("Create", ctx, mock.Anything).Return(&domain.PullRequest{
 			ID:              1,
 			PullRequestID:   "pr123",
@@ -52,11 +55,48 @@ func TestPRService_CreatePR(t *testing.T) {
 		assert.NotNil(t, result)
 	})
 
+	t.Run("success - reviewer count capped by organization settings", func(t *testing.T) {
+		mockPRRepo := new(MockPRRepository)
+		mockUserRepo := new(MockUserRepository)
+		mockTeamRepo := new(MockTeamRepository)
+		service := NewPRService(mockPRRepo, mockUserRepo, mockTeamRepo, new(MockCodeOwnersRepository), new(MockSkillRepository), new(MockPoolRepository), logger)
+
+		orgCtx := tenant.WithOrganization(ctx, &domain.Organization{
+			ID:       2,
+			Slug:     "acme",
+			Settings: domain.OrganizationSettings{ReviewerCount: 1},
+		})
+
+		author := &domain.User{UserID: "user1", IsActive: true, TeamID: 1}
+		teamMembers := []domain.User{
+			{UserID: "user1", IsActive: true},
+			{UserID: "user2", IsActive: true},
+			{UserID: "user3", IsActive: true},
+		}
+
+		mockUserRepo.On("GetByUserID", orgCtx, "user1").Return(author, nil)
+		mockUserRepo.On("GetByTeamID", orgCtx, int64(1)).Return(teamMembers, nil)
+		mockTeamRepo.On("GetAncestors", orgCtx, int64(1)).Return([]domain.Team{{ID: 1}}, nil)
+		mockPRRepo.On("GetPairCounts", orgCtx, mock.Anything, mock.Anything).Return(map[string]int{}, nil)
+		mockPRRepo.On("Create", orgCtx, mock.MatchedBy(func(pr *domain.PullRequest) bool {
+			return len(pr.AssignedReviewers) == 1
+		})).Return(&domain.PullRequest{PullRequestID: "pr123", AssignedReviewers: []string{"user2"}}, nil)
+
+		result, err := service.CreatePR(orgCtx, "user1", &domain.PullRequest{
+			PullRequestID:   "pr123",
+			PullRequestName: "Feature A",
+		})
+		assert.NoError(t, err)
+		assert.Len(t, result.AssignedReviewers, 1)
+
+		mockPRRepo.AssertExpectations(t)
+	})
+
 	t.Run("error - author not active", func(t *testing.T) {
 		mockPRRepo := new(MockPRRepository)
 		mockUserRepo := new(MockUserRepository)
 		mockTeamRepo := new(MockTeamRepository)
-		service := NewPRService(mockPRRepo, mockUserRepo, mockTeamRepo, logger)
+		service := NewPRService(mockPRRepo, mockUserRepo, mockTeamRepo, new(MockCodeOwnersRepository), new(MockSkillRepository), new(MockPoolRepository), logger)
 
 		pr := &domain.PullRequest{
 			PullRequestID:   "pr123",
@@ -80,7 +120,7 @@ func TestPRService_CreatePR(t *testing.T) {
 		mockPRRepo := new(MockPRRepository)
 		mockUserRepo := new(MockUserRepository)
 		mockTeamRepo := new(MockTeamRepository)
-		service := NewPRService(mockPRRepo, mockUserRepo, mockTeamRepo, logger)
+		service := NewPRService(mockPRRepo, mockUserRepo, mockTeamRepo, new(MockCodeOwnersRepository), new(MockSkillRepository), new(MockPoolRepository), logger)
 
 		pr := &domain.PullRequest{
 			PullRequestID:   "",
@@ -97,7 +137,7 @@ func TestPRService_CreatePR(t *testing.T) {
 		mockPRRepo := new(MockPRRepository)
 		mockUserRepo := new(MockUserRepository)
 		mockTeamRepo := new(MockTeamRepository)
-		service := NewPRService(mockPRRepo, mockUserRepo, mockTeamRepo, logger)
+		service := NewPRService(mockPRRepo, mockUserRepo, mockTeamRepo, new(MockCodeOwnersRepository), new(MockSkillRepository), new(MockPoolRepository), logger)
 
 		pr := &domain.PullRequest{
 			PullRequestID:   "pr123",
@@ -114,7 +154,7 @@ func TestPRService_CreatePR(t *testing.T) {
 		mockPRRepo := new(MockPRRepository)
 		mockUserRepo := new(MockUserRepository)
 		mockTeamRepo := new(MockTeamRepository)
-		service := NewPRService(mockPRRepo, mockUserRepo, mockTeamRepo, logger)
+		service := NewPRService(mockPRRepo, mockUserRepo, mockTeamRepo, new(MockCodeOwnersRepository), new(MockSkillRepository), new(MockPoolRepository), logger)
 
 		pr := &domain.PullRequest{
 			PullRequestID:   "pr123",
@@ -131,7 +171,7 @@ func TestPRService_CreatePR(t *testing.T) {
 		mockPRRepo := new(MockPRRepository)
 		mockUserRepo := new(MockUserRepository)
 		mockTeamRepo := new(MockTeamRepository)
-		service := NewPRService(mockPRRepo, mockUserRepo, mockTeamRepo, logger)
+		service := NewPRService(mockPRRepo, mockUserRepo, mockTeamRepo, new(MockCodeOwnersRepository), new(MockSkillRepository), new(MockPoolRepository), logger)
 
 		pr := &domain.PullRequest{
 			PullRequestID:   "pr123",
@@ -150,7 +190,7 @@ func TestPRService_CreatePR(t *testing.T) {
 		mockPRRepo := new(MockPRRepository)
 		mockUserRepo := new(MockUserRepository)
 		mockTeamRepo := new(MockTeamRepository)
-		service := NewPRService(mockPRRepo, mockUserRepo, mockTeamRepo, logger)
+		service := NewPRService(mockPRRepo, mockUserRepo, mockTeamRepo, new(MockCodeOwnersRepository), new(MockSkillRepository), new(MockPoolRepository), logger)
 
 		pr := &domain.PullRequest{
 			PullRequestID:   "pr123",
@@ -171,11 +211,13 @@ func TestPRService_CreatePR(t *testing.T) {
 		assert.True(t, apperror.Is(err, apperror.ErrCodeInvalidInput))
 	})
 
-	t.Run("error - no active reviewers in team", func(t *testing.T) {
+	t.Run("no active reviewers in team and queueing - PR waits for reviewers", func(t *testing.T) {
+		ctx := tenant.WithOrganization(ctx, &domain.Organization{ID: 1, Settings: domain.OrganizationSettings{AtCapacity: domain.AtCapacityQueue}})
 		mockPRRepo := new(MockPRRepository)
 		mockUserRepo := new(MockUserRepository)
 		mockTeamRepo := new(MockTeamRepository)
-		service := NewPRService(mockPRRepo, mockUserRepo, mockTeamRepo, logger)
+		mockPoolRepo := new(MockPoolRepository)
+		service := NewPRService(mockPRRepo, mockUserRepo, mockTeamRepo, new(MockCodeOwnersRepository), new(MockSkillRepository), mockPoolRepo, logger)
 
 		pr := &domain.PullRequest{
 			PullRequestID:   "pr123",
@@ -194,10 +236,70 @@ func TestPRService_CreatePR(t *testing.T) {
 
 		mockUserRepo.On("GetByUserID", ctx, "user1").Return(author, nil)
 		mockUserRepo.On("GetByTeamID", ctx, int64(1)).Return(teamMembers, nil)
+		mockTeamRepo.On("GetAncestors", ctx, int64(1)).Return([]domain.Team{{ID: 1}}, nil)
+		mockPoolRepo.On("GetFallback", ctx, int64(1)).Return(nil, nil)
+		mockPRRepo.On("Create", ctx, mock.MatchedBy(func(pr *domain.PullRequest) bool {
+			return pr.PendingReviewers && len(pr.AssignedReviewers) == 0
+		})).Return(&domain.PullRequest{PullRequestID: "pr123", Status: domain.PRStatusOpen, PendingReviewers: true}, nil)
 
 		result, err := service.CreatePR(ctx, "user1", pr)
-		assert.Error(t, err)
+		assert.NoError(t, err)
+		assert.True(t, result.PendingReviewers)
+		mockPRRepo.AssertExpectations(t)
+	})
+
+	t.Run("error - no active reviewers in team", func(t *testing.T) {
+		mockPRRepo := new(MockPRRepository)
+		mockUserRepo := new(MockUserRepository)
+		mockTeamRepo := new(MockTeamRepository)
+		mockPoolRepo := new(MockPoolRepository)
+		service := NewPRService(mockPRRepo, mockUserRepo, mockTeamRepo, new(MockCodeOwnersRepository), new(MockSkillRepository), mockPoolRepo, logger)
+
+		author := &domain.User{UserID: "user1", IsActive: true, TeamID: 1, TeamName: "backend"}
+		mockUserRepo.On("GetByUserID", ctx, "user1").Return(author, nil)
+		mockUserRepo.On("GetByTeamID", ctx, int64(1)).Return([]domain.User{*author}, nil)
+		mockTeamRepo.On("GetAncestors", ctx, int64(1)).Return([]domain.Team{{ID: 1}}, nil)
+		mockPoolRepo.On("GetFallback", ctx, int64(1)).Return(nil, nil)
+
+		result, err := service.CreatePR(ctx, "user1", &domain.PullRequest{PullRequestID: "pr123", PullRequestName: "Feature A"})
 		assert.Nil(t, result)
+		assert.True(t, apperror.Is(err, apperror.ErrCodeNoCandidate))
+		assert.ErrorContains(t, err, "backend")
+	})
+}
+
+func TestPRService_ListPRs(t *testing.T) {
+	ctx := context.Background()
+	logger := embedlog.NewLogger(false, false)
+
+	t.Run("default limit", func(t *testing.T) {
+		mockPRRepo := new(MockPRRepository)
+		service := NewPRService(mockPRRepo, new(MockUserRepository), new(MockTeamRepository), new(MockCodeOwnersRepository), new(MockSkillRepository), new(MockPoolRepository), logger)
+
+		mockPRRepo.On("List", ctx, domain.PRFilter{Status: domain.PRStatusOpen, Limit: defaultPRListLimit}).
+			Return([]domain.PullRequest{{PullRequestID: "pr-1"}}, nil)
+
+		prs, err := service.ListPRs(ctx, domain.PRFilter{Status: domain.PRStatusOpen})
+		assert.NoError(t, err)
+		assert.Len(t, prs, 1)
+		mockPRRepo.AssertExpectations(t)
+	})
+
+	t.Run("limit is capped", func(t *testing.T) {
+		mockPRRepo := new(MockPRRepository)
+		service := NewPRService(mockPRRepo, new(MockUserRepository), new(MockTeamRepository), new(MockCodeOwnersRepository), new(MockSkillRepository), new(MockPoolRepository), logger)
+
+		mockPRRepo.On("List", ctx, domain.PRFilter{Limit: maxPRListLimit}).Return([]domain.PullRequest{}, nil)
+
+		_, err := service.ListPRs(ctx, domain.PRFilter{Limit: 5000})
+		assert.NoError(t, err)
+		mockPRRepo.AssertExpectations(t)
+	})
+
+	t.Run("invalid status", func(t *testing.T) {
+		service := NewPRService(new(MockPRRepository), new(MockUserRepository), new(MockTeamRepository), new(MockCodeOwnersRepository), new(MockSkillRepository), new(MockPoolRepository), logger)
+
+		_, err := service.ListPRs(ctx, domain.PRFilter{Status: "CLOSED"})
 		assert.True(t, apperror.Is(err, apperror.ErrCodeInvalidInput))
 	})
 }
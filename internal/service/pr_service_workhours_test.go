@@ -0,0 +1,188 @@
+package service
+
+import (
+	"context"
+	"maps"
+	"testing"
+	"time"
+
+	"github.com/ssokov/pr-reviewer-service/internal/apperror"
+	"github.com/ssokov/pr-reviewer-service/internal/model/domain"
+	"github.com/ssokov/pr-reviewer-service/internal/tenant"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/vmkteam/embedlog"
+)
+
+func workingHours(userID, timezone string) domain.User {
+	return domain.User{UserID: userID, IsActive: true, Timezone: timezone, WorkStart: domain.DefaultWorkStart, WorkEnd: domain.DefaultWorkEnd}
+}
+
+func TestPRService_CreatePR_WorkingHours(t *testing.T) {
+	logger := embedlog.NewLogger(false, false)
+	ctx := tenant.WithOrganization(context.Background(), &domain.Organization{
+		ID:       1,
+		Settings: domain.OrganizationSettings{ReviewerCount: 3},
+	})
+	author := &domain.User{UserID: "author", TeamID: 1, IsActive: true}
+
+	mockPRRepo := new(MockPRRepository)
+	mockUserRepo := new(MockUserRepository)
+	mockTeamRepo := new(MockTeamRepository)
+	mockPoolRepo := new(MockPoolRepository)
+	service := NewPRService(mockPRRepo, mockUserRepo, mockTeamRepo, new(MockCodeOwnersRepository), new(MockSkillRepository), mockPoolRepo, logger).(*prService)
+	// Monday 06:00 UTC: 09:00 in Moscow, 07:00 in Berlin, 01:00 in New York.
+	service.now = func() time.Time { return time.Date(2025, time.March, 3, 6, 0, 0, 0, time.UTC) }
+
+	mockUserRepo.On("GetByUserID", ctx, "author").Return(author, nil)
+	mockUserRepo.On("GetByTeamID", ctx, int64(1)).Return([]domain.User{
+		*author,
+		workingHours("new-york", "America/New_York"),
+		workingHours("berlin", "Europe/Berlin"),
+		workingHours("moscow", "Europe/Moscow"),
+		{UserID: "anytime", IsActive: true},
+	}, nil)
+	mockTeamRepo.On("GetAncestors", ctx, int64(1)).Return([]domain.Team{{ID: 1, TeamName: "backend"}}, nil)
+	mockPoolRepo.On("GetFallback", ctx, int64(1)).Return(nil, nil)
+	mockPRRepo.On("GetPairCounts", ctx, "author", mock.Anything).Return(map[string]int{"anytime": 3}, nil)
+	created := &domain.PullRequest{}
+	mockPRRepo.On("Create", ctx, mock.Anything).Run(func(args mock.Arguments) {
+		created.AssignedReviewers = args.Get(1).(*domain.PullRequest).AssignedReviewers
+	}).Return(created, nil)
+
+	pr, err := service.CreatePR(ctx, "author", &domain.PullRequest{PullRequestID: "pr1", PullRequestName: "Change"})
+	require.NoError(t, err)
+	// Available reviewers first, fewest pairings among them; Berlin starts within the default two hours.
+	assert.Equal(t, []string{"moscow", "anytime", "berlin"}, pr.AssignedReviewers)
+}
+
+func TestPRService_GetSLA(t *testing.T) {
+	logger := embedlog.NewLogger(false, false)
+	ctx := tenant.WithOrganization(context.Background(), &domain.Organization{ID: 1})
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	require.NoError(t, err)
+	// Friday 16:00 to Monday 11:00 in Berlin.
+	createdAt := time.Date(2025, time.March, 7, 16, 0, 0, 0, berlin)
+	now := time.Date(2025, time.March, 10, 11, 0, 0, 0, berlin)
+
+	// Reviewers are assigned when the PR is created unless assignedAt says otherwise.
+	setup := func(pr *domain.PullRequest, assignedAt map[string]time.Time, ancestors ...domain.Team) (PRService, *MockUserRepository) {
+		mockPRRepo := new(MockPRRepository)
+		mockUserRepo := new(MockUserRepository)
+		mockTeamRepo := new(MockTeamRepository)
+		service := NewPRService(mockPRRepo, mockUserRepo, mockTeamRepo, new(MockCodeOwnersRepository), new(MockSkillRepository), new(MockPoolRepository), logger).(*prService)
+		service.now = func() time.Time { return now }
+		mockPRRepo.On("GetByPRID", ctx, "pr1").Return(pr, nil)
+		if pr != nil {
+			times := make(map[string]time.Time)
+			for _, reviewerID := range pr.AssignedReviewers {
+				times[reviewerID] = pr.CreatedAt
+			}
+			maps.Copy(times, assignedAt)
+			mockPRRepo.On("GetReviewerAssignedAt", ctx, "pr1").Return(times, nil)
+		}
+		mockUserRepo.On("GetByUserID", ctx, "author").Return(&domain.User{UserID: "author", TeamID: 1, IsActive: true}, nil)
+		mockTeamRepo.On("GetAncestors", ctx, int64(1)).Return(append([]domain.Team{{ID: 1, TeamName: "backend"}}, ancestors...), nil)
+		return service, mockUserRepo
+	}
+
+	t.Run("counts working hours only", func(t *testing.T) {
+		service, mockUserRepo := setup(&domain.PullRequest{
+			PullRequestID:     "pr1",
+			AuthorID:          "author",
+			Status:            domain.PRStatusOpen,
+			AssignedReviewers: []string{"berlin", "anytime"},
+			CreatedAt:         createdAt,
+		}, nil)
+		reviewer := workingHours("berlin", "Europe/Berlin")
+		mockUserRepo.On("GetByUserID", ctx, "berlin").Return(&reviewer, nil)
+		mockUserRepo.On("GetByUserID", ctx, "anytime").Return(&domain.User{UserID: "anytime"}, nil)
+
+		sla, err := service.GetSLA(ctx, "pr1")
+		require.NoError(t, err)
+		assert.Equal(t, 8*time.Hour, sla.SLA)
+		require.Len(t, sla.Reviewers, 2)
+
+		assert.Equal(t, domain.ReviewerSLA{
+			UserID:   "berlin",
+			Timezone: "Europe/Berlin",
+			Elapsed:  4 * time.Hour,
+			DueAt:    time.Date(2025, time.March, 10, 15, 0, 0, 0, berlin),
+		}, sla.Reviewers[0])
+		assert.Equal(t, 67*time.Hour, sla.Reviewers[1].Elapsed)
+		assert.True(t, sla.Reviewers[1].DueAt.Equal(createdAt.Add(8*time.Hour)))
+		assert.True(t, sla.Reviewers[1].Overdue)
+	})
+
+	t.Run("timer of a reassigned reviewer starts at the reassignment", func(t *testing.T) {
+		// Monday 10:00 in Berlin, an hour before now.
+		reassignedAt := time.Date(2025, time.March, 10, 10, 0, 0, 0, berlin)
+		service, mockUserRepo := setup(&domain.PullRequest{
+			PullRequestID:     "pr1",
+			AuthorID:          "author",
+			Status:            domain.PRStatusOpen,
+			AssignedReviewers: []string{"berlin", "late"},
+			CreatedAt:         createdAt,
+		}, map[string]time.Time{"late": reassignedAt})
+		reviewer := workingHours("berlin", "Europe/Berlin")
+		late := workingHours("late", "Europe/Berlin")
+		mockUserRepo.On("GetByUserID", ctx, "berlin").Return(&reviewer, nil)
+		mockUserRepo.On("GetByUserID", ctx, "late").Return(&late, nil)
+
+		sla, err := service.GetSLA(ctx, "pr1")
+		require.NoError(t, err)
+		require.Len(t, sla.Reviewers, 2)
+		assert.Equal(t, 4*time.Hour, sla.Reviewers[0].Elapsed)
+		assert.Equal(t, domain.ReviewerSLA{
+			UserID:   "late",
+			Timezone: "Europe/Berlin",
+			Elapsed:  time.Hour,
+			DueAt:    time.Date(2025, time.March, 10, 18, 0, 0, 0, berlin),
+		}, sla.Reviewers[1])
+	})
+
+	t.Run("timers stop at merge", func(t *testing.T) {
+		mergedAt := time.Date(2025, time.March, 7, 17, 30, 0, 0, berlin)
+		service, mockUserRepo := setup(&domain.PullRequest{
+			PullRequestID:     "pr1",
+			AuthorID:          "author",
+			Status:            domain.PRStatusMerged,
+			AssignedReviewers: []string{"berlin"},
+			CreatedAt:         createdAt,
+			MergedAt:          &mergedAt,
+		}, nil)
+		reviewer := workingHours("berlin", "Europe/Berlin")
+		mockUserRepo.On("GetByUserID", ctx, "berlin").Return(&reviewer, nil)
+
+		sla, err := service.GetSLA(ctx, "pr1")
+		require.NoError(t, err)
+		assert.Equal(t, 90*time.Minute, sla.Reviewers[0].Elapsed)
+		assert.False(t, sla.Reviewers[0].Overdue)
+	})
+
+	t.Run("parent team overrides the organization SLA", func(t *testing.T) {
+		four := 4
+		service, mockUserRepo := setup(&domain.PullRequest{
+			PullRequestID:     "pr1",
+			AuthorID:          "author",
+			Status:            domain.PRStatusOpen,
+			AssignedReviewers: []string{"berlin"},
+			CreatedAt:         createdAt,
+		}, nil, domain.Team{ID: 2, TeamName: "engineering", Settings: domain.TeamSettings{ReviewSLAHours: &four}})
+		reviewer := workingHours("berlin", "Europe/Berlin")
+		mockUserRepo.On("GetByUserID", ctx, "berlin").Return(&reviewer, nil)
+
+		sla, err := service.GetSLA(ctx, "pr1")
+		require.NoError(t, err)
+		assert.Equal(t, 4*time.Hour, sla.SLA)
+		assert.Equal(t, time.Date(2025, time.March, 10, 11, 0, 0, 0, berlin), sla.Reviewers[0].DueAt)
+	})
+
+	t.Run("error - PR not found", func(t *testing.T) {
+		service, _ := setup(nil, nil)
+
+		_, err := service.GetSLA(ctx, "pr1")
+		assert.True(t, apperror.Is(err, apperror.ErrCodePRNotFound), "got %v", err)
+	})
+}
@@ -0,0 +1,135 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/ssokov/pr-reviewer-service/internal/apperror"
+	"github.com/ssokov/pr-reviewer-service/internal/model/domain"
+)
+
+const maxTags = 20
+
+var tagRe = regexp.MustCompile(`^[a-z0-9][a-z0-9+#._-]{0,49}$`)
+
+// normalizeTags lowercases, validates, deduplicates and sorts skills or labels. field names them in errors.
+func normalizeTags(field string, raw []string) ([]string, error) {
+	if len(raw) > maxTags {
+		return nil, apperror.NewInvalidInputError(fmt.Sprintf("at most %d %s are allowed", maxTags, field))
+	}
+
+	tags := make([]string, 0, len(raw))
+	for _, tag := range raw {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if !tagRe.MatchString(tag) {
+			return nil, apperror.NewInvalidInputError(fmt.Sprintf("invalid %s entry %q: use up to 50 letters, digits and + # . _ -", field, tag))
+		}
+		tags = append(tags, tag)
+	}
+	slices.Sort(tags)
+	return slices.Compact(tags), nil
+}
+
+// skillMatch holds what skill-based selection needs about one PR: the skills of the candidates and the labels
+// that must be covered. A label is required when it names a skill somebody in the organization has; other
+// labels only add to the score.
+type skillMatch struct {
+	labels   []string
+	required []string
+	skills   map[string][]string
+}
+
+func (s *prService) loadSkillMatch(ctx context.Context, labels []string, userIDs []string) (*skillMatch, error) {
+	if len(labels) == 0 {
+		return &skillMatch{}, nil
+	}
+
+	known, err := s.skillRepo.ListSkills(ctx)
+	if err != nil {
+		return nil, apperror.NewInternalError("failed to list skills", err)
+	}
+	skills, err := s.skillRepo.GetSkillsByUserIDs(ctx, userIDs)
+	if err != nil {
+		return nil, apperror.NewInternalError("failed to get reviewer skills", err)
+	}
+
+	m := &skillMatch{labels: labels, skills: skills}
+	for _, label := range labels {
+		if slices.Contains(known, label) {
+			m.required = append(m.required, label)
+		}
+	}
+	return m, nil
+}
+
+func (m *skillMatch) has(userID, skill string) bool {
+	return slices.Contains(m.skills[userID], skill)
+}
+
+// score is the number of PR labels the user has as skills.
+func (m *skillMatch) score(userID string) int {
+	n := 0
+	for _, label := range m.labels {
+		if m.has(userID, label) {
+			n++
+		}
+	}
+	return n
+}
+
+// rank orders candidates by score, keeping the team order on ties.
+func (m *skillMatch) rank(candidates []string) []string {
+	ranked := slices.Clone(candidates)
+	slices.SortStableFunc(ranked, func(a, b string) int {
+		return m.score(b) - m.score(a)
+	})
+	return ranked
+}
+
+// uncovered returns the required labels none of reviewers has.
+func (m *skillMatch) uncovered(reviewers []string) []string {
+	var missing []string
+	for _, label := range m.required {
+		if !slices.ContainsFunc(reviewers, func(userID string) bool { return m.has(userID, label) }) {
+			missing = append(missing, label)
+		}
+	}
+	return missing
+}
+
+// coverSkills adds a reviewer for every required label the selection does not cover yet, taking the best scored
+// candidate with the skill, and returns the labels no candidate has. These reviewers are mandatory and may exceed
+// the reviewer count.
+func (m *skillMatch) coverSkills(selections []domain.ReviewerSelection, candidates []string) ([]domain.ReviewerSelection, []string) {
+	ranked := m.rank(candidates)
+	var missing []string
+	for _, label := range m.uncovered(selectedUserIDs(selections)) {
+		if slices.ContainsFunc(selections, func(sel domain.ReviewerSelection) bool { return m.has(sel.UserID, label) }) {
+			continue
+		}
+		idx := slices.IndexFunc(ranked, func(userID string) bool {
+			return m.has(userID, label) && !isSelected(selections, userID)
+		})
+		if idx < 0 {
+			missing = append(missing, label)
+			continue
+		}
+		selections = append(selections, domain.ReviewerSelection{UserID: ranked[idx], Rule: domain.SelectionRuleSkill, Skill: label})
+	}
+	return selections, missing
+}
+
+func selectedUserIDs(selections []domain.ReviewerSelection) []string {
+	ids := make([]string, len(selections))
+	for i, sel := range selections {
+		ids[i] = sel.UserID
+	}
+	return ids
+}
+
+func isSelected(selections []domain.ReviewerSelection, userID string) bool {
+	return slices.ContainsFunc(selections, func(sel domain.ReviewerSelection) bool { return sel.UserID == userID })
+}
@@ -0,0 +1,137 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/ssokov/pr-reviewer-service/internal/apperror"
+	"github.com/ssokov/pr-reviewer-service/internal/model/domain"
+	"github.com/ssokov/pr-reviewer-service/internal/tenant"
+	"github.com/ssokov/pr-reviewer-service/internal/tracing"
+	"github.com/ssokov/pr-reviewer-service/internal/workhours"
+)
+
+// Availability tiers of reviewers, best first.
+const (
+	availableNow = iota
+	availableSoon
+	availableLater
+)
+
+// availability returns the availability tier of each of users: within their working hours, starting them within
+// the organization's availability window, or later. Users without working hours count as available.
+func (s *prService) availability(ctx context.Context, users []domain.User) map[string]int {
+	now := s.now()
+	window := tenant.Settings(ctx).AvailabilityWindow()
+
+	tiers := make(map[string]int, len(users))
+	for i := range users {
+		schedule, ok := userSchedule(&users[i])
+		switch {
+		case !ok || schedule.Contains(now):
+			tiers[users[i].UserID] = availableNow
+		case schedule.NextStart(now).Sub(now) <= window:
+			tiers[users[i].UserID] = availableSoon
+		default:
+			tiers[users[i].UserID] = availableLater
+		}
+	}
+	return tiers
+}
+
+// userSchedule returns the working hours of user; ok is false when none are set.
+func userSchedule(user *domain.User) (schedule workhours.Schedule, ok bool) {
+	if !user.HasWorkingHours() {
+		return workhours.Schedule{}, false
+	}
+	schedule, err := workhours.New(user.Timezone, user.WorkStart, user.WorkEnd)
+	return schedule, err == nil
+}
+
+// reviewSLA returns the review deadline of PRs by authorID, inherited through the author's team and its parent
+// teams like reviewer_count.
+func (s *prService) reviewSLA(ctx context.Context, authorID string) (time.Duration, error) {
+	author, err := s.userRepo.GetByUserID(ctx, authorID)
+	if err != nil {
+		tracing.Logger(ctx, s.logger).Errorf("failed to get author: %v", err)
+		return 0, apperror.NewInternalError("failed to get author", err)
+	}
+	if author == nil {
+		return tenant.Settings(ctx).ReviewSLA(), nil
+	}
+
+	ancestors, err := s.teamAncestors(ctx, author.TeamID)
+	if err != nil {
+		return 0, err
+	}
+	sla, _ := resolveReviewSLA(ctx, ancestors)
+	return sla, nil
+}
+
+// GetSLA starts each reviewer's timer when the reviewer is assigned and stops it when the PR is merged; the deadline
+// is the review SLA of the author's team.
+func (s *prService) GetSLA(ctx context.Context, prID string) (*domain.ReviewSLA, error) {
+	if prID == "" {
+		return nil, apperror.NewInvalidInputError("pull_request_id is required")
+	}
+
+	pr, err := s.prRepo.GetByPRID(ctx, prID)
+	if err != nil {
+		tracing.Logger(ctx, s.logger).Errorf("failed to get PR: %v", err)
+		return nil, apperror.NewInternalError("failed to get PR", err)
+	}
+	if pr == nil {
+		s.logger.Print(ctx, "PR not found", "pr_id", prID)
+		return nil, apperror.NewPRNotFoundError(prID)
+	}
+
+	sla, err := s.reviewSLA(ctx, pr.AuthorID)
+	if err != nil {
+		return nil, err
+	}
+	stop := s.now()
+	if pr.MergedAt != nil {
+		stop = *pr.MergedAt
+	}
+
+	assignedAt, err := s.prRepo.GetReviewerAssignedAt(ctx, pr.PullRequestID)
+	if err != nil {
+		tracing.Logger(ctx, s.logger).Errorf("failed to get reviewer assignment times: %v", err)
+		return nil, apperror.NewInternalError("failed to get reviewer assignment times", err)
+	}
+
+	result := &domain.ReviewSLA{
+		PullRequestID: pr.PullRequestID,
+		Status:        pr.Status,
+		SLA:           sla,
+		Reviewers:     make([]domain.ReviewerSLA, 0, len(pr.AssignedReviewers)),
+	}
+	for _, reviewerID := range pr.AssignedReviewers {
+		reviewer, err := s.userRepo.GetByUserID(ctx, reviewerID)
+		if err != nil {
+			tracing.Logger(ctx, s.logger).Errorf("failed to get reviewer: %v", err)
+			return nil, apperror.NewInternalError("failed to get reviewer", err)
+		}
+
+		start, ok := assignedAt[reviewerID]
+		if !ok {
+			start = pr.CreatedAt
+		}
+		timer := domain.ReviewerSLA{
+			UserID:  reviewerID,
+			Elapsed: stop.Sub(start),
+			DueAt:   start.Add(sla),
+		}
+		if reviewer != nil {
+			if schedule, ok := userSchedule(reviewer); ok {
+				timer.Timezone = reviewer.Timezone
+				timer.Elapsed = schedule.Between(start, stop)
+				timer.DueAt = schedule.Add(start, sla)
+			}
+		}
+		timer.Overdue = timer.Elapsed > sla
+		result.Reviewers = append(result.Reviewers, timer)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,236 @@
+package service
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/ssokov/pr-reviewer-service/internal/apperror"
+	"github.com/ssokov/pr-reviewer-service/internal/auth"
+	"github.com/ssokov/pr-reviewer-service/internal/dryrun"
+	"github.com/ssokov/pr-reviewer-service/internal/model/domain"
+	"github.com/ssokov/pr-reviewer-service/internal/repository"
+	"github.com/ssokov/pr-reviewer-service/internal/tracing"
+	"github.com/vmkteam/embedlog"
+)
+
+type rosterService struct {
+	teamRepo   repository.TeamRepository
+	userRepo   repository.UserRepository
+	transactor repository.Transactor
+	logger     embedlog.Logger
+}
+
+func NewRosterService(teamRepo repository.TeamRepository, userRepo repository.UserRepository, transactor repository.Transactor, logger embedlog.Logger) RosterService {
+	return &rosterService{
+		teamRepo:   teamRepo,
+		userRepo:   userRepo,
+		transactor: transactor,
+		logger:     logger,
+	}
+}
+
+// rosterPlan is what applying a roster does to one user. existing is nil for users that will be created.
+type rosterPlan struct {
+	entry    domain.RosterEntry
+	existing *domain.User
+	changed  bool
+}
+
+// ImportRoster diffs the roster against the current teams and, when every line is valid, including the ones
+// the parser rejected, applies the creates,
+// moves and activations in one transaction. Users missing from the roster are left as they are. The diff is
+// computed before anything is written, so a dry run only skips applying it.
+func (s *rosterService) ImportRoster(ctx context.Context, roster *domain.Roster) (*domain.RosterImportResult, error) {
+	if err := authorizeAdmin(ctx); err != nil {
+		s.logger.Print(ctx, "roster import denied", "actor", auth.Actor(ctx))
+		return nil, err
+	}
+	if len(roster.Entries) == 0 && len(roster.Errors) == 0 {
+		return nil, apperror.NewInvalidInputError("roster is empty")
+	}
+
+	entries := roster.Entries
+	s.logger.Print(ctx, "importing roster", "entries", len(entries))
+
+	teams, err := s.teamRepo.List(ctx)
+	if err != nil {
+		tracing.Logger(ctx, s.logger).Errorf("failed to list teams: %v", err)
+		return nil, apperror.NewInternalError("failed to list teams", err)
+	}
+
+	lineErrors := append(slices.Clone(roster.Errors), validateRoster(entries)...)
+	slices.SortStableFunc(lineErrors, func(a, b domain.RosterLineError) int { return cmp.Compare(a.Line, b.Line) })
+	result := &domain.RosterImportResult{Changes: []domain.RosterChange{}, Errors: lineErrors}
+	invalid := make(map[int]bool, len(result.Errors))
+	for _, e := range result.Errors {
+		invalid[e.Line] = true
+	}
+
+	existingTeams := make(map[string]bool, len(teams))
+	members := make(map[string]*domain.User)
+	for i := range teams {
+		existingTeams[teams[i].TeamName] = true
+		for j := range teams[i].Members {
+			members[teams[i].Members[j].UserID] = &teams[i].Members[j]
+		}
+	}
+
+	newTeams := []string{}
+	plans := make([]rosterPlan, 0, len(entries))
+	for _, entry := range entries {
+		if invalid[entry.Line] {
+			continue
+		}
+
+		if !existingTeams[entry.TeamName] {
+			existingTeams[entry.TeamName] = true
+			newTeams = append(newTeams, entry.TeamName)
+			result.Changes = append(result.Changes, domain.RosterChange{Line: entry.Line, Action: domain.RosterActionCreateTeam, TeamName: entry.TeamName})
+		}
+
+		existing := members[entry.UserID]
+		if existing == nil {
+			// Users outside of any team are not in the team listing.
+			existing, err = s.userRepo.GetByUserID(ctx, entry.UserID)
+			if err != nil {
+				tracing.Logger(ctx, s.logger).Errorf("failed to get user: %v", err)
+				return nil, apperror.NewInternalError("failed to get user", err)
+			}
+		}
+
+		changes := diffRosterEntry(entry, existing)
+		result.Changes = append(result.Changes, changes...)
+		plans = append(plans, rosterPlan{entry: entry, existing: existing, changed: len(changes) > 0})
+	}
+
+	if len(result.Errors) > 0 || dryrun.Enabled(ctx) {
+		s.logger.Print(ctx, "roster not applied", "changes", len(result.Changes), "errors", len(result.Errors), "dry_run", dryrun.Enabled(ctx))
+		return result, nil
+	}
+
+	err = s.transactor.WithinTx(ctx, func(ctx context.Context) error {
+		return s.applyRoster(ctx, teams, newTeams, plans)
+	})
+	if err != nil {
+		tracing.Logger(ctx, s.logger).Errorf("failed to apply roster: %v", err)
+		return nil, apperror.NewInternalError("failed to apply roster", err)
+	}
+
+	result.Applied = true
+	s.logger.Print(ctx, "roster imported", "changes", len(result.Changes))
+	return result, nil
+}
+
+func (s *rosterService) applyRoster(ctx context.Context, teams []domain.Team, newTeams []string, plans []rosterPlan) error {
+	teamIDs := make(map[string]int64, len(teams)+len(newTeams))
+	for _, t := range teams {
+		teamIDs[t.TeamName] = t.ID
+	}
+	for _, name := range newTeams {
+		created, err := s.teamRepo.Create(ctx, &domain.Team{TeamName: name})
+		if err != nil {
+			return fmt.Errorf("create team %s: %w", name, err)
+		}
+		teamIDs[name] = created.ID
+	}
+
+	for _, p := range plans {
+		if !p.changed {
+			continue
+		}
+
+		user := &domain.User{
+			UserID:   p.entry.UserID,
+			Username: p.entry.Username,
+			IsActive: p.entry.IsActive,
+			TeamID:   teamIDs[p.entry.TeamName],
+		}
+		var err error
+		if p.existing == nil {
+			_, err = s.userRepo.Create(ctx, user)
+		} else {
+			_, err = s.userRepo.Update(ctx, user)
+		}
+		if err != nil {
+			return fmt.Errorf("line %d: save user %s: %w", p.entry.Line, p.entry.UserID, err)
+		}
+	}
+	return nil
+}
+
+func (s *rosterService) ExportRoster(ctx context.Context) ([]domain.RosterEntry, error) {
+	teams, err := s.teamRepo.List(ctx)
+	if err != nil {
+		tracing.Logger(ctx, s.logger).Errorf("failed to list teams: %v", err)
+		return nil, apperror.NewInternalError("failed to list teams", err)
+	}
+
+	entries := []domain.RosterEntry{}
+	for _, t := range teams {
+		for _, m := range t.Members {
+			entries = append(entries, domain.RosterEntry{
+				TeamName: t.TeamName,
+				UserID:   m.UserID,
+				Username: m.Username,
+				IsActive: m.IsActive,
+			})
+		}
+	}
+
+	s.logger.Print(ctx, "roster exported", "teams", len(teams), "users", len(entries))
+	return entries, nil
+}
+
+// validateRoster checks required fields and that every user is listed once.
+func validateRoster(entries []domain.RosterEntry) []domain.RosterLineError {
+	lineErrors := []domain.RosterLineError{}
+	seen := make(map[string]int, len(entries))
+	for _, e := range entries {
+		switch {
+		case e.TeamName == "":
+			lineErrors = append(lineErrors, domain.RosterLineError{Line: e.Line, Message: "team_name is required"})
+		case e.UserID == "":
+			lineErrors = append(lineErrors, domain.RosterLineError{Line: e.Line, Message: "user_id is required"})
+		case e.Username == "":
+			lineErrors = append(lineErrors, domain.RosterLineError{Line: e.Line, Message: "username is required"})
+		default:
+			if first, ok := seen[e.UserID]; ok {
+				lineErrors = append(lineErrors, domain.RosterLineError{
+					Line:    e.Line,
+					Message: fmt.Sprintf("user '%s' is already listed on line %d", e.UserID, first),
+				})
+				continue
+			}
+			seen[e.UserID] = e.Line
+		}
+	}
+	return lineErrors
+}
+
+func diffRosterEntry(entry domain.RosterEntry, existing *domain.User) []domain.RosterChange {
+	change := func(action domain.RosterAction, from, to string) domain.RosterChange {
+		return domain.RosterChange{Line: entry.Line, Action: action, TeamName: entry.TeamName, UserID: entry.UserID, From: from, To: to}
+	}
+
+	if existing == nil {
+		return []domain.RosterChange{change(domain.RosterActionCreateUser, "", entry.Username)}
+	}
+
+	var changes []domain.RosterChange
+	if existing.TeamName != entry.TeamName {
+		changes = append(changes, change(domain.RosterActionMoveUser, existing.TeamName, entry.TeamName))
+	}
+	if existing.Username != entry.Username {
+		changes = append(changes, change(domain.RosterActionRenameUser, existing.Username, entry.Username))
+	}
+	if existing.IsActive != entry.IsActive {
+		action := domain.RosterActionDeactivateUser
+		if entry.IsActive {
+			action = domain.RosterActionActivateUser
+		}
+		changes = append(changes, change(action, "", ""))
+	}
+	return changes
+}
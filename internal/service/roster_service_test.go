@@ -0,0 +1,140 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ssokov/pr-reviewer-service/internal/apperror"
+	"github.com/ssokov/pr-reviewer-service/internal/dryrun"
+	"github.com/ssokov/pr-reviewer-service/internal/model/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/vmkteam/embedlog"
+)
+
+func rosterFixture() []domain.Team {
+	return []domain.Team{{
+		ID:       1,
+		TeamName: "backend",
+		Members: []domain.User{
+			{UserID: "u1", Username: "Alice", TeamID: 1, TeamName: "backend", IsActive: true},
+			{UserID: "u4", Username: "Dan", TeamID: 1, TeamName: "backend", IsActive: true},
+		},
+	}}
+}
+
+func TestRosterService_ImportRoster(t *testing.T) {
+	logger := embedlog.NewLogger(false, false)
+	entries := []domain.RosterEntry{
+		{Line: 2, TeamName: "backend", UserID: "u1", Username: "Alice B", IsActive: true},
+		{Line: 3, TeamName: "frontend", UserID: "u2", Username: "Bob", IsActive: true},
+		{Line: 4, TeamName: "backend", UserID: "u3", Username: "Carol", IsActive: false},
+		{Line: 5, TeamName: "backend", UserID: "u4", Username: "Dan", IsActive: true},
+	}
+	wantChanges := []domain.RosterChange{
+		{Line: 2, Action: domain.RosterActionRenameUser, TeamName: "backend", UserID: "u1", From: "Alice", To: "Alice B"},
+		{Line: 3, Action: domain.RosterActionCreateTeam, TeamName: "frontend"},
+		{Line: 3, Action: domain.RosterActionCreateUser, TeamName: "frontend", UserID: "u2", To: "Bob"},
+		{Line: 4, Action: domain.RosterActionMoveUser, TeamName: "backend", UserID: "u3", From: "", To: "backend"},
+		{Line: 4, Action: domain.RosterActionDeactivateUser, TeamName: "backend", UserID: "u3"},
+	}
+
+	t.Run("applies the diff in one transaction", func(t *testing.T) {
+		ctx := context.Background()
+		mockTeamRepo := new(MockTeamRepository)
+		mockUserRepo := new(MockUserRepository)
+		transactor := new(MockTransactor)
+		service := NewRosterService(mockTeamRepo, mockUserRepo, transactor, logger)
+
+		mockTeamRepo.On("List", ctx).Return(rosterFixture(), nil)
+		mockUserRepo.On("GetByUserID", ctx, "u2").Return(nil, nil)
+		mockUserRepo.On("GetByUserID", ctx, "u3").Return(&domain.User{UserID: "u3", Username: "Carol", IsActive: true}, nil)
+		transactor.On("WithinTx", ctx).Return(nil).Once()
+		mockTeamRepo.On("Create", mock.Anything, &domain.Team{TeamName: "frontend"}).Return(&domain.Team{ID: 2, TeamName: "frontend"}, nil).Once()
+		mockUserRepo.On("Update", mock.Anything, &domain.User{UserID: "u1", Username: "Alice B", IsActive: true, TeamID: 1}).Return(&domain.User{}, nil).Once()
+		mockUserRepo.On("Create", mock.Anything, &domain.User{UserID: "u2", Username: "Bob", IsActive: true, TeamID: 2}).Return(&domain.User{}, nil).Once()
+		mockUserRepo.On("Update", mock.Anything, &domain.User{UserID: "u3", Username: "Carol", IsActive: false, TeamID: 1}).Return(&domain.User{}, nil).Once()
+
+		result, err := service.ImportRoster(ctx, &domain.Roster{Entries: entries})
+
+		require.NoError(t, err)
+		assert.True(t, result.Applied)
+		assert.Empty(t, result.Errors)
+		assert.Equal(t, wantChanges, result.Changes)
+		mockTeamRepo.AssertExpectations(t)
+		mockUserRepo.AssertExpectations(t)
+		transactor.AssertExpectations(t)
+	})
+
+	t.Run("dry run returns the diff only", func(t *testing.T) {
+		ctx := dryrun.With(context.Background())
+		mockTeamRepo := new(MockTeamRepository)
+		mockUserRepo := new(MockUserRepository)
+		transactor := new(MockTransactor)
+		service := NewRosterService(mockTeamRepo, mockUserRepo, transactor, logger)
+
+		mockTeamRepo.On("List", ctx).Return(rosterFixture(), nil)
+		mockUserRepo.On("GetByUserID", ctx, "u2").Return(nil, nil)
+		mockUserRepo.On("GetByUserID", ctx, "u3").Return(&domain.User{UserID: "u3", Username: "Carol", IsActive: true}, nil)
+
+		result, err := service.ImportRoster(ctx, &domain.Roster{Entries: entries})
+
+		require.NoError(t, err)
+		assert.False(t, result.Applied)
+		assert.Equal(t, wantChanges, result.Changes)
+		transactor.AssertNotCalled(t, "WithinTx", mock.Anything)
+	})
+
+	t.Run("line errors block the import", func(t *testing.T) {
+		ctx := context.Background()
+		mockTeamRepo := new(MockTeamRepository)
+		transactor := new(MockTransactor)
+		service := NewRosterService(mockTeamRepo, new(MockUserRepository), transactor, logger)
+
+		mockTeamRepo.On("List", ctx).Return(rosterFixture(), nil)
+
+		result, err := service.ImportRoster(ctx, &domain.Roster{
+			Entries: []domain.RosterEntry{
+				{Line: 2, TeamName: "backend", UserID: "u1", Username: "Alice", IsActive: true},
+				{Line: 4, TeamName: "backend", UserID: "u5"},
+				{Line: 5, TeamName: "frontend", UserID: "u1", Username: "Alice", IsActive: true},
+			},
+			Errors: []domain.RosterLineError{{Line: 3, Message: "is_active must be true or false"}},
+		})
+
+		require.NoError(t, err)
+		assert.False(t, result.Applied)
+		assert.Equal(t, []domain.RosterLineError{
+			{Line: 3, Message: "is_active must be true or false"},
+			{Line: 4, Message: "username is required"},
+			{Line: 5, Message: "user 'u1' is already listed on line 2"},
+		}, result.Errors)
+		assert.Empty(t, result.Changes)
+		transactor.AssertNotCalled(t, "WithinTx", mock.Anything)
+	})
+
+	t.Run("only admins can import", func(t *testing.T) {
+		service := NewRosterService(new(MockTeamRepository), new(MockUserRepository), new(MockTransactor), logger)
+
+		_, err := service.ImportRoster(userContext("lead", domain.RoleTeamLead), &domain.Roster{Entries: entries})
+
+		assert.True(t, apperror.Is(err, apperror.ErrCodeForbidden))
+	})
+}
+
+func TestRosterService_ExportRoster(t *testing.T) {
+	ctx := context.Background()
+	mockTeamRepo := new(MockTeamRepository)
+	service := NewRosterService(mockTeamRepo, new(MockUserRepository), new(MockTransactor), embedlog.NewLogger(false, false))
+
+	mockTeamRepo.On("List", ctx).Return(rosterFixture(), nil)
+
+	entries, err := service.ExportRoster(ctx)
+
+	require.NoError(t, err)
+	assert.Equal(t, []domain.RosterEntry{
+		{TeamName: "backend", UserID: "u1", Username: "Alice", IsActive: true},
+		{TeamName: "backend", UserID: "u4", Username: "Dan", IsActive: true},
+	}, entries)
+}
@@ -0,0 +1,118 @@
+package service
+
+import (
+	"context"
+
+	"github.com/ssokov/pr-reviewer-service/internal/apperror"
+	"github.com/ssokov/pr-reviewer-service/internal/auth"
+	"github.com/ssokov/pr-reviewer-service/internal/model/domain"
+	"github.com/ssokov/pr-reviewer-service/internal/repository"
+	"github.com/ssokov/pr-reviewer-service/internal/tracing"
+	"github.com/vmkteam/embedlog"
+)
+
+type skillService struct {
+	skillRepo repository.SkillRepository
+	userRepo  repository.UserRepository
+	prRepo    repository.PRRepository
+	logger    embedlog.Logger
+}
+
+func NewSkillService(skillRepo repository.SkillRepository, userRepo repository.UserRepository, prRepo repository.PRRepository, logger embedlog.Logger) SkillService {
+	return &skillService{
+		skillRepo: skillRepo,
+		userRepo:  userRepo,
+		prRepo:    prRepo,
+		logger:    logger,
+	}
+}
+
+// SetUserSkills replaces the skills of a user. Users may set their own skills; for others the team lead rules of
+// SetIsActive apply.
+func (s *skillService) SetUserSkills(ctx context.Context, userID string, skills []string) ([]string, error) {
+	if userID == "" {
+		return nil, apperror.NewInvalidInputError("user_id is required")
+	}
+	skills, err := normalizeTags("skills", skills)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.userRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		tracing.Logger(ctx, s.logger).Errorf("failed to get user: %v", err)
+		return nil, apperror.NewInternalError("failed to get user", err)
+	}
+	if user == nil {
+		return nil, apperror.NewUserNotFoundError(userID)
+	}
+
+	if p := auth.FromContext(ctx); requiresUserAuthorization(ctx) && p.UserID != userID {
+		if err := authorizeTeamLead(ctx, s.userRepo, user.TeamID); err != nil {
+			s.logger.Print(ctx, "set skills denied", "user_id", userID, "actor", auth.Actor(ctx))
+			return nil, err
+		}
+	}
+
+	if err := s.skillRepo.SetUserSkills(ctx, userID, skills); err != nil {
+		tracing.Logger(ctx, s.logger).Errorf("failed to set user skills: %v", err)
+		return nil, apperror.NewInternalError("failed to set user skills", err)
+	}
+
+	s.logger.Print(ctx, "user skills updated", "user_id", userID, "skills", skills)
+	return skills, nil
+}
+
+func (s *skillService) GetUserSkills(ctx context.Context, userID string) ([]string, error) {
+	if userID == "" {
+		return nil, apperror.NewInvalidInputError("user_id is required")
+	}
+
+	user, err := s.userRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		tracing.Logger(ctx, s.logger).Errorf("failed to get user: %v", err)
+		return nil, apperror.NewInternalError("failed to get user", err)
+	}
+	if user == nil {
+		return nil, apperror.NewUserNotFoundError(userID)
+	}
+
+	skills, err := s.skillRepo.GetSkillsByUserIDs(ctx, []string{userID})
+	if err != nil {
+		tracing.Logger(ctx, s.logger).Errorf("failed to get user skills: %v", err)
+		return nil, apperror.NewInternalError("failed to get user skills", err)
+	}
+	return skills[userID], nil
+}
+
+// SetPRLabels replaces the labels of an open PR. Assigned reviewers stay; the labels steer later reassignments.
+func (s *skillService) SetPRLabels(ctx context.Context, prID string, labels []string) (*domain.PullRequest, error) {
+	if prID == "" {
+		return nil, apperror.NewInvalidInputError("pull_request_id is required")
+	}
+	labels, err := normalizeTags("labels", labels)
+	if err != nil {
+		return nil, err
+	}
+
+	pr, err := s.prRepo.GetByPRID(ctx, prID)
+	if err != nil {
+		tracing.Logger(ctx, s.logger).Errorf("failed to get PR: %v", err)
+		return nil, apperror.NewInternalError("failed to get PR", err)
+	}
+	if pr == nil {
+		return nil, apperror.NewPRNotFoundError(prID)
+	}
+	if pr.Status == domain.PRStatusMerged {
+		return nil, apperror.NewPRMergedError(prID)
+	}
+
+	if err := s.skillRepo.SetPRLabels(ctx, prID, labels); err != nil {
+		tracing.Logger(ctx, s.logger).Errorf("failed to set PR labels: %v", err)
+		return nil, apperror.NewInternalError("failed to set PR labels", err)
+	}
+
+	s.logger.Print(ctx, "PR labels updated", "pr_id", prID, "labels", labels)
+	pr.Labels = labels
+	return pr, nil
+}
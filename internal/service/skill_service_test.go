@@ -0,0 +1,92 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ssokov/pr-reviewer-service/internal/apperror"
+	"github.com/ssokov/pr-reviewer-service/internal/model/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/vmkteam/embedlog"
+)
+
+func TestSkillService_SetUserSkills(t *testing.T) {
+	ctx := context.Background()
+	logger := embedlog.NewLogger(false, false)
+
+	t.Run("success - skills are normalized", func(t *testing.T) {
+		mockSkillRepo := new(MockSkillRepository)
+		mockUserRepo := new(MockUserRepository)
+		service := NewSkillService(mockSkillRepo, mockUserRepo, new(MockPRRepository), logger)
+
+		mockUserRepo.On("GetByUserID", ctx, "u1").Return(&domain.User{UserID: "u1", TeamID: 1}, nil)
+		mockSkillRepo.On("SetUserSkills", ctx, "u1", []string{"go", "security"}).Return(nil)
+
+		skills, err := service.SetUserSkills(ctx, "u1", []string{" Security", "go", "GO"})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"go", "security"}, skills)
+		mockSkillRepo.AssertExpectations(t)
+	})
+
+	t.Run("error - invalid skill", func(t *testing.T) {
+		service := NewSkillService(new(MockSkillRepository), new(MockUserRepository), new(MockPRRepository), logger)
+
+		_, err := service.SetUserSkills(ctx, "u1", []string{"go lang"})
+		assert.True(t, apperror.Is(err, apperror.ErrCodeInvalidInput))
+	})
+
+	t.Run("success - members set their own skills", func(t *testing.T) {
+		memberCtx := userContext("u1", domain.RoleMember)
+		mockSkillRepo := new(MockSkillRepository)
+		mockUserRepo := new(MockUserRepository)
+		service := NewSkillService(mockSkillRepo, mockUserRepo, new(MockPRRepository), logger)
+
+		mockUserRepo.On("GetByUserID", memberCtx, "u1").Return(&domain.User{UserID: "u1", TeamID: 1}, nil)
+		mockSkillRepo.On("SetUserSkills", memberCtx, "u1", []string{"sql"}).Return(nil)
+
+		_, err := service.SetUserSkills(memberCtx, "u1", []string{"sql"})
+		assert.NoError(t, err)
+	})
+
+	t.Run("error - members cannot set skills of others", func(t *testing.T) {
+		memberCtx := userContext("u1", domain.RoleMember)
+		mockUserRepo := new(MockUserRepository)
+		service := NewSkillService(new(MockSkillRepository), mockUserRepo, new(MockPRRepository), logger)
+
+		mockUserRepo.On("GetByUserID", memberCtx, "u2").Return(&domain.User{UserID: "u2", TeamID: 1}, nil)
+
+		_, err := service.SetUserSkills(memberCtx, "u2", []string{"sql"})
+		assert.True(t, apperror.Is(err, apperror.ErrCodeForbidden))
+	})
+}
+
+func TestSkillService_SetPRLabels(t *testing.T) {
+	ctx := context.Background()
+	logger := embedlog.NewLogger(false, false)
+
+	t.Run("success", func(t *testing.T) {
+		mockSkillRepo := new(MockSkillRepository)
+		mockPRRepo := new(MockPRRepository)
+		service := NewSkillService(mockSkillRepo, new(MockUserRepository), mockPRRepo, logger)
+
+		mockPRRepo.On("GetByPRID", ctx, "pr1").Return(&domain.PullRequest{PullRequestID: "pr1", Status: domain.PRStatusOpen}, nil)
+		mockSkillRepo.On("SetPRLabels", ctx, "pr1", []string{"security"}).Return(nil)
+
+		pr, err := service.SetPRLabels(ctx, "pr1", []string{"Security"})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"security"}, pr.Labels)
+	})
+
+	t.Run("error - merged PR", func(t *testing.T) {
+		mockSkillRepo := new(MockSkillRepository)
+		mockPRRepo := new(MockPRRepository)
+		service := NewSkillService(mockSkillRepo, new(MockUserRepository), mockPRRepo, logger)
+
+		mockPRRepo.On("GetByPRID", ctx, "pr1").Return(&domain.PullRequest{PullRequestID: "pr1", Status: domain.PRStatusMerged}, nil)
+
+		_, err := service.SetPRLabels(ctx, "pr1", []string{"security"})
+		assert.True(t, apperror.Is(err, apperror.ErrCodePRMerged))
+		mockSkillRepo.AssertNotCalled(t, "SetPRLabels", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
@@ -2,14 +2,25 @@ package service
 
 import (
 	"context"
+	"math"
+	"time"
 
+	"github.com/ssokov/pr-reviewer-service/internal/apperror"
+	"github.com/ssokov/pr-reviewer-service/internal/model/domain"
 	"github.com/ssokov/pr-reviewer-service/internal/model/dto"
 	"github.com/ssokov/pr-reviewer-service/internal/repository"
+	"github.com/ssokov/pr-reviewer-service/internal/tenant"
 	"github.com/vmkteam/embedlog"
 )
 
 type StatsService interface {
 	GetStats(ctx context.Context) (*dto.StatsResponse, error)
+	// GetTeamStats returns per-team counts rolled up over each subtree, for the subtree rooted at teamName or for
+	// every root team when teamName is empty.
+	GetTeamStats(ctx context.Context, teamName string) (*dto.TeamStatsResponse, error)
+	// GetPairings returns the author-reviewer matrix of a team over the last days, or over the organization's
+	// pairing window when days is 0.
+	GetPairings(ctx context.Context, teamName string, days int) (*dto.PairingsResponse, error)
 }
 
 type statsService struct {
@@ -51,7 +62,7 @@ func (s *statsService) GetStats(ctx context.Context) (*dto.StatsResponse, error)
 		return nil, err
 	}
 
-	topReviewers, err := s.statsRepo.GetTopReviewers(ctx, 10)
+	topReviewers, err := s.statsRepo.GetTopReviewers(ctx, tenant.Settings(ctx).TopReviewers())
 	if err != nil {
 		s.logger.Print(ctx, "failed to get top reviewers", "error", err)
 		return nil, err
@@ -84,3 +95,106 @@ func (s *statsService) GetStats(ctx context.Context) (*dto.StatsResponse, error)
 		TopReviewers: topReviewersDTO,
 	}, nil
 }
+
+func (s *statsService) GetTeamStats(ctx context.Context, teamName string) (*dto.TeamStatsResponse, error) {
+	teams, err := s.statsRepo.GetTeamStats(ctx)
+	if err != nil {
+		s.logger.Print(ctx, "failed to get team stats", "error", err)
+		return nil, apperror.NewInternalError("failed to get team stats", err)
+	}
+
+	children := make(map[int64][]domain.TeamStats)
+	var roots []domain.TeamStats
+	for _, team := range teams {
+		if team.ParentID != 0 {
+			children[team.ParentID] = append(children[team.ParentID], team)
+		}
+		if (teamName == "" && team.ParentID == 0) || team.TeamName == teamName {
+			roots = append(roots, team)
+		}
+	}
+	if teamName != "" && len(roots) == 0 {
+		return nil, apperror.NewTeamNotFoundError(teamName)
+	}
+
+	var build func(team domain.TeamStats, level int) dto.TeamStatsNode
+	build = func(team domain.TeamStats, level int) dto.TeamStatsNode {
+		own := dto.TeamStatsCounts{
+			Members:       team.Members,
+			ActiveMembers: team.ActiveMembers,
+			OpenPRs:       team.OpenPRs,
+			MergedPRs:     team.MergedPRs,
+		}
+		node := dto.TeamStatsNode{TeamName: team.TeamName, Own: own, Subtree: own, Children: []dto.TeamStatsNode{}}
+		if level >= domain.MaxTeamDepth {
+			return node
+		}
+		for _, child := range children[team.TeamID] {
+			childNode := build(child, level+1)
+			node.Subtree.Members += childNode.Subtree.Members
+			node.Subtree.ActiveMembers += childNode.Subtree.ActiveMembers
+			node.Subtree.OpenPRs += childNode.Subtree.OpenPRs
+			node.Subtree.MergedPRs += childNode.Subtree.MergedPRs
+			node.Children = append(node.Children, childNode)
+		}
+		return node
+	}
+
+	nodes := make([]dto.TeamStatsNode, 0, len(roots))
+	for _, root := range roots {
+		nodes = append(nodes, build(root, 1))
+	}
+	return &dto.TeamStatsResponse{Teams: nodes}, nil
+}
+
+func (s *statsService) GetPairings(ctx context.Context, teamName string, days int) (*dto.PairingsResponse, error) {
+	if teamName == "" {
+		return nil, apperror.NewInvalidInputError("team_name is required")
+	}
+	if days < 0 {
+		return nil, apperror.NewInvalidInputError("days must not be negative")
+	}
+
+	window := tenant.Settings(ctx).PairingWindow()
+	if days > 0 {
+		window = time.Duration(days) * 24 * time.Hour
+	}
+
+	pairings, err := s.statsRepo.GetTeamPairings(ctx, teamName, time.Now().Add(-window))
+	if err != nil {
+		s.logger.Print(ctx, "failed to get pairings", "error", err)
+		return nil, apperror.NewInternalError("failed to get pairings", err)
+	}
+	if pairings == nil {
+		return nil, apperror.NewTeamNotFoundError(teamName)
+	}
+
+	rows := make(map[string]*dto.PairingRow, len(pairings.Members))
+	resp := &dto.PairingsResponse{TeamName: pairings.TeamName, WindowDays: int(window.Hours() / 24), Authors: make([]dto.PairingRow, 0, len(pairings.Members))}
+	for _, userID := range pairings.Members {
+		resp.Authors = append(resp.Authors, dto.PairingRow{AuthorID: userID, Reviewers: map[string]int{}})
+	}
+	for i := range resp.Authors {
+		rows[resp.Authors[i].AuthorID] = &resp.Authors[i]
+	}
+
+	for _, pairing := range pairings.Pairings {
+		row, ok := rows[pairing.AuthorID]
+		if !ok {
+			continue
+		}
+		row.Reviewers[pairing.ReviewerID] = pairing.Count
+		row.Reviews += pairing.Count
+	}
+	for i := range resp.Authors {
+		row := &resp.Authors[i]
+		top := 0
+		for _, count := range row.Reviewers {
+			top = max(top, count)
+		}
+		if row.Reviews > 0 {
+			row.TopShare = math.Round(float64(top)/float64(row.Reviews)*100) / 100
+		}
+	}
+	return resp, nil
+}
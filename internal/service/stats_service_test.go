@@ -4,9 +4,15 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
+	"github.com/ssokov/pr-reviewer-service/internal/apperror"
 	"github.com/ssokov/pr-reviewer-service/internal/model/domain"
+	"github.com/ssokov/pr-reviewer-service/internal/model/dto"
+	"github.com/ssokov/pr-reviewer-service/internal/tenant"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 	"github.com/vmkteam/embedlog"
 )
 
@@ -145,4 +151,134 @@ func TestStatsService_GetStats(t *testing.T) {
 
 		mockStatsRepo.AssertExpectations(t)
 	})
+
+	t.Run("success - organization top reviewers limit", func(t *testing.T) {
+		mockStatsRepo := new(MockStatsRepository)
+		service := NewStatsService(mockStatsRepo, logger)
+
+		orgCtx := tenant.WithOrganization(ctx, &domain.Organization{
+			ID:       2,
+			Slug:     "acme",
+			Settings: domain.OrganizationSettings{TopReviewersLimit: 3},
+		})
+
+		mockStatsRepo.On("GetTotalPRs", orgCtx).Return(0, nil)
+		mockStatsRepo.On("GetTotalUsers", orgCtx).Return(0, nil)
+		mockStatsRepo.On("GetActiveUsers", orgCtx).Return(0, nil)
+		mockStatsRepo.On("GetPRsByStatus", orgCtx).Return(map[string]int{}, nil)
+		mockStatsRepo.On("GetTopReviewers", orgCtx, 3).Return([]domain.ReviewerStats{}, nil)
+
+		_, err := service.GetStats(orgCtx)
+		assert.NoError(t, err)
+
+		mockStatsRepo.AssertExpectations(t)
+	})
+}
+
+func TestStatsService_GetTeamStats(t *testing.T) {
+	ctx := context.Background()
+	logger := embedlog.NewLogger(false, false)
+
+	teams := []domain.TeamStats{
+		{TeamID: 1, ParentID: 3, TeamName: "backend", Members: 4, ActiveMembers: 3, OpenPRs: 2, MergedPRs: 5},
+		{TeamID: 2, TeamName: "design", Members: 2, ActiveMembers: 2},
+		{TeamID: 3, TeamName: "engineering", Members: 1, ActiveMembers: 1, MergedPRs: 1},
+		{TeamID: 4, ParentID: 1, TeamName: "payments", Members: 3, ActiveMembers: 2, OpenPRs: 1, MergedPRs: 4},
+	}
+
+	t.Run("success - rolled up per subtree", func(t *testing.T) {
+		mockStatsRepo := new(MockStatsRepository)
+		service := NewStatsService(mockStatsRepo, logger)
+		mockStatsRepo.On("GetTeamStats", ctx).Return(teams, nil)
+
+		result, err := service.GetTeamStats(ctx, "")
+		assert.NoError(t, err)
+		assert.Len(t, result.Teams, 2)
+
+		engineering := result.Teams[1]
+		assert.Equal(t, "engineering", engineering.TeamName)
+		assert.Equal(t, 1, engineering.Own.Members)
+		assert.Equal(t, dto.TeamStatsCounts{Members: 8, ActiveMembers: 6, OpenPRs: 3, MergedPRs: 10}, engineering.Subtree)
+		assert.Equal(t, dto.TeamStatsCounts{Members: 7, ActiveMembers: 5, OpenPRs: 3, MergedPRs: 9}, engineering.Children[0].Subtree)
+	})
+
+	t.Run("success - single subtree", func(t *testing.T) {
+		mockStatsRepo := new(MockStatsRepository)
+		service := NewStatsService(mockStatsRepo, logger)
+		mockStatsRepo.On("GetTeamStats", ctx).Return(teams, nil)
+
+		result, err := service.GetTeamStats(ctx, "payments")
+		assert.NoError(t, err)
+		assert.Len(t, result.Teams, 1)
+		assert.Equal(t, result.Teams[0].Own, result.Teams[0].Subtree)
+	})
+
+	t.Run("error - unknown team", func(t *testing.T) {
+		mockStatsRepo := new(MockStatsRepository)
+		service := NewStatsService(mockStatsRepo, logger)
+		mockStatsRepo.On("GetTeamStats", ctx).Return(teams, nil)
+
+		_, err := service.GetTeamStats(ctx, "ghost")
+		assert.True(t, apperror.Is(err, apperror.ErrCodeTeamNotFound))
+	})
+}
+
+func TestStatsService_GetPairings(t *testing.T) {
+	ctx := context.Background()
+	logger := embedlog.NewLogger(false, false)
+
+	t.Run("success - matrix per author", func(t *testing.T) {
+		mockStatsRepo := new(MockStatsRepository)
+		service := NewStatsService(mockStatsRepo, logger)
+		mockStatsRepo.On("GetTeamPairings", ctx, "backend", mock.Anything).Return(&domain.TeamPairings{
+			TeamName: "backend",
+			Members:  []string{"u1", "u2", "u3"},
+			Pairings: []domain.Pairing{
+				{AuthorID: "u1", ReviewerID: "u2", Count: 9},
+				{AuthorID: "u1", ReviewerID: "u3", Count: 1},
+				{AuthorID: "u2", ReviewerID: "u1", Count: 2},
+				{AuthorID: "u2", ReviewerID: "u3", Count: 2},
+			},
+		}, nil)
+
+		result, err := service.GetPairings(ctx, "backend", 0)
+		require.NoError(t, err)
+		assert.Equal(t, &dto.PairingsResponse{
+			TeamName:   "backend",
+			WindowDays: 30,
+			Authors: []dto.PairingRow{
+				{AuthorID: "u1", Reviews: 10, TopShare: 0.9, Reviewers: map[string]int{"u2": 9, "u3": 1}},
+				{AuthorID: "u2", Reviews: 4, TopShare: 0.5, Reviewers: map[string]int{"u1": 2, "u3": 2}},
+				{AuthorID: "u3", Reviewers: map[string]int{}},
+			},
+		}, result)
+	})
+
+	t.Run("success - custom window", func(t *testing.T) {
+		mockStatsRepo := new(MockStatsRepository)
+		service := NewStatsService(mockStatsRepo, logger)
+		mockStatsRepo.On("GetTeamPairings", ctx, "backend", mock.MatchedBy(func(since time.Time) bool {
+			return time.Since(since).Round(time.Hour) == 7*24*time.Hour
+		})).Return(&domain.TeamPairings{TeamName: "backend"}, nil)
+
+		result, err := service.GetPairings(ctx, "backend", 7)
+		require.NoError(t, err)
+		assert.Equal(t, 7, result.WindowDays)
+	})
+
+	t.Run("error - team not found", func(t *testing.T) {
+		mockStatsRepo := new(MockStatsRepository)
+		service := NewStatsService(mockStatsRepo, logger)
+		mockStatsRepo.On("GetTeamPairings", ctx, "ghost", mock.Anything).Return(nil, nil)
+
+		_, err := service.GetPairings(ctx, "ghost", 0)
+		assert.True(t, apperror.Is(err, apperror.ErrCodeTeamNotFound))
+	})
+
+	t.Run("error - negative days", func(t *testing.T) {
+		service := NewStatsService(new(MockStatsRepository), logger)
+
+		_, err := service.GetPairings(ctx, "backend", -1)
+		assert.True(t, apperror.Is(err, apperror.ErrCodeInvalidInput))
+	})
 }
@@ -3,17 +3,28 @@ package service
 import (
 	"context"
 
+	"github.com/ssokov/pr-reviewer-service/internal/apperror"
+	"github.com/ssokov/pr-reviewer-service/internal/auth"
 	"github.com/ssokov/pr-reviewer-service/internal/model/domain"
 )
 
 func (s *teamService) DeactivateTeam(ctx context.Context, teamName string) ([]domain.User, []domain.PullRequest, error) {
 	s.logger.Print(ctx, "deactivating team", "team_name", teamName)
 
+	if err := authorizeAdmin(ctx); err != nil {
+		s.logger.Print(ctx, "team deactivation denied", "team_name", teamName, "actor", auth.Actor(ctx))
+		return nil, nil, err
+	}
+
 	team, err := s.teamRepo.GetByName(ctx, teamName)
 	if err != nil {
 		s.logger.Print(ctx, "failed to get team", "error", err)
 		return nil, nil, err
 	}
+	if team == nil {
+		s.logger.Print(ctx, "team not found", "team_name", teamName)
+		return nil, nil, apperror.NewTeamNotFoundError(teamName)
+	}
 
 	deactivatedUsers, err := s.userRepo.DeactivateByTeamID(ctx, team.ID)
 	if err != nil {
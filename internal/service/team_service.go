@@ -6,6 +6,7 @@ import (
 	"github.com/ssokov/pr-reviewer-service/internal/apperror"
 	"github.com/ssokov/pr-reviewer-service/internal/model/domain"
 	"github.com/ssokov/pr-reviewer-service/internal/repository"
+	"github.com/ssokov/pr-reviewer-service/internal/tracing"
 	"github.com/vmkteam/embedlog"
 )
 
@@ -33,12 +34,17 @@ func (s *teamService) AddTeam(ctx context.Context, team *domain.Team) (*domain.T
 	if len(team.Members) == 0 {
 		return nil, apperror.NewInvalidInputError("team must have at least one member")
 	}
+	for _, member := range team.Members {
+		if member.TeamRole != "" && !domain.IsKnownTeamRole(member.TeamRole) {
+			return nil, apperror.NewInvalidInputError("team_role must be one of lead, senior, member, trainee")
+		}
+	}
 
 	s.logger.Print(ctx, "creating team", "team_name", team.TeamName, "members_count", len(team.Members))
 
 	exists, err := s.teamRepo.ExistsByName(ctx, team.TeamName)
 	if err != nil {
-		s.logger.Errorf("failed to check team existence: %v", err)
+		tracing.Logger(ctx, s.logger).Errorf("failed to check team existence: %v", err)
 		return nil, apperror.NewInternalError("failed to check team existence", err)
 	}
 	if exists {
@@ -48,7 +54,7 @@ func (s *teamService) AddTeam(ctx context.Context, team *domain.Team) (*domain.T
 
 	createdTeam, err := s.teamRepo.Create(ctx, team)
 	if err != nil {
-		s.logger.Errorf("failed to create team: %v", err)
+		tracing.Logger(ctx, s.logger).Errorf("failed to create team: %v", err)
 		return nil, apperror.NewInternalError("failed to create team", err)
 	}
 
@@ -61,7 +67,7 @@ func (s *teamService) GetTeam(ctx context.Context, teamName string) (*domain.Tea
 
 	team, err := s.teamRepo.GetByName(ctx, teamName)
 	if err != nil {
-		s.logger.Errorf("failed to get team from repository: %v", err)
+		tracing.Logger(ctx, s.logger).Errorf("failed to get team from repository: %v", err)
 		return nil, apperror.NewInternalError("failed to get team", err)
 	}
 	if team == nil {
@@ -78,6 +78,21 @@ func TestTeamService_AddTeam(t *testing.T) {
 		assert.True(t, apperror.Is(err, apperror.ErrCodeInvalidInput))
 	})
 
+	t.Run("error - unknown team role", func(t *testing.T) {
+		mockTeamRepo := new(MockTeamRepository)
+		service := NewTeamService(mockTeamRepo, new(MockUserRepository), new(MockPRRepository), logger)
+
+		team := &domain.Team{
+			TeamName: "Backend Team",
+			Members:  []domain.User{{UserID: "user1", TeamRole: "intern"}},
+		}
+
+		result, err := service.AddTeam(ctx, team)
+		assert.Nil(t, result)
+		assert.True(t, apperror.Is(err, apperror.ErrCodeInvalidInput))
+		mockTeamRepo.AssertNotCalled(t, "Create", ctx, team)
+	})
+
 	t.Run("error - team already exists", func(t *testing.T) {
 		mockTeamRepo := new(MockTeamRepository)
 		mockUserRepo := new(MockUserRepository)
@@ -0,0 +1,115 @@
+package service
+
+import (
+	"context"
+
+	"github.com/ssokov/pr-reviewer-service/internal/model/domain"
+	"github.com/ssokov/pr-reviewer-service/internal/tracing"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// The traced services open a span per call, so repository queries show up as its children.
+
+type tracedPRService struct {
+	next PRService
+}
+
+func NewTracedPRService(next PRService) PRService {
+	return &tracedPRService{next: next}
+}
+
+func (s *tracedPRService) CreatePR(ctx context.Context, authorID string, pr *domain.PullRequest) (created *domain.PullRequest, err error) {
+	ctx, span := tracing.Start(ctx, "PRService.CreatePR")
+	defer func() { tracing.End(span, err) }()
+	span.SetAttributes(attribute.String("pr.id", pr.PullRequestID), attribute.String("pr.author_id", authorID))
+
+	return s.next.CreatePR(ctx, authorID, pr)
+}
+
+func (s *tracedPRService) MergePR(ctx context.Context, prID string) (merged *domain.PullRequest, err error) {
+	ctx, span := tracing.Start(ctx, "PRService.MergePR")
+	defer func() { tracing.End(span, err) }()
+	span.SetAttributes(attribute.String("pr.id", prID))
+
+	return s.next.MergePR(ctx, prID)
+}
+
+func (s *tracedPRService) ReassignReviewer(ctx context.Context, prID string, oldUserID string) (updated *domain.PullRequest, newReviewerID string, err error) {
+	ctx, span := tracing.Start(ctx, "PRService.ReassignReviewer")
+	defer func() { tracing.End(span, err) }()
+	span.SetAttributes(attribute.String("pr.id", prID), attribute.String("pr.old_reviewer_id", oldUserID))
+
+	return s.next.ReassignReviewer(ctx, prID, oldUserID)
+}
+
+func (s *tracedPRService) AddReviewer(ctx context.Context, prID, userID string, pin bool) (updated *domain.PullRequest, err error) {
+	ctx, span := tracing.Start(ctx, "PRService.AddReviewer")
+	defer func() { tracing.End(span, err) }()
+	span.SetAttributes(attribute.String("pr.id", prID), attribute.String("pr.reviewer_id", userID), attribute.Bool("pr.pin", pin))
+
+	return s.next.AddReviewer(ctx, prID, userID, pin)
+}
+
+func (s *tracedPRService) RemoveReviewer(ctx context.Context, prID, userID string) (updated *domain.PullRequest, err error) {
+	ctx, span := tracing.Start(ctx, "PRService.RemoveReviewer")
+	defer func() { tracing.End(span, err) }()
+	span.SetAttributes(attribute.String("pr.id", prID), attribute.String("pr.reviewer_id", userID))
+
+	return s.next.RemoveReviewer(ctx, prID, userID)
+}
+
+func (s *tracedPRService) ListPRs(ctx context.Context, filter domain.PRFilter) (prs []domain.PullRequest, err error) {
+	ctx, span := tracing.Start(ctx, "PRService.ListPRs")
+	defer func() { tracing.End(span, err) }()
+	span.SetAttributes(attribute.String("pr.status", string(filter.Status)))
+
+	return s.next.ListPRs(ctx, filter)
+}
+
+func (s *tracedPRService) GetSLA(ctx context.Context, prID string) (sla *domain.ReviewSLA, err error) {
+	ctx, span := tracing.Start(ctx, "PRService.GetSLA")
+	defer func() { tracing.End(span, err) }()
+	span.SetAttributes(attribute.String("pr.id", prID))
+
+	return s.next.GetSLA(ctx, prID)
+}
+
+func (s *tracedPRService) AssignPendingReviewers(ctx context.Context, prID string) (updated *domain.PullRequest, err error) {
+	ctx, span := tracing.Start(ctx, "PRService.AssignPendingReviewers")
+	defer func() { tracing.End(span, err) }()
+	span.SetAttributes(attribute.String("pr.id", prID))
+
+	return s.next.AssignPendingReviewers(ctx, prID)
+}
+
+type tracedTeamService struct {
+	next TeamService
+}
+
+func NewTracedTeamService(next TeamService) TeamService {
+	return &tracedTeamService{next: next}
+}
+
+func (s *tracedTeamService) AddTeam(ctx context.Context, team *domain.Team) (created *domain.Team, err error) {
+	ctx, span := tracing.Start(ctx, "TeamService.AddTeam")
+	defer func() { tracing.End(span, err) }()
+	span.SetAttributes(attribute.String("team.name", team.TeamName), attribute.Int("team.members", len(team.Members)))
+
+	return s.next.AddTeam(ctx, team)
+}
+
+func (s *tracedTeamService) GetTeam(ctx context.Context, teamName string) (team *domain.Team, err error) {
+	ctx, span := tracing.Start(ctx, "TeamService.GetTeam")
+	defer func() { tracing.End(span, err) }()
+	span.SetAttributes(attribute.String("team.name", teamName))
+
+	return s.next.GetTeam(ctx, teamName)
+}
+
+func (s *tracedTeamService) DeactivateTeam(ctx context.Context, teamName string) (users []domain.User, prs []domain.PullRequest, err error) {
+	ctx, span := tracing.Start(ctx, "TeamService.DeactivateTeam")
+	defer func() { tracing.End(span, err) }()
+	span.SetAttributes(attribute.String("team.name", teamName))
+
+	return s.next.DeactivateTeam(ctx, teamName)
+}
@@ -0,0 +1,39 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ssokov/pr-reviewer-service/internal/apperror"
+	"github.com/ssokov/pr-reviewer-service/internal/model/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestTracedPRService(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	prev := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	t.Cleanup(func() { otel.SetTracerProvider(prev) })
+
+	ctx := context.Background()
+
+	service := NewTracedPRService(&stubPRService{created: &domain.PullRequest{PullRequestID: "pr1"}})
+	_, err := service.CreatePR(ctx, "u1", &domain.PullRequest{PullRequestID: "pr1"})
+	require.NoError(t, err)
+
+	failing := NewTracedPRService(&stubPRService{err: apperror.NewPRNotFoundError("pr2")})
+	_, err = failing.MergePR(ctx, "pr2")
+	require.Error(t, err)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 2)
+	assert.Equal(t, "PRService.CreatePR", spans[0].Name())
+	assert.Equal(t, codes.Unset, spans[0].Status().Code)
+	assert.Equal(t, "PRService.MergePR", spans[1].Name())
+	assert.Equal(t, codes.Error, spans[1].Status().Code)
+}
@@ -4,8 +4,11 @@ import (
 	"context"
 
 	"github.com/ssokov/pr-reviewer-service/internal/apperror"
+	"github.com/ssokov/pr-reviewer-service/internal/auth"
 	"github.com/ssokov/pr-reviewer-service/internal/model/domain"
 	"github.com/ssokov/pr-reviewer-service/internal/repository"
+	"github.com/ssokov/pr-reviewer-service/internal/tracing"
+	"github.com/ssokov/pr-reviewer-service/internal/workhours"
 	"github.com/vmkteam/embedlog"
 )
 
@@ -30,9 +33,25 @@ func (s *userService) SetIsActive(ctx context.Context, userID string, isActive b
 
 	s.logger.Print(ctx, "setting user active status", "user_id", userID, "is_active", isActive)
 
+	if requiresUserAuthorization(ctx) {
+		target, err := s.userRepo.GetByUserID(ctx, userID)
+		if err != nil {
+			tracing.Logger(ctx, s.logger).Errorf("failed to get user: %v", err)
+			return nil, apperror.NewInternalError("failed to get user", err)
+		}
+		if target == nil {
+			s.logger.Print(ctx, "user not found", "user_id", userID)
+			return nil, apperror.NewUserNotFoundError(userID)
+		}
+		if err := authorizeTeamLead(ctx, s.userRepo, target.TeamID); err != nil {
+			s.logger.Print(ctx, "set active status denied", "user_id", userID, "actor", auth.Actor(ctx))
+			return nil, err
+		}
+	}
+
 	user, err := s.userRepo.SetIsActive(ctx, userID, isActive)
 	if err != nil {
-		s.logger.Errorf("failed to set user active status: %v", err)
+		tracing.Logger(ctx, s.logger).Errorf("failed to set user active status: %v", err)
 		return nil, apperror.NewInternalError("failed to set user active status", err)
 	}
 
@@ -45,6 +64,134 @@ func (s *userService) SetIsActive(ctx context.Context, userID string, isActive b
 	return user, nil
 }
 
+// SetTeamRole changes the role of a user within their team. Like SetIsActive, it is open to admins and to the
+// leads of the user's team.
+func (s *userService) SetTeamRole(ctx context.Context, userID string, role domain.TeamRole) (*domain.User, error) {
+	if userID == "" {
+		return nil, apperror.NewInvalidInputError("user_id is required")
+	}
+	if !domain.IsKnownTeamRole(role) {
+		return nil, apperror.NewInvalidInputError("team_role must be one of lead, senior, member, trainee")
+	}
+
+	s.logger.Print(ctx, "setting user team role", "user_id", userID, "team_role", role)
+
+	if requiresUserAuthorization(ctx) {
+		target, err := s.userRepo.GetByUserID(ctx, userID)
+		if err != nil {
+			tracing.Logger(ctx, s.logger).Errorf("failed to get user: %v", err)
+			return nil, apperror.NewInternalError("failed to get user", err)
+		}
+		if target == nil {
+			s.logger.Print(ctx, "user not found", "user_id", userID)
+			return nil, apperror.NewUserNotFoundError(userID)
+		}
+		if err := authorizeTeamLead(ctx, s.userRepo, target.TeamID); err != nil {
+			s.logger.Print(ctx, "set team role denied", "user_id", userID, "actor", auth.Actor(ctx))
+			return nil, err
+		}
+	}
+
+	user, err := s.userRepo.SetTeamRole(ctx, userID, role)
+	if err != nil {
+		tracing.Logger(ctx, s.logger).Errorf("failed to set user team role: %v", err)
+		return nil, apperror.NewInternalError("failed to set user team role", err)
+	}
+	if user == nil {
+		s.logger.Print(ctx, "user not found", "user_id", userID)
+		return nil, apperror.NewUserNotFoundError(userID)
+	}
+
+	s.logger.Print(ctx, "user team role updated", "user_id", userID, "team_role", role)
+	return user, nil
+}
+
+// SetWorkingHours sets the timezone and working hours of a user, in minutes after local midnight. An empty timezone
+// clears them. Users can set their own working hours; for anyone else it takes an admin or a lead of their team.
+func (s *userService) SetWorkingHours(ctx context.Context, userID, timezone string, workStart, workEnd int) (*domain.User, error) {
+	if userID == "" {
+		return nil, apperror.NewInvalidInputError("user_id is required")
+	}
+	if timezone == "" {
+		workStart, workEnd = domain.DefaultWorkStart, domain.DefaultWorkEnd
+	} else if _, err := workhours.New(timezone, workStart, workEnd); err != nil {
+		return nil, apperror.NewInvalidInputError(err.Error())
+	}
+
+	s.logger.Print(ctx, "setting user working hours", "user_id", userID, "timezone", timezone)
+
+	if p := auth.FromContext(ctx); requiresUserAuthorization(ctx) && p.UserID != userID {
+		target, err := s.userRepo.GetByUserID(ctx, userID)
+		if err != nil {
+			tracing.Logger(ctx, s.logger).Errorf("failed to get user: %v", err)
+			return nil, apperror.NewInternalError("failed to get user", err)
+		}
+		if target == nil {
+			s.logger.Print(ctx, "user not found", "user_id", userID)
+			return nil, apperror.NewUserNotFoundError(userID)
+		}
+		if err := authorizeTeamLead(ctx, s.userRepo, target.TeamID); err != nil {
+			s.logger.Print(ctx, "set working hours denied", "user_id", userID, "actor", auth.Actor(ctx))
+			return nil, err
+		}
+	}
+
+	user, err := s.userRepo.SetWorkingHours(ctx, userID, timezone, workStart, workEnd)
+	if err != nil {
+		tracing.Logger(ctx, s.logger).Errorf("failed to set user working hours: %v", err)
+		return nil, apperror.NewInternalError("failed to set user working hours", err)
+	}
+	if user == nil {
+		s.logger.Print(ctx, "user not found", "user_id", userID)
+		return nil, apperror.NewUserNotFoundError(userID)
+	}
+
+	s.logger.Print(ctx, "user working hours updated", "user_id", userID, "timezone", timezone)
+	return user, nil
+}
+
+// SetMaxOpenReviews sets how many open PRs a user reviews at once; nil falls back to the team default and 0 means
+// no limit. Like SetIsActive, it is open to admins and to the leads of the user's team.
+func (s *userService) SetMaxOpenReviews(ctx context.Context, userID string, limit *int) (*domain.User, error) {
+	if userID == "" {
+		return nil, apperror.NewInvalidInputError("user_id is required")
+	}
+	if limit != nil && *limit < 0 {
+		return nil, apperror.NewInvalidInputError("max_open_reviews must not be negative")
+	}
+
+	s.logger.Print(ctx, "setting user max open reviews", "user_id", userID)
+
+	if requiresUserAuthorization(ctx) {
+		target, err := s.userRepo.GetByUserID(ctx, userID)
+		if err != nil {
+			tracing.Logger(ctx, s.logger).Errorf("failed to get user: %v", err)
+			return nil, apperror.NewInternalError("failed to get user", err)
+		}
+		if target == nil {
+			s.logger.Print(ctx, "user not found", "user_id", userID)
+			return nil, apperror.NewUserNotFoundError(userID)
+		}
+		if err := authorizeTeamLead(ctx, s.userRepo, target.TeamID); err != nil {
+			s.logger.Print(ctx, "set max open reviews denied", "user_id", userID, "actor", auth.Actor(ctx))
+			return nil, err
+		}
+	}
+
+	user, err := s.userRepo.SetMaxOpenReviews(ctx, userID, limit)
+	if err != nil {
+		tracing.Logger(ctx, s.logger).Errorf("failed to set user max open reviews: %v", err)
+		return nil, apperror.NewInternalError("failed to set user max open reviews", err)
+	}
+	if user == nil {
+		s.logger.Print(ctx, "user not found", "user_id", userID)
+		return nil, apperror.NewUserNotFoundError(userID)
+	}
+
+	s.logger.Print(ctx, "user max open reviews updated", "user_id", userID)
+	return user, nil
+}
+
 func (s *userService) GetReview(ctx context.Context, userID string) ([]domain.PullRequest, error) {
 	if userID == "" {
 		return nil, apperror.NewInvalidInputError("user_id is required")
@@ -54,7 +201,7 @@ func (s *userService) GetReview(ctx context.Context, userID string) ([]domain.Pu
 
 	user, err := s.userRepo.GetByUserID(ctx, userID)
 	if err != nil {
-		s.logger.Errorf("failed to get user: %v", err)
+		tracing.Logger(ctx, s.logger).Errorf("failed to get user: %v", err)
 		return nil, apperror.NewInternalError("failed to get user", err)
 	}
 
@@ -65,7 +212,7 @@ func (s *userService) GetReview(ctx context.Context, userID string) ([]domain.Pu
 
 	pullRequests, err := s.userRepo.GetByReviewerID(ctx, userID)
 	if err != nil {
-		s.logger.Errorf("failed to get reviews: %v", err)
+		tracing.Logger(ctx, s.logger).Errorf("failed to get reviews: %v", err)
 		return nil, apperror.NewInternalError("failed to get reviews", err)
 	}
 
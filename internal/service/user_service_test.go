@@ -63,6 +63,172 @@ func TestUserService_SetIsActive(t *testing.T) {
 	})
 }
 
+func TestUserService_SetTeamRole(t *testing.T) {
+	ctx := context.Background()
+	logger := embedlog.NewLogger(false, false)
+
+	t.Run("success", func(t *testing.T) {
+		mockUserRepo := new(MockUserRepository)
+		service := NewUserService(mockUserRepo, new(MockTeamRepository), logger)
+
+		mockUserRepo.On("SetTeamRole", ctx, "user123", domain.TeamRoleSenior).
+			Return(&domain.User{UserID: "user123", TeamRole: domain.TeamRoleSenior}, nil)
+
+		result, err := service.SetTeamRole(ctx, "user123", domain.TeamRoleSenior)
+		assert.NoError(t, err)
+		assert.Equal(t, domain.TeamRoleSenior, result.TeamRole)
+		mockUserRepo.AssertExpectations(t)
+	})
+
+	t.Run("error - unknown role", func(t *testing.T) {
+		mockUserRepo := new(MockUserRepository)
+		service := NewUserService(mockUserRepo, new(MockTeamRepository), logger)
+
+		_, err := service.SetTeamRole(ctx, "user123", "intern")
+		assert.True(t, apperror.Is(err, apperror.ErrCodeInvalidInput))
+		mockUserRepo.AssertNotCalled(t, "SetTeamRole", ctx, "user123", domain.TeamRole("intern"))
+	})
+
+	t.Run("error - user not found", func(t *testing.T) {
+		mockUserRepo := new(MockUserRepository)
+		service := NewUserService(mockUserRepo, new(MockTeamRepository), logger)
+
+		mockUserRepo.On("SetTeamRole", ctx, "ghost", domain.TeamRoleLead).Return(nil, nil)
+
+		_, err := service.SetTeamRole(ctx, "ghost", domain.TeamRoleLead)
+		assert.True(t, apperror.Is(err, apperror.ErrCodeUserNotFound))
+	})
+
+	t.Run("error - forbidden for other teams' members", func(t *testing.T) {
+		mockUserRepo := new(MockUserRepository)
+		service := NewUserService(mockUserRepo, new(MockTeamRepository), logger)
+		memberCtx := userContext("u9", domain.RoleMember)
+
+		mockUserRepo.On("GetByUserID", memberCtx, "user123").Return(&domain.User{UserID: "user123", TeamID: 1}, nil)
+		mockUserRepo.On("GetByUserID", memberCtx, "u9").Return(&domain.User{UserID: "u9", TeamID: 2}, nil)
+
+		_, err := service.SetTeamRole(memberCtx, "user123", domain.TeamRoleLead)
+		assert.True(t, apperror.Is(err, apperror.ErrCodeForbidden), "got %v", err)
+		mockUserRepo.AssertNotCalled(t, "SetTeamRole", memberCtx, "user123", domain.TeamRoleLead)
+	})
+}
+
+func TestUserService_SetWorkingHours(t *testing.T) {
+	ctx := context.Background()
+	logger := embedlog.NewLogger(false, false)
+
+	t.Run("success", func(t *testing.T) {
+		mockUserRepo := new(MockUserRepository)
+		service := NewUserService(mockUserRepo, new(MockTeamRepository), logger)
+
+		mockUserRepo.On("SetWorkingHours", ctx, "user123", "Asia/Almaty", 600, 1140).
+			Return(&domain.User{UserID: "user123", Timezone: "Asia/Almaty", WorkStart: 600, WorkEnd: 1140}, nil)
+
+		result, err := service.SetWorkingHours(ctx, "user123", "Asia/Almaty", 600, 1140)
+		assert.NoError(t, err)
+		assert.Equal(t, "Asia/Almaty", result.Timezone)
+		mockUserRepo.AssertExpectations(t)
+	})
+
+	t.Run("empty timezone clears working hours", func(t *testing.T) {
+		mockUserRepo := new(MockUserRepository)
+		service := NewUserService(mockUserRepo, new(MockTeamRepository), logger)
+
+		mockUserRepo.On("SetWorkingHours", ctx, "user123", "", domain.DefaultWorkStart, domain.DefaultWorkEnd).
+			Return(&domain.User{UserID: "user123"}, nil)
+
+		_, err := service.SetWorkingHours(ctx, "user123", "", 0, 0)
+		assert.NoError(t, err)
+		mockUserRepo.AssertExpectations(t)
+	})
+
+	t.Run("error - invalid working hours", func(t *testing.T) {
+		mockUserRepo := new(MockUserRepository)
+		service := NewUserService(mockUserRepo, new(MockTeamRepository), logger)
+
+		_, err := service.SetWorkingHours(ctx, "user123", "Europe/Atlantis", 540, 1080)
+		assert.True(t, apperror.Is(err, apperror.ErrCodeInvalidInput))
+		_, err = service.SetWorkingHours(ctx, "user123", "Europe/Berlin", 1080, 540)
+		assert.True(t, apperror.Is(err, apperror.ErrCodeInvalidInput))
+		mockUserRepo.AssertNotCalled(t, "SetWorkingHours", ctx, "user123", "Europe/Berlin", 1080, 540)
+	})
+
+	t.Run("users set their own working hours", func(t *testing.T) {
+		mockUserRepo := new(MockUserRepository)
+		service := NewUserService(mockUserRepo, new(MockTeamRepository), logger)
+		memberCtx := userContext("u9", domain.RoleMember)
+
+		mockUserRepo.On("SetWorkingHours", memberCtx, "u9", "Europe/Moscow", 540, 1080).Return(&domain.User{UserID: "u9"}, nil)
+
+		_, err := service.SetWorkingHours(memberCtx, "u9", "Europe/Moscow", 540, 1080)
+		assert.NoError(t, err)
+		mockUserRepo.AssertNotCalled(t, "GetByUserID", memberCtx, "u9")
+	})
+
+	t.Run("error - forbidden for other teams' members", func(t *testing.T) {
+		mockUserRepo := new(MockUserRepository)
+		service := NewUserService(mockUserRepo, new(MockTeamRepository), logger)
+		memberCtx := userContext("u9", domain.RoleMember)
+
+		mockUserRepo.On("GetByUserID", memberCtx, "user123").Return(&domain.User{UserID: "user123", TeamID: 1}, nil)
+		mockUserRepo.On("GetByUserID", memberCtx, "u9").Return(&domain.User{UserID: "u9", TeamID: 2}, nil)
+
+		_, err := service.SetWorkingHours(memberCtx, "user123", "Europe/Moscow", 540, 1080)
+		assert.True(t, apperror.Is(err, apperror.ErrCodeForbidden), "got %v", err)
+		mockUserRepo.AssertNotCalled(t, "SetWorkingHours", memberCtx, "user123", "Europe/Moscow", 540, 1080)
+	})
+}
+
+func TestUserService_SetMaxOpenReviews(t *testing.T) {
+	ctx := context.Background()
+	logger := embedlog.NewLogger(false, false)
+	limit := 3
+
+	t.Run("success", func(t *testing.T) {
+		mockUserRepo := new(MockUserRepository)
+		service := NewUserService(mockUserRepo, new(MockTeamRepository), logger)
+
+		mockUserRepo.On("SetMaxOpenReviews", ctx, "user123", &limit).Return(&domain.User{UserID: "user123", MaxOpenReviews: &limit}, nil)
+
+		result, err := service.SetMaxOpenReviews(ctx, "user123", &limit)
+		assert.NoError(t, err)
+		assert.Equal(t, 3, *result.MaxOpenReviews)
+		mockUserRepo.AssertExpectations(t)
+	})
+
+	t.Run("error - negative limit", func(t *testing.T) {
+		mockUserRepo := new(MockUserRepository)
+		service := NewUserService(mockUserRepo, new(MockTeamRepository), logger)
+		negative := -1
+
+		_, err := service.SetMaxOpenReviews(ctx, "user123", &negative)
+		assert.True(t, apperror.Is(err, apperror.ErrCodeInvalidInput))
+		mockUserRepo.AssertNotCalled(t, "SetMaxOpenReviews", ctx, "user123", &negative)
+	})
+
+	t.Run("error - user not found", func(t *testing.T) {
+		mockUserRepo := new(MockUserRepository)
+		service := NewUserService(mockUserRepo, new(MockTeamRepository), logger)
+
+		mockUserRepo.On("SetMaxOpenReviews", ctx, "ghost", (*int)(nil)).Return(nil, nil)
+
+		_, err := service.SetMaxOpenReviews(ctx, "ghost", nil)
+		assert.True(t, apperror.Is(err, apperror.ErrCodeUserNotFound))
+	})
+
+	t.Run("error - members cannot raise their own limit", func(t *testing.T) {
+		mockUserRepo := new(MockUserRepository)
+		service := NewUserService(mockUserRepo, new(MockTeamRepository), logger)
+		memberCtx := userContext("u9", domain.RoleMember)
+
+		mockUserRepo.On("GetByUserID", memberCtx, "u9").Return(&domain.User{UserID: "u9", TeamID: 1}, nil)
+
+		_, err := service.SetMaxOpenReviews(memberCtx, "u9", &limit)
+		assert.True(t, apperror.Is(err, apperror.ErrCodeForbidden), "got %v", err)
+		mockUserRepo.AssertNotCalled(t, "SetMaxOpenReviews", memberCtx, "u9", &limit)
+	})
+}
+
 func TestUserService_GetReview(t *testing.T) {
 	ctx := context.Background()
 	logger := embedlog.NewLogger(false, false)
@@ -0,0 +1,44 @@
+package tenant
+
+import (
+	"context"
+
+	"github.com/ssokov/pr-reviewer-service/internal/model/domain"
+)
+
+type organizationKey struct{}
+
+func WithOrganization(ctx context.Context, org *domain.Organization) context.Context {
+	return context.WithValue(ctx, organizationKey{}, org)
+}
+
+// FromContext returns the organization the request is scoped to or nil when none was resolved.
+func FromContext(ctx context.Context) *domain.Organization {
+	org, _ := ctx.Value(organizationKey{}).(*domain.Organization)
+	return org
+}
+
+// OrganizationID returns the id repositories scope queries by.
+// Contexts without an organization, like background jobs in a single-tenant deployment, use the default one.
+func OrganizationID(ctx context.Context) int64 {
+	if org := FromContext(ctx); org != nil {
+		return org.ID
+	}
+	return domain.DefaultOrganizationID
+}
+
+// Slug returns the slug of the organization in ctx, used to label metrics.
+func Slug(ctx context.Context) string {
+	if org := FromContext(ctx); org != nil {
+		return org.Slug
+	}
+	return domain.DefaultOrganizationSlug
+}
+
+// Settings returns the settings of the organization in ctx, or the defaults.
+func Settings(ctx context.Context) domain.OrganizationSettings {
+	if org := FromContext(ctx); org != nil {
+		return org.Settings
+	}
+	return domain.OrganizationSettings{}
+}
@@ -0,0 +1,32 @@
+package tenant
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ssokov/pr-reviewer-service/internal/model/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOrganizationID(t *testing.T) {
+	t.Run("default when not set", func(t *testing.T) {
+		ctx := context.Background()
+
+		assert.Nil(t, FromContext(ctx))
+		assert.Equal(t, domain.DefaultOrganizationID, OrganizationID(ctx))
+		assert.Equal(t, domain.OrganizationSettings{}, Settings(ctx))
+	})
+
+	t.Run("organization from context", func(t *testing.T) {
+		org := &domain.Organization{
+			ID:       7,
+			Slug:     "acme",
+			Settings: domain.OrganizationSettings{ReviewerCount: 2},
+		}
+		ctx := WithOrganization(context.Background(), org)
+
+		assert.Equal(t, org, FromContext(ctx))
+		assert.Equal(t, int64(7), OrganizationID(ctx))
+		assert.Equal(t, 2, Settings(ctx).ReviewerCount)
+	})
+}
@@ -0,0 +1,47 @@
+package tracing
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/vmkteam/embedlog"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// traceHandler adds the trace and span ids of the record context to every log record.
+type traceHandler struct {
+	slog.Handler
+}
+
+// NewLogHandler wraps h so that records logged with a traced context carry trace_id and span_id.
+func NewLogHandler(h slog.Handler) slog.Handler {
+	return traceHandler{Handler: h}
+}
+
+func (h traceHandler) Handle(ctx context.Context, r slog.Record) error {
+	if sc := trace.SpanContextFromContext(ctx); sc.IsValid() {
+		r.AddAttrs(
+			slog.String("trace_id", sc.TraceID().String()),
+			slog.String("span_id", sc.SpanID().String()),
+		)
+	}
+	return h.Handler.Handle(ctx, r)
+}
+
+func (h traceHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return traceHandler{Handler: h.Handler.WithAttrs(attrs)}
+}
+
+func (h traceHandler) WithGroup(name string) slog.Handler {
+	return traceHandler{Handler: h.Handler.WithGroup(name)}
+}
+
+// Logger returns l with the trace and span ids of ctx attached, so calls without a context,
+// like Errorf, are still correlated with the request trace. l is returned as is outside a span.
+func Logger(ctx context.Context, l embedlog.Logger) embedlog.Logger {
+	sc := trace.SpanContextFromContext(ctx)
+	if !sc.IsValid() {
+		return l
+	}
+	return l.With("trace_id", sc.TraceID().String(), "span_id", sc.SpanID().String())
+}
@@ -0,0 +1,115 @@
+package tracing
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vmkteam/embedlog"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func newRecorder(t *testing.T) *tracetest.SpanRecorder {
+	recorder := tracetest.NewSpanRecorder()
+	prev := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	t.Cleanup(func() { otel.SetTracerProvider(prev) })
+	return recorder
+}
+
+func TestSpanName(t *testing.T) {
+	tests := []struct {
+		sql  string
+		want string
+	}{
+		{"SELECT id FROM pr_system.users WHERE user_id = $1", "SELECT pr_system.users"},
+		{"INSERT INTO pr_system.pr_reviewers (pr_id, reviewer_id) VALUES ($1, $2)", "INSERT pr_system.pr_reviewers"},
+		{"UPDATE pr_system.users SET is_active = $1", "UPDATE pr_system.users"},
+		{"select version()", "SELECT"},
+		{"", "db.query"},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, spanName(tt.sql), tt.sql)
+	}
+}
+
+func TestPgxTracer(t *testing.T) {
+	recorder := newRecorder(t)
+	tracer := NewPgxTracer()
+
+	ctx := tracer.TraceQueryStart(context.Background(), nil, pgx.TraceQueryStartData{
+		SQL: "SELECT id\n\t\tFROM pr_system.users\n\t\tWHERE user_id = $1",
+	})
+	tracer.TraceQueryEnd(ctx, nil, pgx.TraceQueryEndData{
+		CommandTag: pgconn.NewCommandTag("SELECT 1"),
+		Err:        pgx.ErrNoRows,
+	})
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+	assert.Equal(t, "SELECT pr_system.users", spans[0].Name())
+	assert.Equal(t, codes.Error, spans[0].Status().Code)
+
+	attrs := map[string]string{}
+	for _, a := range spans[0].Attributes() {
+		attrs[string(a.Key)] = a.Value.Emit()
+	}
+	assert.Equal(t, "postgresql", attrs["db.system"])
+	assert.Equal(t, "SELECT id FROM pr_system.users WHERE user_id = $1", attrs["db.statement"])
+}
+
+func TestTraceHandler(t *testing.T) {
+	newRecorder(t)
+
+	var buf bytes.Buffer
+	logger := slog.New(NewLogHandler(slog.NewJSONHandler(&buf, nil)))
+
+	logger.InfoContext(context.Background(), "no span")
+	assert.NotContains(t, buf.String(), "trace_id")
+
+	ctx, span := Start(context.Background(), "test")
+	defer span.End()
+
+	buf.Reset()
+	logger.With("pr_id", "pr1").InfoContext(ctx, "with span")
+	assert.Contains(t, buf.String(), `"trace_id":"`+span.SpanContext().TraceID().String()+`"`)
+	assert.Contains(t, buf.String(), `"pr_id":"pr1"`)
+}
+
+func TestLogger(t *testing.T) {
+	newRecorder(t)
+
+	// embedlog writes errors to os.Stderr, captured when the logger is created.
+	r, w, err := os.Pipe()
+	require.NoError(t, err)
+	stderr := os.Stderr
+	os.Stderr = w
+	l := embedlog.NewLogger(false, true)
+	os.Stderr = stderr
+
+	ctx, span := Start(context.Background(), "test")
+	Logger(context.Background(), l).Errorf("no span")
+	Logger(ctx, l).Errorf("with span")
+	span.End()
+	require.NoError(t, w.Close())
+
+	out, err := io.ReadAll(r)
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(string(out)), "\n")
+	require.Len(t, lines, 2)
+	assert.NotContains(t, lines[0], "trace_id")
+	assert.Contains(t, lines[1], `"trace_id":"`+span.SpanContext().TraceID().String()+`"`)
+	assert.Contains(t, lines[1], `"span_id":"`+span.SpanContext().SpanID().String()+`"`)
+}
@@ -0,0 +1,137 @@
+// Package workhours does calendar arithmetic over weekly working hours: whether a moment falls within them, when they
+// start next and how much working time lies between two moments. Working days are Monday to Friday.
+package workhours
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	// Embedded so that timezones resolve on hosts without a zoneinfo database.
+	_ "time/tzdata"
+)
+
+// MinutesPerDay bounds the start and end of the working hours.
+const MinutesPerDay = 24 * 60
+
+// Schedule is a working day from Start to End minutes after local midnight in Location.
+type Schedule struct {
+	Location *time.Location
+	Start    int
+	End      int
+}
+
+// New validates timezone, an IANA name such as Europe/Berlin, and the working hours in minutes after midnight.
+func New(timezone string, start, end int) (Schedule, error) {
+	loc, err := time.LoadLocation(timezone)
+	if err != nil || timezone == "" || strings.EqualFold(timezone, "local") {
+		return Schedule{}, fmt.Errorf("unknown timezone %q", timezone)
+	}
+	if start < 0 || end > MinutesPerDay || start >= end {
+		return Schedule{}, fmt.Errorf("working hours %s-%s must start before they end", FormatClock(start), FormatClock(end))
+	}
+	return Schedule{Location: loc, Start: start, End: end}, nil
+}
+
+// ParseClock parses a time of day in HH:MM format into minutes after midnight. 24:00 is accepted as the end of day.
+func ParseClock(s string) (int, error) {
+	hours, minutes, ok := strings.Cut(s, ":")
+	if !ok || len(hours) != 2 || len(minutes) != 2 {
+		return 0, fmt.Errorf("invalid time %q, expected HH:MM", s)
+	}
+	h, errH := strconv.Atoi(hours)
+	m, errM := strconv.Atoi(minutes)
+	if errH != nil || errM != nil || h < 0 || m < 0 || m > 59 || h*60+m > MinutesPerDay {
+		return 0, fmt.Errorf("invalid time %q, expected HH:MM", s)
+	}
+	return h*60 + m, nil
+}
+
+func FormatClock(minutes int) string {
+	return fmt.Sprintf("%02d:%02d", minutes/60, minutes%60)
+}
+
+// Contains reports whether t falls within the working hours.
+func (s Schedule) Contains(t time.Time) bool {
+	start, end, ok := s.window(t)
+	return ok && !t.Before(start) && t.Before(end)
+}
+
+// NextStart returns t when it falls within the working hours and the start of the next working day otherwise.
+func (s Schedule) NextStart(t time.Time) time.Time {
+	day := s.midnight(t)
+	for i := 0; i <= 7; i, day = i+1, day.AddDate(0, 0, 1) {
+		start, end, ok := s.window(day)
+		if !ok || !t.Before(end) {
+			continue
+		}
+		if t.Before(start) {
+			return start
+		}
+		return t
+	}
+	// Unreachable for a valid schedule: every week has working days.
+	return t
+}
+
+// Between returns the working time between from and to, or 0 when to is not after from.
+func (s Schedule) Between(from, to time.Time) time.Duration {
+	var total time.Duration
+	for day := s.midnight(from); day.Before(to); day = day.AddDate(0, 0, 1) {
+		start, end, ok := s.window(day)
+		if !ok {
+			continue
+		}
+		if start.Before(from) {
+			start = from
+		}
+		if end.After(to) {
+			end = to
+		}
+		if end.After(start) {
+			total += end.Sub(start)
+		}
+	}
+	return total
+}
+
+// Add returns the moment d of working time after from.
+func (s Schedule) Add(from time.Time, d time.Duration) time.Time {
+	if d <= 0 {
+		return from
+	}
+	for day := s.midnight(from); ; day = day.AddDate(0, 0, 1) {
+		start, end, ok := s.window(day)
+		if !ok || !from.Before(end) {
+			continue
+		}
+		if start.Before(from) {
+			start = from
+		}
+		if available := end.Sub(start); d > available {
+			d -= available
+			continue
+		}
+		return start.Add(d)
+	}
+}
+
+// midnight returns the start of the local day of t. Days are stepped from it with AddDate so that daylight saving
+// changes never skip or repeat a day.
+func (s Schedule) midnight(t time.Time) time.Time {
+	local := t.In(s.Location)
+	return time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, s.Location)
+}
+
+// window returns the working hours of the local day of t; ok is false on weekends.
+func (s Schedule) window(t time.Time) (start, end time.Time, ok bool) {
+	local := t.In(s.Location)
+	if wd := local.Weekday(); wd == time.Saturday || wd == time.Sunday {
+		return time.Time{}, time.Time{}, false
+	}
+	y, m, d := local.Date()
+	start = time.Date(y, m, d, s.Start/60, s.Start%60, 0, 0, s.Location)
+	end = time.Date(y, m, d, s.End/60, s.End%60, 0, 0, s.Location)
+	return start, end, true
+}
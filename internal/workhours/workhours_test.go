@@ -0,0 +1,100 @@
+package workhours
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// 2025-03-03 is a Monday.
+func berlin(t *testing.T, day, hour, minute int) time.Time {
+	t.Helper()
+	loc, err := time.LoadLocation("Europe/Berlin")
+	require.NoError(t, err)
+	return time.Date(2025, time.March, day, hour, minute, 0, 0, loc)
+}
+
+func TestNew(t *testing.T) {
+	s, err := New("Asia/Almaty", 9*60, 18*60)
+	require.NoError(t, err)
+	assert.Equal(t, "Asia/Almaty", s.Location.String())
+
+	_, err = New("Mars/Olympus", 9*60, 18*60)
+	assert.Error(t, err)
+	_, err = New("", 9*60, 18*60)
+	assert.Error(t, err)
+	_, err = New("UTC", 18*60, 9*60)
+	assert.Error(t, err)
+	_, err = New("UTC", 0, MinutesPerDay+1)
+	assert.Error(t, err)
+}
+
+func TestParseClock(t *testing.T) {
+	for in, want := range map[string]int{"00:00": 0, "09:30": 570, "24:00": MinutesPerDay} {
+		got, err := ParseClock(in)
+		require.NoError(t, err, in)
+		assert.Equal(t, want, got, in)
+		assert.Equal(t, in, FormatClock(got))
+	}
+	for _, in := range []string{"9:30", "09:60", "24:01", "ab:cd", "0930"} {
+		_, err := ParseClock(in)
+		assert.Error(t, err, in)
+	}
+}
+
+func TestSchedule_Contains(t *testing.T) {
+	s, err := New("Europe/Berlin", 9*60, 18*60)
+	require.NoError(t, err)
+
+	assert.True(t, s.Contains(berlin(t, 3, 9, 0)))
+	assert.True(t, s.Contains(berlin(t, 3, 17, 59)))
+	assert.False(t, s.Contains(berlin(t, 3, 18, 0)))
+	assert.False(t, s.Contains(berlin(t, 3, 8, 59)))
+	assert.False(t, s.Contains(berlin(t, 8, 12, 0)), "saturday")
+	// 08:30 UTC is 09:30 in Berlin.
+	assert.True(t, s.Contains(time.Date(2025, time.March, 3, 8, 30, 0, 0, time.UTC)))
+}
+
+func TestSchedule_NextStart(t *testing.T) {
+	s, err := New("Europe/Berlin", 9*60, 18*60)
+	require.NoError(t, err)
+
+	assert.Equal(t, berlin(t, 3, 10, 0), s.NextStart(berlin(t, 3, 10, 0)))
+	assert.Equal(t, berlin(t, 3, 9, 0), s.NextStart(berlin(t, 3, 7, 0)))
+	assert.Equal(t, berlin(t, 4, 9, 0), s.NextStart(berlin(t, 3, 18, 0)))
+	assert.Equal(t, berlin(t, 10, 9, 0), s.NextStart(berlin(t, 7, 19, 0)), "friday evening")
+}
+
+func TestSchedule_Between(t *testing.T) {
+	s, err := New("Europe/Berlin", 9*60, 18*60)
+	require.NoError(t, err)
+
+	assert.Equal(t, 3*time.Hour, s.Between(berlin(t, 3, 10, 0), berlin(t, 3, 13, 0)))
+	assert.Equal(t, 9*time.Hour, s.Between(berlin(t, 3, 0, 0), berlin(t, 4, 0, 0)))
+	// Friday 17:00 to Monday 10:00 counts one hour on each side of the weekend.
+	assert.Equal(t, 2*time.Hour, s.Between(berlin(t, 7, 17, 0), berlin(t, 10, 10, 0)))
+	assert.Equal(t, 5*9*time.Hour, s.Between(berlin(t, 3, 0, 0), berlin(t, 10, 0, 0)))
+	assert.Zero(t, s.Between(berlin(t, 4, 0, 0), berlin(t, 3, 0, 0)))
+}
+
+func TestSchedule_Add(t *testing.T) {
+	s, err := New("Europe/Berlin", 9*60, 18*60)
+	require.NoError(t, err)
+
+	assert.Equal(t, berlin(t, 3, 13, 0), s.Add(berlin(t, 3, 10, 0), 3*time.Hour))
+	assert.Equal(t, berlin(t, 4, 10, 0), s.Add(berlin(t, 3, 17, 0), 2*time.Hour))
+	assert.Equal(t, berlin(t, 10, 10, 0), s.Add(berlin(t, 7, 17, 0), 2*time.Hour))
+	assert.Equal(t, berlin(t, 3, 11, 0), s.Add(berlin(t, 1, 12, 0), 2*time.Hour), "starts on the next working day")
+	assert.Equal(t, berlin(t, 3, 10, 0), s.Add(berlin(t, 3, 10, 0), 0))
+}
+
+func TestSchedule_DaylightSaving(t *testing.T) {
+	s, err := New("Europe/Berlin", 9*60, 18*60)
+	require.NoError(t, err)
+
+	// Clocks go forward on Sunday 2025-03-30; Friday to Monday still spans two working hours.
+	assert.Equal(t, 2*time.Hour, s.Between(berlin(t, 28, 17, 0), berlin(t, 31, 10, 0)))
+	assert.Equal(t, berlin(t, 31, 9, 0), s.NextStart(berlin(t, 28, 18, 0)))
+}
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS pr_system.audit_log CASCADE;
\ No newline at end of file
@@ -0,0 +1,15 @@
+CREATE TABLE pr_system.audit_log (
+    id BIGINT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    actor VARCHAR(255) NOT NULL,
+    action VARCHAR(100) NOT NULL,
+    target VARCHAR(255) NOT NULL,
+    before JSONB,
+    after JSONB,
+    request_id VARCHAR(100),
+    created_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+CREATE INDEX idx_audit_log_actor ON pr_system.audit_log(actor);
+CREATE INDEX idx_audit_log_action ON pr_system.audit_log(action);
+CREATE INDEX idx_audit_log_target ON pr_system.audit_log(target);
+CREATE INDEX idx_audit_log_created_at ON pr_system.audit_log(created_at);
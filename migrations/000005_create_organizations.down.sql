@@ -0,0 +1,23 @@
+DELETE FROM pr_system.audit_log WHERE organization_id <> 1;
+DELETE FROM pr_system.api_keys WHERE organization_id <> 1;
+DELETE FROM pr_system.pull_requests WHERE organization_id <> 1;
+UPDATE pr_system.users SET team_id = NULL WHERE organization_id <> 1;
+DELETE FROM pr_system.teams WHERE organization_id <> 1;
+DELETE FROM pr_system.users WHERE organization_id <> 1;
+
+ALTER TABLE pr_system.audit_log DROP COLUMN organization_id;
+ALTER TABLE pr_system.api_keys DROP COLUMN organization_id;
+
+ALTER TABLE pr_system.pull_requests DROP CONSTRAINT pull_requests_organization_id_pull_request_id_key;
+ALTER TABLE pr_system.pull_requests DROP COLUMN organization_id;
+ALTER TABLE pr_system.pull_requests ADD CONSTRAINT pull_requests_pull_request_id_key UNIQUE (pull_request_id);
+
+ALTER TABLE pr_system.users DROP CONSTRAINT users_organization_id_user_id_key;
+ALTER TABLE pr_system.users DROP COLUMN organization_id;
+ALTER TABLE pr_system.users ADD CONSTRAINT users_user_id_key UNIQUE (user_id);
+
+ALTER TABLE pr_system.teams DROP CONSTRAINT teams_organization_id_name_key;
+ALTER TABLE pr_system.teams DROP COLUMN organization_id;
+ALTER TABLE pr_system.teams ADD CONSTRAINT teams_name_key UNIQUE (name);
+
+DROP TABLE IF EXISTS pr_system.organizations CASCADE;
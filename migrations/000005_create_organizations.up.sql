@@ -0,0 +1,35 @@
+CREATE TABLE pr_system.organizations (
+    id BIGINT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    slug VARCHAR(100) NOT NULL UNIQUE,
+    name VARCHAR(255) NOT NULL,
+    settings JSONB NOT NULL DEFAULT '{}',
+    created_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+-- Existing data belongs to the default organization, which always has id 1.
+INSERT INTO pr_system.organizations (slug, name) VALUES ('default', 'Default organization');
+
+ALTER TABLE pr_system.teams ADD COLUMN organization_id BIGINT NOT NULL DEFAULT 1 REFERENCES pr_system.organizations(id);
+ALTER TABLE pr_system.teams ALTER COLUMN organization_id DROP DEFAULT;
+ALTER TABLE pr_system.teams DROP CONSTRAINT teams_name_key;
+ALTER TABLE pr_system.teams ADD CONSTRAINT teams_organization_id_name_key UNIQUE (organization_id, name);
+
+ALTER TABLE pr_system.users ADD COLUMN organization_id BIGINT NOT NULL DEFAULT 1 REFERENCES pr_system.organizations(id);
+ALTER TABLE pr_system.users ALTER COLUMN organization_id DROP DEFAULT;
+ALTER TABLE pr_system.users DROP CONSTRAINT users_user_id_key;
+ALTER TABLE pr_system.users ADD CONSTRAINT users_organization_id_user_id_key UNIQUE (organization_id, user_id);
+
+ALTER TABLE pr_system.pull_requests ADD COLUMN organization_id BIGINT NOT NULL DEFAULT 1 REFERENCES pr_system.organizations(id);
+ALTER TABLE pr_system.pull_requests ALTER COLUMN organization_id DROP DEFAULT;
+ALTER TABLE pr_system.pull_requests DROP CONSTRAINT pull_requests_pull_request_id_key;
+ALTER TABLE pr_system.pull_requests ADD CONSTRAINT pull_requests_organization_id_pull_request_id_key UNIQUE (organization_id, pull_request_id);
+
+ALTER TABLE pr_system.api_keys ADD COLUMN organization_id BIGINT NOT NULL DEFAULT 1 REFERENCES pr_system.organizations(id);
+ALTER TABLE pr_system.api_keys ALTER COLUMN organization_id DROP DEFAULT;
+
+ALTER TABLE pr_system.audit_log ADD COLUMN organization_id BIGINT NOT NULL DEFAULT 1 REFERENCES pr_system.organizations(id);
+ALTER TABLE pr_system.audit_log ALTER COLUMN organization_id DROP DEFAULT;
+
+CREATE INDEX idx_users_organization_id ON pr_system.users(organization_id);
+CREATE INDEX idx_pull_requests_organization_id ON pr_system.pull_requests(organization_id);
+CREATE INDEX idx_audit_log_organization_id ON pr_system.audit_log(organization_id);
@@ -0,0 +1,8 @@
+CREATE TABLE pr_system.codeowners (
+    organization_id BIGINT NOT NULL REFERENCES pr_system.organizations(id),
+    repository VARCHAR(255) NOT NULL,
+    mode VARCHAR(20) NOT NULL DEFAULT 'preferred',
+    content TEXT NOT NULL,
+    updated_at TIMESTAMPTZ DEFAULT NOW(),
+    PRIMARY KEY (organization_id, repository)
+);
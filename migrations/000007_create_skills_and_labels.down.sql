@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS pr_system.pr_labels;
+DROP TABLE IF EXISTS pr_system.user_skills;
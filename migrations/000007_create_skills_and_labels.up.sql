@@ -0,0 +1,13 @@
+CREATE TABLE pr_system.user_skills (
+    user_id BIGINT NOT NULL REFERENCES pr_system.users(id) ON DELETE CASCADE,
+    skill VARCHAR(50) NOT NULL,
+    PRIMARY KEY (user_id, skill)
+);
+
+CREATE INDEX idx_user_skills_skill ON pr_system.user_skills(skill);
+
+CREATE TABLE pr_system.pr_labels (
+    pr_id BIGINT NOT NULL REFERENCES pr_system.pull_requests(id) ON DELETE CASCADE,
+    label VARCHAR(50) NOT NULL,
+    PRIMARY KEY (pr_id, label)
+);
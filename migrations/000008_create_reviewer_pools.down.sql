@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS pr_system.team_fallbacks;
+DROP TABLE IF EXISTS pr_system.reviewer_pool_members;
+DROP TABLE IF EXISTS pr_system.reviewer_pools;
@@ -0,0 +1,23 @@
+CREATE TABLE pr_system.reviewer_pools (
+    id BIGINT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    organization_id BIGINT NOT NULL REFERENCES pr_system.organizations(id),
+    name VARCHAR(255) NOT NULL,
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    UNIQUE (organization_id, name)
+);
+
+CREATE TABLE pr_system.reviewer_pool_members (
+    pool_id BIGINT NOT NULL REFERENCES pr_system.reviewer_pools(id) ON DELETE CASCADE,
+    user_id BIGINT NOT NULL REFERENCES pr_system.users(id) ON DELETE CASCADE,
+    PRIMARY KEY (pool_id, user_id)
+);
+
+-- A fallback step names either a team or a pool; steps are walked in position order.
+CREATE TABLE pr_system.team_fallbacks (
+    team_id BIGINT NOT NULL REFERENCES pr_system.teams(id) ON DELETE CASCADE,
+    position INTEGER NOT NULL,
+    fallback_team_id BIGINT REFERENCES pr_system.teams(id) ON DELETE CASCADE,
+    pool_id BIGINT REFERENCES pr_system.reviewer_pools(id) ON DELETE CASCADE,
+    PRIMARY KEY (team_id, position),
+    CHECK ((fallback_team_id IS NULL) <> (pool_id IS NULL))
+);
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS pr_system.idx_teams_parent_team_id;
+ALTER TABLE pr_system.teams DROP COLUMN IF EXISTS settings;
+ALTER TABLE pr_system.teams DROP CONSTRAINT IF EXISTS teams_parent_not_self;
+ALTER TABLE pr_system.teams DROP COLUMN IF EXISTS parent_team_id;
@@ -0,0 +1,5 @@
+ALTER TABLE pr_system.teams ADD COLUMN parent_team_id BIGINT REFERENCES pr_system.teams(id) ON DELETE SET NULL;
+ALTER TABLE pr_system.teams ADD CONSTRAINT teams_parent_not_self CHECK (parent_team_id <> id);
+ALTER TABLE pr_system.teams ADD COLUMN settings JSONB NOT NULL DEFAULT '{}';
+
+CREATE INDEX idx_teams_parent_team_id ON pr_system.teams(parent_team_id);
@@ -0,0 +1,2 @@
+ALTER TABLE pr_system.pr_reviewers DROP COLUMN IF EXISTS is_shadow;
+ALTER TABLE pr_system.users DROP COLUMN IF EXISTS team_role;
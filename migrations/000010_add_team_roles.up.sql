@@ -0,0 +1,4 @@
+ALTER TABLE pr_system.users ADD COLUMN team_role VARCHAR(16) NOT NULL DEFAULT 'member'
+    CHECK (team_role IN ('lead', 'senior', 'member', 'trainee'));
+
+ALTER TABLE pr_system.pr_reviewers ADD COLUMN is_shadow BOOLEAN NOT NULL DEFAULT FALSE;
@@ -0,0 +1,5 @@
+ALTER TABLE pr_system.users
+    DROP CONSTRAINT IF EXISTS users_working_hours_check,
+    DROP COLUMN IF EXISTS work_end,
+    DROP COLUMN IF EXISTS work_start,
+    DROP COLUMN IF EXISTS timezone;
@@ -0,0 +1,5 @@
+ALTER TABLE pr_system.users
+    ADD COLUMN timezone VARCHAR(64) NOT NULL DEFAULT '',
+    ADD COLUMN work_start SMALLINT NOT NULL DEFAULT 540,
+    ADD COLUMN work_end SMALLINT NOT NULL DEFAULT 1080,
+    ADD CONSTRAINT users_working_hours_check CHECK (work_start >= 0 AND work_start < work_end AND work_end <= 1440);
@@ -0,0 +1,2 @@
+ALTER TABLE pr_system.pull_requests DROP COLUMN IF EXISTS pending_reviewers;
+ALTER TABLE pr_system.users DROP COLUMN IF EXISTS max_open_reviews;
@@ -0,0 +1,3 @@
+ALTER TABLE pr_system.users ADD COLUMN max_open_reviews INTEGER CHECK (max_open_reviews >= 0);
+
+ALTER TABLE pr_system.pull_requests ADD COLUMN pending_reviewers BOOLEAN NOT NULL DEFAULT FALSE;
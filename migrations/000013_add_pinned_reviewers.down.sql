@@ -0,0 +1 @@
+ALTER TABLE pr_system.pr_reviewers DROP COLUMN IF EXISTS is_pinned;
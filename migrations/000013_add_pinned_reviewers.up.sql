@@ -0,0 +1 @@
+ALTER TABLE pr_system.pr_reviewers ADD COLUMN is_pinned BOOLEAN NOT NULL DEFAULT FALSE;
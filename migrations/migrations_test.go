@@ -0,0 +1,18 @@
+package migrations
+
+import (
+	"io/fs"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLatestVersion(t *testing.T) {
+	ups, err := fs.Glob(FS, "*.up.sql")
+	require.NoError(t, err)
+
+	version, err := LatestVersion()
+	require.NoError(t, err)
+	assert.Equal(t, uint(len(ups)), version)
+}
@@ -0,0 +1,104 @@
+package migrations
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/golang-migrate/migrate/v4"
+	_ "github.com/golang-migrate/migrate/v4/database/pgx/v5" // registers the pgx5:// driver
+	"github.com/golang-migrate/migrate/v4/source/iofs"
+	"github.com/vmkteam/embedlog"
+)
+
+// Migrator applies the embedded migrations. Every operation holds a postgres advisory lock, so replicas that
+// migrate on startup at the same time run one after another, and the later ones find nothing to apply.
+type Migrator struct {
+	m *migrate.Migrate
+}
+
+type Status struct {
+	Version uint
+	Dirty   bool
+	// Latest is the newest embedded migration.
+	Latest uint
+}
+
+// NewMigrator connects to the database at dsn, a postgres:// URL.
+func NewMigrator(dsn string, logger embedlog.Logger) (*Migrator, error) {
+	src, err := iofs.New(FS, ".")
+	if err != nil {
+		return nil, fmt.Errorf("failed to open embedded migrations: %w", err)
+	}
+
+	m, err := migrate.NewWithSourceInstance("iofs", src, pgx5URL(dsn))
+	if err != nil {
+		return nil, fmt.Errorf("failed to init migrations: %w", err)
+	}
+	m.Log = migrateLogger{logger: logger}
+
+	return &Migrator{m: m}, nil
+}
+
+// Up applies all pending migrations.
+func (m *Migrator) Up() error {
+	if err := m.m.Up(); err != nil && !errors.Is(err, migrate.ErrNoChange) {
+		return err
+	}
+	return nil
+}
+
+// Down rolls back the given number of applied migrations.
+func (m *Migrator) Down(steps int) error {
+	if steps <= 0 {
+		return fmt.Errorf("steps must be positive, got %d", steps)
+	}
+	if err := m.m.Steps(-steps); err != nil && !errors.Is(err, migrate.ErrNoChange) {
+		return err
+	}
+	return nil
+}
+
+// Force sets the version without running migrations, to recover from a dirty state after a manual fix.
+func (m *Migrator) Force(version int) error {
+	return m.m.Force(version)
+}
+
+func (m *Migrator) Status() (Status, error) {
+	latest, err := LatestVersion()
+	if err != nil {
+		return Status{}, err
+	}
+
+	version, dirty, err := m.m.Version()
+	if err != nil && !errors.Is(err, migrate.ErrNilVersion) {
+		return Status{}, err
+	}
+	return Status{Version: version, Dirty: dirty, Latest: latest}, nil
+}
+
+func (m *Migrator) Close() error {
+	srcErr, dbErr := m.m.Close()
+	return errors.Join(srcErr, dbErr)
+}
+
+func pgx5URL(dsn string) string {
+	for _, scheme := range []string{"postgres://", "postgresql://"} {
+		if rest, ok := strings.CutPrefix(dsn, scheme); ok {
+			return "pgx5://" + rest
+		}
+	}
+	return dsn
+}
+
+type migrateLogger struct {
+	logger embedlog.Logger
+}
+
+func (l migrateLogger) Printf(format string, v ...any) {
+	l.logger.Printf(strings.TrimSuffix(format, "\n"), v...)
+}
+
+func (l migrateLogger) Verbose() bool {
+	return false
+}
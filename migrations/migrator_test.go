@@ -0,0 +1,12 @@
+package migrations
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPgx5URL(t *testing.T) {
+	assert.Equal(t, "pgx5://u:p@db:5432/pr_system?sslmode=disable", pgx5URL("postgres://u:p@db:5432/pr_system?sslmode=disable"))
+	assert.Equal(t, "pgx5://u:p@db/pr_system", pgx5URL("postgresql://u:p@db/pr_system"))
+}